@@ -7,70 +7,91 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"time"
 
 	"github.com/irisnet/irishub-sdk-go/crypto"
 	"github.com/irisnet/irishub-sdk-go/types"
 )
 
+// KeyManager is the key manager of a KeyDAO, it implements all the optional interfaces of types.KeyManager
+type KeyManager interface {
+	types.KeyManager
+	types.HDKeyManager
+	types.KeyImporter
+	types.ArmorKeyManager
+	types.ShareKeyManager
+	types.KeyLister
+	types.KeyEditor
+	types.KeySessions
+	types.PubKeyQuerier
+}
+
 type daoAdapter struct {
 	keyDAO    types.KeyDAO
 	storeType types.StoreType
+	prefixes  *types.AddrPrefixCfg
+	sessions  *sessions
+}
+
+//NewDAOAdapter return a apapter for user DAO, whose addresses and public keys use the default prefixes of the process
+func NewDAOAdapter(dao types.KeyDAO, storeType types.StoreType) KeyManager {
+	return NewDAOAdapterWithPrefixes(dao, storeType, types.GetAddrPrefixCfg())
 }
 
-//NewDAOAdapter return a apapter for user DAO
-func NewDAOAdapter(dao types.KeyDAO, storeType types.StoreType) types.KeyManager {
+// NewDAOAdapterWithPrefixes returns a adapter for user DAO, whose addresses and public keys use the given prefixes,
+// e.g. those of a client
+func NewDAOAdapterWithPrefixes(dao types.KeyDAO, storeType types.StoreType, prefixes *types.AddrPrefixCfg) KeyManager {
 	return daoAdapter{
 		keyDAO:    dao,
 		storeType: storeType,
+		prefixes:  prefixes,
+		sessions:  newSessions(),
 	}
 }
 
 func (adapter daoAdapter) Sign(name, password string, data []byte) (signature types.Signature, err error) {
-	store, err := adapter.keyDAO.Read(name)
-	if store == nil || err != nil {
-		return signature, fmt.Errorf("name %s not exist", name)
-	}
-
-	var mm crypto.KeyManager
-	switch store := store.(type) {
-	case types.PrivKeyInfo:
-		privKey, err := adapter.keyDAO.Decrypt(store.PrivKey, password)
-		if err != nil {
-			return signature, err
-		}
-		mm, err = crypto.NewPrivateKeyManager(privKey)
-		if err != nil {
-			return signature, err
-		}
-	case types.KeystoreInfo:
-		mm, err = crypto.NewKeyStoreKeyManager(store.Keystore, password)
-		if err != nil {
+	if len(password) == 0 {
+		if signature, ok, err := adapter.sessions.sign(name, data); ok {
 			return signature, err
 		}
 	}
+
+	mm, _, err := adapter.load(name, password)
+	if err != nil {
+		return signature, err
+	}
+	defer mm.Zero()
 	signByte, err := mm.Sign(data)
+	if err != nil {
+		return signature, err
+	}
 
 	return types.Signature{
-		PubKey:    mm.GetPrivKey().PubKey(),
+		PubKey:    mm.PubKey(),
 		Signature: signByte,
 	}, nil
 }
 
 func (adapter daoAdapter) Insert(name, password string) (string, string, error) {
+	return adapter.InsertWithOptions(name, password, types.HDOptions{})
+}
+
+func (adapter daoAdapter) InsertWithOptions(name, password string, opts types.HDOptions) (string, string, error) {
 	if adapter.keyDAO.Has(name) {
 		return "", "", fmt.Errorf("name %s has existed", name)
 	}
 
-	km, err := crypto.NewKeyManager()
+	path := hdPath(opts)
+	km, err := crypto.NewKeyManagerWithHDPath(opts.BIP39Passphrase, path)
 	if err != nil {
 		return "", "", err
 	}
-	address, store, err := adapter.apply(km, password)
+	mnemonic, err := km.ExportAsMnemonic()
 	if err != nil {
 		return "", "", err
 	}
 
-	mnemonic, err := km.ExportAsMnemonic()
+	address, store, err := adapter.applyOptions(km, password, mnemonic, opts, path)
 	if err != nil {
 		return "", "", err
 	}
@@ -80,16 +101,21 @@ func (adapter daoAdapter) Insert(name, password string) (string, string, error)
 }
 
 func (adapter daoAdapter) Recover(name, password, mnemonic string) (string, error) {
+	return adapter.RecoverWithOptions(name, password, mnemonic, types.HDOptions{})
+}
+
+func (adapter daoAdapter) RecoverWithOptions(name, password, mnemonic string, opts types.HDOptions) (string, error) {
 	if adapter.keyDAO.Has(name) {
 		return "", fmt.Errorf("name %s has existed", name)
 	}
 
-	km, err := crypto.NewMnemonicKeyManager(mnemonic)
+	path := hdPath(opts)
+	km, err := crypto.NewMnemonicKeyManagerWithHDPath(mnemonic, opts.BIP39Passphrase, path)
 	if err != nil {
 		return "", err
 	}
 
-	address, store, err := adapter.apply(km, password)
+	address, store, err := adapter.applyOptions(km, password, mnemonic, opts, path)
 	if err != nil {
 		return address, err
 	}
@@ -98,6 +124,58 @@ func (adapter daoAdapter) Recover(name, password, mnemonic string) (string, erro
 	return address, err
 }
 
+func (adapter daoAdapter) RecoverAccounts(password, mnemonic string, opts types.HDOptions,
+	names ...string) ([]types.DerivedAccount, error) {
+	if len(names) == 0 {
+		return nil, errors.New("no account name is given")
+	}
+	if len(opts.HDPath) > 0 && len(names) > 1 {
+		return nil, errors.New("only one account can be derived from a full HD path")
+	}
+
+	seen := make(map[string]bool, len(names))
+	for _, name := range names {
+		if seen[name] || adapter.keyDAO.Has(name) {
+			return nil, fmt.Errorf("name %s has existed", name)
+		}
+		seen[name] = true
+	}
+
+	// derive all the keys before storing any of them
+	accounts := make([]types.DerivedAccount, len(names))
+	stores := make([]types.Store, len(names))
+	for i, name := range names {
+		o := opts
+		o.Index = opts.Index + uint32(i)
+		path := hdPath(o)
+
+		km, err := crypto.NewMnemonicKeyManagerWithHDPath(mnemonic, opts.BIP39Passphrase, path)
+		if err != nil {
+			return nil, err
+		}
+		address, store, err := adapter.applyOptions(km, password, mnemonic, opts, path)
+		if err != nil {
+			return nil, err
+		}
+		accounts[i] = types.DerivedAccount{
+			Name:    name,
+			Address: address,
+			HDPath:  path,
+		}
+		stores[i] = store
+	}
+
+	for i, acc := range accounts {
+		if err := adapter.keyDAO.Write(acc.Name, stores[i]); err != nil {
+			for _, written := range accounts[:i] {
+				_ = adapter.keyDAO.Delete(written.Name)
+			}
+			return nil, err
+		}
+	}
+	return accounts, nil
+}
+
 func (adapter daoAdapter) Import(name, password string, keystore string) (string, error) {
 	if adapter.keyDAO.Has(name) {
 		return "", fmt.Errorf("%s has existed", name)
@@ -114,35 +192,122 @@ func (adapter daoAdapter) Import(name, password string, keystore string) (string
 	return address, adapter.keyDAO.Write(name, s)
 }
 
-func (adapter daoAdapter) Export(name, password, encryptKeystorePwd string) (keystore string, err error) {
-	store, err := adapter.keyDAO.Read(name)
-	if store == nil || err != nil {
-		return keystore, fmt.Errorf("name %s not exist", name)
+func (adapter daoAdapter) ImportPrivKey(name, password, privKey string) (string, error) {
+	if adapter.keyDAO.Has(name) {
+		return "", fmt.Errorf("%s has existed", name)
 	}
 
-	var km crypto.KeyManager
-	switch store := store.(type) {
-	case types.PrivKeyInfo:
-		privKey, err := adapter.keyDAO.Decrypt(store.PrivKey, password)
+	km, err := crypto.NewPrivateKeyManager(privKey)
+	if err != nil {
+		return "", err
+	}
+	address, s, err := adapter.apply(km, password)
+	if err != nil {
+		return "", err
+	}
+	return address, adapter.keyDAO.Write(name, s)
+}
+
+func (adapter daoAdapter) ImportArmor(name, password, armor, passphrase string) (string, error) {
+	if adapter.keyDAO.Has(name) {
+		return "", fmt.Errorf("%s has existed", name)
+	}
+
+	km, err := crypto.NewArmorKeyManager(armor, passphrase)
+	if err != nil {
+		return "", err
+	}
+	address, s, err := adapter.apply(km, password)
+	if err != nil {
+		return "", err
+	}
+	return address, adapter.keyDAO.Write(name, s)
+}
+
+func (adapter daoAdapter) RecoverFromShares(name, password string, shares []string) (string, error) {
+	if adapter.keyDAO.Has(name) {
+		return "", fmt.Errorf("%s has existed", name)
+	}
+
+	secret, err := crypto.CombineShares(shares)
+	if err != nil {
+		return "", err
+	}
+
+	var address string
+	var s types.Store
+	if secret.Kind == crypto.ShareMnemonic {
+		// the mnemonic was split because it was kept with the key, it is kept again
+		km, err := crypto.NewMnemonicKeyManagerWithHDPath(secret.Mnemonic, secret.BIP39Passphrase, secret.HDPath)
 		if err != nil {
 			return "", err
 		}
-		km, err = crypto.NewPrivateKeyManager(privKey)
+		address, s, err = adapter.applyMnemonic(km, password, secret.Mnemonic, secret.BIP39Passphrase, secret.HDPath)
+	} else {
+		km, err := crypto.NewPrivateKeyManager(secret.PrivKey)
 		if err != nil {
 			return "", err
 		}
-	case types.KeystoreInfo:
-		km, err = crypto.NewKeyStoreKeyManager(store.Keystore, password)
+		address, s, err = adapter.apply(km, password)
+	}
+	if err != nil {
+		return "", err
+	}
+	return address, adapter.keyDAO.Write(name, s)
+}
+
+func (adapter daoAdapter) AddWatchOnly(name, address, pubKey string) (string, error) {
+	if adapter.keyDAO.Has(name) {
+		return "", fmt.Errorf("%s has existed", name)
+	}
+	if len(address) == 0 && len(pubKey) == 0 {
+		return "", errors.New("either the address or the public key is required")
+	}
+
+	// the address and the public key are accepted with any prefix, and stored with those of the adapter
+	var addr types.AccAddress
+	if len(address) > 0 {
+		bz, err := types.Bech32Bytes(address)
 		if err != nil {
 			return "", err
 		}
+		addr = bz
+	}
+	if len(pubKey) > 0 {
+		pk, err := types.PubKeyFromBech32(pubKey)
+		if err != nil {
+			return "", err
+		}
+		pkAddress := types.AccAddress(pk.Address())
+		if addr != nil && !addr.Equals(pkAddress) {
+			return "", fmt.Errorf("address %s does not match the public key, expected %s",
+				address, adapter.prefixes.AccAddressString(pkAddress))
+		}
+		addr = pkAddress
+		if pubKey, err = adapter.prefixes.Bech32ifyAccPub(pk); err != nil {
+			return "", err
+		}
 	}
+	address = adapter.prefixes.AccAddressString(addr)
+
+	return address, adapter.keyDAO.Write(name, types.WatchOnlyInfo{
+		Address: address,
+		PubKey:  pubKey,
+	})
+}
+
+func (adapter daoAdapter) Export(name, password, encryptKeystorePwd string) (keystore string, err error) {
+	km, _, err := adapter.load(name, password)
+	if err != nil {
+		return "", err
+	}
+	defer km.Zero()
 	keyStore, err := km.ExportAsKeystore(encryptKeystorePwd)
 	if err != nil {
 		return "", err
 	}
 
-	keyStore.Address = types.AccAddress(km.GetPrivKey().PubKey().Address()).String()
+	keyStore.Address = adapter.prefixes.AccAddressString(types.AccAddress(km.PubKey().Address()))
 	bz, err := json.Marshal(keyStore)
 	if err != nil {
 		return "", err
@@ -150,7 +315,46 @@ func (adapter daoAdapter) Export(name, password, encryptKeystorePwd string) (key
 	return string(bz), nil
 }
 
+func (adapter daoAdapter) ExportArmor(name, password, passphrase string) (string, error) {
+	km, _, err := adapter.load(name, password)
+	if err != nil {
+		return "", err
+	}
+	defer km.Zero()
+	return km.ExportAsArmor(passphrase)
+}
+
+func (adapter daoAdapter) BackupShares(name, password string, threshold, shares int) ([]string, error) {
+	km, store, err := adapter.load(name, password)
+	if err != nil {
+		return nil, err
+	}
+	defer km.Zero()
+	mnemonic, err := adapter.mnemonic(store, password)
+	if err != nil {
+		return nil, err
+	}
+	if len(mnemonic) == 0 {
+		// the key was imported, its mnemonic is unknown
+		return crypto.SplitKey(km, threshold, shares)
+	}
+
+	secret, err := crypto.DecodeMnemonic(mnemonic)
+	if err != nil {
+		return nil, err
+	}
+	mm, err := crypto.NewMnemonicKeyManagerWithHDPath(secret.Mnemonic, secret.BIP39Passphrase, secret.HDPath)
+	if err != nil {
+		return nil, err
+	}
+	if !mm.GetPrivKey().Equals(km.GetPrivKey()) {
+		return nil, fmt.Errorf("the mnemonic kept with %s does not derive its key", name)
+	}
+	return crypto.SplitMnemonic(secret.Mnemonic, secret.BIP39Passphrase, secret.HDPath, threshold, shares)
+}
+
 func (adapter daoAdapter) Delete(name string) error {
+	adapter.sessions.lock(name)
 	return adapter.keyDAO.Delete(name)
 }
 
@@ -162,21 +366,235 @@ func (adapter daoAdapter) Query(name string) (types.AccAddress, error) {
 
 	switch store := store.(type) {
 	case types.PrivKeyInfo:
-		return types.AccAddressFromBech32(store.Address)
+		return types.Bech32Bytes(store.Address)
 	case types.KeystoreInfo:
 		var keystore crypto.Keystore
 		err := json.Unmarshal([]byte(store.Keystore), &keystore)
 		if err != nil {
 			return nil, err
 		}
-		return types.AccAddressFromBech32(keystore.Address)
+		return types.Bech32Bytes(keystore.Address)
+	case types.WatchOnlyInfo:
+		return types.Bech32Bytes(store.Address)
 	}
 	return nil, errors.New("invalid Store")
 }
 
+// QueryPubKey returns the public key stored with the key without decrypting it, only the keys stored by the older
+// versions are decrypted with the password
+func (adapter daoAdapter) QueryPubKey(name, password string) (string, error) {
+	if store, err := adapter.keyDAO.Read(name); err == nil {
+		switch info := store.(type) {
+		case types.WatchOnlyInfo:
+			if len(info.PubKey) == 0 {
+				return "", fmt.Errorf("the public key of the watch-only account %s is unknown", name)
+			}
+			return info.PubKey, nil
+		case types.PrivKeyInfo:
+			if len(info.PubKey) > 0 {
+				return adapter.pubKey(info.PubKey)
+			}
+		case types.KeystoreInfo:
+			if len(info.PubKey) > 0 {
+				return adapter.pubKey(info.PubKey)
+			}
+		}
+	}
+
+	if len(password) == 0 {
+		if pubKey, ok := adapter.sessions.pubKey(name); ok {
+			return adapter.prefixes.Bech32ifyAccPub(pubKey)
+		}
+	}
+
+	km, _, err := adapter.load(name, password)
+	if err != nil {
+		return "", err
+	}
+	defer km.Zero()
+	return adapter.prefixes.Bech32ifyAccPub(km.PubKey())
+}
+
+func (adapter daoAdapter) List() ([]types.KeyInfo, error) {
+	names, err := types.ListAccounts(adapter.keyDAO)
+	if err != nil {
+		return nil, err
+	}
+
+	infos := make([]types.KeyInfo, 0, len(names))
+	for _, name := range names {
+		store, err := adapter.keyDAO.Read(name)
+		if store == nil || err != nil {
+			return nil, fmt.Errorf("name %s not exist", name)
+		}
+		address, err := adapter.Query(name)
+		if err != nil {
+			return nil, err
+		}
+		infos = append(infos, types.KeyInfo{
+			Name:      name,
+			Address:   adapter.prefixes.AccAddressString(address),
+			StoreType: store.GetType(),
+		})
+	}
+	return infos, nil
+}
+
+func (adapter daoAdapter) Rename(name, newName string) error {
+	if adapter.keyDAO.Has(newName) {
+		return fmt.Errorf("name %s has existed", newName)
+	}
+	store, err := adapter.keyDAO.Read(name)
+	if store == nil || err != nil {
+		return fmt.Errorf("name %s not exist", name)
+	}
+
+	if err := adapter.keyDAO.Write(newName, store); err != nil {
+		return err
+	}
+
+	// the key may have been replaced meanwhile, e.g. by ChangePassword: the new name takes the latest key
+	if current, err := adapter.keyDAO.Read(name); err == nil && current != nil && current != store {
+		if err := types.ReplaceAccount(adapter.keyDAO, newName, current); err != nil {
+			_ = adapter.keyDAO.Delete(newName)
+			return err
+		}
+	}
+	adapter.sessions.lock(name)
+	if err := adapter.keyDAO.Delete(name); err != nil {
+		_ = adapter.keyDAO.Delete(newName)
+		return err
+	}
+	return nil
+}
+
+func (adapter daoAdapter) Unlock(name, password string, timeout time.Duration) error {
+	km, _, err := adapter.load(name, password)
+	if err != nil {
+		return err
+	}
+	// the session owns the decrypted key, it is zeroed when the key is locked
+	adapter.sessions.unlock(name, km, timeout)
+	return nil
+}
+
+func (adapter daoAdapter) Lock(name string) {
+	adapter.sessions.lock(name)
+}
+
+func (adapter daoAdapter) LockAll() {
+	adapter.sessions.lockAll()
+}
+
+func (adapter daoAdapter) Unlocked(name string) bool {
+	return adapter.sessions.unlocked(name)
+}
+
+func (adapter daoAdapter) ChangePassword(name, password, newPassword string) error {
+	km, store, err := adapter.load(name, password)
+	if err != nil {
+		return err
+	}
+	defer km.Zero()
+	mnemonic, err := adapter.mnemonic(store, password)
+	if err != nil {
+		return err
+	}
+	_, newStore, err := adapter.applyAs(km, newPassword, store.GetType(), mnemonic)
+	if err != nil {
+		return err
+	}
+
+	// the key is never missing from the keybase, it keeps the old password if the replacement fails
+	return types.ReplaceAccount(adapter.keyDAO, name, newStore)
+}
+
+// load reads the key and decrypts it with the password
+func (adapter daoAdapter) load(name, password string) (crypto.KeyManager, types.Store, error) {
+	store, err := adapter.keyDAO.Read(name)
+	if store == nil || err != nil {
+		return nil, nil, fmt.Errorf("name %s not exist", name)
+	}
+
+	switch store := store.(type) {
+	case types.PrivKeyInfo:
+		privKey, err := adapter.keyDAO.Decrypt(store.PrivKey, password)
+		if err != nil {
+			return nil, nil, err
+		}
+		km, err := crypto.NewPrivateKeyManager(privKey)
+		return km, store, err
+	case types.KeystoreInfo:
+		km, err := crypto.NewKeyStoreKeyManager(store.Keystore, password)
+		return km, store, err
+	case types.WatchOnlyInfo:
+		return nil, nil, fmt.Errorf("%s is a watch-only account, its transactions must be signed externally", name)
+	}
+	return nil, nil, errors.New("invalid Store")
+}
+
+// pubKey encodes a stored public key with the account public key prefix of the adapter, whatever its prefix
+func (adapter daoAdapter) pubKey(pubKey string) (string, error) {
+	pk, err := types.PubKeyFromBech32(pubKey)
+	if err != nil {
+		return "", err
+	}
+	return adapter.prefixes.Bech32ifyAccPub(pk)
+}
+
+// mnemonic decrypts the mnemonic kept with the key, encoded by crypto.EncodeMnemonic, it is empty for the imported keys
+func (adapter daoAdapter) mnemonic(store types.Store, password string) (string, error) {
+	var mnemonic string
+	switch store := store.(type) {
+	case types.PrivKeyInfo:
+		mnemonic = store.Mnemonic
+	case types.KeystoreInfo:
+		mnemonic = store.Mnemonic
+	}
+	if len(mnemonic) == 0 {
+		return "", nil
+	}
+	return adapter.keyDAO.Decrypt(mnemonic, password)
+}
+
 func (adapter daoAdapter) apply(km crypto.KeyManager, password string) (address string, store types.Store, err error) {
-	address = types.AccAddress(km.GetPrivKey().PubKey().Address()).String()
-	switch adapter.storeType {
+	return adapter.applyAs(km, password, adapter.storeType, "")
+}
+
+// applyOptions keeps the mnemonic with the key only if the caller opted in with opts.KeepMnemonic
+func (adapter daoAdapter) applyOptions(km crypto.KeyManager, password, mnemonic string, opts types.HDOptions,
+	hdPath string) (address string, store types.Store, err error) {
+	if !opts.KeepMnemonic {
+		return adapter.apply(km, password)
+	}
+	return adapter.applyMnemonic(km, password, mnemonic, opts.BIP39Passphrase, hdPath)
+}
+
+// applyMnemonic keeps the mnemonic and the HD path of the key with it, BackupShares splits them
+func (adapter daoAdapter) applyMnemonic(km crypto.KeyManager, password, mnemonic, bip39Passphrase,
+	hdPath string) (address string, store types.Store, err error) {
+	encoded, err := crypto.EncodeMnemonic(mnemonic, bip39Passphrase, hdPath)
+	if err != nil {
+		return "", nil, err
+	}
+	return adapter.applyAs(km, password, adapter.storeType, encoded)
+}
+
+// applyAs encrypts the key, and the encoded mnemonic if it is not empty, with the password
+func (adapter daoAdapter) applyAs(km crypto.KeyManager, password string,
+	storeType types.StoreType, mnemonic string) (address string, store types.Store, err error) {
+	if len(mnemonic) > 0 {
+		if mnemonic, err = adapter.keyDAO.Encrypt(mnemonic, password); err != nil {
+			return "", nil, err
+		}
+	}
+
+	address = adapter.prefixes.AccAddressString(types.AccAddress(km.PubKey().Address()))
+	pubKey, err := adapter.prefixes.Bech32ifyAccPub(km.PubKey())
+	if err != nil {
+		return "", nil, err
+	}
+	switch storeType {
 	case types.Keystore:
 		keystore, err := km.ExportAsKeystore(password)
 		if err != nil {
@@ -191,6 +609,8 @@ func (adapter daoAdapter) apply(km crypto.KeyManager, password string) (address
 
 		store = types.KeystoreInfo{
 			Keystore: string(bz),
+			PubKey:   pubKey,
+			Mnemonic: mnemonic,
 		}
 		return address, store, nil
 	case types.PrivKey:
@@ -203,10 +623,19 @@ func (adapter daoAdapter) apply(km crypto.KeyManager, password string) (address
 			return "", nil, err
 		}
 		store = types.PrivKeyInfo{
-			PrivKey: pk,
-			Address: address,
+			PrivKey:  pk,
+			Address:  address,
+			PubKey:   pubKey,
+			Mnemonic: mnemonic,
 		}
 		return address, store, nil
 	}
-	return address, store, fmt.Errorf("invalid storeType:%d", adapter.storeType)
+	return address, store, fmt.Errorf("invalid storeType:%d", storeType)
+}
+
+func hdPath(opts types.HDOptions) string {
+	if len(opts.HDPath) > 0 {
+		return opts.HDPath
+	}
+	return crypto.NewHDPath(opts.Account, opts.Change, opts.Index)
 }
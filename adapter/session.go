@@ -0,0 +1,111 @@
+package adapter
+
+import (
+	"sync"
+	"time"
+
+	tmcrypto "github.com/tendermint/tendermint/crypto"
+
+	"github.com/irisnet/irishub-sdk-go/crypto"
+	"github.com/irisnet/irishub-sdk-go/types"
+)
+
+// session is an unlocked key, its private key is zeroed when it is locked
+type session struct {
+	km    crypto.KeyManager
+	timer *time.Timer
+}
+
+// sessions holds the unlocked keys by name
+type sessions struct {
+	mu   sync.Mutex
+	keys map[string]*session
+}
+
+func newSessions() *sessions {
+	return &sessions{
+		keys: make(map[string]*session),
+	}
+}
+
+// unlock keeps the key manager until timeout, or until lock when timeout is 0
+func (s *sessions) unlock(name string, km crypto.KeyManager, timeout time.Duration) {
+	sess := &session{km: km}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.remove(name)
+	if timeout > 0 {
+		sess.timer = time.AfterFunc(timeout, func() {
+			s.mu.Lock()
+			defer s.mu.Unlock()
+			// the key may have been locked and unlocked again meanwhile
+			if s.keys[name] == sess {
+				s.remove(name)
+			}
+		})
+	}
+	s.keys[name] = sess
+}
+
+func (s *sessions) sign(name string, data []byte) (types.Signature, bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	sess, ok := s.keys[name]
+	if !ok {
+		return types.Signature{}, false, nil
+	}
+
+	signature, err := sess.km.Sign(data)
+	if err != nil {
+		return types.Signature{}, true, err
+	}
+	return types.Signature{
+		PubKey:    sess.km.PubKey(),
+		Signature: signature,
+	}, true, nil
+}
+
+func (s *sessions) pubKey(name string) (tmcrypto.PubKey, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	sess, ok := s.keys[name]
+	if !ok {
+		return nil, false
+	}
+	return sess.km.PubKey(), true
+}
+
+func (s *sessions) unlocked(name string) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	_, ok := s.keys[name]
+	return ok
+}
+
+func (s *sessions) lock(name string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.remove(name)
+}
+
+func (s *sessions) lockAll() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for name := range s.keys {
+		s.remove(name)
+	}
+}
+
+// remove zeroes the private key of the session, s.mu must be held
+func (s *sessions) remove(name string) {
+	sess, ok := s.keys[name]
+	if !ok {
+		return
+	}
+	if sess.timer != nil {
+		sess.timer.Stop()
+	}
+	sess.km.Zero()
+	delete(s.keys, name)
+}
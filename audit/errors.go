@@ -0,0 +1,14 @@
+package audit
+
+import "fmt"
+
+// TamperError is returned when an entry of the log does not match the hash chain
+type TamperError struct {
+	// Index of the first invalid entry
+	Index  uint64
+	Reason string
+}
+
+func (e *TamperError) Error() string {
+	return fmt.Sprintf("audit log entry %d has been tampered with: %s", e.Index, e.Reason)
+}
@@ -0,0 +1,264 @@
+// Package audit implements a tamper-evident log of the transactions signed by the sdk.
+//
+// The log is an append-only file of JSON lines, every entry holds the hash of the previous one
+// so that modifying, inserting or removing an entry breaks the chain. Removing the last entries
+// can only be detected by comparing the Head with a copy kept elsewhere.
+package audit
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/irisnet/irishub-sdk-go/types"
+)
+
+var _ types.SignatureLog = &Log{}
+
+// Entry is a record of the log
+type Entry struct {
+	Index uint64 `json:"index"`
+	// Time is formatted with RFC3339Nano in UTC
+	Time string `json:"time"`
+	types.SignatureRecord
+	PrevHash string `json:"prev_hash"`
+	Hash     string `json:"hash,omitempty"`
+}
+
+// Head identifies the last entry of a log
+type Head struct {
+	Entries uint64 `json:"entries"`
+	Hash    string `json:"hash"`
+}
+
+// Log is a SignatureLog written to a file, it is safe for concurrent use
+// but the file must only be written by one Log.
+type Log struct {
+	mu   sync.Mutex
+	path string
+	file *os.File
+	head Head
+}
+
+// Open opens or creates the log at path, the existing entries are verified. A last line without newline,
+// the one torn by a crash while it was appended, is removed first: Append writes each entry with its newline.
+func Open(path string) (*Log, error) {
+	file, err := os.OpenFile(path, os.O_RDWR|os.O_CREATE|os.O_APPEND, 0600)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := truncateIncomplete(file); err != nil {
+		_ = file.Close()
+		return nil, err
+	}
+	head, err := Verify(file)
+	if err != nil {
+		_ = file.Close()
+		return nil, err
+	}
+	return &Log{
+		path: path,
+		file: file,
+		head: head,
+	}, nil
+}
+
+// truncateIncomplete removes the bytes of the file after its last newline
+func truncateIncomplete(file *os.File) error {
+	info, err := file.Stat()
+	if err != nil {
+		return err
+	}
+	size := info.Size()
+
+	buf := make([]byte, 4096)
+	end := size
+	for end > 0 {
+		n := int64(len(buf))
+		if end < n {
+			n = end
+		}
+		if _, err := file.ReadAt(buf[:n], end-n); err != nil {
+			return err
+		}
+		if i := bytes.LastIndexByte(buf[:n], '\n'); i >= 0 {
+			end = end - n + int64(i) + 1
+			break
+		}
+		end -= n
+	}
+	if end == size {
+		return nil
+	}
+	if err := file.Truncate(end); err != nil {
+		return err
+	}
+	return file.Sync()
+}
+
+// Append adds a record at the end of the log, the file is synced before it returns
+func (l *Log) Append(record types.SignatureRecord) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if l.file == nil {
+		return fmt.Errorf("audit log %s is closed", l.path)
+	}
+
+	entry := Entry{
+		Index:           l.head.Entries,
+		Time:            time.Now().UTC().Format(time.RFC3339Nano),
+		SignatureRecord: record,
+		PrevHash:        l.head.Hash,
+	}
+	hash, err := entry.hash()
+	if err != nil {
+		return err
+	}
+	entry.Hash = hash
+
+	bz, err := json.Marshal(entry)
+	if err != nil {
+		return err
+	}
+	if _, err := l.file.Write(append(bz, '\n')); err != nil {
+		return err
+	}
+	if err := l.file.Sync(); err != nil {
+		return err
+	}
+
+	l.head = Head{Entries: entry.Index + 1, Hash: entry.Hash}
+	return nil
+}
+
+// Head returns the last entry of the log, keep it elsewhere to detect a truncation of the log
+func (l *Log) Head() Head {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.head
+}
+
+// Verify checks the whole file, including the entries written since it was opened
+func (l *Log) Verify() (Head, error) {
+	return l.Export(ioutil.Discard)
+}
+
+// Export verifies the log and writes its entries to w as JSON lines
+func (l *Log) Export(w io.Writer) (Head, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	file, err := os.Open(l.path)
+	if err != nil {
+		return Head{}, err
+	}
+	defer file.Close()
+
+	head, err := read(file, func(entry Entry) error {
+		bz, err := json.Marshal(entry)
+		if err != nil {
+			return err
+		}
+		_, err = w.Write(append(bz, '\n'))
+		return err
+	})
+	if err != nil {
+		return head, err
+	}
+	if head != l.head {
+		return head, &TamperError{Index: head.Entries, Reason: "the log does not end with the last appended entry"}
+	}
+	return head, nil
+}
+
+// Close closes the file of the log
+func (l *Log) Close() error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if l.file == nil {
+		return nil
+	}
+	err := l.file.Close()
+	l.file = nil
+	return err
+}
+
+// Verify checks the hash chain of the JSON lines read from r, e.g. an exported log.
+// It returns a TamperError for the first invalid entry.
+func Verify(r io.Reader) (Head, error) {
+	return read(r, func(Entry) error { return nil })
+}
+
+// ReadAll verifies the JSON lines read from r and returns their entries
+func ReadAll(r io.Reader) ([]Entry, error) {
+	var entries []Entry
+	_, err := read(r, func(entry Entry) error {
+		entries = append(entries, entry)
+		return nil
+	})
+	return entries, err
+}
+
+func read(r io.Reader, handle func(Entry) error) (Head, error) {
+	var head Head
+	reader := bufio.NewReader(r)
+	for {
+		line, err := reader.ReadBytes('\n')
+		if err == io.EOF && len(line) == 0 {
+			return head, nil
+		}
+		if err != nil && err != io.EOF {
+			return head, err
+		}
+		if err == io.EOF {
+			return head, &TamperError{Index: head.Entries, Reason: "incomplete entry"}
+		}
+
+		var entry Entry
+		decoder := json.NewDecoder(bytes.NewReader(line))
+		decoder.DisallowUnknownFields()
+		if err := decoder.Decode(&entry); err != nil {
+			return head, &TamperError{Index: head.Entries, Reason: err.Error()}
+		}
+		if entry.Index != head.Entries {
+			return head, &TamperError{Index: head.Entries, Reason: fmt.Sprintf("unexpected index %d", entry.Index)}
+		}
+		if entry.PrevHash != head.Hash {
+			return head, &TamperError{Index: head.Entries, Reason: "previous hash mismatch"}
+		}
+		hash, err := entry.hash()
+		if err != nil {
+			return head, err
+		}
+		if entry.Hash != hash {
+			return head, &TamperError{Index: head.Entries, Reason: "hash mismatch"}
+		}
+
+		if err := handle(entry); err != nil {
+			return head, err
+		}
+		head = Head{Entries: entry.Index + 1, Hash: entry.Hash}
+	}
+}
+
+// hash returns the hex encoded SHA-256 of the JSON encoding of the entry without its hash
+func (e Entry) hash() (string, error) {
+	e.Hash = ""
+	bz, err := json.Marshal(e)
+	if err != nil {
+		return "", err
+	}
+	sum := sha256.Sum256(bz)
+	return hex.EncodeToString(sum[:]), nil
+}
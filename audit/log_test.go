@@ -0,0 +1,234 @@
+package audit_test
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+	"github.com/tendermint/tendermint/crypto/tmhash"
+	cmn "github.com/tendermint/tendermint/libs/common"
+
+	"github.com/irisnet/irishub-sdk-go/adapter"
+	"github.com/irisnet/irishub-sdk-go/audit"
+	"github.com/irisnet/irishub-sdk-go/types"
+)
+
+type AuditTestSuite struct {
+	suite.Suite
+	dir  string
+	path string
+}
+
+func TestAuditTestSuite(t *testing.T) {
+	suite.Run(t, new(AuditTestSuite))
+}
+
+func (ats *AuditTestSuite) SetupTest() {
+	dir, err := ioutil.TempDir("", "audit")
+	require.NoError(ats.T(), err)
+	ats.dir = dir
+	ats.path = filepath.Join(dir, "audit.log")
+}
+
+func (ats *AuditTestSuite) TearDownTest() {
+	_ = os.RemoveAll(ats.dir)
+}
+
+func (ats *AuditTestSuite) TestAppendAndVerify() {
+	log, err := audit.Open(ats.path)
+	require.NoError(ats.T(), err)
+	for i := 0; i < 3; i++ {
+		require.NoError(ats.T(), log.Append(record(uint64(i))))
+	}
+	head, err := log.Verify()
+	require.NoError(ats.T(), err)
+	require.Equal(ats.T(), uint64(3), head.Entries)
+	require.Equal(ats.T(), log.Head(), head)
+	require.NoError(ats.T(), log.Close())
+	require.Error(ats.T(), log.Append(record(3)))
+
+	// the chain continues after reopening the log
+	log, err = audit.Open(ats.path)
+	require.NoError(ats.T(), err)
+	require.Equal(ats.T(), head, log.Head())
+	require.NoError(ats.T(), log.Append(record(3)))
+
+	var buf bytes.Buffer
+	head, err = log.Export(&buf)
+	require.NoError(ats.T(), err)
+	require.Equal(ats.T(), uint64(4), head.Entries)
+	require.NoError(ats.T(), log.Close())
+
+	entries, err := audit.ReadAll(&buf)
+	require.NoError(ats.T(), err)
+	require.Len(ats.T(), entries, 4)
+	for i, entry := range entries {
+		require.Equal(ats.T(), uint64(i), entry.Index)
+		require.Equal(ats.T(), record(uint64(i)), entry.SignatureRecord)
+		if i > 0 {
+			require.Equal(ats.T(), entries[i-1].Hash, entry.PrevHash)
+		}
+	}
+	require.Equal(ats.T(), head.Hash, entries[3].Hash)
+}
+
+func (ats *AuditTestSuite) TestTamper() {
+	log, err := audit.Open(ats.path)
+	require.NoError(ats.T(), err)
+	for i := 0; i < 3; i++ {
+		require.NoError(ats.T(), log.Append(record(uint64(i))))
+	}
+	require.NoError(ats.T(), log.Close())
+
+	bz, err := ioutil.ReadFile(ats.path)
+	require.NoError(ats.T(), err)
+	lines := strings.SplitAfter(string(bz), "\n")[:3]
+
+	tests := []struct {
+		name    string
+		content string
+		index   uint64
+	}{
+		{"modified", lines[0] + strings.Replace(lines[1], `"sequence":1`, `"sequence":9`, 1) + lines[2], 1},
+		{"removed", lines[0] + lines[2], 1},
+		{"swapped", lines[1] + lines[0] + lines[2], 0},
+		{"unknown field", lines[0] + strings.Replace(lines[1], `{`, `{"extra":1,`, 1) + lines[2], 1},
+	}
+	for _, tc := range tests {
+		_, err := audit.Verify(strings.NewReader(tc.content))
+		var tamper *audit.TamperError
+		require.True(ats.T(), errors.As(err, &tamper), "%s: %v", tc.name, err)
+		require.Equal(ats.T(), tc.index, tamper.Index, tc.name)
+
+		require.NoError(ats.T(), ioutil.WriteFile(ats.path, []byte(tc.content), 0600))
+		_, err = audit.Open(ats.path)
+		require.Error(ats.T(), err, tc.name)
+	}
+
+	// an incomplete entry is a tamper in an exported log
+	_, err = audit.Verify(strings.NewReader(lines[0] + strings.TrimSuffix(lines[1], "\n")))
+	var tamper *audit.TamperError
+	require.True(ats.T(), errors.As(err, &tamper), err)
+	require.Equal(ats.T(), uint64(1), tamper.Index)
+
+	// the truncation of the last entries is only detected with a copy of the head
+	require.NoError(ats.T(), ioutil.WriteFile(ats.path, []byte(lines[0]+lines[1]), 0600))
+	log, err = audit.Open(ats.path)
+	require.NoError(ats.T(), err)
+	require.Equal(ats.T(), uint64(2), log.Head().Entries)
+	require.NoError(ats.T(), log.Close())
+}
+
+func (ats *AuditTestSuite) TestTornEntry() {
+	log, err := audit.Open(ats.path)
+	require.NoError(ats.T(), err)
+	for i := 0; i < 2; i++ {
+		require.NoError(ats.T(), log.Append(record(uint64(i))))
+	}
+	head := log.Head()
+	require.NoError(ats.T(), log.Close())
+
+	bz, err := ioutil.ReadFile(ats.path)
+	require.NoError(ats.T(), err)
+	for _, torn := range []string{`{"index":2,"ti`, strings.SplitAfter(string(bz), "\n")[1][:40]} {
+		// a crash tore the last entry while it was appended, it is removed when the log is opened
+		require.NoError(ats.T(), ioutil.WriteFile(ats.path, append(bz, torn...), 0600))
+		log, err = audit.Open(ats.path)
+		require.NoError(ats.T(), err)
+		require.Equal(ats.T(), head, log.Head())
+		content, err := ioutil.ReadFile(ats.path)
+		require.NoError(ats.T(), err)
+		require.Equal(ats.T(), bz, content)
+
+		require.NoError(ats.T(), log.Append(record(2)))
+		head2, err := log.Verify()
+		require.NoError(ats.T(), err)
+		require.Equal(ats.T(), uint64(3), head2.Entries)
+		require.NoError(ats.T(), log.Close())
+	}
+
+	// a log whose only line is torn is emptied
+	require.NoError(ats.T(), ioutil.WriteFile(ats.path, []byte(`{"index":0`), 0600))
+	log, err = audit.Open(ats.path)
+	require.NoError(ats.T(), err)
+	require.Equal(ats.T(), audit.Head{}, log.Head())
+	require.NoError(ats.T(), log.Close())
+}
+
+func (ats *AuditTestSuite) TestTxContextSign() {
+	cdc := types.NewAminoCodec()
+	types.RegisterCodec(cdc)
+	cdc.RegisterConcrete(testMsg{}, "audit/testMsg")
+
+	km := adapter.NewDAOAdapter(types.NewMemoryDB(), types.PrivKey)
+	address, _, err := km.Insert("hot", "password")
+	require.NoError(ats.T(), err)
+
+	log, err := audit.Open(ats.path)
+	require.NoError(ats.T(), err)
+	defer log.Close()
+
+	txCtx := &types.TxContext{}
+	txCtx.WithCodec(cdc).
+		WithChainID("test").
+		WithAccountNumber(1).
+		WithSequence(2).
+		WithKeyManager(km).
+		WithPassword("password").
+		WithSignatureLog(log)
+
+	tx, err := txCtx.BuildAndSign("hot", []types.Msg{testMsg{}})
+	require.NoError(ats.T(), err)
+	txBytes, err := cdc.MarshalBinaryLengthPrefixed(tx)
+	require.NoError(ats.T(), err)
+
+	_, err = txCtx.WithSimulate(true).BuildAndSign("hot", []types.Msg{testMsg{}})
+	require.NoError(ats.T(), err)
+
+	var buf bytes.Buffer
+	_, err = log.Export(&buf)
+	require.NoError(ats.T(), err)
+	entries, err := audit.ReadAll(&buf)
+	require.NoError(ats.T(), err)
+	require.Len(ats.T(), entries, 1)
+	require.Equal(ats.T(), "hot", entries[0].Name)
+	require.Equal(ats.T(), address, entries[0].Address)
+	require.Equal(ats.T(), "test", entries[0].ChainID)
+	require.Equal(ats.T(), uint64(1), entries[0].AccountNumber)
+	require.Equal(ats.T(), uint64(2), entries[0].Sequence)
+	require.Equal(ats.T(), []string{"audit/test"}, entries[0].Msgs)
+	require.Equal(ats.T(), cmn.HexBytes(tmhash.Sum(txBytes)).String(), entries[0].TxHash)
+
+	// a transaction which can not be recorded is not signed
+	require.NoError(ats.T(), log.Close())
+	_, err = txCtx.WithSimulate(false).BuildAndSign("hot", []types.Msg{testMsg{}})
+	require.Error(ats.T(), err)
+}
+
+func record(sequence uint64) types.SignatureRecord {
+	return types.SignatureRecord{
+		Name:          "hot",
+		Address:       "faa1address",
+		ChainID:       "test",
+		AccountNumber: 1,
+		Sequence:      sequence,
+		Msgs:          []string{"bank/send"},
+		SignBytesHash: fmt.Sprintf("%064d", sequence),
+		TxHash:        fmt.Sprintf("%064X", sequence),
+	}
+}
+
+type testMsg struct{}
+
+func (testMsg) Route() string                  { return "audit" }
+func (testMsg) Type() string                   { return "test" }
+func (testMsg) ValidateBasic() error           { return nil }
+func (testMsg) GetSignBytes() []byte           { return []byte(`{"test":true}`) }
+func (testMsg) GetSigners() []types.AccAddress { return nil }
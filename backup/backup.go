@@ -0,0 +1,281 @@
+// Package backup exports all the keys of a KeyDAO to one archive encrypted with a backup passphrase,
+// and restores them into another KeyDAO, e.g. to move a signing service to a new host.
+//
+// The keys are archived as they are stored: the private keys stay encrypted with their own passwords,
+// the archive is encrypted once more with the backup passphrase by AESGCM.
+package backup
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/irisnet/irishub-sdk-go/crypto"
+	"github.com/irisnet/irishub-sdk-go/types"
+)
+
+// Version is the version of the archives written by Export
+const Version = 1
+
+// Conflict tells Restore what to do with a key whose name is already used
+type Conflict int
+
+const (
+	// Skip keeps the existing key
+	Skip Conflict = iota
+	// Overwrite replaces the existing key
+	Overwrite
+	// Rename restores the key as <name>-<n> with the first free n
+	Rename
+)
+
+// Archive is the JSON encoding of a backup
+type Archive struct {
+	Version int `json:"version"`
+	// Created is formatted with RFC3339 in UTC
+	Created string `json:"created"`
+	// Data is the encrypted JSON encoding of the keys
+	Data string `json:"data"`
+}
+
+// Key is an archived key, PrivKey and Mnemonic are encrypted as by the exported KeyDAO. PubKey is the public key
+// stored with the key, if any.
+type Key struct {
+	Name      string          `json:"name"`
+	StoreType types.StoreType `json:"store_type"`
+	Address   string          `json:"address"`
+	PrivKey   string          `json:"priv_key,omitempty"`
+	Keystore  string          `json:"keystore,omitempty"`
+	PubKey    string          `json:"pub_key,omitempty"`
+	Mnemonic  string          `json:"mnemonic,omitempty"`
+}
+
+// RestoreOptions configures Restore
+type RestoreOptions struct {
+	Conflict Conflict
+	// Passwords returns the passwords of the archived keys, each key is then decrypted and its address
+	// derived and compared to the archived one. Without it only the watch-only keys are verified.
+	Passwords types.PasswordProvider
+}
+
+// Result is the outcome of the restore of a key
+type Result struct {
+	Name    string `json:"name"`
+	Address string `json:"address"`
+	// RestoredAs is the name of the restored key, empty if the key has been skipped
+	RestoredAs string `json:"restored_as"`
+	// Verified reports whether the address has been derived from the key
+	Verified bool `json:"verified"`
+}
+
+// Export archives all the keys of dao
+func Export(dao types.KeyDAO, passphrase string) ([]byte, error) {
+	if len(passphrase) == 0 {
+		return nil, errors.New("the backup passphrase is required")
+	}
+
+	names, err := types.ListAccounts(dao)
+	if err != nil {
+		return nil, err
+	}
+	keys := make([]Key, 0, len(names))
+	for _, name := range names {
+		store, err := dao.Read(name)
+		if err != nil {
+			return nil, err
+		}
+		if store == nil {
+			// deleted meanwhile
+			continue
+		}
+		key, err := newKey(name, store)
+		if err != nil {
+			return nil, err
+		}
+		keys = append(keys, key)
+	}
+
+	bz, err := json.Marshal(keys)
+	if err != nil {
+		return nil, err
+	}
+	data, err := types.AESGCM{}.Encrypt(string(bz), passphrase)
+	if err != nil {
+		return nil, err
+	}
+	return json.MarshalIndent(Archive{
+		Version: Version,
+		Created: time.Now().UTC().Format(time.RFC3339),
+		Data:    data,
+	}, "", "  ")
+}
+
+// Open decrypts the archive and returns its keys
+func Open(archive []byte, passphrase string) ([]Key, error) {
+	var a Archive
+	if err := json.Unmarshal(archive, &a); err != nil {
+		return nil, fmt.Errorf("invalid archive: %s", err.Error())
+	}
+	if a.Version != Version {
+		return nil, fmt.Errorf("unsupported archive version: %d", a.Version)
+	}
+
+	bz, err := types.AESGCM{}.Decrypt(a.Data, passphrase)
+	if err != nil {
+		return nil, err
+	}
+	var keys []Key
+	if err := json.Unmarshal([]byte(bz), &keys); err != nil {
+		return nil, fmt.Errorf("invalid archive: %s", err.Error())
+	}
+	return keys, nil
+}
+
+// Restore writes the keys of the archive into dao. All the keys are verified before any is written,
+// the first invalid key fails the restore.
+func Restore(dao types.KeyDAO, archive []byte, passphrase string, opts RestoreOptions) ([]Result, error) {
+	keys, err := Open(archive, passphrase)
+	if err != nil {
+		return nil, err
+	}
+
+	results := make([]Result, len(keys))
+	archived := make(map[string]bool, len(keys))
+	for i, key := range keys {
+		verified, err := verify(dao, key, opts.Passwords)
+		if err != nil {
+			return nil, fmt.Errorf("failed to verify %s: %s", key.Name, err.Error())
+		}
+		results[i] = Result{Name: key.Name, Address: key.Address, Verified: verified}
+		archived[key.Name] = true
+	}
+
+	for i, key := range keys {
+		name := key.Name
+		if dao.Has(name) {
+			switch opts.Conflict {
+			case Skip:
+				continue
+			case Overwrite:
+				// the existing key is replaced in one step, it is kept if the restore fails
+				if err := types.ReplaceAccount(dao, name, key.store()); err != nil {
+					return results, fmt.Errorf("failed to restore %s: %s", key.Name, err.Error())
+				}
+				results[i].RestoredAs = name
+				continue
+			case Rename:
+				name = freeName(dao, name, archived)
+			default:
+				return results, fmt.Errorf("unknown conflict resolution: %d", opts.Conflict)
+			}
+		}
+
+		if err := dao.Write(name, key.store()); err != nil {
+			return results, fmt.Errorf("failed to restore %s: %s", key.Name, err.Error())
+		}
+		archived[name] = true
+		results[i].RestoredAs = name
+	}
+	return results, nil
+}
+
+func newKey(name string, store types.Store) (Key, error) {
+	key := Key{Name: name, StoreType: store.GetType()}
+	switch store := store.(type) {
+	case types.PrivKeyInfo:
+		key.Address, key.PrivKey, key.PubKey, key.Mnemonic = store.Address, store.PrivKey, store.PubKey, store.Mnemonic
+	case types.KeystoreInfo:
+		var keystore crypto.Keystore
+		if err := json.Unmarshal([]byte(store.Keystore), &keystore); err != nil {
+			return key, fmt.Errorf("invalid keystore of %s: %s", name, err.Error())
+		}
+		key.Address, key.Keystore, key.PubKey, key.Mnemonic = keystore.Address, store.Keystore, store.PubKey, store.Mnemonic
+	case types.WatchOnlyInfo:
+		key.Address, key.PubKey = store.Address, store.PubKey
+	default:
+		return key, fmt.Errorf("unsupported store type of %s: %s", name, store.GetType())
+	}
+	return key, nil
+}
+
+func (k Key) store() types.Store {
+	switch k.StoreType {
+	case types.PrivKey:
+		return types.PrivKeyInfo{PrivKey: k.PrivKey, Address: k.Address, PubKey: k.PubKey, Mnemonic: k.Mnemonic}
+	case types.Keystore:
+		return types.KeystoreInfo{Keystore: k.Keystore, PubKey: k.PubKey, Mnemonic: k.Mnemonic}
+	}
+	return types.WatchOnlyInfo{Address: k.Address, PubKey: k.PubKey}
+}
+
+// verify derives the address of the key and compares it to the archived one
+func verify(dao types.KeyDAO, key Key, passwords types.PasswordProvider) (bool, error) {
+	var address types.AccAddress
+	switch key.StoreType {
+	case types.PrivKey, types.Keystore:
+		if passwords == nil {
+			return false, nil
+		}
+		password, err := passwords.Password(key.Name)
+		if err != nil {
+			return false, err
+		}
+
+		var km crypto.KeyManager
+		if key.StoreType == types.PrivKey {
+			privKey, err := dao.Decrypt(key.PrivKey, password)
+			if err != nil {
+				return false, err
+			}
+			if km, err = crypto.NewPrivateKeyManager(privKey); err != nil {
+				return false, err
+			}
+		} else if km, err = crypto.NewKeyStoreKeyManager(key.Keystore, password); err != nil {
+			return false, err
+		}
+		address = types.AccAddress(km.GetPrivKey().PubKey().Address())
+		// the stored public key is returned without decrypting the key
+		if len(key.PubKey) > 0 {
+			pubKey, err := types.PubKeyFromBech32(key.PubKey)
+			if err != nil {
+				return false, err
+			}
+			if !pubKey.Equals(km.GetPrivKey().PubKey()) {
+				return false, fmt.Errorf("the public key of %s is not the one of its key", key.Name)
+			}
+		}
+	case types.WatchOnly:
+		if len(key.PubKey) == 0 {
+			_, err := types.Bech32Bytes(key.Address)
+			return false, err
+		}
+		pubKey, err := types.PubKeyFromBech32(key.PubKey)
+		if err != nil {
+			return false, err
+		}
+		address = types.AccAddress(pubKey.Address())
+	default:
+		return false, fmt.Errorf("unsupported store type: %s", key.StoreType)
+	}
+
+	// the archived address may have any prefix
+	archived, err := types.Bech32Bytes(key.Address)
+	if err != nil {
+		return false, err
+	}
+	if !address.Equals(archived) {
+		return false, fmt.Errorf("the key derives the address %s, expected %s", address, key.Address)
+	}
+	return true, nil
+}
+
+// freeName returns the first name <name>-<n> which is neither stored nor archived
+func freeName(dao types.KeyDAO, name string, archived map[string]bool) string {
+	for n := 1; ; n++ {
+		candidate := fmt.Sprintf("%s-%d", name, n)
+		if !dao.Has(candidate) && !archived[candidate] {
+			return candidate
+		}
+	}
+}
@@ -0,0 +1,173 @@
+package backup_test
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/irisnet/irishub-sdk-go/adapter"
+	"github.com/irisnet/irishub-sdk-go/backup"
+	"github.com/irisnet/irishub-sdk-go/types"
+)
+
+type BackupTestSuite struct {
+	suite.Suite
+	dao       types.KeyDAO
+	addresses map[string]string
+	passwords types.PasswordProvider
+}
+
+func TestBackupTestSuite(t *testing.T) {
+	suite.Run(t, new(BackupTestSuite))
+}
+
+func (bts *BackupTestSuite) SetupTest() {
+	bts.dao = types.NewMemoryDB()
+	bts.addresses = make(map[string]string)
+	bts.passwords = types.PasswordFunc(func(name string) (string, error) {
+		return name + "-password", nil
+	})
+
+	for name, storeType := range map[string]types.StoreType{"priv": types.PrivKey, "keystore": types.Keystore} {
+		address, _, err := adapter.NewDAOAdapter(bts.dao, storeType).Insert(name, name+"-password")
+		require.NoError(bts.T(), err)
+		bts.addresses[name] = address
+	}
+	address, err := adapter.NewDAOAdapter(bts.dao, types.PrivKey).AddWatchOnly("watch", bts.addresses["priv"], "")
+	require.NoError(bts.T(), err)
+	bts.addresses["watch"] = address
+}
+
+func (bts *BackupTestSuite) TestExportRestore() {
+	archive, err := backup.Export(bts.dao, "backup passphrase")
+	require.NoError(bts.T(), err)
+
+	_, err = backup.Open(archive, "wrong passphrase")
+	require.Error(bts.T(), err)
+	keys, err := backup.Open(archive, "backup passphrase")
+	require.NoError(bts.T(), err)
+	require.Len(bts.T(), keys, 3)
+
+	dao := types.NewMemoryDB()
+	results, err := backup.Restore(dao, archive, "backup passphrase", backup.RestoreOptions{Passwords: bts.passwords})
+	require.NoError(bts.T(), err)
+	require.Len(bts.T(), results, 3)
+	for _, result := range results {
+		require.Equal(bts.T(), bts.addresses[result.Name], result.Address)
+		require.Equal(bts.T(), result.Name, result.RestoredAs)
+		require.Equal(bts.T(), result.Name != "watch", result.Verified)
+	}
+
+	// the restored keys sign with their passwords and keep their public keys
+	km := adapter.NewDAOAdapter(dao, types.PrivKey)
+	for _, name := range []string{"priv", "keystore"} {
+		signature, err := km.Sign(name, name+"-password", []byte("data"))
+		require.NoError(bts.T(), err)
+		pubKey, err := km.QueryPubKey(name, "")
+		require.NoError(bts.T(), err)
+		expected, err := types.Bech32ifyAccPub(signature.PubKey)
+		require.NoError(bts.T(), err)
+		require.Equal(bts.T(), expected, pubKey)
+	}
+	infos, err := km.List()
+	require.NoError(bts.T(), err)
+	require.Contains(bts.T(), infos, types.KeyInfo{Name: "watch", Address: bts.addresses["watch"], StoreType: types.WatchOnly})
+}
+
+func (bts *BackupTestSuite) TestConflicts() {
+	archive, err := backup.Export(bts.dao, "backup passphrase")
+	require.NoError(bts.T(), err)
+
+	dao := types.NewMemoryDB()
+	km := adapter.NewDAOAdapter(dao, types.PrivKey)
+	existing, _, err := km.Insert("priv", "password")
+	require.NoError(bts.T(), err)
+
+	results, err := backup.Restore(dao, archive, "backup passphrase", backup.RestoreOptions{Conflict: backup.Skip})
+	require.NoError(bts.T(), err)
+	restored := restoredAs(results)
+	require.Equal(bts.T(), "", restored["priv"])
+	require.Equal(bts.T(), "keystore", restored["keystore"])
+	address, err := km.Query("priv")
+	require.NoError(bts.T(), err)
+	require.Equal(bts.T(), existing, address.String())
+
+	results, err = backup.Restore(dao, archive, "backup passphrase", backup.RestoreOptions{Conflict: backup.Rename})
+	require.NoError(bts.T(), err)
+	restored = restoredAs(results)
+	require.Equal(bts.T(), "priv-1", restored["priv"])
+	require.Equal(bts.T(), "keystore-1", restored["keystore"])
+	address, err = km.Query("priv-1")
+	require.NoError(bts.T(), err)
+	require.Equal(bts.T(), bts.addresses["priv"], address.String())
+
+	// the existing key is kept when it can not be replaced
+	_, err = backup.Restore(failingReplace{dao}, archive, "backup passphrase", backup.RestoreOptions{Conflict: backup.Overwrite})
+	require.Error(bts.T(), err)
+	address, err = km.Query("priv")
+	require.NoError(bts.T(), err)
+	require.Equal(bts.T(), existing, address.String())
+
+	results, err = backup.Restore(dao, archive, "backup passphrase", backup.RestoreOptions{Conflict: backup.Overwrite})
+	require.NoError(bts.T(), err)
+	require.Equal(bts.T(), "priv", restoredAs(results)["priv"])
+	address, err = km.Query("priv")
+	require.NoError(bts.T(), err)
+	require.Equal(bts.T(), bts.addresses["priv"], address.String())
+}
+
+// failingReplace is a keybase which can not replace its keys, e.g. a full disk
+type failingReplace struct {
+	types.MemoryDB
+}
+
+func (f failingReplace) Replace(name string, store types.Store) error {
+	return errors.New("no space left on device")
+}
+
+func (bts *BackupTestSuite) TestVerify() {
+	archive, err := backup.Export(bts.dao, "backup passphrase")
+	require.NoError(bts.T(), err)
+
+	wrong := types.PasswordFunc(func(name string) (string, error) {
+		return "wrong password", nil
+	})
+	dao := types.NewMemoryDB()
+	_, err = backup.Restore(dao, archive, "backup passphrase", backup.RestoreOptions{Passwords: wrong})
+	require.Error(bts.T(), err)
+	names, err := dao.List()
+	require.NoError(bts.T(), err)
+	require.Empty(bts.T(), names)
+
+	// an archived address which does not match the key
+	keys, err := backup.Open(archive, "backup passphrase")
+	require.NoError(bts.T(), err)
+	for i := range keys {
+		if keys[i].Name == "priv" {
+			keys[i].Address = bts.addresses["keystore"]
+		}
+	}
+	bz, err := json.Marshal(keys)
+	require.NoError(bts.T(), err)
+	data, err := types.AESGCM{}.Encrypt(string(bz), "backup passphrase")
+	require.NoError(bts.T(), err)
+	tampered, err := json.Marshal(backup.Archive{Version: backup.Version, Data: data})
+	require.NoError(bts.T(), err)
+
+	_, err = backup.Restore(dao, tampered, "backup passphrase", backup.RestoreOptions{Passwords: bts.passwords})
+	require.Error(bts.T(), err)
+
+	_, err = backup.Open([]byte(`{"version": 2}`), "backup passphrase")
+	require.Error(bts.T(), err)
+}
+
+func restoredAs(results []backup.Result) map[string]string {
+	restored := make(map[string]string, len(results))
+	for _, result := range results {
+		restored[result.Name] = result.RestoredAs
+	}
+	return restored
+}
@@ -4,6 +4,8 @@ import (
 	"fmt"
 
 	"io"
+	"reflect"
+	"sync"
 
 	"github.com/irisnet/irishub-sdk-go/modules"
 	"github.com/irisnet/irishub-sdk-go/modules/asset"
@@ -19,33 +21,58 @@ import (
 	"github.com/irisnet/irishub-sdk-go/modules/tendermint"
 	"github.com/irisnet/irishub-sdk-go/rpc"
 	sdk "github.com/irisnet/irishub-sdk-go/types"
+	"github.com/irisnet/irishub-sdk-go/utils/cache"
 	"github.com/irisnet/irishub-sdk-go/utils/log"
 )
 
 type Client struct {
-	cdc     sdk.Codec
-	modules map[string]sdk.Module
-	logger  *log.Logger
+	cdc      sdk.Codec
+	base     sdk.BaseClient
+	mu       *sync.RWMutex
+	modules  map[string]sdk.Module
+	logger   log.Logger
+	prefixes *sdk.AddrPrefixCfg
+	version  sdk.Version
+	stats    func() map[string]cache.Stats
 
 	sdk.WSClient
 	sdk.TxManager
 	sdk.TokenConvert
 }
 
+// NewClient returns the client of the config, it panics if the config is invalid or the node can not be used
 func NewClient(cfg sdk.ClientConfig) Client {
+	client, err := NewClientWithError(cfg)
+	if err != nil {
+		panic(err)
+	}
+	return client
+}
+
+// NewClientWithError returns the client of the config, or every problem of the config in a types.ConfigError,
+// or the error met while preparing the client, such as a keybase which can not be opened
+func NewClientWithError(cfg sdk.ClientConfig) (Client, error) {
 	cdc := sdk.NewAminoCodec()
-	baseClient := modules.NewBaseClient(cdc, cfg)
+	baseClient, err := modules.NewBaseClientWithError(cdc, cfg)
+	if err != nil {
+		return Client{}, err
+	}
 
 	client := &Client{
 		cdc:          cdc,
+		base:         baseClient,
+		mu:           new(sync.RWMutex),
 		modules:      make(map[string]sdk.Module),
 		logger:       baseClient.Logger(),
+		prefixes:     baseClient.AddrPrefixCfg(),
+		version:      baseClient.ChainVersion(),
+		stats:        baseClient.CacheStats,
 		WSClient:     baseClient.TmClient,
 		TxManager:    baseClient,
 		TokenConvert: baseClient,
 	}
 
-	client.registerModule(
+	err = client.RegisterModule(
 		bank.Create(baseClient),
 		service.Create(baseClient),
 		oracle.Create(baseClient),
@@ -54,69 +81,146 @@ func NewClient(cfg sdk.ClientConfig) Client {
 		gov.Create(baseClient),
 		slashing.Create(baseClient),
 		random.Create(baseClient),
-		keys.Create(baseClient.KeyManager),
+		keys.Create(baseClient.KeyManager, baseClient.AddrPrefixCfg(), baseClient.ForgetKeys),
 		asset.Create(baseClient),
 		tendermint.Create(baseClient),
 	)
+	if err != nil {
+		// the client is not returned, nothing else stops its subscriptions and its RPC client
+		_ = baseClient.Stop()
+		return Client{}, err
+	}
+	sdk.RegisterCodec(cdc)
 
-	return *client
+	return *client, nil
 }
 
-func (s *Client) registerModule(modules ...sdk.Module) {
+// RegisterModule adds the modules, e.g. those of a fork of IRIShub, and registers their msgs and types in the codec
+// of the client. A module is created with BaseClient, which builds, signs and broadcasts its msgs and runs its
+// queries. It fails if a module of the same name is registered or if the codec of a module conflicts with the
+// registered types, the modules before it are kept.
+func (s *Client) RegisterModule(modules ...sdk.Module) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
 	for _, m := range modules {
 		if _, existed := s.modules[m.Name()]; existed {
-			panic(fmt.Sprintf("module[%s] has existed", m.Name()))
+			return fmt.Errorf("module %s is already registered", m.Name())
+		}
+		if err := registerCodec(m, s.cdc); err != nil {
+			return err
 		}
-		m.RegisterCodec(s.cdc)
 		s.modules[m.Name()] = m
 	}
-	sdk.RegisterCodec(s.cdc)
+	return nil
+}
+
+// registerCodec returns the panic of the codec, e.g. a name already registered, as an error
+func registerCodec(m sdk.Module, cdc sdk.Codec) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("failed to register the codec of the module %s: %v", m.Name(), r)
+		}
+	}()
+	m.RegisterCodec(cdc)
+	return nil
+}
+
+// BaseClient returns the client shared by the modules to send their transactions and run their queries
+func (s *Client) BaseClient() sdk.BaseClient {
+	return s.base
+}
+
+// Module returns the module registered with the name
+func (s *Client) Module(name string) (sdk.Module, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	m, ok := s.modules[name]
+	return m, ok
+}
+
+// ModuleAs sets target, a non-nil pointer to an interface or to the type of the module, to the module registered
+// with the name, it fails if the name is not registered or the module is not assignable to target
+func (s *Client) ModuleAs(name string, target interface{}) error {
+	m, ok := s.Module(name)
+	if !ok {
+		return fmt.Errorf("module %s is not registered", name)
+	}
+	v := reflect.ValueOf(target)
+	if v.Kind() != reflect.Ptr || v.IsNil() {
+		return fmt.Errorf("the target must be a non-nil pointer, not %T", target)
+	}
+	if !reflect.TypeOf(m).AssignableTo(v.Elem().Type()) {
+		return fmt.Errorf("module %s is a %T, not a %s", name, m, v.Elem().Type())
+	}
+	v.Elem().Set(reflect.ValueOf(m))
+	return nil
+}
+
+func (s *Client) module(name string) sdk.Module {
+	m, _ := s.Module(name)
+	return m
 }
 
 func (s *Client) Bank() rpc.Bank {
-	return s.modules[bank.ModuleName].(rpc.Bank)
+	return s.module(bank.ModuleName).(rpc.Bank)
 }
 
 func (s *Client) Distr() rpc.Distribution {
-	return s.modules[distribution.ModuleName].(rpc.Distribution)
+	return s.module(distribution.ModuleName).(rpc.Distribution)
 }
 
 func (s *Client) Service() rpc.Service {
-	return s.modules[service.ModuleName].(rpc.Service)
+	return s.module(service.ModuleName).(rpc.Service)
 }
 
 func (s *Client) Oracle() rpc.Oracle {
-	return s.modules[oracle.ModuleName].(rpc.Oracle)
+	return s.module(oracle.ModuleName).(rpc.Oracle)
 }
 
 func (s *Client) Staking() rpc.Staking {
-	return s.modules[staking.ModuleName].(rpc.Staking)
+	return s.module(staking.ModuleName).(rpc.Staking)
 }
 
 func (s *Client) Gov() rpc.Gov {
-	return s.modules[gov.ModuleName].(rpc.Gov)
+	return s.module(gov.ModuleName).(rpc.Gov)
 }
 
 func (s *Client) Slashing() rpc.Slashing {
-	return s.modules[slashing.ModuleName].(rpc.Slashing)
+	return s.module(slashing.ModuleName).(rpc.Slashing)
 }
 
 func (s *Client) Random() rpc.Random {
-	return s.modules[random.ModuleName].(rpc.Random)
+	return s.module(random.ModuleName).(rpc.Random)
 }
 
 func (s *Client) Keys() rpc.Keys {
-	return s.modules[keys.ModuleName].(rpc.Keys)
+	return s.module(keys.ModuleName).(rpc.Keys)
 }
 
 func (s *Client) Asset() rpc.Asset {
-	return s.modules[asset.ModuleName].(rpc.Asset)
+	return s.module(asset.ModuleName).(rpc.Asset)
 }
 
 func (s *Client) Tendermint() rpc.Tendermint {
-	return s.modules[tendermint.ModuleName].(rpc.Tendermint)
+	return s.module(tendermint.ModuleName).(rpc.Tendermint)
+}
+
+// AddrPrefixCfg returns the bech32 prefixes of the addresses of the client
+func (s *Client) AddrPrefixCfg() *sdk.AddrPrefixCfg {
+	return s.prefixes
+}
+
+// ChainVersion returns the version of IRIShub run by the node, which selects the queries of the modules
+func (s *Client) ChainVersion() sdk.Version {
+	return s.version
+}
+
+// CacheStats returns the statistics of the caches of the accounts, the tokens and the params, by name
+func (s *Client) CacheStats() map[string]cache.Stats {
+	return s.stats()
 }
 
+// SetOutput replaces the writer of the default logger, it has no effect on ClientConfig.Logger
 func (s *Client) SetOutput(w io.Writer) {
-	s.logger.SetOutput(w)
+	log.SetOutput(s.logger, w)
 }
@@ -0,0 +1,683 @@
+package sdk_test
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/tendermint/tendermint/crypto"
+	"github.com/tendermint/tendermint/crypto/secp256k1"
+
+	sdk "github.com/irisnet/irishub-sdk-go"
+	"github.com/irisnet/irishub-sdk-go/adapter"
+	"github.com/irisnet/irishub-sdk-go/modules/asset"
+	"github.com/irisnet/irishub-sdk-go/modules/bank"
+	"github.com/irisnet/irishub-sdk-go/modules/distribution"
+	"github.com/irisnet/irishub-sdk-go/modules/gov"
+	"github.com/irisnet/irishub-sdk-go/modules/oracle"
+	"github.com/irisnet/irishub-sdk-go/modules/random"
+	"github.com/irisnet/irishub-sdk-go/modules/service"
+	"github.com/irisnet/irishub-sdk-go/modules/slashing"
+	"github.com/irisnet/irishub-sdk-go/modules/staking"
+	"github.com/irisnet/irishub-sdk-go/rpc"
+	"github.com/irisnet/irishub-sdk-go/test"
+	"github.com/irisnet/irishub-sdk-go/test/fakechain"
+	"github.com/irisnet/irishub-sdk-go/types"
+	"github.com/irisnet/irishub-sdk-go/utils/cache"
+)
+
+func TestNewClientWithError(t *testing.T) {
+	_, err := sdk.NewClientWithError(types.ClientConfig{Mode: "block"})
+	require.Error(t, err)
+	configErr, ok := err.(types.ConfigError)
+	require.True(t, ok)
+	require.Len(t, configErr.Problems, 5, err.Error())
+
+	// the token of the fee is unknown to the chain
+	fees, e := types.ParseDecCoins("0.6atom")
+	require.NoError(t, e)
+	_, err = sdk.NewClientWithError(types.ClientConfig{
+		TmClient: fakechain.New(),
+		ChainID:  "test",
+		Fee:      fees,
+		KeyDAO:   types.NewMemoryDB(),
+	})
+	require.Error(t, err)
+}
+
+func TestDiscoverChain(t *testing.T) {
+	fees, e := types.ParseDecCoins("0.6iris")
+	require.NoError(t, e)
+	newClient := func(chain *fakechain.Chain, chainID string, network types.Network) (sdk.Client, error) {
+		return sdk.NewClientWithError(types.ClientConfig{
+			TmClient:      chain,
+			ChainID:       chainID,
+			Network:       network,
+			DiscoverChain: true,
+			Fee:           fees,
+			KeyDAO:        types.NewMemoryDB(),
+		})
+	}
+
+	// the network of a known chain-id
+	client, err := newClient(fakechain.New(fakechain.WithChainID("irishub")), "", "")
+	require.NoError(t, err)
+	require.True(t, types.Mainnet.AddrPrefixCfg().Equal(client.AddrPrefixCfg()))
+
+	_, err = newClient(fakechain.New(fakechain.WithChainID("irishub")), "test", "")
+	require.Error(t, err)
+	_, err = newClient(fakechain.New(fakechain.WithChainID("irishub")), "", types.Testnet)
+	require.Error(t, err)
+
+	// the network of the prefixes of the validators
+	chain := fakechain.New(fakechain.WithChainID("private"))
+	operator := types.Testnet.AddrPrefixCfg().ValAddressString(types.ValAddress([]byte("validator___________")))
+	chain.RegisterQuerier("custom/stake/validators", func(fakechain.Context, []byte) ([]byte, error) {
+		return []byte(fmt.Sprintf(`[{"operator_address":"%s"}]`, operator)), nil
+	})
+	client, err = newClient(chain, "private", "")
+	require.NoError(t, err)
+	require.True(t, types.Testnet.AddrPrefixCfg().Equal(client.AddrPrefixCfg()))
+
+	_, err = newClient(chain, "", types.Mainnet)
+	require.Error(t, err)
+
+	// the network of a numbered testnet chain
+	client, err = newClient(fakechain.New(fakechain.WithChainID("fuxi-9000")), "", "")
+	require.NoError(t, err)
+	require.True(t, types.Testnet.AddrPrefixCfg().Equal(client.AddrPrefixCfg()))
+
+	// the network of an unknown chain without validators must be configured
+	_, err = newClient(fakechain.New(fakechain.WithChainID("private")), "", "")
+	require.Error(t, err)
+	client, err = newClient(fakechain.New(fakechain.WithChainID("private")), "", types.Testnet)
+	require.NoError(t, err)
+	require.True(t, types.Testnet.AddrPrefixCfg().Equal(client.AddrPrefixCfg()))
+}
+
+func TestQueryValidatorsPrefixes(t *testing.T) {
+	fees, e := types.ParseDecCoins("0.6iris")
+	require.NoError(t, e)
+	client, err := sdk.NewClientWithError(types.ClientConfig{
+		TmClient: fakechain.New(fakechain.WithChainID("irishub")),
+		ChainID:  "irishub",
+		Network:  types.Mainnet,
+		Fee:      fees,
+		KeyDAO:   types.NewMemoryDB(),
+	})
+	require.NoError(t, err)
+
+	// the validators are encoded with the prefixes of the client, not the default ones of the process
+	mainnet := types.Mainnet.AddrPrefixCfg()
+	res, err := client.Tendermint().QueryValidators(1)
+	require.NoError(t, err)
+	require.NotEmpty(t, res.Validators)
+	for _, v := range res.Validators {
+		require.True(t, strings.HasPrefix(v.Bech32Address, mainnet.GetBech32ConsensusAddrPrefix()+"1"), v.Bech32Address)
+		require.True(t, strings.HasPrefix(v.Bech32PubKey, mainnet.GetBech32ConsensusPubPrefix()+"1"), v.Bech32PubKey)
+	}
+}
+
+func TestDefaultPrefixes(t *testing.T) {
+	fees, e := types.ParseDecCoins("0.6iris")
+	require.NoError(t, e)
+	client := sdk.NewClient(types.ClientConfig{
+		TmClient: fakechain.New(fakechain.WithChainID("test")),
+		ChainID:  "test",
+		Fee:      fees,
+		KeyDAO:   types.NewMemoryDB(),
+	})
+
+	// the default network of the clients and the default prefixes of the process are those of Mainnet
+	address, err := client.Keys().Recover("default", "1234567890", test.Mnemonic)
+	require.NoError(t, err)
+	require.True(t, strings.HasPrefix(address, "iaa1"), address)
+	addr, err := client.AddrPrefixCfg().AccAddressFromBech32(address)
+	require.NoError(t, err)
+	require.Equal(t, address, addr.String())
+	bz, e := json.Marshal(addr)
+	require.NoError(t, e)
+	require.Equal(t, `"`+address+`"`, string(bz))
+	require.True(t, strings.HasPrefix(types.ValAddress(addr).String(), "iva1"))
+}
+
+func TestWatchOnlyPrefixes(t *testing.T) {
+	fees, e := types.ParseDecCoins("0.6iris")
+	require.NoError(t, e)
+	dao := types.NewMemoryDB()
+	client, err := sdk.NewClientWithError(types.ClientConfig{
+		TmClient: fakechain.New(fakechain.WithChainID("irishub")),
+		ChainID:  "irishub",
+		Network:  types.Mainnet,
+		Fee:      fees,
+		KeyDAO:   dao,
+	})
+	require.NoError(t, err)
+
+	// the watch-only address is given with the testnet prefixes, it is stored with those of the client
+	mainnet := types.Mainnet.AddrPrefixCfg()
+	addr := types.AccAddress(secp256k1.GenPrivKey().PubKey().Address())
+	address, err := client.Keys().AddWatchOnly("watch", types.Testnet.AddrPrefixCfg().AccAddressString(addr), "")
+	require.NoError(t, err)
+	require.Equal(t, mainnet.AccAddressString(addr), address)
+
+	store, e := dao.Read("watch")
+	require.NoError(t, e)
+	require.Equal(t, mainnet.AccAddressString(addr), store.(types.WatchOnlyInfo).Address)
+
+	infos, e := adapter.NewDAOAdapterWithPrefixes(dao, types.PrivKey, mainnet).List()
+	require.NoError(t, e)
+	require.Len(t, infos, 1)
+	require.Equal(t, mainnet.AccAddressString(addr), infos[0].Address)
+}
+
+func TestCacheInvalidation(t *testing.T) {
+	fees, e := types.ParseDecCoins("0.6iris")
+	require.NoError(t, e)
+	chain := fakechain.New(fakechain.WithChainID("test"))
+	keyDAO := types.NewMemoryDB()
+	newClient := func() sdk.Client {
+		return sdk.NewClient(types.ClientConfig{
+			TmClient: chain,
+			ChainID:  "test",
+			Fee:      fees,
+			Mode:     types.Commit,
+			KeyDAO:   keyDAO,
+			Cache:    types.CacheConfig{Invalidate: true},
+		})
+	}
+	client1, client2 := newClient(), newClient()
+	stats := func(client sdk.Client, name string) cache.Stats {
+		return client.CacheStats()[name]
+	}
+
+	address, err := client1.Keys().Recover("cache", "1234567890", test.Mnemonic)
+	require.NoError(t, err)
+	require.NoError(t, chain.Fund(address, types.NewCoins(types.NewCoin("iris-atto", types.NewIntWithDecimal(10, 18)))))
+	baseTx := types.BaseTx{From: "cache", Password: "1234567890", Gas: 20000}
+	amount, e := types.ParseDecCoins("0.1iris")
+	require.NoError(t, e)
+
+	// the account is outdated in the cache of client1 when client2 sends a tx
+	_, err = client1.Bank().Send(address, amount, baseTx)
+	require.NoError(t, err)
+	_, err = client2.Bank().Send(address, amount, baseTx)
+	require.NoError(t, err)
+	require.Eventually(t, func() bool {
+		return stats(client1, "accounts").Invalidations == 1
+	}, 5*time.Second, 10*time.Millisecond)
+	require.Zero(t, stats(client2, "accounts").Invalidations)
+
+	// the tokens are outdated when a token is edited
+	chain.AddTokens(types.Token{Symbol: "btc", Name: "Bitcoin", Scale: 8, MinUnit: "satoshi", Owner: address})
+	chain.RegisterHandler(asset.ModuleName, func(ctx fakechain.Context, msg types.Msg) (types.Tags, error) {
+		return nil, nil
+	})
+	btc, e := types.ParseDecCoins("1btc")
+	require.NoError(t, e)
+	_, err = client1.ToMinCoin(btc...)
+	require.NoError(t, err)
+	require.Equal(t, 2, stats(client1, "tokens").Size)
+
+	owner, err := client1.AddrPrefixCfg().AccAddressFromBech32(address)
+	require.NoError(t, err)
+	_, err = client2.BuildAndSend([]types.Msg{asset.MsgEditToken{Symbol: "btc", Owner: owner, Name: "BTC"}}, baseTx)
+	require.NoError(t, err)
+	require.Eventually(t, func() bool {
+		return stats(client1, "tokens").Size == 0
+	}, 5*time.Second, 10*time.Millisecond)
+
+	// the params are outdated when a proposal passes
+	validators := 100
+	var mu sync.Mutex
+	chain.RegisterQuerier("custom/params/module", func(fakechain.Context, []byte) ([]byte, error) {
+		mu.Lock()
+		defer mu.Unlock()
+		return []byte(fmt.Sprintf(`{"type":"irishub/stake/Params","value":{"unbonding_time":"60000000000",`+
+			`"max_validators":%d}}`, validators)), nil
+	})
+	params, err := client1.Staking().QueryParams()
+	require.NoError(t, err)
+	require.Equal(t, 100, params.MaxValidators)
+
+	mu.Lock()
+	validators = 200
+	mu.Unlock()
+	params, err = client1.Staking().QueryParams()
+	require.NoError(t, err)
+	require.Equal(t, 100, params.MaxValidators)
+
+	chain.EndBlock(types.Tags{{Key: "action", Value: "proposal-passed"}, {Key: "proposal-id", Value: "1"}})
+	require.Eventually(t, func() bool {
+		params, err := client1.Staking().QueryParams()
+		return err == nil && params.MaxValidators == 200
+	}, 5*time.Second, 10*time.Millisecond)
+	require.NotZero(t, stats(client1, "params").Hits)
+}
+
+// subscriptionCounter counts the subscriptions to the txs and the blocks
+type subscriptionCounter struct {
+	*fakechain.Chain
+	subscriptions int
+}
+
+func (c *subscriptionCounter) SubscribeTx(builder *types.EventQueryBuilder,
+	handler types.EventTxHandler) (types.Subscription, types.Error) {
+	c.subscriptions++
+	return c.Chain.SubscribeTx(builder, handler)
+}
+
+func (c *subscriptionCounter) SubscribeNewBlock(builder *types.EventQueryBuilder,
+	handler types.EventNewBlockHandler) (types.Subscription, types.Error) {
+	c.subscriptions++
+	return c.Chain.SubscribeNewBlock(builder, handler)
+}
+
+func TestCacheInvalidationProtobuf(t *testing.T) {
+	fees, e := types.ParseDecCoins("0.3iris")
+	require.NoError(t, e)
+	for _, version := range []string{"0.16.3", "1.0.0"} {
+		chain := &subscriptionCounter{
+			Chain: fakechain.New(fakechain.WithChainID("irishub-1"), fakechain.WithAppVersion(version)),
+		}
+		_, err := sdk.NewClientWithError(types.ClientConfig{
+			TmClient: chain,
+			Network:  types.Mainnet,
+			ChainID:  "irishub-1",
+			Fee:      fees,
+			KeyDAO:   types.NewMemoryDB(),
+			Cache:    types.CacheConfig{Invalidate: true},
+		})
+		require.NoError(t, err, version)
+		// the events of IRIShub 1.x are not decoded, the caches are not invalidated by them
+		if types.TxEncodingOf(version) == types.Protobuf {
+			require.Zero(t, chain.subscriptions, version)
+		} else {
+			require.Equal(t, 2, chain.subscriptions, version)
+		}
+	}
+}
+
+func TestCacheBackendSharedByKeybases(t *testing.T) {
+	fees, e := types.ParseDecCoins("0.6iris")
+	require.NoError(t, e)
+	chain := fakechain.New(fakechain.WithChainID("test"))
+	backend := &mapBackend{entries: make(map[string][]byte)}
+	// two processes of different keybases share the backend
+	newClient := func() sdk.Client {
+		return sdk.NewClient(types.ClientConfig{
+			TmClient: chain,
+			ChainID:  "test",
+			Fee:      fees,
+			Mode:     types.Commit,
+			KeyDAO:   types.NewMemoryDB(),
+			Cache:    types.CacheConfig{Backend: backend},
+		})
+	}
+	client1, client2 := newClient(), newClient()
+
+	address1, err := client1.Keys().Recover("alice", "1234567890", test.Mnemonic)
+	require.NoError(t, err)
+	address2, _, err := client2.Keys().Add("alice", "1234567890")
+	require.NoError(t, err)
+	require.NotEqual(t, address1, address2)
+	for _, address := range []string{address1, address2} {
+		require.NoError(t, chain.Fund(address, types.NewCoins(types.NewCoin("iris-atto", types.NewIntWithDecimal(10, 18)))))
+	}
+
+	// each client resolves the name with its own keybase and signs with its own key
+	baseTx := types.BaseTx{From: "alice", Password: "1234567890", Gas: 20000}
+	amount, e := types.ParseDecCoins("0.1iris")
+	require.NoError(t, e)
+	for i := 0; i < 2; i++ {
+		_, err = client1.Bank().Send(address1, amount, baseTx)
+		require.NoError(t, err)
+		_, err = client2.Bank().Send(address2, amount, baseTx)
+		require.NoError(t, err)
+	}
+	addr, err := client2.BaseClient().QueryAddress("alice")
+	require.NoError(t, err)
+	require.Equal(t, address2, client2.AddrPrefixCfg().AccAddressString(addr))
+
+	// neither the names nor the sequences are written to the backend
+	for key := range backend.keys() {
+		require.NotContains(t, key, "/accounts:", key)
+	}
+}
+
+// mapBackend is a cache.Backend shared by the clients of a test
+type mapBackend struct {
+	mu      sync.Mutex
+	entries map[string][]byte
+}
+
+func (b *mapBackend) Get(key string) ([]byte, time.Duration, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	value, ok := b.entries[key]
+	if !ok {
+		return nil, 0, cache.ErrNotFound
+	}
+	return value, 0, nil
+}
+
+func (b *mapBackend) Set(key string, value []byte, ttl time.Duration) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.entries[key] = value
+	return nil
+}
+
+func (b *mapBackend) Delete(key string) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	delete(b.entries, key)
+	return nil
+}
+
+func (b *mapBackend) DeletePrefix(prefix string) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	for key := range b.entries {
+		if strings.HasPrefix(key, prefix) {
+			delete(b.entries, key)
+		}
+	}
+	return nil
+}
+
+func (b *mapBackend) keys() map[string]bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	keys := make(map[string]bool, len(b.entries))
+	for key := range b.entries {
+		keys[key] = true
+	}
+	return keys
+}
+
+// msgMintNFT is a msg of a module of a fork of IRIShub, it only implements types.Msg
+type msgMintNFT struct {
+	Owner types.AccAddress `json:"owner"`
+	ID    string           `json:"id"`
+}
+
+func (msg msgMintNFT) Route() string { return "nft" }
+
+func (msg msgMintNFT) Type() string { return "mint_nft" }
+
+func (msg msgMintNFT) ValidateBasic() error {
+	if len(msg.ID) == 0 {
+		return errors.New("id is required")
+	}
+	return nil
+}
+
+func (msg msgMintNFT) GetSignBytes() []byte {
+	bz, _ := json.Marshal(msg)
+	return bz
+}
+
+func (msg msgMintNFT) GetSigners() []types.AccAddress {
+	return []types.AccAddress{msg.Owner}
+}
+
+type nftOwner struct {
+	Owner string `json:"owner"`
+}
+
+func (o nftOwner) Convert() interface{} {
+	return o.Owner
+}
+
+type nft interface {
+	types.Module
+	Mint(id string, baseTx types.BaseTx) (types.ResultTx, types.Error)
+	Owner(id string) (string, error)
+}
+
+type nftClient struct {
+	types.BaseClient
+	name string
+}
+
+func (n nftClient) Name() string {
+	return n.name
+}
+
+func (n nftClient) RegisterCodec(cdc types.Codec) {
+	cdc.RegisterConcrete(msgMintNFT{}, "irismod/nft/MsgMintNFT")
+}
+
+func (n nftClient) Mint(id string, baseTx types.BaseTx) (types.ResultTx, types.Error) {
+	owner, err := n.QueryAddress(baseTx.From)
+	if err != nil {
+		return types.ResultTx{}, err
+	}
+	return n.BuildAndSend([]types.Msg{msgMintNFT{Owner: owner, ID: id}}, baseTx)
+}
+
+func (n nftClient) Owner(id string) (string, error) {
+	var owner nftOwner
+	if err := n.QueryWithResponse("custom/nft/owner", map[string]string{"id": id}, &owner); err != nil {
+		return "", err
+	}
+	return owner.Convert().(string), nil
+}
+
+func TestRegisterModule(t *testing.T) {
+	fees, e := types.ParseDecCoins("0.6iris")
+	require.NoError(t, e)
+	chain := fakechain.New(fakechain.WithChainID("test"))
+	// the prefixes of the client are not the default ones of the process
+	client := sdk.NewClient(types.ClientConfig{
+		TmClient: chain,
+		ChainID:  "test",
+		Network:  types.Testnet,
+		Fee:      fees,
+		Mode:     types.Commit,
+		KeyDAO:   types.NewMemoryDB(),
+	})
+
+	owners := make(map[string]string)
+	var mu sync.Mutex
+	chain.RegisterHandler("nft", func(ctx fakechain.Context, msg types.Msg) (types.Tags, error) {
+		mint := msg.(msgMintNFT)
+		mu.Lock()
+		defer mu.Unlock()
+		owners[mint.ID] = client.AddrPrefixCfg().AccAddressString(mint.Owner)
+		return types.Tags{{Key: "nft-id", Value: mint.ID}}, nil
+	})
+	chain.RegisterQuerier("custom/nft/owner", func(ctx fakechain.Context, data []byte) ([]byte, error) {
+		var params map[string]string
+		if err := json.Unmarshal(data, &params); err != nil {
+			return nil, err
+		}
+		mu.Lock()
+		defer mu.Unlock()
+		return json.Marshal(nftOwner{Owner: owners[params["id"]]})
+	})
+
+	require.NoError(t, client.RegisterModule(nftClient{BaseClient: client.BaseClient(), name: "nft"}))
+	require.Error(t, client.RegisterModule(nftClient{BaseClient: client.BaseClient(), name: "nft"}))
+	// the msg is already registered in the codec
+	require.Error(t, client.RegisterModule(nftClient{BaseClient: client.BaseClient(), name: "nft2"}))
+	_, ok := client.Module("nft2")
+	require.False(t, ok)
+
+	var n nft
+	require.NoError(t, client.ModuleAs("nft", &n))
+	require.Error(t, client.ModuleAs("bank", &n))
+	require.Error(t, client.ModuleAs("nft3", &n))
+	require.Error(t, client.ModuleAs("nft", n))
+	var b rpc.Bank
+	require.NoError(t, client.ModuleAs("bank", &b))
+
+	address, err := client.Keys().Recover("nft", "1234567890", test.Mnemonic)
+	require.NoError(t, err)
+	require.NoError(t, chain.Fund(address, types.NewCoins(types.NewCoin("iris-atto", types.NewIntWithDecimal(10, 18)))))
+	res, err := n.Mint("kitty", types.BaseTx{From: "nft", Password: "1234567890", Gas: 20000})
+	require.NoError(t, err)
+	require.Equal(t, "kitty", res.Tags.GetValue("nft-id"))
+
+	owner, e := n.Owner("kitty")
+	require.NoError(t, e)
+	require.Equal(t, address, owner)
+	require.True(t, strings.HasPrefix(owner, "faa1"), owner)
+}
+
+// coreKeyManager only implements the methods of types.KeyManager
+type coreKeyManager struct {
+	types.KeyManager
+}
+
+func TestOptionalKeyManager(t *testing.T) {
+	fees, e := types.ParseDecCoins("0.6iris")
+	require.NoError(t, e)
+	chain := fakechain.New(fakechain.WithChainID("test"))
+	client := sdk.NewClient(types.ClientConfig{
+		TmClient:   chain,
+		ChainID:    "test",
+		Fee:        fees,
+		Mode:       types.Commit,
+		KeyManager: coreKeyManager{adapter.NewDAOAdapter(types.NewMemoryDB(), types.Keystore)},
+		PasswordProvider: types.PasswordFunc(func(name string) (string, error) {
+			return "1234567890", nil
+		}),
+	})
+
+	address, err := client.Keys().Recover("core", "1234567890", test.Mnemonic)
+	require.NoError(t, err)
+	require.NoError(t, chain.Fund(address, types.NewCoins(types.NewCoin("iris-atto", types.NewIntWithDecimal(10, 18)))))
+
+	_, err = client.Keys().List()
+	require.True(t, errors.Is(err, types.ErrKeyManagerUnsupported))
+	err = client.Keys().Unlock("core", "1234567890", 0)
+	require.True(t, errors.Is(err, types.ErrKeyManagerUnsupported))
+	client.Keys().Lock("core")
+	_, err = client.Keys().ShowPubKey("core", "1234567890")
+	require.True(t, errors.Is(err, types.ErrKeyManagerUnsupported))
+
+	// the password is asked to the provider, the key manager does not tell whether the key is unlocked
+	amount, e := types.ParseDecCoins("0.1iris")
+	require.NoError(t, e)
+	_, err = client.Bank().Send(address, amount, types.BaseTx{From: "core", Gas: 20000})
+	require.NoError(t, err)
+}
+
+// legacyMsg hides the SignDoc of a msg, it is signed with GetSignBytes
+type legacyMsg struct {
+	types.Msg
+}
+
+// fill sets the addresses, the public keys and the numbers of v, the empty slices of structs get one element
+func fill(v reflect.Value) {
+	switch v.Type() {
+	case reflect.TypeOf(types.AccAddress{}):
+		v.Set(reflect.ValueOf(types.AccAddress("address_____________")))
+		return
+	case reflect.TypeOf(types.ValAddress{}):
+		v.Set(reflect.ValueOf(types.ValAddress("validator___________")))
+		return
+	case reflect.TypeOf(types.Dec{}):
+		v.Set(reflect.ValueOf(types.NewDecWithPrec(5, 1)))
+		return
+	case reflect.TypeOf(types.Int{}):
+		v.Set(reflect.ValueOf(types.NewInt(10)))
+		return
+	case reflect.TypeOf((*crypto.PubKey)(nil)).Elem():
+		v.Set(reflect.ValueOf(secp256k1.GenPrivKey().PubKey()))
+		return
+	}
+	switch v.Kind() {
+	case reflect.Struct:
+		for i := 0; i < v.NumField(); i++ {
+			if v.Type().Field(i).PkgPath == "" {
+				fill(v.Field(i))
+			}
+		}
+	case reflect.Slice:
+		if v.Len() == 0 && v.Type().Elem().Kind() == reflect.Struct || v.Type().Elem() == reflect.TypeOf(types.AccAddress{}) {
+			v.Set(reflect.Append(v, reflect.New(v.Type().Elem()).Elem()))
+		}
+		for i := 0; i < v.Len(); i++ {
+			fill(v.Index(i))
+		}
+	}
+}
+
+func TestSignDoc(t *testing.T) {
+	fees, e := types.ParseDecCoins("0.6iris")
+	require.NoError(t, e)
+	client := sdk.NewClient(types.ClientConfig{
+		TmClient: fakechain.New(fakechain.WithChainID("test")),
+		ChainID:  "test",
+		Fee:      fees,
+		KeyDAO:   types.NewMemoryDB(),
+	})
+	cdc := types.NewAminoCodec()
+	for _, m := range []types.Module{
+		bank.Create(client.BaseClient()),
+		asset.Create(client.BaseClient()),
+		distribution.Create(client.BaseClient()),
+		gov.Create(client.BaseClient()),
+		oracle.Create(client.BaseClient()),
+		random.Create(client.BaseClient()),
+		service.Create(client.BaseClient()),
+		slashing.Create(client.BaseClient()),
+		staking.Create(client.BaseClient()),
+	} {
+		m.RegisterCodec(cdc)
+	}
+
+	msgs := []types.Msg{
+		&bank.MsgSend{}, &bank.MsgBurn{}, &bank.MsgSetMemoRegexp{},
+		&asset.MsgIssueToken{}, &asset.MsgTransferTokenOwner{}, &asset.MsgEditToken{}, &asset.MsgMintToken{},
+		&distribution.MsgSetWithdrawAddress{}, &distribution.MsgWithdrawDelegatorRewardsAll{},
+		&distribution.MsgWithdrawDelegatorReward{}, &distribution.MsgWithdrawValidatorRewardsAll{},
+		&gov.MsgDeposit{}, &gov.MsgVote{},
+		&oracle.MsgCreateFeed{}, &oracle.MsgStartFeed{}, &oracle.MsgPauseFeed{}, &oracle.MsgEditFeed{},
+		&random.MsgRequestRand{},
+		&service.MsgDefineService{}, &service.MsgBindService{}, &service.MsgUpdateServiceBinding{},
+		&service.MsgSetWithdrawAddress{}, &service.MsgDisableServiceBinding{}, &service.MsgEnableServiceBinding{},
+		&service.MsgRefundServiceDeposit{}, &service.MsgCallService{}, &service.MsgRespondService{},
+		&service.MsgPauseRequestContext{}, &service.MsgStartRequestContext{}, &service.MsgKillRequestContext{},
+		&service.MsgUpdateRequestContext{}, &service.MsgWithdrawEarnedFees{}, &service.MsgWithdrawTax{},
+		&slashing.MsgUnjail{},
+		&staking.MsgCreateValidator{}, &staking.MsgEditValidator{}, &staking.MsgDelegate{},
+		&staking.MsgUndelegate{}, &staking.MsgBeginRedelegate{},
+	}
+	testnet, mainnet := types.Testnet.AddrPrefixCfg(), types.Mainnet.AddrPrefixCfg()
+	for _, ptr := range msgs {
+		fill(reflect.ValueOf(ptr).Elem())
+		msg := reflect.ValueOf(ptr).Elem().Interface().(types.Msg)
+		_, ok := msg.(types.SignDocMsg)
+		require.True(t, ok, "%T", msg)
+
+		for _, config := range []*types.AddrPrefixCfg{testnet, mainnet} {
+			signMsg := types.StdSignMsg{ChainID: "test", Memo: "memo", Msgs: []types.Msg{msg}}
+			bz, err := signMsg.SignBytes(types.NewPrefixCodec(cdc, config))
+			require.NoError(t, err, "%T", msg)
+
+			// the sign bytes are those of a process whose default prefixes are those of the client
+			var want []byte
+			defaultPrefixes := types.GetAddrPrefixCfg()
+			types.SetAddrPrefixCfg(config)
+			signMsg.Msgs = []types.Msg{legacyMsg{msg}}
+			want, err = signMsg.SignBytes(cdc)
+			types.SetAddrPrefixCfg(defaultPrefixes)
+			require.NoError(t, err, "%T", msg)
+			require.Equal(t, string(want), string(bz), "%T", msg)
+		}
+		bz, err := types.StdSignMsg{Msgs: []types.Msg{msg}}.SignBytes(types.NewPrefixCodec(cdc, mainnet))
+		require.NoError(t, err)
+		require.NotContains(t, string(bz), "faa1", "%T", msg)
+		require.NotContains(t, string(bz), "fva1", "%T", msg)
+	}
+}
@@ -0,0 +1,281 @@
+// Package clikeys reads the keybase of iriscli, and of the other Cosmos SDK command line interfaces,
+// to move its keys into a types.KeyManager, e.g. one writing into any KeyDAO:
+//
+// 	kb, err := clikeys.Open(filepath.Join(os.Getenv("HOME"), ".iriscli"))
+// 	defer kb.Close()
+// 	km := adapter.NewDAOAdapter(keyDAO, types.PrivKey)
+// 	imported, err := kb.ImportAll(km, func(name string) (string, string, error) {
+// 		return cliPassphrase, newPassword, nil
+// 	})
+//
+// The keybase is opened read-only, iriscli must not be running meanwhile.
+// The keystores written by `iriscli keys export` are imported by KeyManager.Import. The armors written
+// by the `keys export` command of the Cosmos SDK 0.38+ command line interfaces are imported by
+// KeyManager.ImportArmor, and KeyManager.ExportArmor writes the files read by their `keys import`.
+package clikeys
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/syndtr/goleveldb/leveldb/opt"
+	"github.com/tendermint/go-amino"
+	"github.com/tendermint/tendermint/crypto"
+	cryptoAmino "github.com/tendermint/tendermint/crypto/encoding/amino"
+	dbm "github.com/tendermint/tm-db"
+
+	"github.com/irisnet/irishub-sdk-go/types"
+)
+
+const (
+	keyDBName  = "keys"
+	infoSuffix = ".info"
+)
+
+// the types of key
+const (
+	TypeLocal   = "local"
+	TypeLedger  = "ledger"
+	TypeOffline = "offline"
+	TypeMulti   = "multi"
+)
+
+// Info describes a key of the keybase
+type Info struct {
+	Name    string `json:"name"`
+	Type    string `json:"type"`
+	Address string `json:"address"`
+	PubKey  string `json:"pub_key"`
+}
+
+// Keybase is a read-only keybase of the command line interface
+type Keybase struct {
+	db dbm.DB
+}
+
+// Open opens the keybase of the home directory of the command line interface, e.g. ~/.iriscli
+func Open(home string) (*Keybase, error) {
+	db, err := dbm.NewGoLevelDBWithOpts(keyDBName, filepath.Join(home, "keys"), &opt.Options{
+		ReadOnly:       true,
+		ErrorIfMissing: true,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to open the keybase of %s: %s", home, err.Error())
+	}
+	return &Keybase{db: db}, nil
+}
+
+// Close releases the keybase
+func (kb *Keybase) Close() error {
+	return kb.db.Close()
+}
+
+// List returns the keys sorted by name
+func (kb *Keybase) List() ([]Info, error) {
+	it, err := kb.db.Iterator(nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer it.Close()
+
+	var infos []Info
+	for ; it.Valid(); it.Next() {
+		key := string(it.Key())
+		if !strings.HasSuffix(key, infoSuffix) {
+			continue
+		}
+		name := strings.TrimSuffix(key, infoSuffix)
+		info, err := decodeInfo(name, it.Value())
+		if err != nil {
+			return nil, err
+		}
+
+		pubKey, err := types.Bech32ifyAccPub(info.pubKey())
+		if err != nil {
+			return nil, err
+		}
+		infos = append(infos, Info{
+			Name:    name,
+			Type:    info.keyType(),
+			Address: types.AccAddress(info.pubKey().Address()).String(),
+			PubKey:  pubKey,
+		})
+	}
+	return infos, nil
+}
+
+// Armor returns the armored private key of a local key, it is encrypted with the passphrase of the key
+func (kb *Keybase) Armor(name string) (string, error) {
+	info, err := kb.read(name)
+	if err != nil {
+		return "", err
+	}
+	local, ok := info.(localInfo)
+	if !ok {
+		return "", fmt.Errorf("%s is a %s key, its private key is not in the keybase", name, info.keyType())
+	}
+	return local.PrivKeyArmor, nil
+}
+
+// ImportAll imports every key of the keybase with km and returns the imported names. passwords returns,
+// for a local key, its passphrase in the keybase and its password in km. The ledger, offline and multisig
+// keys are imported as watch-only accounts, km must implement types.KeyImporter and types.ArmorKeyManager.
+// It stops at the first error, e.g. when a name is already used in km.
+func (kb *Keybase) ImportAll(km types.KeyManager,
+	passwords func(name string) (passphrase, password string, err error)) ([]string, error) {
+	if _, ok := km.(types.KeyImporter); !ok {
+		return nil, errors.Wrap(types.ErrKeyManagerUnsupported, "the watch-only accounts can not be imported")
+	}
+	if _, ok := km.(types.ArmorKeyManager); !ok {
+		return nil, errors.Wrap(types.ErrKeyManagerUnsupported, "the armored keys can not be imported")
+	}
+
+	infos, err := kb.List()
+	if err != nil {
+		return nil, err
+	}
+
+	var imported []string
+	for _, info := range infos {
+		if err := kb.importKey(km, info, passwords); err != nil {
+			return imported, fmt.Errorf("failed to import %s: %s", info.Name, err.Error())
+		}
+		imported = append(imported, info.Name)
+	}
+	return imported, nil
+}
+
+func (kb *Keybase) importKey(km types.KeyManager, info Info,
+	passwords func(name string) (passphrase, password string, err error)) error {
+	switch info.Type {
+	case TypeMulti:
+		// the bech32 multisig public keys are longer than the addresses accept
+		_, err := km.(types.KeyImporter).AddWatchOnly(info.Name, info.Address, "")
+		return err
+	case TypeLedger, TypeOffline:
+		_, err := km.(types.KeyImporter).AddWatchOnly(info.Name, info.Address, info.PubKey)
+		return err
+	}
+
+	armor, err := kb.Armor(info.Name)
+	if err != nil {
+		return err
+	}
+	passphrase, password, err := passwords(info.Name)
+	if err != nil {
+		return err
+	}
+	_, err = km.(types.ArmorKeyManager).ImportArmor(info.Name, password, armor, passphrase)
+	return err
+}
+
+func (kb *Keybase) read(name string) (keyInfo, error) {
+	bz, err := kb.db.Get([]byte(name + infoSuffix))
+	if err != nil {
+		return nil, err
+	}
+	if bz == nil {
+		return nil, fmt.Errorf("name %s not exist", name)
+	}
+	return decodeInfo(name, bz)
+}
+
+func decodeInfo(name string, bz []byte) (keyInfo, error) {
+	var info keyInfo
+	if err := cdc.UnmarshalBinaryLengthPrefixed(bz, &info); err != nil {
+		// the ledger keys of iriscli v0.16 have another path
+		var irisInfo keyInfo
+		if irisCdc.UnmarshalBinaryLengthPrefixed(bz, &irisInfo) == nil {
+			return irisInfo, nil
+		}
+		return nil, fmt.Errorf("failed to decode the key %s: %s", name, err.Error())
+	}
+	return info, nil
+}
+
+// the key descriptions written by the keybase, Algo was added by Cosmos SDK v0.38
+type keyInfo interface {
+	keyType() string
+	pubKey() crypto.PubKey
+}
+
+type localInfo struct {
+	Name         string        `json:"name"`
+	PubKey       crypto.PubKey `json:"pubkey"`
+	PrivKeyArmor string        `json:"privkey.armor"`
+	Algo         string        `json:"algo"`
+}
+
+func (i localInfo) keyType() string       { return TypeLocal }
+func (i localInfo) pubKey() crypto.PubKey { return i.PubKey }
+
+type ledgerInfo struct {
+	Name   string        `json:"name"`
+	PubKey crypto.PubKey `json:"pubkey"`
+	Path   bip44Params   `json:"path"`
+	Algo   string        `json:"algo"`
+}
+
+func (i ledgerInfo) keyType() string       { return TypeLedger }
+func (i ledgerInfo) pubKey() crypto.PubKey { return i.PubKey }
+
+type bip44Params struct {
+	Purpose      uint32 `json:"purpose"`
+	CoinType     uint32 `json:"coinType"`
+	Account      uint32 `json:"account"`
+	Change       bool   `json:"change"`
+	AddressIndex uint32 `json:"addressIndex"`
+}
+
+type offlineInfo struct {
+	Name   string        `json:"name"`
+	PubKey crypto.PubKey `json:"pubkey"`
+	Algo   string        `json:"algo"`
+}
+
+func (i offlineInfo) keyType() string       { return TypeOffline }
+func (i offlineInfo) pubKey() crypto.PubKey { return i.PubKey }
+
+type multiInfo struct {
+	Name      string               `json:"name"`
+	PubKey    crypto.PubKey        `json:"pubkey"`
+	Threshold uint                 `json:"threshold"`
+	PubKeys   []multisigPubKeyInfo `json:"pubkeys"`
+}
+
+type multisigPubKeyInfo struct {
+	PubKey crypto.PubKey `json:"pubkey"`
+	Weight uint          `json:"weight"`
+}
+
+func (i multiInfo) keyType() string       { return TypeMulti }
+func (i multiInfo) pubKey() crypto.PubKey { return i.PubKey }
+
+// irisLedgerInfo is a ledger key of iriscli v0.16, whose path is the list of the BIP44 indexes
+type irisLedgerInfo struct {
+	Name   string        `json:"name"`
+	PubKey crypto.PubKey `json:"pubkey"`
+	Path   []uint32      `json:"path"`
+}
+
+func (i irisLedgerInfo) keyType() string       { return TypeLedger }
+func (i irisLedgerInfo) pubKey() crypto.PubKey { return i.PubKey }
+
+var (
+	cdc     = amino.NewCodec()
+	irisCdc = amino.NewCodec()
+)
+
+func init() {
+	for _, c := range []*amino.Codec{cdc, irisCdc} {
+		cryptoAmino.RegisterAmino(c)
+		c.RegisterInterface((*keyInfo)(nil), nil)
+		c.RegisterConcrete(localInfo{}, "crypto/keys/localInfo", nil)
+		c.RegisterConcrete(offlineInfo{}, "crypto/keys/offlineInfo", nil)
+		c.RegisterConcrete(multiInfo{}, "crypto/keys/multiInfo", nil)
+	}
+	cdc.RegisterConcrete(ledgerInfo{}, "crypto/keys/ledgerInfo", nil)
+	irisCdc.RegisterConcrete(irisLedgerInfo{}, "crypto/keys/ledgerInfo", nil)
+}
@@ -0,0 +1,217 @@
+package clikeys
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/tendermint/tendermint/crypto/secp256k1"
+	dbm "github.com/tendermint/tm-db"
+
+	"github.com/irisnet/irishub-sdk-go/adapter"
+	"github.com/irisnet/irishub-sdk-go/crypto"
+	"github.com/irisnet/irishub-sdk-go/types"
+)
+
+const mnemonic = "diary narrow around pigeon someone health syrup ask seek among search element canoe wheat " +
+	"repair brisk blanket patient rigid episode strong cable shield excite"
+
+func TestImportAll(t *testing.T) {
+	home, err := ioutil.TempDir("", "iriscli")
+	require.NoError(t, err)
+	defer os.RemoveAll(home)
+
+	// write a keybase as iriscli does
+	local := secp256k1.GenPrivKey()
+	armor, err := crypto.EncryptArmorPrivKey(local, "passphrase")
+	require.NoError(t, err)
+	offline := secp256k1.GenPrivKey().PubKey()
+
+	db, err := dbm.NewGoLevelDB(keyDBName, filepath.Join(home, "keys"))
+	require.NoError(t, err)
+	writeInfo(t, db, "local", localInfo{Name: "local", PubKey: local.PubKey(), PrivKeyArmor: armor})
+	writeInfo(t, db, "offline", offlineInfo{Name: "offline", PubKey: offline})
+	require.NoError(t, db.Close())
+
+	kb, err := Open(home)
+	require.NoError(t, err)
+	defer kb.Close()
+
+	infos, err := kb.List()
+	require.NoError(t, err)
+	require.Len(t, infos, 2)
+	require.Equal(t, TypeLocal, infos[0].Type)
+	require.Equal(t, types.AccAddress(local.PubKey().Address()).String(), infos[0].Address)
+	require.Equal(t, TypeOffline, infos[1].Type)
+
+	_, err = kb.Armor("offline")
+	require.Error(t, err)
+
+	km := adapter.NewDAOAdapter(types.NewMemoryDB(), types.PrivKey)
+	imported, err := kb.ImportAll(km, func(name string) (string, string, error) {
+		return "passphrase", "password", nil
+	})
+	require.NoError(t, err)
+	require.Equal(t, []string{"local", "offline"}, imported)
+
+	sig, err := km.Sign("local", "password", []byte("data"))
+	require.NoError(t, err)
+	require.Equal(t, local.PubKey(), sig.PubKey)
+	address, err := km.Query("offline")
+	require.NoError(t, err)
+	require.Equal(t, infos[1].Address, address.String())
+
+	// back to the armored format of the command line interface
+	exported, err := km.ExportArmor("local", "password", "new passphrase")
+	require.NoError(t, err)
+	privKey, err := crypto.UnarmorDecryptPrivKey(exported, "new passphrase")
+	require.NoError(t, err)
+	require.Equal(t, local, privKey)
+
+	// the names are already used
+	_, err = kb.ImportAll(km, func(name string) (string, string, error) {
+		return "passphrase", "password", nil
+	})
+	require.Error(t, err)
+}
+
+// testdata/iriscli is a keybase written by iriscli v0.16:
+//
+// 	iriscli keys add local --recover    # mnemonic, passphrase 12345678
+// 	iriscli keys add second             # passphrase 87654321
+// 	iriscli keys add offline --pubkey <public key of second>
+// 	iriscli keys add multi --multisig local,second --multisig-threshold 1
+//
+// and testdata/local.json is the keystore of local written by `iriscli keys export local`, password abcdefgh
+func TestImportIriscli(t *testing.T) {
+	// the keys are listed with the prefixes of the testnet of the iriscli which wrote them
+	defer types.SetAddrPrefixCfg(types.GetAddrPrefixCfg())
+	types.SetNetwork(types.Testnet)
+
+	home, err := ioutil.TempDir("", "iriscli")
+	require.NoError(t, err)
+	defer os.RemoveAll(home)
+	copyDir(t, "testdata/iriscli", home)
+
+	kb, err := Open(home)
+	require.NoError(t, err)
+	defer kb.Close()
+
+	infos, err := kb.List()
+	require.NoError(t, err)
+	require.Equal(t, []Info{{
+		Name:    "local",
+		Type:    TypeLocal,
+		Address: "faa1qjsa5rguug68vurdng7dm0xmlx59lh9dg6luul",
+		PubKey:  "fap1addwnpepqfj5sk4mpj8jvzxldu6qlevs6anjhl3nqyf5xwll3vr8tuftke6tgt6h8t2",
+	}, {
+		Name:    "multi",
+		Type:    TypeMulti,
+		Address: "faa1fedj6hpye44zt5wyc5zsmag8gl8qvf9uqm9w74",
+		PubKey: "fap1ytql0csgqyfzd666axrjzqn9fpdtkry0ycyd7me5pljep4m890lrxqgngvallzcxwhcjhdn5ksfzd666axrjzqke5cw" +
+			"qyu0gtlm5w5jlmrje7ep4ylgk56eg5espq35patgmupwh852975kh",
+	}, {
+		Name:    "offline",
+		Type:    TypeOffline,
+		Address: "faa1hdn0wf40yu9apsl7z9fqgynwt6x778v20y729w",
+		PubKey:  "fap1addwnpepqtv6v8qzw859la682f0a3evlvs6j05t2dv52vcqsg6q745d7qhtn6dnvayd",
+	}, {
+		Name:    "second",
+		Type:    TypeLocal,
+		Address: "faa1hdn0wf40yu9apsl7z9fqgynwt6x778v20y729w",
+		PubKey:  "fap1addwnpepqtv6v8qzw859la682f0a3evlvs6j05t2dv52vcqsg6q745d7qhtn6dnvayd",
+	}}, infos)
+
+	km := adapter.NewDAOAdapter(types.NewMemoryDB(), types.PrivKey)
+	passphrases := map[string]string{"local": "12345678", "second": "87654321"}
+	imported, err := kb.ImportAll(km, func(name string) (string, string, error) {
+		return passphrases[name], "password", nil
+	})
+	require.NoError(t, err)
+	require.Equal(t, []string{"local", "multi", "offline", "second"}, imported)
+
+	for _, info := range infos {
+		address, err := km.Query(info.Name)
+		require.NoError(t, err)
+		require.Equal(t, info.Address, address.String())
+	}
+	pubKey, err := km.QueryPubKey("second", "password")
+	require.NoError(t, err)
+	require.Equal(t, infos[3].PubKey, pubKey)
+
+	// the key recovered by iriscli is the one recovered by the SDK
+	address, err := km.Recover("recovered", "password", mnemonic)
+	require.NoError(t, err)
+	require.Equal(t, infos[0].Address, address)
+
+	keystore, err := ioutil.ReadFile("testdata/local.json")
+	require.NoError(t, err)
+	address, err = km.Import("keystore", "abcdefgh", string(keystore))
+	require.NoError(t, err)
+	require.Equal(t, infos[0].Address, address)
+}
+
+func TestImportIriscliLedger(t *testing.T) {
+	home, err := ioutil.TempDir("", "iriscli")
+	require.NoError(t, err)
+	defer os.RemoveAll(home)
+
+	// the ledger keys of iriscli v0.16 have a path of indexes, those of the Cosmos SDK a BIP44Params
+	pubKey := secp256k1.GenPrivKey().PubKey()
+	db, err := dbm.NewGoLevelDB(keyDBName, filepath.Join(home, "keys"))
+	require.NoError(t, err)
+	bz, err := irisCdc.MarshalBinaryLengthPrefixed(keyInfo(irisLedgerInfo{Name: "iris", PubKey: pubKey, Path: []uint32{44, 118, 0, 0, 0}}))
+	require.NoError(t, err)
+	require.NoError(t, db.SetSync([]byte("iris"+infoSuffix), bz))
+	writeInfo(t, db, "cosmos", ledgerInfo{Name: "cosmos", PubKey: pubKey, Path: bip44Params{Purpose: 44, CoinType: 118}})
+	require.NoError(t, db.Close())
+
+	kb, err := Open(home)
+	require.NoError(t, err)
+	defer kb.Close()
+
+	infos, err := kb.List()
+	require.NoError(t, err)
+	require.Len(t, infos, 2)
+	for _, info := range infos {
+		require.Equal(t, TypeLedger, info.Type)
+		require.Equal(t, types.AccAddress(pubKey.Address()).String(), info.Address)
+	}
+}
+
+func TestOpenMissing(t *testing.T) {
+	home, err := ioutil.TempDir("", "iriscli")
+	require.NoError(t, err)
+	defer os.RemoveAll(home)
+
+	_, err = Open(home)
+	require.Error(t, err)
+}
+
+func copyDir(t *testing.T, src, dst string) {
+	require.NoError(t, filepath.Walk(src, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(src, path)
+		if err != nil {
+			return err
+		}
+		if info.IsDir() {
+			return os.MkdirAll(filepath.Join(dst, rel), 0755)
+		}
+		bz, err := ioutil.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		return ioutil.WriteFile(filepath.Join(dst, rel), bz, 0644)
+	}))
+}
+
+func writeInfo(t *testing.T, db dbm.DB, name string, info keyInfo) {
+	bz, err := cdc.MarshalBinaryLengthPrefixed(info)
+	require.NoError(t, err)
+	require.NoError(t, db.SetSync([]byte(name+infoSuffix), bz))
+}
@@ -0,0 +1,112 @@
+// Command keybackup exports all the keys of a LevelDB keybase to one encrypted archive,
+// and restores an archive into a keybase, e.g. to move the signer to a new host.
+//
+// 	keybackup -home /var/lib/irishub-sdk export keys.backup
+// 	keybackup -home /var/lib/signer -conflict rename -password-dir /etc/signer/passwords restore keys.backup
+//
+// The backup passphrase is read from the terminal. The passwords of the keys, read from
+// -password-dir or the environment with -password-env, are used to verify the restored keys.
+// The home is the DBRootDir of an SDK client, the keybase of iriscli is read by the package clikeys.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"os"
+
+	"github.com/irisnet/irishub-sdk-go/backup"
+	"github.com/irisnet/irishub-sdk-go/types"
+)
+
+var conflicts = map[string]backup.Conflict{
+	"skip":      backup.Skip,
+	"overwrite": backup.Overwrite,
+	"rename":    backup.Rename,
+}
+
+func main() {
+	flag.Usage = func() {
+		fmt.Fprintf(flag.CommandLine.Output(), "usage: %s [flags] export|restore <archive>\n", os.Args[0])
+		flag.PrintDefaults()
+	}
+	home := flag.String("home", "", "root directory of the keybase, the keys are stored in <home>/keys")
+	network := flag.String("network", string(types.Mainnet), "network of the keys, mainnet or testnet")
+	conflict := flag.String("conflict", "skip", "restore of an existing name: skip, overwrite or rename")
+	passwordDir := flag.String("password-dir", "", "directory of the files holding the passwords of the keys")
+	passwordEnv := flag.String("password-env", "", "prefix of the environment variables holding the passwords of the keys")
+	flag.Parse()
+
+	if len(*home) == 0 || flag.NArg() != 2 {
+		flag.Usage()
+		os.Exit(2)
+	}
+	if _, ok := conflicts[*conflict]; !ok {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	var passwords types.PasswordProvider
+	if len(*passwordDir) > 0 {
+		passwords = types.NewFilePasswordProvider(*passwordDir)
+	} else if len(*passwordEnv) > 0 {
+		passwords = types.NewEnvPasswordProvider(*passwordEnv)
+	}
+
+	if err := run(*home, types.Network(*network), flag.Arg(0), flag.Arg(1), backup.RestoreOptions{
+		Conflict:  conflicts[*conflict],
+		Passwords: passwords,
+	}); err != nil {
+		fmt.Fprintln(os.Stderr, err.Error())
+		os.Exit(1)
+	}
+}
+
+func run(home string, network types.Network, command, file string, opts backup.RestoreOptions) error {
+	types.SetNetwork(network)
+
+	cdc := types.NewAminoCodec()
+	types.RegisterCodec(cdc)
+	keybase, err := types.NewLevelDB(home, cdc)
+	if err != nil {
+		return err
+	}
+
+	switch command {
+	case "export":
+		passphrase, err := types.NewTerminalPasswordProvider().Password("the backup")
+		if err != nil {
+			return err
+		}
+		archive, err := backup.Export(keybase, passphrase)
+		if err != nil {
+			return err
+		}
+		return ioutil.WriteFile(file, archive, 0600)
+	case "restore":
+		archive, err := ioutil.ReadFile(file)
+		if err != nil {
+			return err
+		}
+		passphrase, err := types.NewTerminalPasswordProvider().Password("the backup")
+		if err != nil {
+			return err
+		}
+		results, err := backup.Restore(keybase, archive, passphrase, opts)
+		if err != nil {
+			return err
+		}
+		for _, result := range results {
+			switch {
+			case len(result.RestoredAs) == 0:
+				fmt.Printf("%s\t%s\tskipped\n", result.Name, result.Address)
+			case result.Verified:
+				fmt.Printf("%s\t%s\trestored as %s, verified\n", result.Name, result.Address, result.RestoredAs)
+			default:
+				fmt.Printf("%s\t%s\trestored as %s, not verified\n", result.Name, result.Address, result.RestoredAs)
+			}
+		}
+		return nil
+	}
+	return fmt.Errorf("unknown command %s, expected export or restore", command)
+}
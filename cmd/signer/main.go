@@ -0,0 +1,81 @@
+// Command signer is the reference remote signer daemon. It signs with the keys of a local
+// LevelDB keybase, such as the one written by an SDK client into its DBRootDir, and only
+// accepts the clients presenting a certificate signed by the given CA.
+//
+// 	signer -home /var/lib/irishub-sdk -listen unix:///var/run/irishub-signer.sock \
+// 		-cert server.crt -key server.key -ca ca.crt
+//
+// The home is the DBRootDir of an SDK client. The keybase of iriscli has another format,
+// its keys are imported first into an SDK keybase by the package clikeys.
+//
+// The application is then pointed at it with:
+//
+// 	tlsConfig, err := signer.ClientTLSConfig("client.crt", "client.key", "ca.crt", "signer")
+// 	km, err := signer.NewClient("unix:///var/run/irishub-signer.sock", tlsConfig, 0)
+// 	client := sdk.NewClient(types.ClientConfig{KeyManager: km, ...})
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"github.com/irisnet/irishub-sdk-go/adapter"
+	"github.com/irisnet/irishub-sdk-go/signer"
+	"github.com/irisnet/irishub-sdk-go/types"
+	"github.com/irisnet/irishub-sdk-go/utils/log"
+)
+
+func main() {
+	home := flag.String("home", "", "root directory of the keybase, the keys are stored in <home>/keys")
+	listen := flag.String("listen", "unix:///tmp/irishub-signer.sock", "listen address, unix://<path> or tcp://<host:port>")
+	certFile := flag.String("cert", "", "PEM encoded certificate of the signer")
+	keyFile := flag.String("key", "", "PEM encoded private key of the certificate")
+	caFile := flag.String("ca", "", "PEM encoded CA which signs the client certificates")
+	network := flag.String("network", string(types.Mainnet), "network of the keys, mainnet or testnet")
+	level := flag.String("log-level", "info", "log level")
+	flag.Parse()
+
+	if len(*home) == 0 || len(*certFile) == 0 || len(*keyFile) == 0 || len(*caFile) == 0 {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	if err := run(*home, *listen, *certFile, *keyFile, *caFile, types.Network(*network), *level); err != nil {
+		fmt.Fprintln(os.Stderr, err.Error())
+		os.Exit(1)
+	}
+}
+
+func run(home, listen, certFile, keyFile, caFile string, network types.Network, level string) error {
+	types.SetNetwork(network)
+	logger := log.NewLogger(level)
+
+	cdc := types.NewAminoCodec()
+	types.RegisterCodec(cdc)
+	keybase, err := types.NewLevelDB(home, cdc)
+	if err != nil {
+		return err
+	}
+
+	tlsConfig, err := signer.ServerTLSConfig(certFile, keyFile, caFile)
+	if err != nil {
+		return err
+	}
+	server, err := signer.NewServer(adapter.NewDAOAdapter(keybase, types.PrivKey), tlsConfig, logger)
+	if err != nil {
+		return err
+	}
+
+	sig := make(chan os.Signal, 1)
+	signal.Notify(sig, syscall.SIGINT, syscall.SIGTERM)
+	go func() {
+		<-sig
+		_ = server.Close()
+	}()
+
+	logger.Info("signer started", "address", listen)
+	return server.ListenAndServe(listen)
+}
@@ -0,0 +1,94 @@
+package crypto
+
+import (
+	"encoding/hex"
+	"fmt"
+
+	"github.com/tendermint/crypto/bcrypt"
+	"github.com/tendermint/tendermint/crypto"
+	"github.com/tendermint/tendermint/crypto/armor"
+	cryptoAmino "github.com/tendermint/tendermint/crypto/encoding/amino"
+	"github.com/tendermint/tendermint/crypto/secp256k1"
+	"github.com/tendermint/tendermint/crypto/xsalsa20symmetric"
+)
+
+// the armored private keys of the Cosmos SDK keybase, as stored in the keybase of iriscli
+// and written by the `keys export` command of the Cosmos SDK 0.38+ command line interfaces
+const (
+	blockTypePrivKey = "TENDERMINT PRIVATE KEY"
+	armorKDF         = "bcrypt"
+	bcryptSaltSize   = 16
+	// BcryptSecurityParameter is the bcrypt cost of the armored private keys
+	BcryptSecurityParameter = 12
+)
+
+// NewArmorKeyManager decrypts an armored private key with its passphrase
+func NewArmorKeyManager(armorStr, passphrase string) (KeyManager, error) {
+	privKey, err := UnarmorDecryptPrivKey(armorStr, passphrase)
+	if err != nil {
+		return nil, err
+	}
+	secpPrivKey, ok := privKey.(secp256k1.PrivKeySecp256k1)
+	if !ok {
+		return nil, fmt.Errorf("only secp256k1 keys are supported")
+	}
+	return &keyManager{privKey: secpPrivKey}, nil
+}
+
+func (m *keyManager) ExportAsArmor(passphrase string) (string, error) {
+	return EncryptArmorPrivKey(m.privKey, passphrase)
+}
+
+// EncryptArmorPrivKey encrypts the private key with a key derived from the passphrase by bcrypt
+// and armors it, the result can be imported by the `keys import` command of the Cosmos SDK
+func EncryptArmorPrivKey(privKey crypto.PrivKey, passphrase string) (string, error) {
+	salt := crypto.CRandBytes(bcryptSaltSize)
+	key, err := armorKey(salt, passphrase)
+	if err != nil {
+		return "", err
+	}
+	header := map[string]string{
+		"kdf":  armorKDF,
+		"salt": fmt.Sprintf("%X", salt),
+	}
+	return armor.EncodeArmor(blockTypePrivKey, header, xsalsa20symmetric.EncryptSymmetric(privKey.Bytes(), key)), nil
+}
+
+// UnarmorDecryptPrivKey returns the private key of an armor written by EncryptArmorPrivKey or by the Cosmos SDK keybase
+func UnarmorDecryptPrivKey(armorStr, passphrase string) (crypto.PrivKey, error) {
+	blockType, header, encBytes, err := armor.DecodeArmor(armorStr)
+	if err != nil {
+		return nil, err
+	}
+	if blockType != blockTypePrivKey {
+		return nil, fmt.Errorf("unrecognized armor type: %s", blockType)
+	}
+	if header["kdf"] != armorKDF {
+		return nil, fmt.Errorf("unrecognized KDF type: %s", header["kdf"])
+	}
+	if header["salt"] == "" {
+		return nil, fmt.Errorf("missing salt bytes")
+	}
+	salt, err := hex.DecodeString(header["salt"])
+	if err != nil {
+		return nil, fmt.Errorf("invalid salt bytes: %s", err.Error())
+	}
+
+	key, err := armorKey(salt, passphrase)
+	if err != nil {
+		return nil, err
+	}
+	privKeyBytes, err := xsalsa20symmetric.DecryptSymmetric(encBytes, key)
+	if err != nil {
+		return nil, fmt.Errorf("invalid account password")
+	}
+	return cryptoAmino.PrivKeyFromBytes(privKeyBytes)
+}
+
+func armorKey(salt []byte, passphrase string) ([]byte, error) {
+	key, err := bcrypt.GenerateFromPassword(salt, []byte(passphrase), BcryptSecurityParameter)
+	if err != nil {
+		return nil, err
+	}
+	return crypto.Sha256(key), nil
+}
@@ -19,6 +19,45 @@ const (
 	FullPath    = BIP44Prefix + PartialPath
 )
 
+// NewHDPath returns the BIP44 path of the account, e.g. 44'/118'/account'/change/index
+func NewHDPath(account uint32, change bool, index uint32) string {
+	c := 0
+	if change {
+		c = 1
+	}
+	return fmt.Sprintf("%s%d'/%d/%d", BIP44Prefix, account, c, index)
+}
+
+// ValidateHDPath checks that the path is a BIP44 path, purpose'/coin_type'/account'/change/address_index,
+// the coin type can be any other than 118
+func ValidateHDPath(path string) error {
+	parts := strings.Split(path, "/")
+	if len(parts) != 5 {
+		return fmt.Errorf("invalid BIP44 path %s: it must contain 5 levels", path)
+	}
+	if parts[0] != "44'" {
+		return fmt.Errorf("invalid BIP44 path %s: the purpose must be 44'", path)
+	}
+
+	for i, part := range parts {
+		harden := strings.HasSuffix(part, "'")
+		if harden != (i < 3) {
+			if i < 3 {
+				return fmt.Errorf("invalid BIP44 path %s: the level %d must be hardened", path, i)
+			}
+			return fmt.Errorf("invalid BIP44 path %s: the level %d must not be hardened", path, i)
+		}
+		idx, err := strconv.ParseUint(strings.TrimSuffix(part, "'"), 10, 32)
+		if err != nil || idx >= 0x80000000 {
+			return fmt.Errorf("invalid BIP44 path %s: invalid index %s", path, part)
+		}
+		if i == 3 && idx > 1 {
+			return fmt.Errorf("invalid BIP44 path %s: the change must be 0 or 1", path)
+		}
+	}
+	return nil
+}
+
 // ComputeMastersFromSeed returns the master public key, master secret, and chain code in hex.
 func ComputeMastersFromSeed(seed []byte) (secret [32]byte, chainCode [32]byte) {
 	masterSecret := []byte("Bitcoin seed")
@@ -20,16 +20,29 @@ type KeyManager interface {
 	ExportAsMnemonic() (string, error)
 	ExportAsPrivateKey() (string, error)
 	ExportAsKeystore(password string) (Keystore, error)
+	ExportAsArmor(passphrase string) (string, error)
+	// PubKey returns the public key without copying the private key
+	PubKey() crypto.PubKey
+	// Zero overwrites the private key in memory, the key manager can not sign anymore
+	Zero()
 }
 
 type keyManager struct {
-	privKey  crypto.PrivKey
+	privKey  secp256k1.PrivKeySecp256k1
 	mnemonic string
 }
 
 func NewMnemonicKeyManager(mnemonic string) (KeyManager, error) {
+	return NewMnemonicKeyManagerWithHDPath(mnemonic, defaultBIP39Passphrase, FullPath)
+}
+
+// NewMnemonicKeyManagerWithHDPath derives the key of the BIP44 path from the mnemonic and the BIP39 passphrase
+func NewMnemonicKeyManagerWithHDPath(mnemonic, bip39Passphrase, hdPath string) (KeyManager, error) {
+	if err := ValidateHDPath(hdPath); err != nil {
+		return nil, err
+	}
 	k := keyManager{}
-	err := k.recoveryFromMnemonic(mnemonic, FullPath)
+	err := k.recoveryFromMnemonic(mnemonic, bip39Passphrase, hdPath)
 	return &k, err
 }
 
@@ -40,6 +53,11 @@ func NewPrivateKeyManager(priKey string) (KeyManager, error) {
 }
 
 func NewKeyManager() (KeyManager, error) {
+	return NewKeyManagerWithHDPath(defaultBIP39Passphrase, FullPath)
+}
+
+// NewKeyManagerWithHDPath generates a new mnemonic and derives the key of the BIP44 path
+func NewKeyManagerWithHDPath(bip39Passphrase, hdPath string) (KeyManager, error) {
 	entropy, err := bip39.NewEntropy(256)
 	if err != nil {
 		return nil, err
@@ -48,7 +66,7 @@ func NewKeyManager() (KeyManager, error) {
 	if err != nil {
 		return nil, err
 	}
-	return NewMnemonicKeyManager(mnemonic)
+	return NewMnemonicKeyManagerWithHDPath(mnemonic, bip39Passphrase, hdPath)
 }
 
 func (m *keyManager) ExportAsMnemonic() (string, error) {
@@ -59,11 +77,7 @@ func (m *keyManager) ExportAsMnemonic() (string, error) {
 }
 
 func (m *keyManager) ExportAsPrivateKey() (string, error) {
-	secpPrivateKey, ok := m.privKey.(secp256k1.PrivKeySecp256k1)
-	if !ok {
-		return "", fmt.Errorf(" Only PrivKeySecp256k1 key is supported ")
-	}
-	return hex.EncodeToString(secpPrivateKey[:]), nil
+	return hex.EncodeToString(m.privKey[:]), nil
 }
 
 func (m *keyManager) Sign(data []byte) ([]byte, error) {
@@ -74,12 +88,23 @@ func (m *keyManager) GetPrivKey() crypto.PrivKey {
 	return m.privKey
 }
 
-func (m *keyManager) recoveryFromMnemonic(mnemonic, keyPath string) error {
+func (m *keyManager) PubKey() crypto.PubKey {
+	return m.privKey.PubKey()
+}
+
+func (m *keyManager) Zero() {
+	for i := range m.privKey {
+		m.privKey[i] = 0
+	}
+	m.mnemonic = ""
+}
+
+func (m *keyManager) recoveryFromMnemonic(mnemonic, bip39Passphrase, keyPath string) error {
 	words := strings.Split(mnemonic, " ")
 	if len(words) != 12 && len(words) != 24 {
 		return fmt.Errorf("mnemonic length should either be 12 or 24")
 	}
-	seed, err := bip39.NewSeedWithErrorChecking(mnemonic, defaultBIP39Passphrase)
+	seed, err := bip39.NewSeedWithErrorChecking(mnemonic, bip39Passphrase)
 	if err != nil {
 		return err
 	}
@@ -0,0 +1,20 @@
+package crypto
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/tendermint/tendermint/crypto/secp256k1"
+)
+
+func TestZero(t *testing.T) {
+	km, err := NewKeyManager()
+	require.NoError(t, err)
+	pubKey := km.GetPrivKey().PubKey()
+	require.Equal(t, pubKey, km.PubKey())
+
+	km.Zero()
+	require.Equal(t, secp256k1.PrivKeySecp256k1{}, km.GetPrivKey())
+	_, err = km.ExportAsMnemonic()
+	require.Error(t, err)
+}
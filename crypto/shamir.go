@@ -0,0 +1,385 @@
+package crypto
+
+import (
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/cosmos/go-bip39"
+	"github.com/tendermint/tendermint/crypto"
+)
+
+// The shares of a secret are SLIP-0039 style: every share is a list of words of the BIP39 english
+// wordlist, and any threshold of them rebuilds the secret with Shamir's secret sharing over GF(256).
+// A share holds:
+//
+//	version (4 bits) | kind (4 bits) | identifier (2 bytes) | threshold | index | length of the secret |
+//	share of (secret | digest) | checksum (4 bytes)
+//
+// where the digest is the first 4 bytes of SHA-256 of the secret, which detects shares of different
+// secrets, and the checksum the first 4 bytes of SHA-256 of the share, which detects mistyped words.
+const (
+	shareVersion     = 0
+	shareHeaderSize  = 6
+	shareDigestSize  = 4
+	shareChecksumLen = 4
+	// MaxShares is the maximum number of shares of a secret
+	MaxShares = 16
+)
+
+// ShareKind is the kind of secret split into shares
+type ShareKind byte
+
+const (
+	// ShareMnemonic is the BIP39 entropy of a mnemonic with the HD path and the BIP39 passphrase of the key
+	ShareMnemonic ShareKind = 0
+	// SharePrivKey is a secp256k1 private key
+	SharePrivKey ShareKind = 1
+)
+
+// Secret is what the shares rebuild
+type Secret struct {
+	Kind ShareKind
+	// Mnemonic, BIP39Passphrase and HDPath derive the key of ShareMnemonic
+	Mnemonic        string
+	BIP39Passphrase string
+	HDPath          string
+	// PrivKey is the hex encoded private key of SharePrivKey
+	PrivKey string
+}
+
+// SplitMnemonic splits the BIP39 entropy of the mnemonic into shares, threshold of them rebuild it.
+// The BIP39 passphrase and the HD path of the key are split with it, so that the shares derive the same key.
+func SplitMnemonic(mnemonic, bip39Passphrase, hdPath string, threshold, shares int) ([]string, error) {
+	secret, err := mnemonicSecret(mnemonic, bip39Passphrase, hdPath)
+	if err != nil {
+		return nil, err
+	}
+	return splitSecret(ShareMnemonic, secret, threshold, shares)
+}
+
+// EncodeMnemonic returns the BIP39 entropy of the mnemonic, the BIP39 passphrase and the HD path of the key
+// hex encoded, the keybase keeps them encrypted to split them into shares later
+func EncodeMnemonic(mnemonic, bip39Passphrase, hdPath string) (string, error) {
+	secret, err := mnemonicSecret(mnemonic, bip39Passphrase, hdPath)
+	if err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(secret), nil
+}
+
+// DecodeMnemonic returns the mnemonic, the BIP39 passphrase and the HD path encoded by EncodeMnemonic
+func DecodeMnemonic(encoded string) (Secret, error) {
+	bz, err := hex.DecodeString(encoded)
+	if err != nil {
+		return Secret{}, err
+	}
+	return decodeMnemonicSecret(bz)
+}
+
+// mnemonicSecret returns len(entropy) | entropy | len(hdPath) | hdPath | bip39Passphrase
+func mnemonicSecret(mnemonic, bip39Passphrase, hdPath string) ([]byte, error) {
+	entropy, err := mnemonicEntropy(mnemonic)
+	if err != nil {
+		return nil, err
+	}
+	if err := ValidateHDPath(hdPath); err != nil {
+		return nil, err
+	}
+	if len(hdPath) > 0xff {
+		return nil, errors.New("the HD path is too long")
+	}
+
+	secret := append([]byte{byte(len(entropy))}, entropy...)
+	secret = append(append(secret, byte(len(hdPath))), hdPath...)
+	return append(secret, bip39Passphrase...), nil
+}
+
+func decodeMnemonicSecret(bz []byte) (Secret, error) {
+	secret := Secret{Kind: ShareMnemonic}
+	entropy, rest, ok := cutLengthPrefixed(bz)
+	if !ok {
+		return secret, errors.New("invalid mnemonic share")
+	}
+	hdPath, passphrase, ok := cutLengthPrefixed(rest)
+	if !ok {
+		return secret, errors.New("invalid mnemonic share")
+	}
+	mnemonic, err := bip39.NewMnemonic(entropy)
+	if err != nil {
+		return secret, err
+	}
+	secret.Mnemonic, secret.HDPath, secret.BIP39Passphrase = mnemonic, string(hdPath), string(passphrase)
+	return secret, nil
+}
+
+// SplitKey splits the private key of the key manager, the shares rebuild the key whatever its HD path
+func SplitKey(km KeyManager, threshold, shares int) ([]string, error) {
+	privKey, err := km.ExportAsPrivateKey()
+	if err != nil {
+		return nil, err
+	}
+	bz, err := hex.DecodeString(privKey)
+	if err != nil {
+		return nil, err
+	}
+	return splitSecret(SharePrivKey, bz, threshold, shares)
+}
+
+// CombineShares rebuilds the secret of the shares
+func CombineShares(shares []string) (Secret, error) {
+	kind, bz, err := combineShares(shares)
+	if err != nil {
+		return Secret{}, err
+	}
+	secret := Secret{Kind: kind}
+	switch kind {
+	case ShareMnemonic:
+		return decodeMnemonicSecret(bz)
+	case SharePrivKey:
+		secret.PrivKey = hex.EncodeToString(bz)
+		return secret, nil
+	}
+	return secret, fmt.Errorf("unknown kind of share: %d", kind)
+}
+
+// NewSharesKeyManager rebuilds a key from its shares
+func NewSharesKeyManager(shares []string) (KeyManager, error) {
+	secret, err := CombineShares(shares)
+	if err != nil {
+		return nil, err
+	}
+	if secret.Kind == ShareMnemonic {
+		return NewMnemonicKeyManagerWithHDPath(secret.Mnemonic, secret.BIP39Passphrase, secret.HDPath)
+	}
+	return NewPrivateKeyManager(secret.PrivKey)
+}
+
+// cutLengthPrefixed splits bz after the bytes prefixed by their length
+func cutLengthPrefixed(bz []byte) ([]byte, []byte, bool) {
+	if len(bz) == 0 || len(bz) < 1+int(bz[0]) {
+		return nil, nil, false
+	}
+	return bz[1 : 1+int(bz[0])], bz[1+int(bz[0]):], true
+}
+
+func splitSecret(kind ShareKind, secret []byte, threshold, shares int) ([]string, error) {
+	if threshold < 1 || threshold > shares || shares > MaxShares {
+		return nil, fmt.Errorf("invalid threshold %d of %d shares, 1 <= threshold <= shares <= %d", threshold, shares, MaxShares)
+	}
+	if threshold == 1 && shares > 1 {
+		return nil, errors.New("a threshold of 1 requires a single share")
+	}
+	if len(secret) > 0xff {
+		return nil, errors.New("the secret is too long")
+	}
+
+	digest := sha256.Sum256(secret)
+	value := append(append([]byte{}, secret...), digest[:shareDigestSize]...)
+	identifier := crypto.CRandBytes(2)
+
+	// one random polynomial of degree threshold-1 per byte, whose constant term is the byte
+	coefficients := make([][]byte, threshold)
+	coefficients[0] = value
+	for i := 1; i < threshold; i++ {
+		coefficients[i] = crypto.CRandBytes(len(value))
+	}
+
+	result := make([]string, shares)
+	for i := 0; i < shares; i++ {
+		x := byte(i + 1)
+		share := []byte{
+			shareVersion<<4 | byte(kind),
+			identifier[0], identifier[1],
+			byte(threshold), x, byte(len(secret)),
+		}
+		for j := range value {
+			// Horner's method
+			var y byte
+			for k := threshold - 1; k >= 0; k-- {
+				y = gfMul(y, x) ^ coefficients[k][j]
+			}
+			share = append(share, y)
+		}
+		checksum := sha256.Sum256(share)
+		result[i] = encodeWords(append(share, checksum[:shareChecksumLen]...))
+	}
+	return result, nil
+}
+
+func combineShares(shares []string) (ShareKind, []byte, error) {
+	if len(shares) == 0 {
+		return 0, nil, errors.New("no share is given")
+	}
+
+	var header []byte
+	xs := make([]byte, 0, len(shares))
+	ys := make([][]byte, 0, len(shares))
+	for i, s := range shares {
+		share, err := decodeShare(s)
+		if err != nil {
+			return 0, nil, fmt.Errorf("invalid share %d: %s", i+1, err.Error())
+		}
+		if header == nil {
+			header = share[:shareHeaderSize]
+		} else if string(share[:4]) != string(header[:4]) || share[5] != header[5] {
+			return 0, nil, fmt.Errorf("share %d does not belong to the same secret as share 1", i+1)
+		}
+		for _, x := range xs {
+			if x == share[4] {
+				return 0, nil, fmt.Errorf("share %d is given twice", share[4])
+			}
+		}
+		xs = append(xs, share[4])
+		ys = append(ys, share[shareHeaderSize:])
+	}
+
+	threshold := int(header[3])
+	if len(xs) < threshold {
+		return 0, nil, fmt.Errorf("%d shares are required, got %d", threshold, len(xs))
+	}
+	xs, ys = xs[:threshold], ys[:threshold]
+
+	// Lagrange interpolation at 0
+	value := make([]byte, len(ys[0]))
+	for i := range xs {
+		basis := byte(1)
+		for j := range xs {
+			if i != j {
+				basis = gfMul(basis, gfDiv(xs[j], xs[j]^xs[i]))
+			}
+		}
+		for k := range value {
+			value[k] ^= gfMul(ys[i][k], basis)
+		}
+	}
+
+	secret, digest := value[:len(value)-shareDigestSize], value[len(value)-shareDigestSize:]
+	expected := sha256.Sum256(secret)
+	if subtle.ConstantTimeCompare(digest, expected[:shareDigestSize]) != 1 {
+		return 0, nil, errors.New("invalid digest, the shares do not belong to the same secret")
+	}
+	return ShareKind(header[0] & 0x0f), secret, nil
+}
+
+func decodeShare(s string) ([]byte, error) {
+	words := strings.Fields(s)
+	// the length of the secret is in the header, decode it first
+	bz, err := decodeWords(words, shareHeaderSize)
+	if err != nil {
+		return nil, err
+	}
+	if bz[0]>>4 != shareVersion {
+		return nil, fmt.Errorf("unsupported version %d", bz[0]>>4)
+	}
+	size := shareHeaderSize + int(bz[5]) + shareDigestSize + shareChecksumLen
+	if len(words) != wordCount(size) {
+		return nil, fmt.Errorf("expected %d words, got %d", wordCount(size), len(words))
+	}
+	if bz, err = decodeWords(words, size); err != nil {
+		return nil, err
+	}
+
+	share, checksum := bz[:size-shareChecksumLen], bz[size-shareChecksumLen:]
+	expected := sha256.Sum256(share)
+	if subtle.ConstantTimeCompare(checksum, expected[:shareChecksumLen]) != 1 {
+		return nil, errors.New("invalid checksum")
+	}
+	if share[3] == 0 || share[4] == 0 {
+		return nil, errors.New("invalid threshold or index")
+	}
+	return share, nil
+}
+
+// encodeWords encodes the bytes 11 bits per word, the last word is padded with zeros
+func encodeWords(bz []byte) string {
+	n := new(big.Int).SetBytes(bz)
+	count := wordCount(len(bz))
+	n.Lsh(n, uint(count*11-len(bz)*8))
+
+	words := make([]string, count)
+	mask := big.NewInt(2047)
+	for i := count - 1; i >= 0; i-- {
+		words[i] = bip39.WordList[new(big.Int).And(n, mask).Int64()]
+		n.Rsh(n, 11)
+	}
+	return strings.Join(words, " ")
+}
+
+// decodeWords returns the first size bytes encoded by the words
+func decodeWords(words []string, size int) ([]byte, error) {
+	if len(words) < wordCount(size) {
+		return nil, fmt.Errorf("expected at least %d words, got %d", wordCount(size), len(words))
+	}
+	words = words[:wordCount(size)]
+
+	n := new(big.Int)
+	for _, word := range words {
+		index, ok := bip39.ReverseWordMap[word]
+		if !ok {
+			return nil, fmt.Errorf("unknown word %s", word)
+		}
+		n.Lsh(n, 11)
+		n.Or(n, big.NewInt(int64(index)))
+	}
+	n.Rsh(n, uint(len(words)*11-size*8))
+	return leftPad(n.Bytes(), size), nil
+}
+
+func wordCount(size int) int {
+	return (size*8 + 10) / 11
+}
+
+// mnemonicEntropy returns the BIP39 entropy of the mnemonic
+func mnemonicEntropy(mnemonic string) ([]byte, error) {
+	if !bip39.IsMnemonicValid(mnemonic) {
+		return nil, errors.New("invalid mnemonic")
+	}
+	words := strings.Fields(mnemonic)
+	bits := len(words) * 11
+	// the checksum is 1 bit per 32 bits of entropy
+	checksumBits := bits / 33
+
+	n := new(big.Int)
+	for _, word := range words {
+		n.Lsh(n, 11)
+		n.Or(n, big.NewInt(int64(bip39.ReverseWordMap[word])))
+	}
+	n.Rsh(n, uint(checksumBits))
+	return leftPad(n.Bytes(), (bits-checksumBits)/8), nil
+}
+
+func leftPad(bz []byte, size int) []byte {
+	if len(bz) >= size {
+		return bz
+	}
+	padded := make([]byte, size)
+	copy(padded[size-len(bz):], bz)
+	return padded
+}
+
+// gfMul multiplies in GF(2^8) with the polynomial of AES, x^8 + x^4 + x^3 + x + 1
+func gfMul(a, b byte) byte {
+	var p byte
+	for i := 0; i < 8; i++ {
+		// branch-free: the mask is 0xff when the low bit of b is set
+		p ^= a & -(b & 1)
+		carry := -(a >> 7)
+		a = a<<1 ^ 0x1b&carry
+		b >>= 1
+	}
+	return p
+}
+
+// gfDiv divides by b != 0, its inverse is b^254
+func gfDiv(a, b byte) byte {
+	inverse := byte(1)
+	for i := 0; i < 254; i++ {
+		inverse = gfMul(inverse, b)
+	}
+	return gfMul(a, inverse)
+}
@@ -0,0 +1,127 @@
+package crypto
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestGF256(t *testing.T) {
+	// example of FIPS-197
+	require.Equal(t, byte(0xc1), gfMul(0x57, 0x83))
+	for a := 1; a < 256; a++ {
+		require.Equal(t, byte(1), gfDiv(byte(a), byte(a)))
+		require.Equal(t, byte(0x57), gfMul(gfDiv(0x57, byte(a)), byte(a)))
+	}
+}
+
+func TestSplitMnemonic(t *testing.T) {
+	for _, bits := range []int{128, 256} {
+		km, err := NewKeyManager()
+		require.NoError(t, err)
+		mnemonic, err := km.ExportAsMnemonic()
+		require.NoError(t, err)
+		if bits == 128 {
+			mnemonic = "wrap bubble bunker win flat south life shed twelve payment super taste"
+		}
+
+		shares, err := SplitMnemonic(mnemonic, "passphrase", "44'/118'/1'/0/2", 3, 5)
+		require.NoError(t, err)
+		require.Len(t, shares, 5)
+
+		for _, subset := range [][]int{{0, 1, 2}, {4, 2, 0}, {1, 3, 4, 0}} {
+			var given []string
+			for _, i := range subset {
+				given = append(given, shares[i])
+			}
+			secret, err := CombineShares(given)
+			require.NoError(t, err)
+			require.Equal(t, ShareMnemonic, secret.Kind)
+			require.Equal(t, mnemonic, secret.Mnemonic)
+			require.Equal(t, "passphrase", secret.BIP39Passphrase)
+			require.Equal(t, "44'/118'/1'/0/2", secret.HDPath)
+		}
+
+		_, err = CombineShares(shares[:2])
+		require.Error(t, err)
+		_, err = CombineShares([]string{shares[0], shares[0], shares[1]})
+		require.Error(t, err)
+
+		// the key of the HD path and the passphrase is rebuilt
+		km, err = NewMnemonicKeyManagerWithHDPath(mnemonic, "passphrase", "44'/118'/1'/0/2")
+		require.NoError(t, err)
+		km1, err := NewSharesKeyManager(shares[2:])
+		require.NoError(t, err)
+		require.Equal(t, km.GetPrivKey(), km1.GetPrivKey())
+
+		encoded, err := EncodeMnemonic(mnemonic, "passphrase", "44'/118'/1'/0/2")
+		require.NoError(t, err)
+		secret, err := DecodeMnemonic(encoded)
+		require.NoError(t, err)
+		require.Equal(t, Secret{
+			Kind:            ShareMnemonic,
+			Mnemonic:        mnemonic,
+			BIP39Passphrase: "passphrase",
+			HDPath:          "44'/118'/1'/0/2",
+		}, secret)
+	}
+}
+
+func TestSplitKey(t *testing.T) {
+	km, err := NewPrivateKeyManager("2b8f7bd1a7b3d1eb4c8cb1cbe1d0e8b1f8a1e6f4b5c2d7a9e0f1a2b3c4d5e6f7")
+	require.NoError(t, err)
+	shares, err := SplitKey(km, 2, 3)
+	require.NoError(t, err)
+
+	km1, err := NewSharesKeyManager(shares[1:])
+	require.NoError(t, err)
+	require.Equal(t, km.GetPrivKey(), km1.GetPrivKey())
+
+	// the private key of a mnemonic key is split, not its mnemonic
+	km, err = NewKeyManager()
+	require.NoError(t, err)
+	shares, err = SplitKey(km, 1, 1)
+	require.NoError(t, err)
+	secret, err := CombineShares(shares)
+	require.NoError(t, err)
+	require.Equal(t, SharePrivKey, secret.Kind)
+	km1, err = NewSharesKeyManager(shares)
+	require.NoError(t, err)
+	require.Equal(t, km.GetPrivKey(), km1.GetPrivKey())
+}
+
+func TestInvalidShares(t *testing.T) {
+	mnemonic := "wrap bubble bunker win flat south life shed twelve payment super taste"
+	for _, params := range [][2]int{{0, 1}, {3, 2}, {1, 2}, {2, MaxShares + 1}} {
+		_, err := SplitMnemonic(mnemonic, "", FullPath, params[0], params[1])
+		require.Error(t, err, params)
+	}
+	_, err := SplitMnemonic("wrap bubble bunker", "", FullPath, 2, 3)
+	require.Error(t, err)
+	_, err = SplitMnemonic(mnemonic, "", "44'/118'/0'", 2, 3)
+	require.Error(t, err)
+
+	shares, err := SplitMnemonic(mnemonic, "", FullPath, 2, 3)
+	require.NoError(t, err)
+	other, err := SplitMnemonic(mnemonic, "", FullPath, 2, 3)
+	require.NoError(t, err)
+
+	// shares of another split
+	_, err = CombineShares([]string{shares[0], other[1]})
+	require.Error(t, err)
+
+	// a mistyped word
+	words := strings.Fields(shares[1])
+	if words[7] == "abandon" {
+		words[7] = "ability"
+	} else {
+		words[7] = "abandon"
+	}
+	_, err = CombineShares([]string{shares[0], strings.Join(words, " ")})
+	require.Error(t, err)
+
+	// a missing word
+	_, err = CombineShares([]string{shares[0], strings.Join(words[1:], " ")})
+	require.Error(t, err)
+}
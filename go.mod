@@ -3,6 +3,7 @@ module github.com/irisnet/irishub-sdk-go
 go 1.13
 
 require (
+	github.com/BurntSushi/toml v0.3.1
 	github.com/VividCortex/gohistogram v1.0.0 // indirect
 	github.com/bluele/gcache v0.0.0-20190518031135-bc40bd653833
 	github.com/btcsuite/btcd v0.20.1-beta
@@ -17,8 +18,11 @@ require (
 	github.com/rs/cors v1.7.0 // indirect
 	github.com/rs/zerolog v1.18.0
 	github.com/stretchr/testify v1.5.1
+	github.com/syndtr/goleveldb v1.0.1-0.20190923125748-758128399b1d
+	github.com/tendermint/crypto v0.0.0-20191022145703-50d29ede1e15
 	github.com/tendermint/go-amino v0.15.1
 	github.com/tendermint/tendermint v0.31.0
 	github.com/tendermint/tm-db v0.5.1
 	golang.org/x/crypto v0.0.0-20200117160349-530e935923ad
+	gopkg.in/yaml.v2 v2.2.2
 )
@@ -1,75 +1,173 @@
 package modules
 
 import (
+	"context"
+	"encoding/json"
 	"fmt"
-	"time"
+
+	rpcclient "github.com/tendermint/tendermint/rpc/client"
 
 	sdk "github.com/irisnet/irishub-sdk-go/types"
 	"github.com/irisnet/irishub-sdk-go/utils/cache"
 	"github.com/irisnet/irishub-sdk-go/utils/log"
+	"github.com/irisnet/irishub-sdk-go/utils/proto"
+	"github.com/irisnet/irishub-sdk-go/utils/trace"
 )
 
 // Must be used with locker, otherwise there are thread safety issues
 type accountQuery struct {
 	sdk.Queries
-	*log.Logger
+	log.Logger
 	cache.Cache
 
 	keyManager sdk.KeyManager
-	expiration time.Duration
+	prefixes   *sdk.AddrPrefixCfg
+	encoding   sdk.TxEncoding
+	abci       rpcclient.ABCIClient
+	tracer     trace.Tracer
 }
 
 func (a accountQuery) QueryAndRefreshAccount(address string) (sdk.BaseAccount, sdk.Error) {
-	account, err := a.Get(a.prefixKey(address))
-	if err != nil {
-		return a.refresh(address)
+	return a.queryAndRefreshAccount(context.Background(), address)
+}
+
+func (a accountQuery) queryAndRefreshAccount(ctx context.Context, address string) (sdk.BaseAccount, sdk.Error) {
+	ctx, span := a.tracer.Start(ctx, "QueryAndRefreshAccount", trace.String("address", address))
+	defer span.End()
+	logger := log.ForContext(ctx, a.Logger)
+
+	acc, ok := a.cachedAccount(address)
+	if !ok {
+		span.SetAttributes(trace.Bool("cached", false))
+		acc, err := a.refresh(ctx, address)
+		if err != nil {
+			span.RecordError(err)
+		}
+		return acc, err
 	}
+	span.SetAttributes(trace.Bool("cached", true))
 
-	acc := account.(accountInfo)
+	addr, err := a.prefixes.AccAddressFromBech32(address)
+	if err != nil {
+		return sdk.BaseAccount{}, sdk.Wrap(err)
+	}
 	baseAcc := sdk.BaseAccount{
-		Address:       sdk.MustAccAddressFromBech32(address),
+		Address:       addr,
 		AccountNumber: acc.N,
 		Sequence:      acc.S + 1,
 	}
-	a.saveAccount(baseAcc)
+	a.saveAccount(ctx, baseAcc)
 
-	a.Debug().
-		Str("address", address).
-		Msg("query account from cache")
+	logger.Debug("query account from cache", "address", address)
 	return baseAcc, nil
 }
 
-func (a accountQuery) QueryAccount(address string) (sdk.BaseAccount, sdk.Error) {
-	addr, err := sdk.AccAddressFromBech32(address)
+// nextAccount returns the account with the sequence following the cached one, without increasing the cached sequence
+func (a accountQuery) nextAccount(ctx context.Context, address string) (sdk.BaseAccount, sdk.Error) {
+	acc, ok := a.cachedAccount(address)
+	if !ok {
+		return a.queryAccount(ctx, address)
+	}
+	addr, err := a.prefixes.AccAddressFromBech32(address)
 	if err != nil {
 		return sdk.BaseAccount{}, sdk.Wrap(err)
 	}
+	return sdk.BaseAccount{
+		Address:       addr,
+		AccountNumber: acc.N,
+		Sequence:      acc.S + 1,
+	}, nil
+}
+
+// useSequence caches the sequence of a transaction signed outside of the SDK and broadcast as the last one used by
+// the account, unless a later one is cached
+func (a accountQuery) useSequence(ctx context.Context, addr sdk.AccAddress, accountNumber, sequence uint64) {
+	if info, ok := a.cachedAccount(a.prefixes.AccAddressString(addr)); ok && info.S >= sequence {
+		return
+	}
+	a.saveAccount(ctx, sdk.BaseAccount{
+		Address:       addr,
+		AccountNumber: accountNumber,
+		Sequence:      sequence,
+	})
+}
+
+func (a accountQuery) QueryAccount(address string) (sdk.BaseAccount, sdk.Error) {
+	return a.queryAccount(context.Background(), address)
+}
+
+func (a accountQuery) queryAccount(ctx context.Context, address string) (sdk.BaseAccount, sdk.Error) {
+	if _, err := a.prefixes.AccAddressFromBech32(address); err != nil {
+		return sdk.BaseAccount{}, sdk.Wrap(err)
+	}
+	if a.encoding == sdk.Protobuf {
+		return a.queryProtoAccount(ctx, address)
+	}
 
 	param := struct {
-		Address sdk.AccAddress
+		Address string
 	}{
-		Address: addr,
+		Address: address,
 	}
 
 	var account sdk.BaseAccount
 	if err := a.QueryWithResponse("custom/acc/account", param, &account); err != nil {
 		return sdk.BaseAccount{}, sdk.Wrap(err)
 	}
-	a.Debug().
-		Str("address", address).
-		Msg("query account from chain")
+	log.ForContext(ctx, a.Logger).Debug("query account from chain", "address", address)
+	return account, nil
+}
+
+// queryProtoAccount queries the auth and the bank modules of the Cosmos SDK 0.40+
+func (a accountQuery) queryProtoAccount(ctx context.Context, address string) (sdk.BaseAccount, sdk.Error) {
+	req := new(proto.Encoder).String(1, address).Bytes()
+	res, err := queryABCI(a.abci, "/cosmos.auth.v1beta1.Query/Account", req)
+	if err != nil {
+		return sdk.BaseAccount{}, sdk.Wrap(err)
+	}
+	account, err := sdk.UnmarshalProtoAccount(res, a.prefixes)
+	if err != nil {
+		return sdk.BaseAccount{}, sdk.Wrap(err)
+	}
+
+	res, err = queryABCI(a.abci, "/cosmos.bank.v1beta1.Query/AllBalances", req)
+	if err != nil {
+		return sdk.BaseAccount{}, sdk.Wrap(err)
+	}
+	fields, err := proto.Decode(res)
+	if err != nil {
+		return sdk.BaseAccount{}, sdk.Wrap(err)
+	}
+	for _, f := range fields {
+		if f.Number != 1 {
+			continue
+		}
+		coin, err := sdk.UnmarshalProtoCoin(f.Value)
+		if err != nil {
+			return sdk.BaseAccount{}, sdk.Wrap(err)
+		}
+		account.Coins = append(account.Coins, coin)
+	}
+
+	log.ForContext(ctx, a.Logger).Debug("query account from chain", "address", address)
 	return account, nil
 }
 
 func (a accountQuery) QueryAddress(name string) (sdk.AccAddress, sdk.Error) {
-	addr, err := a.Get(a.prefixKey(name))
+	return a.queryAddress(context.Background(), name)
+}
+
+func (a accountQuery) queryAddress(ctx context.Context, name string) (sdk.AccAddress, sdk.Error) {
+	ctx, span := a.tracer.Start(ctx, "QueryAddress", trace.String("name", name))
+	defer span.End()
+	logger := log.ForContext(ctx, a.Logger)
+
+	addr, err := a.Get(a.nameKey(name))
 	if err == nil {
-		address, err := sdk.AccAddressFromBech32(addr.(string))
+		address, err := a.prefixes.AccAddressFromBech32(string(addr.([]byte)))
 		if err != nil {
-			a.Warn().
-				Str("name", name).
-				Msg("invalid address")
-			_ = a.Remove(a.prefixKey(name))
+			logger.Warn("invalid address", "name", name)
+			_ = a.Remove(a.nameKey(name))
 		} else {
 			return address, nil
 		}
@@ -77,21 +175,15 @@ func (a accountQuery) QueryAddress(name string) (sdk.AccAddress, sdk.Error) {
 
 	address, err := a.keyManager.Query(name)
 	if err != nil {
-		a.Warn().
-			Str("name", name).
-			Msg("can't find account")
+		logger.Warn("can't find account", "name", name)
+		span.RecordError(err)
 		return address, sdk.Wrap(err)
 	}
 
-	if err := a.SetWithExpire(a.prefixKey(name), address.String(), a.expiration); err != nil {
-		a.Warn().
-			Str("name", name).
-			Msg("cache user failed")
+	if err := a.Set(a.nameKey(name), []byte(a.prefixes.AccAddressString(address))); err != nil {
+		logger.Warn("cache user failed", "name", name)
 	}
-	a.Debug().
-		Str("name", name).
-		Str("address", address.String()).
-		Msg("query user from cache")
+	logger.Debug("query user from cache", "name", name, "address", a.prefixes.AccAddressString(address))
 	return address, nil
 }
 
@@ -99,40 +191,66 @@ func (a accountQuery) removeCache(address string) bool {
 	return a.Remove(a.prefixKey(address))
 }
 
-func (a accountQuery) refresh(address string) (sdk.BaseAccount, sdk.Error) {
-	account, err := a.QueryAccount(address)
+func (a accountQuery) refresh(ctx context.Context, address string) (sdk.BaseAccount, sdk.Error) {
+	account, err := a.queryAccount(ctx, address)
 	if err != nil {
-		a.Err(err).
-			Str("address", address).
-			Msg("update cache failed")
+		log.ForContext(ctx, a.Logger).Error("update cache failed", "err", err, "address", address)
 		return sdk.BaseAccount{}, sdk.Wrap(err)
 	}
 
-	a.saveAccount(account)
+	a.saveAccount(ctx, account)
 	return account, nil
 }
 
-func (a accountQuery) saveAccount(account sdk.BaseAccount) {
-	address := account.Address.String()
+func (a accountQuery) saveAccount(ctx context.Context, account sdk.BaseAccount) {
+	logger := log.ForContext(ctx, a.Logger)
+	address := a.prefixes.AccAddressString(account.Address)
 	info := accountInfo{
 		N: account.AccountNumber,
 		S: account.Sequence,
 	}
-	if err := a.SetWithExpire(a.prefixKey(address), info, a.expiration); err != nil {
-		a.Warn().
-			Str("address", address).
-			Msg("cache account failed")
+	bz, err := json.Marshal(info)
+	if err == nil {
+		err = a.Set(a.prefixKey(address), bz)
+	}
+	if err != nil {
+		logger.Warn("cache account failed", "err", err, "address", address)
 		return
 	}
-	a.Debug().
-		Str("address", address).
-		Msgf("cache account %s", a.expiration.String())
+	logger.Debug("cache account", "address", address)
+}
+
+// cachedAccount returns the account number and the last sequence used by the account, if they are cached
+func (a accountQuery) cachedAccount(address string) (accountInfo, bool) {
+	var info accountInfo
+	bz, err := a.Get(a.prefixKey(address))
+	if err != nil {
+		return info, false
+	}
+	if err := json.Unmarshal(bz.([]byte), &info); err != nil {
+		_ = a.Remove(a.prefixKey(address))
+		return info, false
+	}
+	return info, true
 }
 
 func (a accountQuery) prefixKey(address string) string {
 	return fmt.Sprintf("account:%s", address)
 }
 
+// nameKey is the key of the address of a key name of the keybase
+func (a accountQuery) nameKey(name string) string {
+	return fmt.Sprintf("name:%s", name)
+}
+
+// ForgetKeys removes the cached addresses of the deleted and renamed keys,
+// a name used again by another key is then resolved to the address of the new key
+func (a accountQuery) ForgetKeys(names ...string) {
+	for _, name := range names {
+		a.Remove(a.nameKey(name))
+	}
+}
+
 type accountInfo struct {
 	N uint64 `json:"n"`
 	S uint64 `json:"s"`
@@ -11,13 +11,18 @@ import (
 
 type assetClient struct {
 	sdk.BaseClient
-	*log.Logger
+	log.Logger
+	queries *sdk.VersionedQueries
 }
 
 func Create(ac sdk.BaseClient) rpc.Asset {
+	queries := new(sdk.VersionedQueries).
+		Register(sdk.V017, querierV017{ac}).
+		Register(sdk.V100, querierV100{ac})
 	return assetClient{
 		BaseClient: ac,
 		Logger:     ac.Logger(),
+		queries:    queries,
 	}
 }
 
@@ -34,6 +39,29 @@ func (a assetClient) QueryToken(symbol string) (sdk.Token, error) {
 }
 
 func (a assetClient) QueryTokens(owner string) (sdk.Tokens, error) {
+	return a.querier().queryTokens(owner)
+}
+
+func (a assetClient) QueryFees(symbol string) (rpc.TokenFees, error) {
+	return a.querier().queryFees(symbol)
+}
+
+// querier returns the queries of the version of the chain
+func (a assetClient) querier() querier {
+	return a.queries.Route(a.ChainVersion()).(querier)
+}
+
+// querier is implemented by the queries of each version of the chain
+type querier interface {
+	queryTokens(owner string) (sdk.Tokens, error)
+	queryFees(symbol string) (rpc.TokenFees, error)
+}
+
+type querierV017 struct {
+	sdk.BaseClient
+}
+
+func (a querierV017) queryTokens(owner string) (sdk.Tokens, error) {
 	param := struct {
 		Symbol string
 		Owner  string
@@ -49,7 +77,7 @@ func (a assetClient) QueryTokens(owner string) (sdk.Tokens, error) {
 	return tokens, nil
 }
 
-func (a assetClient) QueryFees(symbol string) (rpc.TokenFees, error) {
+func (a querierV017) queryFees(symbol string) (rpc.TokenFees, error) {
 	param := struct {
 		Symbol string
 	}{
@@ -62,3 +90,15 @@ func (a assetClient) QueryFees(symbol string) (rpc.TokenFees, error) {
 	}
 	return tokens.Convert().(rpc.TokenFees), nil
 }
+
+type querierV100 struct {
+	sdk.BaseClient
+}
+
+func (a querierV100) queryTokens(owner string) (sdk.Tokens, error) {
+	return nil, sdk.UnsupportedByProtobuf("asset.QueryTokens")
+}
+
+func (a querierV100) queryFees(symbol string) (rpc.TokenFees, error) {
+	return rpc.TokenFees{}, sdk.UnsupportedByProtobuf("asset.QueryFees")
+}
@@ -0,0 +1,61 @@
+package asset
+
+import (
+	sdk "github.com/irisnet/irishub-sdk-go/types"
+)
+
+// Codespace is the codespace of the errors of the asset module, which is the token module of IRIShub 1.x
+const Codespace = "token"
+
+// the errors returned by the token module of IRIShub 1.x
+var (
+	ErrInvalidName          = sdk.RegisterError(Codespace, 2, "invalid token name")
+	ErrInvalidMinUnit       = sdk.RegisterError(Codespace, 3, "invalid token min unit")
+	ErrInvalidSymbol        = sdk.RegisterError(Codespace, 4, "invalid token symbol")
+	ErrInvalidInitSupply    = sdk.RegisterError(Codespace, 5, "invalid token initial supply")
+	ErrInvalidMaxSupply     = sdk.RegisterError(Codespace, 6, "invalid token maximum supply")
+	ErrInvalidScale         = sdk.RegisterError(Codespace, 7, "invalid token scale")
+	ErrSymbolAlreadyExists  = sdk.RegisterError(Codespace, 8, "symbol already exists")
+	ErrMinUnitAlreadyExists = sdk.RegisterError(Codespace, 9, "min unit already exists")
+	ErrTokenNotExists       = sdk.RegisterError(Codespace, 10, "token does not exist")
+	ErrInvalidAddress       = sdk.RegisterError(Codespace, 11, "the owner of the token must be specified")
+	ErrInvalidOwner         = sdk.RegisterError(Codespace, 12, "invalid token owner")
+	ErrNotMintable          = sdk.RegisterError(Codespace, 13, "token is not mintable")
+	ErrNotFoundTokenAmt     = sdk.RegisterError(Codespace, 14, "burned token amount not found")
+	ErrInvalidAmount        = sdk.RegisterError(Codespace, 15, "invalid amount")
+	ErrInvalidBaseFee       = sdk.RegisterError(Codespace, 16, "invalid base fee")
+	ErrInvalidToAddress     = sdk.RegisterError(Codespace, 17, "the new owner must not be same as the original owner")
+)
+
+// LegacyCodespace is the codespace of the errors of the asset module of IRIShub 0.x
+const LegacyCodespace = "asset"
+
+// the errors returned by the asset module of IRIShub 0.x
+var (
+	ErrLegacyInvalidMoniker                = sdk.RegisterLegacyError(LegacyCodespace, 100, "invalid moniker")
+	ErrLegacyInvalidIdentity               = sdk.RegisterLegacyError(LegacyCodespace, 101, "invalid identity")
+	ErrLegacyInvalidDetails                = sdk.RegisterLegacyError(LegacyCodespace, 102, "invalid details")
+	ErrLegacyInvalidWebsite                = sdk.RegisterLegacyError(LegacyCodespace, 103, "invalid website")
+	ErrLegacyUnknownGateway                = sdk.RegisterLegacyError(LegacyCodespace, 104, "unknown gateway")
+	ErrLegacyGatewayAlreadyExists          = sdk.RegisterLegacyError(LegacyCodespace, 105, "gateway already exists")
+	ErrLegacyInvalidOwner                  = sdk.RegisterLegacyError(LegacyCodespace, 106, "invalid owner")
+	ErrLegacyNoUpdatesProvided             = sdk.RegisterLegacyError(LegacyCodespace, 107, "no updates provided")
+	ErrLegacyInvalidAddress                = sdk.RegisterLegacyError(LegacyCodespace, 108, "invalid address")
+	ErrLegacyInvalidToAddress              = sdk.RegisterLegacyError(LegacyCodespace, 109, "invalid to address")
+	ErrLegacyNilAssetOwner                 = sdk.RegisterLegacyError(LegacyCodespace, 110, "nil asset owner")
+	ErrLegacyInvalidAssetFamily            = sdk.RegisterLegacyError(LegacyCodespace, 111, "invalid asset family")
+	ErrLegacyInvalidAssetSource            = sdk.RegisterLegacyError(LegacyCodespace, 112, "invalid asset source")
+	ErrLegacyInvalidAssetName              = sdk.RegisterLegacyError(LegacyCodespace, 113, "invalid asset name")
+	ErrLegacyInvalidAssetSymbol            = sdk.RegisterLegacyError(LegacyCodespace, 114, "invalid asset symbol")
+	ErrLegacyInvalidAssetCanonicalSymbol   = sdk.RegisterLegacyError(LegacyCodespace, 115, "invalid asset canonical symbol")
+	ErrLegacyInvalidAssetMinUnitAlias      = sdk.RegisterLegacyError(LegacyCodespace, 116, "invalid asset min unit alias")
+	ErrLegacyInvalidAssetInitSupply        = sdk.RegisterLegacyError(LegacyCodespace, 117, "invalid asset initial supply")
+	ErrLegacyInvalidAssetMaxSupply         = sdk.RegisterLegacyError(LegacyCodespace, 118, "invalid asset maximum supply")
+	ErrLegacyInvalidAssetDecimal           = sdk.RegisterLegacyError(LegacyCodespace, 119, "invalid asset decimal")
+	ErrLegacyAssetAlreadyExists            = sdk.RegisterLegacyError(LegacyCodespace, 120, "asset already exists")
+	ErrLegacyUnauthorizedIssueGatewayAsset = sdk.RegisterLegacyError(LegacyCodespace, 121, "unauthorized to issue the gateway asset")
+	ErrLegacyAssetNotExists                = sdk.RegisterLegacyError(LegacyCodespace, 122, "asset does not exist")
+	ErrLegacyAssetNotMintable              = sdk.RegisterLegacyError(LegacyCodespace, 123, "asset is not mintable")
+	ErrLegacyInsufficientCoins             = sdk.RegisterLegacyError(LegacyCodespace, 130, "insufficient coins")
+	ErrLegacySignersMissingInContext       = sdk.RegisterLegacyError(LegacyCodespace, 131, "signers missing in context")
+)
@@ -43,6 +43,12 @@ func (msg MsgIssueToken) Route() string { return ModuleName }
 // Implements Msg.
 func (msg MsgIssueToken) Type() string { return "issue_token" }
 
+// Implements RecipientMsg, the issued tokens are given to the owner.
+func (msg MsgIssueToken) Recipients() []sdk.AccAddress { return nil }
+
+// Implements NoValidatorMsg.
+func (msg MsgIssueToken) ActsOnNoValidator() {}
+
 // Implements Msg.
 func (msg MsgIssueToken) ValidateBasic() error {
 	//nothing
@@ -59,6 +65,32 @@ func (msg MsgIssueToken) GetSignBytes() []byte {
 	return json.MustSort(b)
 }
 
+// Implements SignDocMsg.
+func (msg MsgIssueToken) SignDoc(config *sdk.AddrPrefixCfg) interface{} {
+	return sdk.TypedSignDoc{
+		Type: "irishub/asset/MsgIssueToken",
+		Value: struct {
+			Symbol        string `json:"symbol"`
+			Name          string `json:"name"`
+			Decimal       uint8  `json:"decimal"`
+			MinUnitAlias  string `json:"min_unit_alias"`
+			InitialSupply uint64 `json:"initial_supply"`
+			MaxSupply     uint64 `json:"max_supply"`
+			Mintable      bool   `json:"mintable"`
+			Owner         string `json:"owner"`
+		}{
+			Symbol:        msg.Symbol,
+			Name:          msg.Name,
+			Decimal:       msg.Decimal,
+			MinUnitAlias:  msg.MinUnitAlias,
+			InitialSupply: msg.InitialSupply,
+			MaxSupply:     msg.MaxSupply,
+			Mintable:      msg.Mintable,
+			Owner:         config.AccAddressString(msg.Owner),
+		},
+	}
+}
+
 // Implements Msg.
 func (msg MsgIssueToken) GetSigners() []sdk.AccAddress {
 	return []sdk.AccAddress{msg.Owner}
@@ -81,6 +113,22 @@ func (msg MsgTransferTokenOwner) GetSignBytes() []byte {
 	return json.MustSort(b)
 }
 
+// Implements SignDocMsg.
+func (msg MsgTransferTokenOwner) SignDoc(config *sdk.AddrPrefixCfg) interface{} {
+	return sdk.TypedSignDoc{
+		Type: "irishub/asset/MsgTransferTokenOwner",
+		Value: struct {
+			SrcOwner string `json:"src_owner"`
+			DstOwner string `json:"dst_owner"`
+			Symbol   string `json:"symbol"`
+		}{
+			SrcOwner: config.AccAddressString(msg.SrcOwner),
+			DstOwner: config.AccAddressString(msg.DstOwner),
+			Symbol:   msg.Symbol,
+		},
+	}
+}
+
 // GetSigners implements Msg
 func (msg MsgTransferTokenOwner) GetSigners() []sdk.AccAddress {
 	return []sdk.AccAddress{msg.SrcOwner}
@@ -96,6 +144,15 @@ func (msg MsgTransferTokenOwner) Route() string { return ModuleName }
 // Type implements Msg
 func (msg MsgTransferTokenOwner) Type() string { return "transfer_token_owner" }
 
+// Implements NoSpendMsg.
+func (msg MsgTransferTokenOwner) SpendsNoCoins() {}
+
+// Implements RecipientMsg.
+func (msg MsgTransferTokenOwner) Recipients() []sdk.AccAddress { return []sdk.AccAddress{msg.DstOwner} }
+
+// Implements NoValidatorMsg.
+func (msg MsgTransferTokenOwner) ActsOnNoValidator() {}
+
 // MsgEditToken for editing a specified token
 type MsgEditToken struct {
 	Symbol    string         `json:"symbol"` //  symbol of token
@@ -110,6 +167,15 @@ func (msg MsgEditToken) Route() string { return ModuleName }
 // Type implements Msg
 func (msg MsgEditToken) Type() string { return "edit_token" }
 
+// Implements NoSpendMsg.
+func (msg MsgEditToken) SpendsNoCoins() {}
+
+// Implements RecipientMsg.
+func (msg MsgEditToken) Recipients() []sdk.AccAddress { return nil }
+
+// Implements NoValidatorMsg.
+func (msg MsgEditToken) ActsOnNoValidator() {}
+
 // ValidateBasic implements Msg
 func (msg MsgEditToken) ValidateBasic() error {
 	//nothing
@@ -126,6 +192,26 @@ func (msg MsgEditToken) GetSignBytes() []byte {
 	return json.MustSort(b)
 }
 
+// Implements SignDocMsg.
+func (msg MsgEditToken) SignDoc(config *sdk.AddrPrefixCfg) interface{} {
+	return sdk.TypedSignDoc{
+		Type: "irishub/asset/MsgEditToken",
+		Value: struct {
+			Symbol    string `json:"symbol"`
+			Owner     string `json:"owner"`
+			MaxSupply uint64 `json:"max_supply"`
+			Mintable  Bool   `json:"mintable"`
+			Name      string `json:"name"`
+		}{
+			Symbol:    msg.Symbol,
+			Owner:     config.AccAddressString(msg.Owner),
+			MaxSupply: msg.MaxSupply,
+			Mintable:  msg.Mintable,
+			Name:      msg.Name,
+		},
+	}
+}
+
 // GetSigners implements Msg
 func (msg MsgEditToken) GetSigners() []sdk.AccAddress {
 	return []sdk.AccAddress{msg.Owner}
@@ -144,6 +230,17 @@ func (msg MsgMintToken) Route() string { return ModuleName }
 // Type implements Msg
 func (msg MsgMintToken) Type() string { return "mint_token" }
 
+// Implements RecipientMsg, the tokens are minted to the owner when To is empty.
+func (msg MsgMintToken) Recipients() []sdk.AccAddress {
+	if len(msg.To) == 0 {
+		return nil
+	}
+	return []sdk.AccAddress{msg.To}
+}
+
+// Implements NoValidatorMsg.
+func (msg MsgMintToken) ActsOnNoValidator() {}
+
 // GetSignBytes implements Msg
 func (msg MsgMintToken) GetSignBytes() []byte {
 	b, err := cdc.MarshalJSON(msg)
@@ -153,6 +250,24 @@ func (msg MsgMintToken) GetSignBytes() []byte {
 	return json.MustSort(b)
 }
 
+// Implements SignDocMsg.
+func (msg MsgMintToken) SignDoc(config *sdk.AddrPrefixCfg) interface{} {
+	return sdk.TypedSignDoc{
+		Type: "irishub/asset/MsgMintToken",
+		Value: struct {
+			Symbol string `json:"symbol"`
+			Owner  string `json:"owner"`
+			To     string `json:"to"`
+			Amount uint64 `json:"amount"`
+		}{
+			Symbol: msg.Symbol,
+			Owner:  config.AccAddressString(msg.Owner),
+			To:     config.AccAddressString(msg.To),
+			Amount: msg.Amount,
+		},
+	}
+}
+
 // GetSigners implements Msg
 func (msg MsgMintToken) GetSigners() []sdk.AccAddress {
 	return []sdk.AccAddress{msg.Owner}
@@ -8,17 +8,23 @@ import (
 	sdk "github.com/irisnet/irishub-sdk-go/types"
 	utils "github.com/irisnet/irishub-sdk-go/utils"
 	"github.com/irisnet/irishub-sdk-go/utils/log"
+	"github.com/irisnet/irishub-sdk-go/utils/proto"
 )
 
 type bankClient struct {
 	sdk.BaseClient
-	*log.Logger
+	log.Logger
+	queries *sdk.VersionedQueries
 }
 
 func Create(ac sdk.BaseClient) rpc.Bank {
+	queries := new(sdk.VersionedQueries).
+		Register(sdk.V017, querierV017{ac}).
+		Register(sdk.V100, querierV100{ac})
 	return bankClient{
 		BaseClient: ac,
 		Logger:     ac.Logger(),
+		queries:    queries,
 	}
 }
 
@@ -39,19 +45,23 @@ func (b bankClient) QueryAccount(address string) (sdk.BaseAccount, sdk.Error) {
 	return account, nil
 }
 
+// BatchQueryAccounts returns the accounts of the addresses in their order, with the error of each address
+func (b bankClient) BatchQueryAccounts(addresses ...string) []rpc.AccountResult {
+	results := make([]rpc.AccountResult, len(addresses))
+	b.QueryBatch(len(addresses), func(i int) {
+		res := rpc.AccountResult{Address: addresses[i]}
+		res.Account, res.Err = b.QueryAccount(addresses[i])
+		if res.Err == nil {
+			res.Balance, res.Err = b.ToMainCoin(res.Account.Coins...)
+		}
+		results[i] = res
+	})
+	return results
+}
+
 // GetTokenStats return token statistic, including total loose tokens, total burned tokens and total bonded tokens.
 func (b bankClient) QueryTokenStats(tokenID string) (rpc.TokenStats, sdk.Error) {
-	param := struct {
-		TokenId string
-	}{
-		TokenId: tokenID,
-	}
-
-	var ts tokenStats
-	if err := b.QueryWithResponse("custom/acc/tokenStats", param, &ts); err != nil {
-		return rpc.TokenStats{}, sdk.Wrap(err)
-	}
-	return ts.Convert().(rpc.TokenStats), nil
+	return b.querier().queryTokenStats(tokenID)
 }
 
 //Send is responsible for transferring tokens from `From` to `to` account
@@ -70,7 +80,7 @@ func (b bankClient) Send(to string, amount sdk.DecCoins, baseTx sdk.BaseTx) (sdk
 		NewInput(sender, amt),
 	}
 
-	outAddr, err := sdk.AccAddressFromBech32(to)
+	outAddr, err := b.AddrPrefixCfg().AccAddressFromBech32(to)
 	if err != nil {
 		return sdk.ResultTx{}, sdk.Wrapf(fmt.Sprintf("%s invalid address", to))
 	}
@@ -100,7 +110,7 @@ func (b bankClient) MultiSend(receipts rpc.Receipts, baseTx sdk.BaseTx) (resTxs
 			return nil, sdk.Wrap(err)
 		}
 
-		outAddr, e := sdk.AccAddressFromBech32(receipt.Address)
+		outAddr, e := b.AddrPrefixCfg().AccAddressFromBech32(receipt.Address)
 		if e != nil {
 			return nil, sdk.Wrapf(fmt.Sprintf("%s invalid address", receipt.Address))
 		}
@@ -134,7 +144,7 @@ func (b bankClient) SendBatch(sender sdk.AccAddress,
 				return nil, sdk.Wrap(err)
 			}
 
-			outAddr, e := sdk.AccAddressFromBech32(receipt.Address)
+			outAddr, e := b.AddrPrefixCfg().AccAddressFromBech32(receipt.Address)
 			if e != nil {
 				return nil, sdk.Wrapf(fmt.Sprintf("%s invalid address", receipt.Address))
 			}
@@ -193,8 +203,8 @@ func (b bankClient) SubscribeSendTx(from, to string, callback rpc.EventMsgSendCa
 					callback(rpc.EventDataMsgSend{
 						Height: data.Height,
 						Hash:   data.Hash,
-						From:   m.Address.String(),
-						To:     value.Outputs[i].Address.String(),
+						From:   b.AddrPrefixCfg().AccAddressString(m.Address),
+						To:     b.AddrPrefixCfg().AccAddressString(value.Outputs[i].Address),
 						Amount: m.Coins,
 					})
 				}
@@ -203,3 +213,86 @@ func (b bankClient) SubscribeSendTx(from, to string, callback rpc.EventMsgSendCa
 	})
 	return subscription
 }
+
+// querier returns the queries of the version of the chain
+func (b bankClient) querier() querier {
+	return b.queries.Route(b.ChainVersion()).(querier)
+}
+
+// querier is implemented by the queries of each version of the chain
+type querier interface {
+	queryTokenStats(tokenID string) (rpc.TokenStats, sdk.Error)
+}
+
+type querierV017 struct {
+	sdk.BaseClient
+}
+
+func (b querierV017) queryTokenStats(tokenID string) (rpc.TokenStats, sdk.Error) {
+	param := struct {
+		TokenId string
+	}{
+		TokenId: tokenID,
+	}
+
+	var ts tokenStats
+	if err := b.QueryWithResponse("custom/acc/tokenStats", param, &ts); err != nil {
+		return rpc.TokenStats{}, sdk.Wrap(err)
+	}
+	return ts.Convert().(rpc.TokenStats), nil
+}
+
+type querierV100 struct {
+	sdk.BaseClient
+}
+
+func (b querierV100) queryTokenStats(tokenID string) (rpc.TokenStats, sdk.Error) {
+	token, err := b.QueryToken(tokenID)
+	if err != nil {
+		return rpc.TokenStats{}, sdk.Wrap(err)
+	}
+	denom := token.GetMinUnit()
+
+	req := new(proto.Encoder).String(1, denom).Bytes()
+	res, err := b.QueryProto("/cosmos.bank.v1beta1.Query/SupplyOf", req)
+	if err != nil {
+		return rpc.TokenStats{}, sdk.Wrap(err)
+	}
+	bz, _, err := proto.Lookup(res, 1)
+	if err != nil {
+		return rpc.TokenStats{}, sdk.Wrap(err)
+	}
+	supply, err := sdk.UnmarshalProtoCoin(bz)
+	if err != nil {
+		return rpc.TokenStats{}, sdk.Wrap(err)
+	}
+
+	stats := rpc.TokenStats{
+		LooseTokens: sdk.NewCoins(supply),
+		TotalSupply: sdk.NewCoins(supply),
+	}
+	if denom != sdk.IRISv1.MinUnit {
+		return stats, nil
+	}
+
+	// the staking token is bonded in the staking pool
+	res, err = b.QueryProto("/cosmos.staking.v1beta1.Query/Pool", nil)
+	if err != nil {
+		return rpc.TokenStats{}, sdk.Wrap(err)
+	}
+	pool, _, err := proto.Lookup(res, 1)
+	if err != nil {
+		return rpc.TokenStats{}, sdk.Wrap(err)
+	}
+	bonded, _, err := proto.Lookup(pool, 2)
+	if err != nil {
+		return rpc.TokenStats{}, sdk.Wrap(err)
+	}
+	amount, ok := sdk.NewIntFromString(string(bonded))
+	if !ok {
+		return rpc.TokenStats{}, sdk.Wrapf("invalid bonded tokens %s", bonded)
+	}
+	stats.BondedTokens = sdk.NewCoins(sdk.NewCoin(denom, amount))
+	stats.LooseTokens = sdk.NewCoins(sdk.NewCoin(denom, supply.Amount.Sub(amount)))
+	return stats, nil
+}
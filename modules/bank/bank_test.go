@@ -1,14 +1,27 @@
 package bank_test
 
 import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
+	sdk "github.com/irisnet/irishub-sdk-go"
+	"github.com/irisnet/irishub-sdk-go/crypto"
+	"github.com/irisnet/irishub-sdk-go/modules/bank"
 	"github.com/irisnet/irishub-sdk-go/rpc"
 	"github.com/irisnet/irishub-sdk-go/test"
+	"github.com/irisnet/irishub-sdk-go/test/fakechain"
 	"github.com/irisnet/irishub-sdk-go/types"
+	"github.com/irisnet/irishub-sdk-go/utils/log"
+	"github.com/irisnet/irishub-sdk-go/utils/proto"
+	"github.com/irisnet/irishub-sdk-go/utils/trace"
 	"github.com/stretchr/testify/require"
 	"github.com/stretchr/testify/suite"
 	"math/rand"
+	"strings"
 	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
 )
@@ -56,6 +69,27 @@ func (bts BankTestSuite) TestSend() {
 	require.NotEmpty(bts.T(), result.Hash)
 }
 
+func (bts BankTestSuite) TestSendUnlocked() {
+	coins, err := types.ParseDecCoins("0.1iris")
+	bts.NoError(err)
+	to := "faa1hp29kuh22vpjjlnctmyml5s75evsnsd8r4x0mm"
+	baseTx := types.BaseTx{
+		From: bts.Account().Name,
+		Gas:  20000,
+		Memo: "test",
+		Mode: types.Commit,
+	}
+
+	require.NoError(bts.T(), bts.Keys().Unlock(bts.Account().Name, bts.Account().Password, 0))
+	result, err := bts.Bank().Send(to, coins, baseTx)
+	require.NoError(bts.T(), err)
+	require.NotEmpty(bts.T(), result.Hash)
+
+	bts.Keys().Lock(bts.Account().Name)
+	_, err = bts.Bank().Send(to, coins, baseTx)
+	require.Error(bts.T(), err)
+}
+
 func (bts BankTestSuite) TestBurn() {
 	amt, err := types.NewDecimalFromStr("0.1")
 	require.NoError(bts.T(), err)
@@ -152,3 +186,278 @@ func (bts BankTestSuite) TestMultiSend() {
 	end := time.Now()
 	fmt.Println(fmt.Sprintf("total senconds:%s", end.Sub(begin).String()))
 }
+
+func (bts BankTestSuite) TestWatchOnlySend() {
+	name, password := bts.Account().Name, bts.Account().Password
+	keystore, err := bts.Keys().Export(name, password, password)
+	require.NoError(bts.T(), err)
+	km, e := crypto.NewKeyStoreKeyManager(keystore, password)
+	require.NoError(bts.T(), e)
+
+	pubKey, err := bts.Keys().ShowPubKey(name, password)
+	require.NoError(bts.T(), err)
+	watch := bts.RandStringOfLength(10)
+	address, err := bts.Keys().AddWatchOnly(watch, "", pubKey)
+	require.NoError(bts.T(), err)
+	require.Equal(bts.T(), bts.Account().Address.String(), address)
+	defer func() {
+		_ = bts.Keys().Delete(watch)
+	}()
+
+	decCoins, e := types.ParseDecCoins("0.1iris")
+	require.NoError(bts.T(), e)
+	coins, err := bts.ToMinCoin(decCoins...)
+	require.NoError(bts.T(), err)
+	to := types.MustAccAddressFromBech32("faa1hp29kuh22vpjjlnctmyml5s75evsnsd8r4x0mm")
+	msg := bank.NewMsgSend(
+		[]bank.Input{bank.NewInput(bts.Account().Address, coins)},
+		[]bank.Output{bank.NewOutput(to, coins)},
+	)
+	baseTx := types.BaseTx{
+		From: watch,
+		Gas:  20000,
+		Memo: "test",
+		Mode: types.Commit,
+	}
+
+	_, err = bts.BuildAndSend([]types.Msg{msg}, baseTx)
+	require.Error(bts.T(), err)
+
+	tx, err := bts.BuildUnsignedTx([]types.Msg{msg}, baseTx)
+	require.NoError(bts.T(), err)
+	// the sequence is not used until the signed transaction is broadcast
+	tx1, err := bts.BuildUnsignedTx([]types.Msg{msg}, baseTx)
+	require.NoError(bts.T(), err)
+	require.Equal(bts.T(), tx.Sequence, tx1.Sequence)
+	signature, e := km.Sign(tx.SignBytes)
+	require.NoError(bts.T(), e)
+
+	_, err = bts.AttachSignature(tx, km.GetPrivKey().PubKey(), []byte("invalid"))
+	require.Error(bts.T(), err)
+
+	signedTx, err := bts.AttachSignature(tx, km.GetPrivKey().PubKey(), signature)
+	require.NoError(bts.T(), err)
+	result, err := bts.Broadcast(signedTx, types.Commit)
+	require.NoError(bts.T(), err)
+	require.NotEmpty(bts.T(), result.Hash)
+
+	tx2, err := bts.BuildUnsignedTx([]types.Msg{msg}, baseTx)
+	require.NoError(bts.T(), err)
+	require.Equal(bts.T(), tx.Sequence+1, tx2.Sequence)
+}
+
+func (bts BankTestSuite) TestMainnetPrefixes() {
+	// the process default is the testnet, the client uses the prefixes of the mainnet
+	fees, e := types.ParseDecCoins("0.6iris")
+	require.NoError(bts.T(), e)
+	chain := fakechain.New(fakechain.WithChainID("mainnet"))
+	client := sdk.NewClient(types.ClientConfig{
+		TmClient: chain,
+		Network:  types.Mainnet,
+		ChainID:  "mainnet",
+		Fee:      fees,
+		KeyDAO:   types.NewMemoryDB(),
+		Mode:     types.Commit,
+	})
+
+	name, password := bts.RandStringOfLength(10), bts.RandStringOfLength(8)
+	address, err := client.Keys().Recover(name, password, test.Mnemonic)
+	require.NoError(bts.T(), err)
+	require.True(bts.T(), strings.HasPrefix(address, "iaa1"))
+	balance := types.NewCoin(types.IRIS.MinUnit, types.NewIntWithDecimal(10, int(types.IRIS.Scale)))
+	require.NoError(bts.T(), chain.Fund(address, types.NewCoins(balance)))
+
+	to := types.Mainnet.AddrPrefixCfg().AccAddressString(types.AccAddress([]byte("recipient___________")))
+	coins, e := types.ParseDecCoins("0.1iris")
+	require.NoError(bts.T(), e)
+	_, err = client.Bank().Send(to, coins, types.BaseTx{From: name, Password: password})
+	require.NoError(bts.T(), err)
+
+	account, err := client.Bank().QueryAccount(to)
+	require.NoError(bts.T(), err)
+	require.Equal(bts.T(), "100000000000000000iris-atto", account.Coins.String())
+
+	// the addresses of the testnet are rejected
+	_, err = client.Bank().QueryAccount(bts.Account().Address.String())
+	require.Error(bts.T(), err)
+}
+
+func (bts BankTestSuite) TestProtobufAccount() {
+	// IRIShub 1.x is detected from the version of the node
+	mainnet := types.Mainnet.AddrPrefixCfg()
+	addr := types.AccAddress([]byte("account_____________"))
+	chain := fakechain.New(fakechain.WithChainID("irishub-1"), fakechain.WithAppVersion("1.0.0"))
+	chain.RegisterQuerier("/cosmos.auth.v1beta1.Query/Account", func(_ fakechain.Context, data []byte) ([]byte, error) {
+		fields, err := proto.Decode(data)
+		if err != nil || string(fields[0].Value) != mainnet.AccAddressString(addr) {
+			return nil, fmt.Errorf("unexpected request %x", data)
+		}
+		account := new(proto.Encoder).String(1, string(fields[0].Value)).Uint64(3, 9).Uint64(4, 2)
+		return new(proto.Encoder).Any(1, "/cosmos.auth.v1beta1.BaseAccount", account.Bytes()).Bytes(), nil
+	})
+	chain.RegisterQuerier("/cosmos.bank.v1beta1.Query/AllBalances", func(_ fakechain.Context, data []byte) ([]byte, error) {
+		balance := types.MarshalProtoCoin(types.NewCoin("uiris", types.NewInt(100)))
+		return new(proto.Encoder).Message(1, balance).Bytes(), nil
+	})
+	chain.RegisterQuerier("/cosmos.bank.v1beta1.Query/SupplyOf", func(_ fakechain.Context, data []byte) ([]byte, error) {
+		if !bytes.Equal(data, new(proto.Encoder).String(1, "uiris").Bytes()) {
+			return nil, fmt.Errorf("unexpected request %x", data)
+		}
+		supply := types.MarshalProtoCoin(types.NewCoin("uiris", types.NewInt(1000)))
+		return new(proto.Encoder).Message(1, supply).Bytes(), nil
+	})
+	chain.RegisterQuerier("/cosmos.staking.v1beta1.Query/Pool", func(_ fakechain.Context, _ []byte) ([]byte, error) {
+		pool := new(proto.Encoder).String(1, "100").String(2, "700")
+		return new(proto.Encoder).Message(1, pool.Bytes()).Bytes(), nil
+	})
+
+	fees, e := types.ParseDecCoins("0.3iris")
+	require.NoError(bts.T(), e)
+	client := sdk.NewClient(types.ClientConfig{
+		TmClient: chain,
+		Network:  types.Mainnet,
+		ChainID:  "irishub-1",
+		Fee:      fees,
+		KeyDAO:   types.NewMemoryDB(),
+	})
+
+	account, err := client.Bank().QueryAccount(mainnet.AccAddressString(addr))
+	require.NoError(bts.T(), err)
+	require.Equal(bts.T(), uint64(9), account.AccountNumber)
+	require.Equal(bts.T(), uint64(2), account.Sequence)
+	require.Equal(bts.T(), "100uiris", account.Coins.String())
+
+	// the amounts use the 6 decimals of IRIShub 1.x
+	coins, err := client.ToMinCoin(fees...)
+	require.NoError(bts.T(), err)
+	require.Equal(bts.T(), "300000uiris", coins.String())
+
+	stats, err := client.Bank().QueryTokenStats("iris")
+	require.NoError(bts.T(), err)
+	require.Equal(bts.T(), "1000uiris", stats.TotalSupply.String())
+	require.Equal(bts.T(), "700uiris", stats.BondedTokens.String())
+	require.Equal(bts.T(), "300uiris", stats.LooseTokens.String())
+
+	// the queries of the irismod modules and of the transactions are refused
+	_, err = client.Bank().QueryTokenStats("btc")
+	require.True(bts.T(), errors.Is(err, types.ErrProtobufUnsupported))
+	_, err = client.Service().QueryDefinition("oracle")
+	require.True(bts.T(), errors.Is(err, types.ErrProtobufUnsupported))
+	_, err = client.Tendermint().QueryTx("00")
+	require.True(bts.T(), errors.Is(err, types.ErrProtobufUnsupported))
+	require.Contains(bts.T(), err.Error(), "QueryTx")
+}
+
+func (bts BankTestSuite) TestSendInsufficientFunds() {
+	coins, err := types.ParseDecCoins("100000000000iris")
+	bts.NoError(err)
+	to := "faa1hp29kuh22vpjjlnctmyml5s75evsnsd8r4x0mm"
+	baseTx := types.BaseTx{
+		From:     bts.Account().Name,
+		Gas:      20000,
+		Mode:     types.Commit,
+		Password: bts.Account().Password,
+	}
+
+	_, err = bts.Bank().Send(to, coins, baseTx)
+	require.Error(bts.T(), err)
+	require.True(bts.T(), errors.Is(err, types.ErrInsufficientFunds), err.Error())
+	require.False(bts.T(), errors.Is(err, types.ErrUnauthorized))
+}
+
+func (bts BankTestSuite) TestLogger() {
+	fees, e := types.ParseDecCoins("0.6iris")
+	require.NoError(bts.T(), e)
+	var buf bytes.Buffer
+	chain := fakechain.New(fakechain.WithChainID("test"))
+	client, err := sdk.NewClientWithError(types.ClientConfig{
+		TmClient: chain,
+		ChainID:  "test",
+		Fee:      fees,
+		KeyDAO:   types.NewMemoryDB(),
+		Logger:   log.New(&buf, "debug", log.JSON),
+	})
+	require.NoError(bts.T(), err)
+
+	address, err := client.Keys().Recover("logger", "1234567890", test.Mnemonic)
+	require.NoError(bts.T(), err)
+	require.NoError(bts.T(), chain.Fund(address, types.NewCoins(types.NewCoin("iris-atto", types.NewIntWithDecimal(1, 18)))))
+	_, err = client.Bank().QueryAccount(address)
+	require.NoError(bts.T(), err)
+	amount, e := types.ParseDecCoins("0.1iris")
+	require.NoError(bts.T(), e)
+	_, err = client.Bank().Send(address, amount, types.BaseTx{
+		From:     "logger",
+		Password: "1234567890",
+		Ctx:      trace.ContextWithTraceID(context.Background(), "send-1"),
+	})
+	require.NoError(bts.T(), err)
+
+	var found, broadcast bool
+	for _, line := range strings.Split(strings.TrimSpace(buf.String()), "\n") {
+		var fields map[string]interface{}
+		require.NoError(bts.T(), json.Unmarshal([]byte(line), &fields), line)
+		switch fields["message"] {
+		case "query account from chain":
+			require.Equal(bts.T(), address, fields["address"])
+			found = true
+		case "broadcast tx":
+			require.Equal(bts.T(), "send-1", fields["trace_id"])
+			broadcast = true
+		}
+	}
+	require.True(bts.T(), found, buf.String())
+	require.True(bts.T(), broadcast, buf.String())
+	require.NotContains(bts.T(), buf.String(), "1234567890")
+}
+
+func (bts BankTestSuite) TestBatchQueryAccounts() {
+	fees, e := types.ParseDecCoins("0.6iris")
+	require.NoError(bts.T(), e)
+	chain := fakechain.New(fakechain.WithChainID("test"))
+	client := sdk.NewClient(types.ClientConfig{
+		TmClient:         chain,
+		ChainID:          "test",
+		Fee:              fees,
+		KeyDAO:           types.NewMemoryDB(),
+		BatchConcurrency: 4,
+	})
+
+	// the token of the balances is queried once by the concurrent conversions
+	var tokenQueries int32
+	chain.AddTokens(types.Token{Symbol: "btc", Name: "Bitcoin", Scale: 8, MinUnit: "btc-min"})
+	chain.RegisterQuerier("custom/asset/token", func(ctx fakechain.Context, data []byte) ([]byte, error) {
+		atomic.AddInt32(&tokenQueries, 1)
+		time.Sleep(20 * time.Millisecond)
+		token, _ := ctx.Token("btc")
+		return ctx.Codec.MarshalJSON(token)
+	})
+
+	var addresses []string
+	for i := 0; i < 9; i++ {
+		address, err := client.Keys().RecoverWithOptions(fmt.Sprintf("batch%d", i), "1234567890", test.Mnemonic,
+			types.HDOptions{Index: uint32(i)})
+		require.NoError(bts.T(), err)
+		if i != 7 {
+			coins := types.NewCoins(types.NewCoin("btc-min", types.NewInt(int64(i+1)*100000000)))
+			require.NoError(bts.T(), chain.Fund(address, coins))
+		}
+		addresses = append(addresses, address)
+	}
+	addresses = append(addresses, "iaa1invalid")
+
+	results := client.Bank().BatchQueryAccounts(addresses...)
+	require.Len(bts.T(), results, len(addresses))
+	for i, res := range results {
+		require.Equal(bts.T(), addresses[i], res.Address)
+		if i == 7 || i == 9 {
+			require.Error(bts.T(), res.Err, i)
+			continue
+		}
+		require.NoError(bts.T(), res.Err)
+		require.Equal(bts.T(), addresses[i], client.AddrPrefixCfg().AccAddressString(res.Account.Address))
+		require.Equal(bts.T(), fmt.Sprintf("%dbtc", i+1), res.Balance.String())
+	}
+	require.Equal(bts.T(), int32(1), atomic.LoadInt32(&tokenQueries))
+	require.Empty(bts.T(), client.Bank().BatchQueryAccounts())
+}
@@ -0,0 +1,26 @@
+package bank
+
+import (
+	sdk "github.com/irisnet/irishub-sdk-go/types"
+)
+
+// Codespace is the codespace of the errors of the bank module
+const Codespace = ModuleName
+
+// the errors returned by the bank module of IRIShub 1.x
+var (
+	ErrNoInputs            = sdk.RegisterError(Codespace, 2, "no inputs to send transaction")
+	ErrNoOutputs           = sdk.RegisterError(Codespace, 3, "no outputs to send transaction")
+	ErrInputOutputMismatch = sdk.RegisterError(Codespace, 4, "sum inputs != sum outputs")
+	ErrSendDisabled        = sdk.RegisterError(Codespace, 5, "send transactions are disabled")
+)
+
+// the errors returned by the bank module of IRIShub 0.x
+var (
+	ErrLegacyInvalidInput      = sdk.RegisterLegacyError(Codespace, 101, "invalid input coins")
+	ErrLegacyInvalidOutput     = sdk.RegisterLegacyError(Codespace, 102, "invalid output coins")
+	ErrLegacyBurnEmptyCoins    = sdk.RegisterLegacyError(Codespace, 103, "burn empty coins")
+	ErrLegacyInvalidMemo       = sdk.RegisterLegacyError(Codespace, 104, "invalid memo")
+	ErrLegacyInvalidMemoRegexp = sdk.RegisterLegacyError(Codespace, 105, "invalid memo regexp")
+	ErrLegacyInvalidAccount    = sdk.RegisterLegacyError(Codespace, 106, "invalid account")
+)
@@ -1,7 +1,6 @@
 package bank
 
 import (
-	"encoding/json"
 	"errors"
 	"fmt"
 	"regexp"
@@ -10,6 +9,7 @@ import (
 
 	"github.com/irisnet/irishub-sdk-go/types"
 	json2 "github.com/irisnet/irishub-sdk-go/utils/json"
+	"github.com/irisnet/irishub-sdk-go/utils/proto"
 )
 
 const (
@@ -23,6 +23,8 @@ var (
 	_ types.Msg = MsgBurn{}
 	_ types.Msg = MsgSetMemoRegexp{}
 
+	_ types.ProtoMsg = MsgSend{}
+
 	cdc = types.NewAminoCodec()
 )
 
@@ -78,24 +80,35 @@ func (msg MsgSend) ValidateBasic() error {
 
 // Implements Msg.
 func (msg MsgSend) GetSignBytes() []byte {
-	var inputs, outputs []json.RawMessage
-	for _, input := range msg.Inputs {
-		inputs = append(inputs, input.GetSignBytes())
+	b, err := cdc.MarshalJSON(msg.SignDoc(types.GetAddrPrefixCfg()))
+	if err != nil {
+		panic(err)
 	}
-	for _, output := range msg.Outputs {
-		outputs = append(outputs, output.GetSignBytes())
+	return json2.MustSort(b)
+}
+
+// Implements SignDocMsg, the inputs and outputs are signed without the type of the msg.
+func (msg MsgSend) SignDoc(config *types.AddrPrefixCfg) interface{} {
+	var inputs, outputs []balanceSignDoc
+	for _, in := range msg.Inputs {
+		inputs = append(inputs, balanceSignDoc{config.AccAddressString(in.Address), in.Coins})
 	}
-	b, err := cdc.MarshalJSON(struct {
-		Inputs  []json.RawMessage `json:"inputs"`
-		Outputs []json.RawMessage `json:"outputs"`
+	for _, out := range msg.Outputs {
+		outputs = append(outputs, balanceSignDoc{config.AccAddressString(out.Address), out.Coins})
+	}
+	return struct {
+		Inputs  []balanceSignDoc `json:"inputs"`
+		Outputs []balanceSignDoc `json:"outputs"`
 	}{
 		Inputs:  inputs,
 		Outputs: outputs,
-	})
-	if err != nil {
-		panic(err)
 	}
-	return json2.MustSort(b)
+}
+
+// balanceSignDoc is an Input or an Output as signed
+type balanceSignDoc struct {
+	Address string      `json:"address"`
+	Coins   types.Coins `json:"coins"`
 }
 
 // Implements Msg.
@@ -107,6 +120,65 @@ func (msg MsgSend) GetSigners() []types.AccAddress {
 	return addrs
 }
 
+// Implements SpendMsg.
+func (msg MsgSend) SpentCoins() types.Coins {
+	var coins types.Coins
+	for _, in := range msg.Inputs {
+		coins = coins.Add(in.Coins...)
+	}
+	return coins
+}
+
+// Implements SpendMsg.
+func (msg MsgSend) Recipients() []types.AccAddress {
+	addrs := make([]types.AccAddress, len(msg.Outputs))
+	for i, out := range msg.Outputs {
+		addrs[i] = out.Address
+	}
+	return addrs
+}
+
+// Implements NoValidatorMsg.
+func (msg MsgSend) ActsOnNoValidator() {}
+
+// Implements ProtoMsg, a single input and output is a MsgSend of the Cosmos SDK, the others a MsgMultiSend.
+func (msg MsgSend) ProtoTypeURL() string {
+	if len(msg.Inputs) == 1 && len(msg.Outputs) == 1 {
+		return "/cosmos.bank.v1beta1.MsgSend"
+	}
+	return "/cosmos.bank.v1beta1.MsgMultiSend"
+}
+
+// Implements ProtoMsg.
+func (msg MsgSend) MarshalProto(prefixes *types.AddrPrefixCfg) ([]byte, error) {
+	e := new(proto.Encoder)
+	if len(msg.Inputs) == 1 && len(msg.Outputs) == 1 {
+		e.String(1, prefixes.AccAddressString(msg.Inputs[0].Address)).
+			String(2, prefixes.AccAddressString(msg.Outputs[0].Address))
+		for _, coin := range msg.Inputs[0].Coins {
+			e.Message(3, types.MarshalProtoCoin(coin))
+		}
+		return e.Bytes(), nil
+	}
+
+	for _, in := range msg.Inputs {
+		e.Message(1, marshalProtoBalance(prefixes.AccAddressString(in.Address), in.Coins))
+	}
+	for _, out := range msg.Outputs {
+		e.Message(2, marshalProtoBalance(prefixes.AccAddressString(out.Address), out.Coins))
+	}
+	return e.Bytes(), nil
+}
+
+// marshalProtoBalance encodes an Input or an Output of the Cosmos SDK
+func marshalProtoBalance(address string, coins types.Coins) []byte {
+	e := new(proto.Encoder).String(1, address)
+	for _, coin := range coins {
+		e.Message(2, types.MarshalProtoCoin(coin))
+	}
+	return e.Bytes()
+}
+
 //----------------------------------------
 // Input
 
@@ -228,11 +300,34 @@ func (msg MsgBurn) GetSignBytes() []byte {
 	return json2.MustSort(b)
 }
 
+// Implements SignDocMsg.
+func (msg MsgBurn) SignDoc(config *types.AddrPrefixCfg) interface{} {
+	return types.TypedSignDoc{
+		Type: "irishub/bank/Burn",
+		Value: struct {
+			Owner string      `json:"owner"`
+			Coins types.Coins `json:"coins"`
+		}{
+			Owner: config.AccAddressString(msg.Owner),
+			Coins: msg.Coins,
+		},
+	}
+}
+
 // Implements Msg.
 func (msg MsgBurn) GetSigners() []types.AccAddress {
 	return []types.AccAddress{msg.Owner}
 }
 
+// Implements SpendMsg.
+func (msg MsgBurn) SpentCoins() types.Coins { return msg.Coins }
+
+// Implements SpendMsg.
+func (msg MsgBurn) Recipients() []types.AccAddress { return nil }
+
+// Implements NoValidatorMsg.
+func (msg MsgBurn) ActsOnNoValidator() {}
+
 // MsgSetMemoRegexp - set memo regexp
 type MsgSetMemoRegexp struct {
 	Owner      types.AccAddress `json:"owner"`
@@ -250,6 +345,15 @@ func (msg MsgSetMemoRegexp) Route() string { return ModuleName }
 // nolint
 func (msg MsgSetMemoRegexp) Type() string { return "set-memo-regexp" }
 
+// Implements NoSpendMsg.
+func (msg MsgSetMemoRegexp) SpendsNoCoins() {}
+
+// Implements RecipientMsg.
+func (msg MsgSetMemoRegexp) Recipients() []types.AccAddress { return nil }
+
+// Implements NoValidatorMsg.
+func (msg MsgSetMemoRegexp) ActsOnNoValidator() {}
+
 // Implements Msg.
 func (msg MsgSetMemoRegexp) ValidateBasic() error {
 	if len(msg.Owner) == 0 {
@@ -273,6 +377,20 @@ func (msg MsgSetMemoRegexp) GetSignBytes() []byte {
 	return json2.MustSort(b)
 }
 
+// Implements SignDocMsg.
+func (msg MsgSetMemoRegexp) SignDoc(config *types.AddrPrefixCfg) interface{} {
+	return types.TypedSignDoc{
+		Type: "irishub/bank/SetMemoRegexp",
+		Value: struct {
+			Owner      string `json:"owner"`
+			MemoRegexp string `json:"memo_regexp"`
+		}{
+			Owner:      config.AccAddressString(msg.Owner),
+			MemoRegexp: msg.MemoRegexp,
+		},
+	}
+}
+
 // Implements Msg.
 func (msg MsgSetMemoRegexp) GetSigners() []types.AccAddress {
 	return []types.AccAddress{msg.Owner}
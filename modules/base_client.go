@@ -1,11 +1,10 @@
 // Package modules is to warpped the API provided by each module of irishub
-//
-//
 package modules
 
 import (
-	"errors"
+	"context"
 	"fmt"
+	"os"
 	"time"
 
 	"github.com/irisnet/irishub-sdk-go/adapter"
@@ -13,19 +12,18 @@ import (
 	"github.com/irisnet/irishub-sdk-go/modules/service"
 	sdk "github.com/irisnet/irishub-sdk-go/types"
 	"github.com/irisnet/irishub-sdk-go/utils"
-	"github.com/irisnet/irishub-sdk-go/utils/cache"
 	"github.com/irisnet/irishub-sdk-go/utils/log"
+	"github.com/irisnet/irishub-sdk-go/utils/trace"
 	cmn "github.com/tendermint/tendermint/libs/common"
 	rpcclient "github.com/tendermint/tendermint/rpc/client"
 )
 
 const (
-	concurrency       = 16
-	cacheCapacity     = 100
-	cacheExpirePeriod = 1 * time.Minute
-	timeout           = 5 * time.Second
-	tryThreshold      = 3
-	maxMsgsCnt        = 10
+	concurrency      = 16
+	batchConcurrency = 8
+	timeout          = 5 * time.Second
+	tryThreshold     = 3
+	maxMsgsCnt       = 10
 )
 
 type baseClient struct {
@@ -35,65 +33,160 @@ type baseClient struct {
 	tokenQuery
 	paramsQuery
 
-	logger *log.Logger
-	cfg    *sdk.ClientConfig
-	cdc    sdk.Codec
+	logger  log.Logger
+	tracer  trace.Tracer
+	cfg     *sdk.ClientConfig
+	cdc     sdk.Codec
+	encoder sdk.TxEncoder
+	version sdk.Version
+	caches  caches
+	// subscriptions are those of watchCaches
+	subscriptions []sdk.Subscription
+	// ownsTmClient is true if the RPC client was created for the client, it is stopped by Stop
+	ownsTmClient bool
 
 	l *locker
 }
 
-//NewBaseClient return the baseClient for every sub modules
+// NewBaseClient return the baseClient for every sub modules, it panics if the config is invalid
 func NewBaseClient(cdc sdk.Codec, cfg sdk.ClientConfig) *baseClient {
-	initConfig(cdc, &cfg)
+	base, err := NewBaseClientWithError(cdc, cfg)
+	if err != nil {
+		panic(err)
+	}
+	return base
+}
+
+// NewBaseClientWithError returns the baseClient for every sub modules, or the problems of the config
+// (a types.ConfigError) and the errors met while connecting to the node
+func NewBaseClientWithError(cdc sdk.Codec, cfg sdk.ClientConfig) (_ *baseClient, err error) {
+	if err := initConfig(cdc, &cfg); err != nil {
+		return nil, err
+	}
+	// the codec carries the prefixes of the client, with which the transactions are signed
+	cdc = sdk.NewPrefixCodec(cdc, cfg.AddrPrefixCfg)
 
 	//create logger
-	logger := log.NewLogger(cfg.Level)
+	logger := cfg.Logger
+	if logger == nil {
+		logger = log.New(os.Stdout, cfg.Level, cfg.LogFormat)
+	}
+	logger = log.Redact(logger)
+
+	tmClient := cfg.TmClient
+	if tmClient == nil {
+		tmClient = NewRPCClient(cfg.NodeURI, cdc, logger)
+		// the RPC client created for the client is stopped if the client is not returned,
+		// the TmClient of the config belongs to the caller
+		defer func() {
+			if stopper, ok := tmClient.(interface{ Stop() error }); ok && err != nil {
+				_ = stopper.Stop()
+			}
+		}()
+	}
+	if setter, ok := tmClient.(sdk.CodecSetter); ok {
+		setter.SetCodec(cdc)
+	}
+
+	keyManager := cfg.KeyManager
+	if keyManager == nil {
+		keyManager = adapter.NewDAOAdapterWithPrefixes(cfg.KeyDAO, cfg.StoreType, cfg.AddrPrefixCfg)
+	}
+
+	version := detectVersion(tmClient, logger)
+	if len(cfg.TxEncoding) == 0 {
+		cfg.TxEncoding = version.TxEncoding()
+	}
+	if version == (sdk.Version{}) && cfg.TxEncoding == sdk.Protobuf {
+		// a node of unknown version whose client is configured with Protobuf runs IRIShub 1.x
+		version = sdk.V100
+	}
+	encoder, err := sdk.NewTxEncoder(cfg.TxEncoding, cdc)
+	if err != nil {
+		return nil, err
+	}
 
 	base := baseClient{
-		KeyManager: adapter.NewDAOAdapter(cfg.KeyDAO, cfg.StoreType),
-		TmClient:   NewRPCClient(cfg.NodeURI, cdc, logger),
+		KeyManager: keyManager,
+		TmClient:   tmClient,
 		logger:     logger,
+		tracer:     cfg.Tracer,
 		cfg:        &cfg,
 		cdc:        cdc,
+		encoder:    encoder,
+		version:    version,
 		l:          NewLocker(concurrency),
+
+		ownsTmClient: cfg.TmClient == nil,
 	}
 
-	c := cache.NewLRU(cacheCapacity)
+	base.caches = newCaches(cfg.ChainID, cfg.Cache)
 	base.accountQuery = accountQuery{
 		Queries:    base,
 		Logger:     base.Logger(),
-		Cache:      c,
+		Cache:      base.caches[accountsCache],
 		keyManager: base.KeyManager,
-		expiration: cacheExpirePeriod,
+		prefixes:   cfg.AddrPrefixCfg,
+		encoding:   cfg.TxEncoding,
+		abci:       tmClient,
+		tracer:     base.tracer,
 	}
 
-	base.tokenQuery = tokenQuery{
-		q:      base,
-		Logger: base.Logger(),
-		Cache:  c,
-	}
+	base.tokenQuery = newTokenQuery(base, base.Logger(), base.caches[tokensCache], cfg.TxEncoding, version)
 
-	base.paramsQuery = paramsQuery{
-		Queries:    base,
-		Logger:     base.Logger(),
-		Cache:      c,
-		cdc:        cdc,
-		expiration: cacheExpirePeriod,
-	}
+	base.paramsQuery = newParamsQuery(base, base.Logger(), base.caches[paramsCache], cdc, version)
 
 	fees, err := base.ToMinCoin(base.cfg.Fee...)
 	if err != nil {
-		panic(err)
+		return nil, sdk.WrapWithMessage(err, "invalid fee %s", base.cfg.Fee)
 	}
 	cfg.Fee = sdk.NewDecCoinsFromCoins(fees...)
 
-	return &base
+	if cfg.Cache.Invalidate {
+		if cfg.TxEncoding == sdk.Protobuf {
+			// the txs and the events of IRIShub 1.x are not decoded by the subscriptions
+			logger.Warn("the caches are not invalidated by the events of IRIShub 1.x, they expire with their TTL")
+		} else if err := base.watchCaches(); err != nil {
+			return nil, sdk.WrapWithMessage(err, "failed to subscribe to the events invalidating the caches")
+		}
+	}
+
+	return &base, nil
+}
+
+// Stop removes the subscriptions of the client and stops the RPC client created for it, the TmClient of the config
+// belongs to the caller and is not stopped
+func (base *baseClient) Stop() error {
+	for _, sub := range base.subscriptions {
+		_ = base.Unsubscribe(sub)
+	}
+	base.subscriptions = nil
+	if stopper, ok := base.TmClient.(interface{ Stop() error }); ok && base.ownsTmClient {
+		return stopper.Stop()
+	}
+	return nil
 }
 
-func (base *baseClient) Logger() *log.Logger {
+func (base *baseClient) Logger() log.Logger {
 	return base.logger
 }
 
+func (base *baseClient) AddrPrefixCfg() *sdk.AddrPrefixCfg {
+	return base.cfg.AddrPrefixCfg
+}
+
+func (base *baseClient) ChainVersion() sdk.Version {
+	return base.version
+}
+
+func (base *baseClient) TxEncoding() sdk.TxEncoding {
+	return base.encoder.Encoding()
+}
+
+func (base *baseClient) QueryBatch(n int, query func(i int)) {
+	utils.Parallel(n, base.cfg.BatchConcurrency, query)
+}
+
 func (base *baseClient) BuildAndSend(msg []sdk.Msg, baseTx sdk.BaseTx) (sdk.ResultTx, sdk.Error) {
 	res, err := base.SendMsgBatch(msg, baseTx)
 	if err != nil || len(res) == 0 {
@@ -108,17 +201,25 @@ func (base *baseClient) SendMsgBatch(msgs sdk.Msgs, baseTx sdk.BaseTx) (rs []sdk
 		return rs, sdk.Wrapf("must have at least one message in list")
 	}
 
+	ctx, span := base.tracer.Start(baseTx.Ctx, "SendMsgBatch",
+		trace.String("from", baseTx.From),
+		trace.Int64("msgs", int64(len(msgs))),
+	)
+	defer span.End()
+	ctx = trace.EnsureTraceID(ctx)
+	logger := log.ForContext(ctx, base.Logger())
+
 	defer sdk.CatchPanic(func(errMsg string) {
-		base.Logger().Error().
-			Msgf("broadcast msg failed:%s", errMsg)
+		logger.Error(fmt.Sprintf("broadcast msg failed:%s", errMsg))
 	})
 	//validate msg
 	for _, m := range msgs {
 		if err := m.ValidateBasic(); err != nil {
+			span.RecordError(err)
 			return rs, sdk.Wrap(err)
 		}
 	}
-	base.Logger().Debug().Msg("validate msg success")
+	logger.Debug("validate msg success")
 
 	//lock the account
 	base.l.Lock(baseTx.From)
@@ -132,60 +233,73 @@ resize:
 		mss := ms.(sdk.Msgs)
 
 	retry:
-		txByte, ctx, err := base.buildTx(mss, baseTx)
+		txByte, txCtx, err := base.buildTx(ctx, mss, baseTx)
 		if err != nil {
+			span.RecordError(err)
 			return rs, err
 		}
 
 		if err := base.ValidateTxSize(len(txByte), mss); err != nil {
-			base.Logger().Warn().
-				Int("msgsLength", batch).
-				Msg(err.Error())
+			logger.Warn(err.Error(), "msgsLength", batch)
 
 			// filter out transactions that have been sent
 			msgs = msgs[i*batch:]
 			// reset the maximum number of msg in each transaction
 			batch = batch / 2
-			_ = base.removeCache(ctx.Address())
+			_ = base.removeCache(txCtx.Address())
+			txCtx.ReleasePolicy()
 			goto resize
 		}
 
-		res, err := base.broadcastTx(txByte, ctx.Mode())
+		res, err := base.broadcastTx(ctx, txByte, txCtx.Mode())
 		if err != nil {
+			// the transaction may still be executed after a timeout, its reservation is only released
+			// when the chain refused it
+			if sdk.IsChainError(err) {
+				txCtx.ReleasePolicy()
+			}
 			if sdk.Code(err.Code()) == sdk.InvalidSequence {
-				base.Logger().Warn().
-					Str("address", ctx.Address()).
-					Int("tryCnt", tryCnt).
-					Msg("cached account information outdated, retrying ...")
+				logger.Warn("cached account information outdated, retrying ...",
+					"address", txCtx.Address(),
+					"tryCnt", tryCnt)
 
-				_ = base.removeCache(ctx.Address())
+				_ = base.removeCache(txCtx.Address())
 				if tryCnt++; tryCnt >= tryThreshold {
+					span.RecordError(err)
 					return rs, err
 				}
 				goto retry
 			}
 
-			base.Logger().
-				Err(err).
-				Msg("broadcast transaction failed")
+			logger.Error("broadcast transaction failed", "err", err)
+			span.RecordError(err)
 			return rs, err
 		}
-		base.Logger().Info().
-			Str("txHash", res.Hash).
-			Int64("height", res.Height).
-			Msg("broadcast transaction success")
+		logger.Info("broadcast transaction success", "txHash", res.Hash, "height", res.Height)
 		rs = append(rs, res)
 	}
 	return rs, nil
 }
 
+// Broadcast sends a transaction signed outside of the SDK, e.g. by AttachSignature. Once it is sent, its sequences
+// are cached as the last ones used by the signers.
 func (base baseClient) Broadcast(signedTx sdk.StdTx, mode sdk.BroadcastMode) (sdk.ResultTx, sdk.Error) {
-	txByte, err := base.cdc.MarshalBinaryLengthPrefixed(signedTx)
+	txByte, err := base.encoder.EncodeTx(signedTx)
 	if err != nil {
 		return sdk.ResultTx{}, sdk.Wrap(err)
 	}
 
-	return base.broadcastTx(txByte, mode)
+	ctx := context.Background()
+	res, e := base.broadcastTx(ctx, txByte, mode)
+	if e != nil {
+		return res, e
+	}
+	for _, sig := range signedTx.Signatures {
+		if sig.PubKey != nil {
+			base.useSequence(ctx, sdk.AccAddress(sig.PubKey.Address()), sig.AccountNumber, sig.Sequence)
+		}
+	}
+	return res, nil
 }
 
 func (base baseClient) QueryWithResponse(path string, data interface{}, result sdk.Response) error {
@@ -222,12 +336,29 @@ func (base baseClient) Query(path string, data interface{}) ([]byte, error) {
 
 	resp := result.Response
 	if !resp.IsOK() {
-		return nil, errors.New(resp.Log)
+		return nil, sdk.GetErrorOf(base.version, resp.Codespace, resp.Code, resp.Log)
 	}
 
 	return resp.Value, nil
 }
 
+// queryABCI sends the request to the path without encoding it, e.g. a protobuf query of the Cosmos SDK 0.40+
+func queryABCI(client rpcclient.ABCIClient, path string, req []byte) ([]byte, error) {
+	result, err := client.ABCIQueryWithOptions(path, req, rpcclient.ABCIQueryOptions{})
+	if err != nil {
+		return nil, err
+	}
+	if resp := result.Response; !resp.IsOK() {
+		// only the nodes of IRIShub 1.x answer these queries
+		return nil, sdk.GetErrorOf(sdk.V100, resp.Codespace, resp.Code, resp.Log)
+	}
+	return result.Response.Value, nil
+}
+
+func (base baseClient) QueryProto(path string, req []byte) ([]byte, error) {
+	return queryABCI(base.TmClient, path, req)
+}
+
 func (base baseClient) QueryStore(key cmn.HexBytes, storeName string) (res []byte, err error) {
 	path := fmt.Sprintf("/store/%s/%s", storeName, "key")
 	opts := rpcclient.ABCIQueryOptions{
@@ -242,64 +373,85 @@ func (base baseClient) QueryStore(key cmn.HexBytes, storeName string) (res []byt
 
 	resp := result.Response
 	if !resp.IsOK() {
-		return res, errors.New(resp.Log)
+		return res, sdk.GetErrorOf(base.version, resp.Codespace, resp.Code, resp.Log)
 	}
 	return resp.Value, nil
 }
 
-func (base *baseClient) prepare(baseTx sdk.BaseTx) (*sdk.TxContext, error) {
+// prepare returns the context of a transaction of baseTx.From. With reserve, the cached sequence of the account is
+// increased for the transaction, which the SDK signs and sends.
+func (base *baseClient) prepare(ctx context.Context, baseTx sdk.BaseTx, reserve bool) (*sdk.TxContext, error) {
+	ctx, span := base.tracer.Start(ctx, "prepare", trace.String("from", baseTx.From))
+	defer span.End()
+
 	fees, _ := base.cfg.Fee.TruncateDecimal()
-	ctx := &sdk.TxContext{}
-	ctx.WithCodec(base.cdc).
+	txCtx := &sdk.TxContext{}
+	txCtx.WithCodec(base.cdc).
+		WithTxEncoder(base.encoder).
 		WithChainID(base.cfg.ChainID).
 		WithKeyManager(base.KeyManager).
 		WithNetwork(base.cfg.Network).
 		WithFee(fees).
 		WithMode(base.cfg.Mode).
 		WithSimulate(false).
-		WithGas(base.cfg.Gas)
+		WithGas(base.cfg.Gas).
+		WithSigningPolicy(base.cfg.SigningPolicy).
+		WithSignatureLog(base.cfg.SignatureLog)
 
-	addr, err := base.QueryAddress(baseTx.From)
+	addr, err := base.queryAddress(ctx, baseTx.From)
 	if err != nil {
+		span.RecordError(err)
 		return nil, err
 	}
-	ctx.WithAddress(addr.String())
+	address := base.AddrPrefixCfg().AccAddressString(addr)
+	txCtx.WithAddress(address)
 
-	account, err := base.QueryAndRefreshAccount(addr.String())
+	queryAccount := base.nextAccount
+	if reserve {
+		queryAccount = base.queryAndRefreshAccount
+	}
+	account, err := queryAccount(ctx, address)
 	if err != nil {
+		span.RecordError(err)
 		return nil, err
 	}
-	ctx.WithAccountNumber(account.AccountNumber).
+	txCtx.WithAccountNumber(account.AccountNumber).
 		WithSequence(account.Sequence).
 		WithPassword(baseTx.Password)
 
 	if !baseTx.Fee.Empty() && baseTx.Fee.IsValid() {
 		fees, err := base.ToMinCoin(baseTx.Fee...)
 		if err != nil {
+			span.RecordError(err)
 			return nil, err
 		}
-		ctx.WithFee(fees)
+		txCtx.WithFee(fees)
 	}
 
 	if len(baseTx.Mode) > 0 {
-		ctx.WithMode(baseTx.Mode)
+		txCtx.WithMode(baseTx.Mode)
 	}
 
 	if baseTx.Simulate {
-		ctx.WithSimulate(baseTx.Simulate)
+		txCtx.WithSimulate(baseTx.Simulate)
 	}
 
 	if baseTx.Gas > 0 {
-		ctx.WithGas(baseTx.Gas)
+		txCtx.WithGas(baseTx.Gas)
 	}
 
 	if len(baseTx.Memo) > 0 {
-		ctx.WithMemo(baseTx.Memo)
+		txCtx.WithMemo(baseTx.Memo)
 	}
-	return ctx, nil
+	return txCtx, nil
 }
 
 func (base *baseClient) ValidateTxSize(txSize int, msgs []sdk.Msg) sdk.Error {
+	// the limits are params of IRIShub 0.x, the nodes of 1.x check the size of the transactions themselves
+	if base.encoder.Encoding() == sdk.Protobuf {
+		return nil
+	}
+
 	var isServiceTx bool
 	for _, msg := range msgs {
 		if msg.Route() == service.ModuleName {
@@ -335,35 +487,40 @@ func (base *baseClient) ValidateTxSize(txSize int, msgs []sdk.Msg) sdk.Error {
 	return nil
 }
 
-func initConfig(cdc sdk.Codec, cfg *sdk.ClientConfig) {
-	if len(cfg.NodeURI) == 0 {
-		panic(fmt.Errorf("nodeURI is required"))
+// initConfig validates the config, reporting every problem at once, discovers the chain if asked, then applies the defaults
+func initConfig(cdc sdk.Codec, cfg *sdk.ClientConfig) error {
+	if err := cfg.Validate(); err != nil {
+		return err
+	}
+
+	if cfg.DiscoverChain {
+		if err := discoverChain(cfg); err != nil {
+			return err
+		}
 	}
 
+	// discoverChain has set the network or failed, the network of a known chain-id is taken before the default one
+	if len(cfg.Network) == 0 && cfg.AddrPrefixCfg == nil {
+		if network, ok := sdk.NetworkOfChainID(cfg.ChainID); ok {
+			cfg.Network = network
+		}
+	}
 	if len(cfg.Network) == 0 {
 		cfg.Network = sdk.Mainnet
 	}
 
-	if len(cfg.ChainID) == 0 {
-		panic(fmt.Errorf("chainID is required"))
+	if cfg.AddrPrefixCfg == nil {
+		cfg.AddrPrefixCfg = cfg.Network.AddrPrefixCfg()
 	}
 
 	if cfg.Gas == 0 {
 		cfg.Gas = 20000
 	}
 
-	if cfg.Fee == nil || cfg.Fee.Empty() {
-		panic(fmt.Errorf("fee is required"))
-	}
-
-	if cfg.KeyDAO == nil {
-		if len(cfg.DBRootDir) == 0 {
-			panic(fmt.Errorf("DBRootDir is required when use default keyDao"))
-		}
-
+	if cfg.KeyDAO == nil && cfg.KeyManager == nil {
 		keybase, err := sdk.NewLevelDB(cfg.DBRootDir, cdc)
 		if err != nil {
-			panic(err)
+			return sdk.WrapWithMessage(err, "failed to open the keybase in %s", cfg.DBRootDir)
 		}
 		cfg.KeyDAO = keybase
 	}
@@ -380,11 +537,40 @@ func initConfig(cdc sdk.Codec, cfg *sdk.ClientConfig) {
 		cfg.Timeout = timeout
 	}
 
+	if cfg.BatchConcurrency == 0 {
+		cfg.BatchConcurrency = batchConcurrency
+	}
+
 	if len(cfg.Level) == 0 {
 		cfg.Level = "info"
 	}
 
-	sdk.SetNetwork(cfg.Network)
+	if cfg.Tracer == nil {
+		cfg.Tracer = trace.NoopTracer{}
+	}
+	return nil
+}
+
+// detectVersion asks the node for the version of IRIShub, the zero version is returned if it is unknown
+func detectVersion(tmClient sdk.TmClient, logger log.Logger) sdk.Version {
+	info, err := tmClient.ABCIInfo()
+	if err != nil {
+		logger.Warn("failed to query the version of the node, the queries of the oldest version are used",
+			"err", err)
+		return sdk.Version{}
+	}
+	if len(info.Response.Version) == 0 {
+		logger.Debug("the node does not report its version, the queries of the oldest version are used")
+		return sdk.Version{}
+	}
+	version, err := sdk.ParseVersion(info.Response.Version)
+	if err != nil {
+		logger.Warn("unknown version of the node, the queries of the oldest version are used",
+			"version", info.Response.Version)
+		return sdk.Version{}
+	}
+	logger.Debug("detected the version of the node", "version", version.String())
+	return version
 }
 
 type locker struct {
@@ -392,7 +578,7 @@ type locker struct {
 	size   int
 }
 
-//NewLocker implement the function of lock, can lock resources according to conditions
+// NewLocker implement the function of lock, can lock resources according to conditions
 func NewLocker(size int) *locker {
 	shards := make([]chan int, size)
 	for i := 0; i < size; i++ {
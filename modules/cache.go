@@ -0,0 +1,110 @@
+package modules
+
+import (
+	"time"
+
+	sdk "github.com/irisnet/irishub-sdk-go/types"
+	"github.com/irisnet/irishub-sdk-go/utils/cache"
+)
+
+// the names of the caches of the client, which prefix their keys in the backend with the chain-id.
+// The accounts are never written to the backend.
+const (
+	accountsCache = "accounts"
+	tokensCache   = "tokens"
+	paramsCache   = "params"
+)
+
+const (
+	defaultCacheCapacity = 100
+	defaultCacheTTL      = 1 * time.Minute
+
+	// the tags of the end block of a passed proposal
+	tagAction            = "action"
+	actionProposalPassed = "proposal-passed"
+)
+
+// tokenMsgs are the types of the msgs which change a token
+var tokenMsgs = map[string]bool{
+	"edit_token":           true,
+	"mint_token":           true,
+	"transfer_token_owner": true,
+}
+
+type caches map[string]cache.Tiered
+
+func newCaches(chainID string, cfg sdk.CacheConfig) caches {
+	withDefaults := func(c cache.Config, ttl time.Duration) cache.Config {
+		if c.Capacity == 0 {
+			c.Capacity = defaultCacheCapacity
+		}
+		if c.TTL == 0 {
+			c.TTL = ttl
+		}
+		return c
+	}
+	return caches{
+		// the accounts stay in the process: the addresses of the key names belong to its keybase, and the sequences
+		// written by several processes would race in the backend
+		accountsCache: cache.NewTiered(chainID+"/"+accountsCache, withDefaults(cfg.Accounts, defaultCacheTTL), nil),
+		tokensCache:   cache.NewTiered(chainID+"/"+tokensCache, withDefaults(cfg.Tokens, 0), cfg.Backend),
+		paramsCache:   cache.NewTiered(chainID+"/"+paramsCache, withDefaults(cfg.Params, defaultCacheTTL), cfg.Backend),
+	}
+}
+
+// CacheStats returns the statistics of the caches of the accounts, the tokens and the params, by name
+func (base *baseClient) CacheStats() map[string]cache.Stats {
+	stats := make(map[string]cache.Stats, len(base.caches))
+	for name, c := range base.caches {
+		stats[name] = c.Stats()
+	}
+	return stats
+}
+
+// watchCaches subscribes to the txs and the blocks of the chain to remove the outdated entries of the caches
+func (base *baseClient) watchCaches() error {
+	txSub, err := base.SubscribeTx(nil, base.invalidateTx)
+	if err != nil {
+		return err
+	}
+	blockSub, err := base.SubscribeNewBlock(nil, base.invalidateBlock)
+	if err != nil {
+		_ = base.Unsubscribe(txSub)
+		return err
+	}
+	base.subscriptions = append(base.subscriptions, txSub, blockSub)
+	return nil
+}
+
+// invalidateTx removes the accounts whose sequence was used by another client and the tokens changed by the tx
+func (base *baseClient) invalidateTx(tx sdk.EventDataTx) {
+	for _, sig := range tx.Tx.Signatures {
+		if sig.PubKey == nil {
+			continue
+		}
+		address := base.cfg.AddrPrefixCfg.AccAddressString(sdk.AccAddress(sig.PubKey.Address()))
+		// the cached sequence is the last one used by the client
+		if info, ok := base.cachedAccount(address); ok && info.S < sig.Sequence {
+			_ = base.removeCache(address)
+			base.logger.Debug("invalidate the cached account", "address", address, "height", tx.Height)
+		}
+	}
+	for _, msg := range tx.Tx.Msgs {
+		if tokenMsgs[msg.Type()] {
+			base.caches[tokensCache].Purge()
+			base.logger.Debug("invalidate the cached tokens", "msg", msg.Type(), "height", tx.Height)
+			return
+		}
+	}
+}
+
+// invalidateBlock removes the params when a proposal passes, e.g. a parameter change
+func (base *baseClient) invalidateBlock(block sdk.EventDataNewBlock) {
+	for _, action := range block.ResultEndBlock.Tags.GetValues(tagAction) {
+		if action == actionProposalPassed {
+			base.caches[paramsCache].Purge()
+			base.logger.Debug("invalidate the cached params", "height", block.Block.Height)
+			return
+		}
+	}
+}
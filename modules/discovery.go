@@ -0,0 +1,98 @@
+package modules
+
+import (
+	"encoding/json"
+	"fmt"
+
+	rpcclient "github.com/tendermint/tendermint/rpc/client"
+
+	sdk "github.com/irisnet/irishub-sdk-go/types"
+	"github.com/irisnet/irishub-sdk-go/utils/bech32"
+)
+
+// the queries of the validators of IRIShub 0.x and 1.x, whose operator addresses show the prefixes of the chain
+var validatorsQueries = []struct {
+	path   string
+	params string
+}{
+	{"custom/stake/validators", `{"Page":"1","Size":1}`},
+	{"custom/staking/validators", `{"page":1,"limit":1,"status":"Bonded"}`},
+}
+
+type discoveryClient interface {
+	rpcclient.StatusClient
+	rpcclient.ABCIClient
+}
+
+// discoverChain sets the chain-id reported by the node and the network inferred from the prefixes of its
+// validators or from the known chains, it fails if they disagree with the configured ones or if the network
+// is neither inferred nor configured
+func discoverChain(cfg *sdk.ClientConfig) error {
+	var node discoveryClient
+	switch c := cfg.TmClient.(type) {
+	case nil:
+		node = rpcclient.NewHTTP(cfg.NodeURI, "/websocket")
+	case discoveryClient:
+		node = c
+	default:
+		return fmt.Errorf("the TmClient %T does not report the status of the node", cfg.TmClient)
+	}
+
+	status, err := node.Status()
+	if err != nil {
+		return sdk.WrapWithMessage(err, "failed to query the status of the node")
+	}
+	chainID := status.NodeInfo.Network
+	if len(cfg.ChainID) > 0 && cfg.ChainID != chainID {
+		return fmt.Errorf("the chain-id is %s but the node is on %s", cfg.ChainID, chainID)
+	}
+	cfg.ChainID = chainID
+
+	network, known := sdk.NetworkOfChainID(chainID)
+	if prefix := validatorPrefix(node); len(prefix) > 0 {
+		if cfg.AddrPrefixCfg != nil {
+			if expected := cfg.AddrPrefixCfg.GetBech32ValidatorAddrPrefix(); expected != prefix {
+				return fmt.Errorf("the validator prefix is %s but the validators of the node use %s", expected, prefix)
+			}
+			return nil
+		}
+		if network, known = sdk.NetworkOfValidatorPrefix(prefix); !known {
+			return fmt.Errorf("the validators of the node use the unknown prefix %s, AddrPrefixCfg is required", prefix)
+		}
+	}
+	if cfg.AddrPrefixCfg != nil {
+		return nil
+	}
+	if !known {
+		if len(cfg.Network) == 0 {
+			return fmt.Errorf("the network of the chain %s is unknown, Network or AddrPrefixCfg is required", chainID)
+		}
+		return nil
+	}
+	if len(cfg.Network) > 0 && cfg.Network != network {
+		return fmt.Errorf("the network is %s but the node is on %s", cfg.Network, network)
+	}
+	cfg.Network = network
+	return nil
+}
+
+// validatorPrefix returns the bech32 prefix of the operator addresses of the validators, empty if the node
+// does not answer the query
+func validatorPrefix(node rpcclient.ABCIClient) string {
+	for _, q := range validatorsQueries {
+		res, err := node.ABCIQuery(q.path, []byte(q.params))
+		if err != nil || !res.Response.IsOK() {
+			continue
+		}
+		var validators []struct {
+			OperatorAddress string `json:"operator_address"`
+		}
+		if err := json.Unmarshal(res.Response.Value, &validators); err != nil || len(validators) == 0 {
+			continue
+		}
+		if hrp, _, err := bech32.DecodeAndConvert(validators[0].OperatorAddress); err == nil {
+			return hrp
+		}
+	}
+	return ""
+}
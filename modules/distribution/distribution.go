@@ -4,11 +4,18 @@ import (
 	"github.com/irisnet/irishub-sdk-go/rpc"
 	sdk "github.com/irisnet/irishub-sdk-go/types"
 	"github.com/irisnet/irishub-sdk-go/utils/log"
+	"github.com/irisnet/irishub-sdk-go/utils/proto"
 )
 
 type distributionClient struct {
 	sdk.BaseClient
-	*log.Logger
+	log.Logger
+	queries *sdk.VersionedQueries
+}
+
+// querier is implemented by the queries of each version of the chain
+type querier interface {
+	queryRewards(delegator string) (rpc.Rewards, sdk.Error)
 }
 
 func (d distributionClient) RegisterCodec(cdc sdk.Codec) {
@@ -20,29 +27,35 @@ func (d distributionClient) Name() string {
 }
 
 func Create(ac sdk.BaseClient) rpc.Distribution {
+	queries := new(sdk.VersionedQueries).
+		Register(sdk.V017, querierV017{ac}).
+		Register(sdk.V100, querierV100{ac})
 	return distributionClient{
 		BaseClient: ac,
 		Logger:     ac.Logger(),
+		queries:    queries,
 	}
 }
 
 func (d distributionClient) QueryRewards(delegator string) (rpc.Rewards, sdk.Error) {
-	address, err := sdk.AccAddressFromBech32(delegator)
-	if err != nil {
+	if _, err := d.AddrPrefixCfg().AccAddressFromBech32(delegator); err != nil {
 		return rpc.Rewards{}, sdk.Wrap(err)
 	}
+	return d.querier().queryRewards(delegator)
+}
 
-	param := struct {
-		Address sdk.AccAddress
-	}{
-		Address: address,
-	}
-
-	var rewards rewards
-	if err := d.QueryWithResponse("custom/distr/rewards", param, &rewards); err != nil {
-		return rpc.Rewards{}, sdk.Wrap(err)
-	}
-	return rewards.Convert().(rpc.Rewards), nil
+// BatchQueryRewards returns the rewards of the delegators in their order, with the error of each delegator
+func (d distributionClient) BatchQueryRewards(delegators ...string) []rpc.RewardsResult {
+	results := make([]rpc.RewardsResult, len(delegators))
+	d.QueryBatch(len(delegators), func(i int) {
+		res := rpc.RewardsResult{Delegator: delegators[i]}
+		res.Rewards, res.Err = d.QueryRewards(delegators[i])
+		if res.Err == nil {
+			res.Total, res.Err = d.ToMainCoin(res.Rewards.Total...)
+		}
+		results[i] = res
+	})
+	return results
 }
 
 func (d distributionClient) SetWithdrawAddr(withdrawAddr string, baseTx sdk.BaseTx) (sdk.ResultTx, sdk.Error) {
@@ -51,7 +64,7 @@ func (d distributionClient) SetWithdrawAddr(withdrawAddr string, baseTx sdk.Base
 		return sdk.ResultTx{}, sdk.Wrap(err)
 	}
 
-	withdraw, err := sdk.AccAddressFromBech32(withdrawAddr)
+	withdraw, err := d.AddrPrefixCfg().AccAddressFromBech32(withdrawAddr)
 	if err != nil {
 		return sdk.ResultTx{}, sdk.Wrap(err)
 	}
@@ -60,9 +73,9 @@ func (d distributionClient) SetWithdrawAddr(withdrawAddr string, baseTx sdk.Base
 		DelegatorAddr: delegator,
 		WithdrawAddr:  withdraw,
 	}
-	d.Info().Str("delegator", delegator.String()).
-		Str("withdrawAddr", withdrawAddr).
-		Msg("execute setWithdrawAddr transaction")
+	d.Info("execute setWithdrawAddr transaction",
+		"delegator", d.AddrPrefixCfg().AccAddressString(delegator),
+		"withdrawAddr", withdrawAddr)
 	return d.BuildAndSend([]sdk.Msg{msg}, baseTx)
 }
 
@@ -79,11 +92,10 @@ func (d distributionClient) WithdrawRewards(isValidator bool, onlyFromValidator
 			ValidatorAddr: sdk.ValAddress(delegator.Bytes()),
 		})
 
-		d.Info().Str("delegator", delegator.String()).
-			Msg("execute withdrawValidatorRewardsAll transaction")
+		d.Info("execute withdrawValidatorRewardsAll transaction", "delegator", d.AddrPrefixCfg().AccAddressString(delegator))
 		break
 	case onlyFromValidator != "":
-		valAddr, err := sdk.ValAddressFromBech32(onlyFromValidator)
+		valAddr, err := d.AddrPrefixCfg().ValAddressFromBech32(onlyFromValidator)
 		if err != nil {
 			return sdk.ResultTx{}, sdk.Wrap(err)
 		}
@@ -92,19 +104,74 @@ func (d distributionClient) WithdrawRewards(isValidator bool, onlyFromValidator
 			DelegatorAddr: delegator,
 		})
 
-		d.Info().Str("delegator", delegator.String()).
-			Str("validator", onlyFromValidator).
-			Msg("execute withdrawDelegatorReward transaction")
+		d.Info("execute withdrawDelegatorReward transaction",
+			"delegator", d.AddrPrefixCfg().AccAddressString(delegator),
+			"validator", onlyFromValidator)
 		break
 	default:
 		msgs = append(msgs, MsgWithdrawDelegatorRewardsAll{
 			DelegatorAddr: delegator,
 		})
 
-		d.Info().Str("delegator", delegator.String()).
-			Str("validator", onlyFromValidator).
-			Msg("execute withdrawDelegatorRewardsAll transaction")
+		d.Info("execute withdrawDelegatorRewardsAll transaction",
+			"delegator", d.AddrPrefixCfg().AccAddressString(delegator),
+			"validator", onlyFromValidator)
 		break
 	}
 	return d.BuildAndSend(msgs, baseTx)
 }
+
+// querier returns the queries of the version of the chain
+func (d distributionClient) querier() querier {
+	return d.queries.Route(d.ChainVersion()).(querier)
+}
+
+type querierV017 struct {
+	sdk.BaseClient
+}
+
+func (d querierV017) queryRewards(delegator string) (rpc.Rewards, sdk.Error) {
+	param := struct {
+		Address string
+	}{
+		Address: delegator,
+	}
+
+	var rewards rewards
+	if err := d.QueryWithResponse("custom/distr/rewards", param, &rewards); err != nil {
+		return rpc.Rewards{}, sdk.Wrap(err)
+	}
+	return rewards.Convert().(rpc.Rewards), nil
+}
+
+type querierV100 struct {
+	sdk.BaseClient
+}
+
+func (d querierV100) queryRewards(delegator string) (rpc.Rewards, sdk.Error) {
+	req := new(proto.Encoder).String(1, delegator).Bytes()
+	res, err := d.QueryProto("/cosmos.distribution.v1beta1.Query/DelegationTotalRewards", req)
+	if err != nil {
+		return rpc.Rewards{}, sdk.Wrap(err)
+	}
+	rewards, err := unmarshalProtoRewards(res)
+	if err != nil {
+		return rpc.Rewards{}, sdk.Wrap(err)
+	}
+
+	// the commission of the validator operated by the delegator, if any
+	addr, err := d.AddrPrefixCfg().AccAddressFromBech32(delegator)
+	if err != nil {
+		return rpc.Rewards{}, sdk.Wrap(err)
+	}
+	req = new(proto.Encoder).String(1, d.AddrPrefixCfg().ValAddressString(sdk.ValAddress(addr))).Bytes()
+	res, err = d.QueryProto("/cosmos.distribution.v1beta1.Query/ValidatorCommission", req)
+	if err != nil {
+		return rpc.Rewards{}, sdk.Wrap(err)
+	}
+	if rewards.Commission, err = unmarshalProtoCommission(res); err != nil {
+		return rpc.Rewards{}, sdk.Wrap(err)
+	}
+	rewards.Total = rewards.Total.Add(rewards.Commission...)
+	return rewards, nil
+}
@@ -1,11 +1,21 @@
 package distribution_test
 
 import (
+	"bytes"
+	"fmt"
 	"github.com/stretchr/testify/require"
+	"sync/atomic"
 	"testing"
+	"time"
 
+	"github.com/tendermint/tendermint/crypto/secp256k1"
+
+	client "github.com/irisnet/irishub-sdk-go"
+	"github.com/irisnet/irishub-sdk-go/rpc"
 	"github.com/irisnet/irishub-sdk-go/test"
+	"github.com/irisnet/irishub-sdk-go/test/fakechain"
 	sdk "github.com/irisnet/irishub-sdk-go/types"
+	"github.com/irisnet/irishub-sdk-go/utils/proto"
 	"github.com/stretchr/testify/suite"
 )
 
@@ -56,3 +66,131 @@ func (dts *DistrTestSuite) TestWithdrawRewards() {
 	require.NoError(dts.T(), err)
 	require.NotEmpty(dts.T(), rs.Hash)
 }
+
+func TestBatchQueryRewards(t *testing.T) {
+	fees, e := sdk.ParseDecCoins("0.6iris")
+	require.NoError(t, e)
+	chain := fakechain.New(fakechain.WithChainID("test"))
+	c := client.NewClient(sdk.ClientConfig{
+		TmClient:         chain,
+		ChainID:          "test",
+		Fee:              fees,
+		KeyDAO:           sdk.NewMemoryDB(),
+		BatchConcurrency: 4,
+	})
+
+	// the token of the rewards is queried once by the concurrent conversions
+	var tokenQueries int32
+	chain.AddTokens(sdk.Token{Symbol: "btc", Name: "Bitcoin", Scale: 8, MinUnit: "btc-min"})
+	chain.RegisterQuerier("custom/asset/token", func(ctx fakechain.Context, data []byte) ([]byte, error) {
+		var params struct {
+			Symbol string
+		}
+		if err := ctx.DecodeJSON(data, &params); err != nil {
+			return nil, err
+		}
+		token, ok := ctx.Token(params.Symbol)
+		if ok && token.Symbol == "btc" {
+			atomic.AddInt32(&tokenQueries, 1)
+			time.Sleep(20 * time.Millisecond)
+		}
+		if !ok {
+			return nil, fakechain.NewError(sdk.InvalidRequest, "token %s does not exist", params.Symbol)
+		}
+		return ctx.Codec.MarshalJSON(token)
+	})
+
+	// the delegator 4 fails on the chain, the rewards of the delegator 5 are of an unknown token
+	var delegators []string
+	rewards := make(map[string]sdk.Coins)
+	for i := 0; i < 8; i++ {
+		address, err := c.Keys().RecoverWithOptions(fmt.Sprintf("delegator%d", i), "1234567890", test.Mnemonic,
+			sdk.HDOptions{Index: uint32(i)})
+		require.NoError(t, err)
+		denom := "btc-min"
+		if i == 5 {
+			denom = "eth-min"
+		}
+		rewards[address] = sdk.NewCoins(sdk.NewCoin(denom, sdk.NewInt(int64(i+1)*100000000)))
+		delegators = append(delegators, address)
+	}
+	chain.RegisterQuerier("custom/distr/rewards", func(ctx fakechain.Context, data []byte) ([]byte, error) {
+		var params struct {
+			Address sdk.AccAddress
+		}
+		if err := ctx.DecodeJSON(data, &params); err != nil {
+			return nil, err
+		}
+		address := ctx.AddrPrefixCfg().AccAddressString(params.Address)
+		if address == delegators[4] {
+			return nil, fakechain.NewError(sdk.InvalidRequest, "no rewards of %s", address)
+		}
+		return ctx.EncodeJSON(struct {
+			Total sdk.Coins `json:"total"`
+		}{
+			Total: rewards[address],
+		})
+	})
+	delegators = append(delegators, "iaa1invalid")
+
+	results := c.Distr().BatchQueryRewards(delegators...)
+	require.Len(t, results, len(delegators))
+	for i, res := range results {
+		require.Equal(t, delegators[i], res.Delegator)
+		if i == 4 || i == 5 || i == 8 {
+			require.Error(t, res.Err, i)
+			continue
+		}
+		require.NoError(t, res.Err)
+		require.Equal(t, rewards[delegators[i]], res.Rewards.Total)
+		require.Equal(t, fmt.Sprintf("%dbtc", i+1), res.Total.String())
+	}
+	require.Equal(t, int32(1), atomic.LoadInt32(&tokenQueries))
+	require.Empty(t, c.Distr().BatchQueryRewards())
+}
+
+func TestQueryRewardsV100(t *testing.T) {
+	prefixes := sdk.Mainnet.AddrPrefixCfg()
+	addr := sdk.AccAddress(secp256k1.GenPrivKey().PubKey().Address())
+	delegator := prefixes.AccAddressString(addr)
+	validator := prefixes.ValAddressString(sdk.ValAddress(addr))
+	decCoin := func(amount string) []byte {
+		return new(proto.Encoder).String(1, "uiris").String(2, amount).Bytes()
+	}
+
+	chain := fakechain.New(fakechain.WithChainID("irishub-1"), fakechain.WithAppVersion("1.0.0"))
+	chain.RegisterQuerier("/cosmos.distribution.v1beta1.Query/DelegationTotalRewards", func(_ fakechain.Context, data []byte) ([]byte, error) {
+		if !bytes.Equal(data, new(proto.Encoder).String(1, delegator).Bytes()) {
+			return nil, fmt.Errorf("unexpected request %x", data)
+		}
+		reward := new(proto.Encoder).String(1, validator).Message(2, decCoin("1500000000000000000000"))
+		return new(proto.Encoder).
+			Message(1, reward.Bytes()).
+			Message(2, decCoin("1500000000000000000000")).
+			Bytes(), nil
+	})
+	chain.RegisterQuerier("/cosmos.distribution.v1beta1.Query/ValidatorCommission", func(_ fakechain.Context, data []byte) ([]byte, error) {
+		if !bytes.Equal(data, new(proto.Encoder).String(1, validator).Bytes()) {
+			return nil, fmt.Errorf("unexpected request %x", data)
+		}
+		commission := new(proto.Encoder).Message(1, decCoin("200999999999999999999"))
+		return new(proto.Encoder).Message(1, commission.Bytes()).Bytes(), nil
+	})
+	fees, e := sdk.ParseDecCoins("0.3iris")
+	require.NoError(t, e)
+	c := client.NewClient(sdk.ClientConfig{
+		TmClient: chain,
+		Network:  sdk.Mainnet,
+		ChainID:  "irishub-1",
+		Fee:      fees,
+		KeyDAO:   sdk.NewMemoryDB(),
+	})
+
+	rewards, err := c.Distr().QueryRewards(delegator)
+	require.NoError(t, err)
+	require.Equal(t, rpc.Rewards{
+		Total:       sdk.NewCoins(sdk.NewCoin("uiris", sdk.NewInt(1700))),
+		Delegations: []rpc.DelegationRewards{{Validator: validator, Reward: sdk.NewCoins(sdk.NewCoin("uiris", sdk.NewInt(1500)))}},
+		Commission:  sdk.NewCoins(sdk.NewCoin("uiris", sdk.NewInt(200))),
+	}, rewards)
+}
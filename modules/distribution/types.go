@@ -2,11 +2,14 @@ package distribution
 
 import (
 	"errors"
+	"fmt"
+	"math/big"
 
 	"github.com/irisnet/irishub-sdk-go/rpc"
 
 	sdk "github.com/irisnet/irishub-sdk-go/types"
 	"github.com/irisnet/irishub-sdk-go/utils/json"
+	"github.com/irisnet/irishub-sdk-go/utils/proto"
 )
 
 const (
@@ -19,6 +22,9 @@ var (
 	_ sdk.Msg = MsgWithdrawDelegatorRewardsAll{}
 	_ sdk.Msg = MsgWithdrawValidatorRewardsAll{}
 
+	_ sdk.ProtoMsg = MsgSetWithdrawAddress{}
+	_ sdk.ProtoMsg = MsgWithdrawDelegatorReward{}
+
 	cdc = sdk.NewAminoCodec()
 )
 
@@ -38,11 +44,35 @@ func (msg MsgSetWithdrawAddress) Route() string { return ModuleName }
 
 func (msg MsgSetWithdrawAddress) Type() string { return "set_withdraw_address" }
 
+// Implements NoSpendMsg.
+func (msg MsgSetWithdrawAddress) SpendsNoCoins() {}
+
+// Implements RecipientMsg.
+func (msg MsgSetWithdrawAddress) Recipients() []sdk.AccAddress {
+	return []sdk.AccAddress{msg.WithdrawAddr}
+}
+
+// Implements NoValidatorMsg.
+func (msg MsgSetWithdrawAddress) ActsOnNoValidator() {}
+
 // Return address that must sign over msg.GetSignBytes()
 func (msg MsgSetWithdrawAddress) GetSigners() []sdk.AccAddress {
 	return []sdk.AccAddress{msg.DelegatorAddr}
 }
 
+// Implements ProtoMsg.
+func (msg MsgSetWithdrawAddress) ProtoTypeURL() string {
+	return "/cosmos.distribution.v1beta1.MsgSetWithdrawAddress"
+}
+
+// Implements ProtoMsg.
+func (msg MsgSetWithdrawAddress) MarshalProto(prefixes *sdk.AddrPrefixCfg) ([]byte, error) {
+	e := new(proto.Encoder).
+		String(1, prefixes.AccAddressString(msg.DelegatorAddr)).
+		String(2, prefixes.AccAddressString(msg.WithdrawAddr))
+	return e.Bytes(), nil
+}
+
 // get the bytes for the message signer to sign on
 func (msg MsgSetWithdrawAddress) GetSignBytes() []byte {
 	b, err := cdc.MarshalJSON(msg)
@@ -52,6 +82,20 @@ func (msg MsgSetWithdrawAddress) GetSignBytes() []byte {
 	return json.MustSort(b)
 }
 
+// Implements SignDocMsg.
+func (msg MsgSetWithdrawAddress) SignDoc(config *sdk.AddrPrefixCfg) interface{} {
+	return sdk.TypedSignDoc{
+		Type: "irishub/distr/MsgModifyWithdrawAddress",
+		Value: struct {
+			DelegatorAddr string `json:"delegator_addr"`
+			WithdrawAddr  string `json:"withdraw_addr"`
+		}{
+			DelegatorAddr: config.AccAddressString(msg.DelegatorAddr),
+			WithdrawAddr:  config.AccAddressString(msg.WithdrawAddr),
+		},
+	}
+}
+
 // quick validity check
 func (msg MsgSetWithdrawAddress) ValidateBasic() error {
 	if msg.DelegatorAddr == nil {
@@ -78,6 +122,15 @@ func (msg MsgWithdrawDelegatorRewardsAll) Route() string { return ModuleName }
 
 func (msg MsgWithdrawDelegatorRewardsAll) Type() string { return "withdraw_delegation_rewards_all" }
 
+// Implements NoSpendMsg.
+func (msg MsgWithdrawDelegatorRewardsAll) SpendsNoCoins() {}
+
+// Implements RecipientMsg.
+func (msg MsgWithdrawDelegatorRewardsAll) Recipients() []sdk.AccAddress { return nil }
+
+// Implements NoValidatorMsg.
+func (msg MsgWithdrawDelegatorRewardsAll) ActsOnNoValidator() {}
+
 // Return address that must sign over msg.GetSignBytes()
 func (msg MsgWithdrawDelegatorRewardsAll) GetSigners() []sdk.AccAddress {
 	return []sdk.AccAddress{sdk.AccAddress(msg.DelegatorAddr)}
@@ -92,6 +145,18 @@ func (msg MsgWithdrawDelegatorRewardsAll) GetSignBytes() []byte {
 	return json.MustSort(b)
 }
 
+// Implements SignDocMsg.
+func (msg MsgWithdrawDelegatorRewardsAll) SignDoc(config *sdk.AddrPrefixCfg) interface{} {
+	return sdk.TypedSignDoc{
+		Type: "irishub/distr/MsgWithdrawDelegationRewardsAll",
+		Value: struct {
+			DelegatorAddr string `json:"delegator_addr"`
+		}{
+			DelegatorAddr: config.AccAddressString(msg.DelegatorAddr),
+		},
+	}
+}
+
 // quick validity check
 func (msg MsgWithdrawDelegatorRewardsAll) ValidateBasic() error {
 	if msg.DelegatorAddr == nil {
@@ -112,11 +177,35 @@ func (msg MsgWithdrawDelegatorReward) Route() string { return ModuleName }
 
 func (msg MsgWithdrawDelegatorReward) Type() string { return "withdraw_delegation_reward" }
 
+// Implements NoSpendMsg.
+func (msg MsgWithdrawDelegatorReward) SpendsNoCoins() {}
+
+// Implements RecipientMsg.
+func (msg MsgWithdrawDelegatorReward) Recipients() []sdk.AccAddress { return nil }
+
 // Return address that must sign over msg.GetSignBytes()
 func (msg MsgWithdrawDelegatorReward) GetSigners() []sdk.AccAddress {
 	return []sdk.AccAddress{sdk.AccAddress(msg.DelegatorAddr)}
 }
 
+// Implements ValidatorMsg.
+func (msg MsgWithdrawDelegatorReward) Validators() []sdk.ValAddress {
+	return []sdk.ValAddress{msg.ValidatorAddr}
+}
+
+// Implements ProtoMsg.
+func (msg MsgWithdrawDelegatorReward) ProtoTypeURL() string {
+	return "/cosmos.distribution.v1beta1.MsgWithdrawDelegatorReward"
+}
+
+// Implements ProtoMsg.
+func (msg MsgWithdrawDelegatorReward) MarshalProto(prefixes *sdk.AddrPrefixCfg) ([]byte, error) {
+	e := new(proto.Encoder).
+		String(1, prefixes.AccAddressString(msg.DelegatorAddr)).
+		String(2, prefixes.ValAddressString(msg.ValidatorAddr))
+	return e.Bytes(), nil
+}
+
 // get the bytes for the message signer to sign on
 func (msg MsgWithdrawDelegatorReward) GetSignBytes() []byte {
 	b, err := cdc.MarshalJSON(msg)
@@ -126,6 +215,20 @@ func (msg MsgWithdrawDelegatorReward) GetSignBytes() []byte {
 	return json.MustSort(b)
 }
 
+// Implements SignDocMsg.
+func (msg MsgWithdrawDelegatorReward) SignDoc(config *sdk.AddrPrefixCfg) interface{} {
+	return sdk.TypedSignDoc{
+		Type: "irishub/distr/MsgWithdrawDelegationReward",
+		Value: struct {
+			DelegatorAddr string `json:"delegator_addr"`
+			ValidatorAddr string `json:"validator_addr"`
+		}{
+			DelegatorAddr: config.AccAddressString(msg.DelegatorAddr),
+			ValidatorAddr: config.ValAddressString(msg.ValidatorAddr),
+		},
+	}
+}
+
 // quick validity check
 func (msg MsgWithdrawDelegatorReward) ValidateBasic() error {
 	if msg.DelegatorAddr == nil {
@@ -146,6 +249,17 @@ func (msg MsgWithdrawValidatorRewardsAll) Route() string { return ModuleName }
 
 func (msg MsgWithdrawValidatorRewardsAll) Type() string { return "withdraw_validator_rewards_all" }
 
+// Implements NoSpendMsg.
+func (msg MsgWithdrawValidatorRewardsAll) SpendsNoCoins() {}
+
+// Implements RecipientMsg.
+func (msg MsgWithdrawValidatorRewardsAll) Recipients() []sdk.AccAddress { return nil }
+
+// Implements ValidatorMsg.
+func (msg MsgWithdrawValidatorRewardsAll) Validators() []sdk.ValAddress {
+	return []sdk.ValAddress{msg.ValidatorAddr}
+}
+
 // Return address that must sign over msg.GetSignBytes()
 func (msg MsgWithdrawValidatorRewardsAll) GetSigners() []sdk.AccAddress {
 	return []sdk.AccAddress{sdk.AccAddress(msg.ValidatorAddr.Bytes())}
@@ -160,6 +274,18 @@ func (msg MsgWithdrawValidatorRewardsAll) GetSignBytes() []byte {
 	return json.MustSort(b)
 }
 
+// Implements SignDocMsg.
+func (msg MsgWithdrawValidatorRewardsAll) SignDoc(config *sdk.AddrPrefixCfg) interface{} {
+	return sdk.TypedSignDoc{
+		Type: "irishub/distr/MsgWithdrawValidatorRewardsAll",
+		Value: struct {
+			ValidatorAddr string `json:"validator_addr"`
+		}{
+			ValidatorAddr: config.ValAddressString(msg.ValidatorAddr),
+		},
+	}
+}
+
 // quick validity check
 func (msg MsgWithdrawValidatorRewardsAll) ValidateBasic() error {
 	if msg.ValidatorAddr == nil {
@@ -178,7 +304,7 @@ func (r rewards) Convert() interface{} {
 	var delegations []rpc.DelegationRewards
 	for _, d := range r.Delegations {
 		delegations = append(delegations, rpc.DelegationRewards{
-			Validator: d.Validator.String(),
+			Validator: d.Validator,
 			Reward:    d.Reward,
 		})
 	}
@@ -190,8 +316,92 @@ func (r rewards) Convert() interface{} {
 }
 
 type delegationsRewards struct {
-	Validator sdk.ValAddress `json:"validator"`
-	Reward    sdk.Coins      `json:"reward"`
+	Validator string    `json:"validator"`
+	Reward    sdk.Coins `json:"reward"`
+}
+
+// unmarshalProtoRewards decodes a cosmos.distribution.v1beta1.QueryDelegationTotalRewardsResponse
+func unmarshalProtoRewards(bz []byte) (rpc.Rewards, error) {
+	var rewards rpc.Rewards
+	fields, err := proto.Decode(bz)
+	if err != nil {
+		return rewards, err
+	}
+	var total [][]byte
+	for _, f := range fields {
+		switch f.Number {
+		case 1:
+			reward, err := proto.Decode(f.Value)
+			if err != nil {
+				return rpc.Rewards{}, err
+			}
+			var d rpc.DelegationRewards
+			var coins [][]byte
+			for _, r := range reward {
+				switch r.Number {
+				case 1:
+					d.Validator = string(r.Value)
+				case 2:
+					coins = append(coins, r.Value)
+				}
+			}
+			if d.Reward, err = unmarshalProtoDecCoins(coins); err != nil {
+				return rpc.Rewards{}, err
+			}
+			rewards.Delegations = append(rewards.Delegations, d)
+		case 2:
+			total = append(total, f.Value)
+		}
+	}
+	if rewards.Total, err = unmarshalProtoDecCoins(total); err != nil {
+		return rpc.Rewards{}, err
+	}
+	return rewards, nil
+}
+
+// unmarshalProtoCommission decodes a cosmos.distribution.v1beta1.QueryValidatorCommissionResponse
+func unmarshalProtoCommission(bz []byte) (sdk.Coins, error) {
+	commission, _, err := proto.Lookup(bz, 1)
+	if err != nil {
+		return nil, err
+	}
+	fields, err := proto.Decode(commission)
+	if err != nil {
+		return nil, err
+	}
+	var coins [][]byte
+	for _, f := range fields {
+		if f.Number == 1 {
+			coins = append(coins, f.Value)
+		}
+	}
+	return unmarshalProtoDecCoins(coins)
+}
+
+// unmarshalProtoDecCoins decodes cosmos.base.v1beta1.DecCoin, truncating their amounts to integers
+func unmarshalProtoDecCoins(bzs [][]byte) (sdk.Coins, error) {
+	precision := new(big.Int).Exp(big.NewInt(10), big.NewInt(proto.DecPrecision), nil)
+	coins := sdk.Coins{}
+	for _, bz := range bzs {
+		fields, err := proto.Decode(bz)
+		if err != nil {
+			return nil, err
+		}
+		var denom string
+		amount := new(big.Int)
+		for _, f := range fields {
+			switch f.Number {
+			case 1:
+				denom = string(f.Value)
+			case 2:
+				if _, ok := amount.SetString(string(f.Value), 10); !ok {
+					return nil, fmt.Errorf("invalid amount %s", f.Value)
+				}
+			}
+		}
+		coins = coins.Add(sdk.NewCoin(denom, sdk.NewIntFromBigInt(amount.Quo(amount, precision))))
+	}
+	return coins, nil
 }
 
 func registerCodec(cdc sdk.Codec) {
@@ -0,0 +1,54 @@
+package gov
+
+import (
+	sdk "github.com/irisnet/irishub-sdk-go/types"
+)
+
+// Codespace is the codespace of the errors of the gov module
+const Codespace = ModuleName
+
+// the errors returned by the gov module of IRIShub 1.x
+var (
+	ErrUnknownProposal         = sdk.RegisterError(Codespace, 2, "unknown proposal")
+	ErrInactiveProposal        = sdk.RegisterError(Codespace, 3, "inactive proposal")
+	ErrAlreadyActiveProposal   = sdk.RegisterError(Codespace, 4, "proposal already active")
+	ErrInvalidProposalContent  = sdk.RegisterError(Codespace, 5, "invalid proposal content")
+	ErrInvalidProposalType     = sdk.RegisterError(Codespace, 6, "invalid proposal type")
+	ErrInvalidVote             = sdk.RegisterError(Codespace, 7, "invalid vote option")
+	ErrInvalidGenesis          = sdk.RegisterError(Codespace, 8, "invalid genesis state")
+	ErrNoProposalHandlerExists = sdk.RegisterError(Codespace, 9, "no handler exists for proposal type")
+)
+
+// the errors returned by the gov module of IRIShub 0.x
+var (
+	ErrLegacyUnknownProposal              = sdk.RegisterLegacyError(Codespace, 1, "unknown proposal")
+	ErrLegacyInactiveProposal             = sdk.RegisterLegacyError(Codespace, 2, "inactive proposal")
+	ErrLegacyAlreadyActiveProposal        = sdk.RegisterLegacyError(Codespace, 3, "proposal already active")
+	ErrLegacyAlreadyFinishedProposal      = sdk.RegisterLegacyError(Codespace, 4, "proposal already finished")
+	ErrLegacyAddressNotStaked             = sdk.RegisterLegacyError(Codespace, 5, "address is not staked")
+	ErrLegacyInvalidTitle                 = sdk.RegisterLegacyError(Codespace, 6, "invalid title")
+	ErrLegacyInvalidDescription           = sdk.RegisterLegacyError(Codespace, 7, "invalid description")
+	ErrLegacyInvalidProposalType          = sdk.RegisterLegacyError(Codespace, 8, "invalid proposal type")
+	ErrLegacyInvalidVote                  = sdk.RegisterLegacyError(Codespace, 9, "invalid vote option")
+	ErrLegacyInvalidGenesis               = sdk.RegisterLegacyError(Codespace, 10, "invalid genesis state")
+	ErrLegacyInvalidProposalStatus        = sdk.RegisterLegacyError(Codespace, 11, "invalid proposal status")
+	ErrLegacyInvalidParam                 = sdk.RegisterLegacyError(Codespace, 12, "invalid param")
+	ErrLegacyInvalidParamOp               = sdk.RegisterLegacyError(Codespace, 13, "invalid param operation")
+	ErrLegacySwitchPeriodInProcess        = sdk.RegisterLegacyError(Codespace, 14, "switch period in process")
+	ErrLegacyInvalidPercent               = sdk.RegisterLegacyError(Codespace, 15, "invalid percent")
+	ErrLegacyInvalidUsageType             = sdk.RegisterLegacyError(Codespace, 16, "invalid usage type")
+	ErrLegacyInvalidInput                 = sdk.RegisterLegacyError(Codespace, 17, "invalid input")
+	ErrLegacyInvalidVersion               = sdk.RegisterLegacyError(Codespace, 18, "invalid version")
+	ErrLegacyInvalidProposal              = sdk.RegisterLegacyError(Codespace, 19, "invalid proposal")
+	ErrLegacyNotEnoughInitialDeposit      = sdk.RegisterLegacyError(Codespace, 20, "not enough initial deposit")
+	ErrLegacyDepositDeleted               = sdk.RegisterLegacyError(Codespace, 21, "deposit deleted")
+	ErrLegacyVoteNotExisted               = sdk.RegisterLegacyError(Codespace, 22, "vote does not exist")
+	ErrLegacyDepositNotExisted            = sdk.RegisterLegacyError(Codespace, 23, "deposit does not exist")
+	ErrLegacyNotInDepositPeriod           = sdk.RegisterLegacyError(Codespace, 24, "not in the deposit period")
+	ErrLegacyAlreadyVote                  = sdk.RegisterLegacyError(Codespace, 25, "already voted")
+	ErrLegacyOnlyValidatorOrDelegatorVote = sdk.RegisterLegacyError(Codespace, 26, "only validators and delegators can vote")
+	ErrLegacyMoreThanMaxProposal          = sdk.RegisterLegacyError(Codespace, 27, "more than the maximum proposals")
+	ErrLegacyInvalidUpgradeParams         = sdk.RegisterLegacyError(Codespace, 28, "invalid upgrade params")
+	ErrLegacyEmptyParam                   = sdk.RegisterLegacyError(Codespace, 29, "empty param")
+	ErrLegacyInvalidParamNum              = sdk.RegisterLegacyError(Codespace, 30, "invalid number of params")
+)
@@ -8,13 +8,18 @@ import (
 
 type govClient struct {
 	sdk.BaseClient
-	*log.Logger
+	log.Logger
+	queries *sdk.VersionedQueries
 }
 
 func Create(ac sdk.BaseClient) rpc.Gov {
+	queries := new(sdk.VersionedQueries).
+		Register(sdk.V017, querierV017{ac}).
+		Register(sdk.V100, querierV100{ac})
 	return govClient{
 		BaseClient: ac,
 		Logger:     ac.Logger(),
+		queries:    queries,
 	}
 }
 
@@ -35,11 +40,10 @@ func (g govClient) Deposit(proposalID uint64, amount sdk.DecCoins, baseTx sdk.Ba
 		Depositor:  depositor,
 		Amount:     amt,
 	}
-	g.Info().
-		Uint64("proposalID", proposalID).
-		Str("depositor", depositor.String()).
-		Str("amount", amt.String()).
-		Msg("execute gov deposit")
+	g.Info("execute gov deposit",
+		"proposalID", proposalID,
+		"depositor", g.AddrPrefixCfg().AccAddressString(depositor),
+		"amount", amt.String())
 	return g.BuildAndSend([]sdk.Msg{msg}, baseTx)
 }
 
@@ -60,172 +64,65 @@ func (g govClient) Vote(proposalID uint64, option rpc.VoteOption, baseTx sdk.Bas
 		Voter:      voter,
 		Option:     op,
 	}
-	g.Info().
-		Uint64("proposalID", proposalID).
-		Str("voter", voter.String()).
-		Str("option", string(option)).
-		Msg("execute gov vote")
+	g.Info("execute gov vote", "proposalID", proposalID, "voter", g.AddrPrefixCfg().AccAddressString(voter), "option", string(option))
 	return g.BuildAndSend([]sdk.Msg{msg}, baseTx)
 }
 
 // QueryProposal returns the proposal of the specified proposalID
 func (g govClient) QueryProposal(proposalID uint64) (rpc.Proposal, sdk.Error) {
-	param := struct {
-		ProposalID uint64
-	}{
-		ProposalID: proposalID,
-	}
-
-	res, err := g.Query("custom/gov/proposal", param)
-	if err != nil {
-		return nil, sdk.Wrap(err)
-	}
-
-	var proposal proposal
-	if err = cdc.UnmarshalJSON(res, &proposal); err != nil {
-		return nil, sdk.Wrap(err)
-	}
-
-	return proposal.Convert().(rpc.Proposal), nil
+	return g.querier().queryProposal(proposalID)
 }
 
 // QueryProposals returns all proposals of the specified params
 func (g govClient) QueryProposals(request rpc.ProposalRequest) ([]rpc.Proposal, sdk.Error) {
-	var voter, depositor sdk.AccAddress
-	var err error
 	if len(request.Voter) != 0 {
-		voter, err = sdk.AccAddressFromBech32(request.Voter)
-		if err != nil {
+		if _, err := g.AddrPrefixCfg().AccAddressFromBech32(request.Voter); err != nil {
 			return nil, sdk.Wrap(err)
 		}
 	}
 
 	if len(request.Depositor) != 0 {
-		depositor, err = sdk.AccAddressFromBech32(request.Depositor)
-		if err != nil {
+		if _, err := g.AddrPrefixCfg().AccAddressFromBech32(request.Depositor); err != nil {
 			return nil, sdk.Wrap(err)
 		}
 	}
-
-	param := struct {
-		Voter          sdk.AccAddress
-		Depositor      sdk.AccAddress
-		ProposalStatus string
-		Limit          uint64
-	}{
-		Voter:          voter,
-		Depositor:      depositor,
-		ProposalStatus: request.ProposalStatus,
-		Limit:          request.Limit,
-	}
-
-	res, err := g.Query("custom/gov/proposals", param)
-	if err != nil {
-		return nil, sdk.Wrap(err)
-	}
-
-	var proposals proposals
-	if err := cdc.UnmarshalJSON(res, &proposals); err != nil {
-		return nil, sdk.Wrap(err)
-	}
-
-	var ps []rpc.Proposal
-	for _, p := range proposals {
-		ps = append(ps, p.Convert().(rpc.Proposal))
-	}
-	return ps, nil
+	return g.querier().queryProposals(request)
 }
 
 // QueryVote returns the vote of the specified proposalID and voter
 func (g govClient) QueryVote(proposalID uint64, voter string) (rpc.Vote, sdk.Error) {
-	v, err := sdk.AccAddressFromBech32(voter)
-	if err != nil {
-		return rpc.Vote{}, sdk.Wrap(err)
-	}
-
-	param := struct {
-		ProposalID uint64
-		Voter      sdk.AccAddress
-	}{
-		ProposalID: proposalID,
-		Voter:      v,
-	}
-
-	var vote vote
-	if err := g.QueryWithResponse("custom/gov/vote", param, &vote); err != nil {
+	if _, err := g.AddrPrefixCfg().AccAddressFromBech32(voter); err != nil {
 		return rpc.Vote{}, sdk.Wrap(err)
 	}
-	return vote.Convert().(rpc.Vote), nil
+	return g.querier().queryVote(proposalID, voter)
 }
 
 // QueryVotes returns all votes of the specified proposalID
 func (g govClient) QueryVotes(proposalID uint64) ([]rpc.Vote, sdk.Error) {
-	param := struct {
-		ProposalID uint64
-	}{
-		ProposalID: proposalID,
-	}
-
-	var vs votes
-	err := g.QueryWithResponse("custom/gov/votes", param, &vs)
-	if err != nil {
-		return nil, sdk.Wrap(err)
-	}
-	return vs.Convert().([]rpc.Vote), nil
+	return g.querier().queryVotes(proposalID)
 }
 
 // QueryDeposit returns the deposit of the specified proposalID and depositor
 func (g govClient) QueryDeposit(proposalID uint64, depositor string) (rpc.Deposit, sdk.Error) {
-	d, err := sdk.AccAddressFromBech32(depositor)
-	if err != nil {
+	if _, err := g.AddrPrefixCfg().AccAddressFromBech32(depositor); err != nil {
 		return rpc.Deposit{}, sdk.Wrap(err)
 	}
-
-	param := struct {
-		ProposalID uint64
-		Depositor  sdk.AccAddress
-	}{
-		ProposalID: proposalID,
-		Depositor:  d,
-	}
-
-	var deposit deposit
-	if err := g.QueryWithResponse("custom/gov/deposit", param, &deposit); err != nil {
-		return rpc.Deposit{}, sdk.Wrap(err)
-	}
-	return deposit.Convert().(rpc.Deposit), nil
+	return g.querier().queryDeposit(proposalID, depositor)
 }
 
 // QueryDeposits returns all deposits of the specified proposalID
 func (g govClient) QueryDeposits(proposalID uint64) ([]rpc.Deposit, sdk.Error) {
-	param := struct {
-		ProposalID uint64
-	}{
-		ProposalID: proposalID,
-	}
-
-	var deposits deposits
-	err := g.QueryWithResponse("custom/gov/deposits", param, &deposits)
-	if err != nil {
-		return nil, sdk.Wrap(err)
-	}
-	return deposits.Convert().([]rpc.Deposit), nil
+	return g.querier().queryDeposits(proposalID)
 }
 
 // QueryTally returns the result of proposal by the specified proposalID
 func (g govClient) QueryTally(proposalID uint64) (rpc.TallyResult, sdk.Error) {
-	param := struct {
-		ProposalID uint64
-	}{
-		ProposalID: proposalID,
-	}
+	return g.querier().queryTally(proposalID)
+}
 
-	var tally tallyResult
-	err := g.QueryWithResponse("custom/gov/tally", param, &tally)
-	if err != nil {
-		return rpc.TallyResult{}, sdk.Wrap(err)
-	}
-	return tally.Convert().(rpc.TallyResult), nil
+// querier returns the queries of the version of the chain
+func (g govClient) querier() querier {
+	return g.queries.Route(g.ChainVersion()).(querier)
 }
 
 func (g govClient) RegisterCodec(cdc sdk.Codec) {
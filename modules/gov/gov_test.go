@@ -1,9 +1,19 @@
 package gov_test
 
 import (
+	"fmt"
+	"github.com/stretchr/testify/require"
 	"testing"
+	"time"
 
+	"github.com/tendermint/tendermint/crypto/secp256k1"
+
+	client "github.com/irisnet/irishub-sdk-go"
+	"github.com/irisnet/irishub-sdk-go/rpc"
 	"github.com/irisnet/irishub-sdk-go/test"
+	"github.com/irisnet/irishub-sdk-go/test/fakechain"
+	sdk "github.com/irisnet/irishub-sdk-go/types"
+	"github.com/irisnet/irishub-sdk-go/utils/proto"
 	"github.com/stretchr/testify/suite"
 )
 
@@ -70,3 +80,87 @@ func (gts *GovTestSuite) SetupTest() {
 //	require.NoError(gts.T(), err)
 //	require.NotEmpty(gts.T(), tally.Yes)
 //}
+
+func TestQueryV100(t *testing.T) {
+	prefixes := sdk.Mainnet.AddrPrefixCfg()
+	voter := prefixes.AccAddressString(sdk.AccAddress(secp256k1.GenPrivKey().PubKey().Address()))
+	timestamp := new(proto.Encoder).Uint64(1, 1600000000).Bytes()
+	coin := sdk.MarshalProtoCoin(sdk.NewCoin("uiris", sdk.NewInt(1000)))
+	proposal := func(id uint64) []byte {
+		content := new(proto.Encoder).String(1, "title").String(2, "description").Bytes()
+		return new(proto.Encoder).
+			Uint64(1, id).
+			Any(2, "/cosmos.gov.v1beta1.TextProposal", content).
+			Uint64(3, 2).
+			Message(4, new(proto.Encoder).String(1, "10").String(2, "0").String(3, "5").String(4, "0").Bytes()).
+			Message(5, timestamp).
+			Message(7, coin).
+			Bytes()
+	}
+
+	chain := fakechain.New(fakechain.WithChainID("irishub-1"), fakechain.WithAppVersion("1.0.0"))
+	chain.RegisterQuerier("/cosmos.gov.v1beta1.Query/Proposal", func(_ fakechain.Context, data []byte) ([]byte, error) {
+		fields, err := proto.Decode(data)
+		if err != nil || len(fields) != 1 {
+			return nil, fmt.Errorf("unexpected request %x", data)
+		}
+		return new(proto.Encoder).Message(1, proposal(fields[0].Varint)).Bytes(), nil
+	})
+	chain.RegisterQuerier("/cosmos.gov.v1beta1.Query/Proposals", func(_ fakechain.Context, data []byte) ([]byte, error) {
+		fields, err := proto.Decode(data)
+		if err != nil || fields[0].Number != 1 || fields[0].Varint != 2 || string(fields[1].Value) != voter {
+			return nil, fmt.Errorf("unexpected request %x", data)
+		}
+		return new(proto.Encoder).
+			Message(1, proposal(1)).
+			Message(1, proposal(2)).
+			Message(2, new(proto.Encoder).Raw(1, []byte{3}).Bytes()).
+			Bytes(), nil
+	})
+	chain.RegisterQuerier("/cosmos.gov.v1beta1.Query/Votes", func(_ fakechain.Context, data []byte) ([]byte, error) {
+		vote := new(proto.Encoder).Uint64(1, 1).String(2, voter).Uint64(3, 4)
+		return new(proto.Encoder).Message(1, vote.Bytes()).Bytes(), nil
+	})
+	chain.RegisterQuerier("/cosmos.gov.v1beta1.Query/Deposit", func(_ fakechain.Context, data []byte) ([]byte, error) {
+		deposit := new(proto.Encoder).Uint64(1, 1).String(2, voter).Message(3, coin)
+		return new(proto.Encoder).Message(1, deposit.Bytes()).Bytes(), nil
+	})
+	fees, e := sdk.ParseDecCoins("0.3iris")
+	require.NoError(t, e)
+	c := client.NewClient(sdk.ClientConfig{
+		TmClient: chain,
+		Network:  sdk.Mainnet,
+		ChainID:  "irishub-1",
+		Fee:      fees,
+		KeyDAO:   sdk.NewMemoryDB(),
+	})
+
+	p, err := c.Gov().QueryProposal(7)
+	require.NoError(t, err)
+	require.Equal(t, rpc.BasicProposal{
+		ProposalID:     7,
+		Title:          "title",
+		Description:    "description",
+		ProposalType:   "PlainText",
+		ProposalStatus: "VotingPeriod",
+		TallyResult:    rpc.TallyResult{Yes: "10", Abstain: "0", No: "5", NoWithVeto: "0"},
+		SubmitTime:     time.Unix(1600000000, 0).UTC(),
+		TotalDeposit:   sdk.NewCoins(sdk.NewCoin("uiris", sdk.NewInt(1000))),
+	}, p)
+
+	// the limit queries a single page
+	ps, err := c.Gov().QueryProposals(rpc.ProposalRequest{Voter: voter, ProposalStatus: "VotingPeriod", Limit: 2})
+	require.NoError(t, err)
+	require.Len(t, ps, 2)
+	require.Equal(t, uint64(2), ps[1].GetProposalID())
+	_, err = c.Gov().QueryProposals(rpc.ProposalRequest{ProposalStatus: "Active"})
+	require.Error(t, err)
+
+	votes, err := c.Gov().QueryVotes(1)
+	require.NoError(t, err)
+	require.Equal(t, []rpc.Vote{{Voter: voter, ProposalID: 1, Option: "NoWithVeto"}}, votes)
+
+	deposit, err := c.Gov().QueryDeposit(1, voter)
+	require.NoError(t, err)
+	require.Equal(t, sdk.NewCoins(sdk.NewCoin("uiris", sdk.NewInt(1000))), deposit.Amount)
+}
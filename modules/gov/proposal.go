@@ -29,7 +29,7 @@ type proposal interface {
 	GetTotalDeposit() sdk.Coins
 	GetVotingStartTime() time.Time
 	GetVotingEndTime() time.Time
-	GetProposer() sdk.AccAddress
+	GetProposer() string
 	sdk.Response
 }
 
@@ -42,18 +42,18 @@ func (ps proposals) Convert() interface{} {
 
 // Basic proposals
 type BasicProposal struct {
-	ProposalID      uint64         `json:"proposal_id"`       //  ID of the proposal
-	Title           string         `json:"title"`             //  Title of the proposal
-	Description     string         `json:"description"`       //  Description of the proposal
-	ProposalType    string         `json:"proposal_type"`     //  Type of proposal. Initial set {plainTextProposal, softwareUpgradeProposal}
-	Status          string         `json:"proposal_status"`   //  Status of the proposal {Pending, Active, Passed, Rejected}
-	TallyResult     tallyResult    `json:"tally_result"`      //  Result of Tallys
-	SubmitTime      time.Time      `json:"submit_time"`       //  Time of the block where TxGovSubmitProposal was included
-	DepositEndTime  time.Time      `json:"deposit_end_time"`  // Time that the proposal would expire if deposit amount isn't met
-	TotalDeposit    sdk.Coins      `json:"total_deposit"`     //  Current deposit on this proposal. Initial value is set at InitialDeposit
-	VotingStartTime time.Time      `json:"voting_start_time"` //  Time of the block where MinDeposit was reached. -1 if MinDeposit is not reached
-	VotingEndTime   time.Time      `json:"voting_end_time"`   // Time that the VotingPeriod for this proposal will end and votes will be tallied
-	Proposer        sdk.AccAddress `json:"proposer"`
+	ProposalID      uint64      `json:"proposal_id"`       //  ID of the proposal
+	Title           string      `json:"title"`             //  Title of the proposal
+	Description     string      `json:"description"`       //  Description of the proposal
+	ProposalType    string      `json:"proposal_type"`     //  Type of proposal. Initial set {plainTextProposal, softwareUpgradeProposal}
+	Status          string      `json:"proposal_status"`   //  Status of the proposal {Pending, Active, Passed, Rejected}
+	TallyResult     tallyResult `json:"tally_result"`      //  Result of Tallys
+	SubmitTime      time.Time   `json:"submit_time"`       //  Time of the block where TxGovSubmitProposal was included
+	DepositEndTime  time.Time   `json:"deposit_end_time"`  // Time that the proposal would expire if deposit amount isn't met
+	TotalDeposit    sdk.Coins   `json:"total_deposit"`     //  Current deposit on this proposal. Initial value is set at InitialDeposit
+	VotingStartTime time.Time   `json:"voting_start_time"` //  Time of the block where MinDeposit was reached. -1 if MinDeposit is not reached
+	VotingEndTime   time.Time   `json:"voting_end_time"`   // Time that the VotingPeriod for this proposal will end and votes will be tallied
+	Proposer        string      `json:"proposer"`
 }
 
 func (b BasicProposal) GetTitle() string {
@@ -96,7 +96,7 @@ func (b BasicProposal) GetVotingEndTime() time.Time {
 	return b.VotingEndTime
 }
 
-func (b BasicProposal) GetProposer() sdk.AccAddress {
+func (b BasicProposal) GetProposer() string {
 	return b.Proposer
 }
 
@@ -119,7 +119,7 @@ func (b BasicProposal) Convert() interface{} {
 		TotalDeposit:    b.TotalDeposit,
 		VotingStartTime: b.VotingStartTime,
 		VotingEndTime:   b.VotingEndTime,
-		Proposer:        b.Proposer.String(),
+		Proposer:        b.Proposer,
 	}
 }
 
@@ -169,10 +169,10 @@ func (b parameterProposal) Convert() interface{} {
 
 // Implements proposal Interface
 type taxUsage struct {
-	Usage       string         `json:"usage"`
-	DestAddress sdk.AccAddress `json:"dest_address"`
-	Percent     string         `json:"percent"`
-	Amount      sdk.Coins      `json:"amount"`
+	Usage       string    `json:"usage"`
+	DestAddress string    `json:"dest_address"`
+	Percent     string    `json:"percent"`
+	Amount      sdk.Coins `json:"amount"`
 }
 
 type communityTaxUsageProposal struct {
@@ -185,7 +185,7 @@ func (b communityTaxUsageProposal) Convert() interface{} {
 		Proposal: b.BasicProposal.Convert().(rpc.BasicProposal),
 		TaxUsage: rpc.TaxUsage{
 			Usage:       b.TaxUsage.Usage,
-			DestAddress: b.TaxUsage.DestAddress.String(),
+			DestAddress: b.TaxUsage.DestAddress,
 			Percent:     b.TaxUsage.Percent,
 		},
 	}
@@ -0,0 +1,306 @@
+package gov
+
+import (
+	"github.com/irisnet/irishub-sdk-go/rpc"
+	sdk "github.com/irisnet/irishub-sdk-go/types"
+	"github.com/irisnet/irishub-sdk-go/utils/proto"
+)
+
+// querier is implemented by the queries of each version of the chain
+type querier interface {
+	queryProposal(proposalID uint64) (rpc.Proposal, sdk.Error)
+	queryProposals(request rpc.ProposalRequest) ([]rpc.Proposal, sdk.Error)
+	queryVote(proposalID uint64, voter string) (rpc.Vote, sdk.Error)
+	queryVotes(proposalID uint64) ([]rpc.Vote, sdk.Error)
+	queryDeposit(proposalID uint64, depositor string) (rpc.Deposit, sdk.Error)
+	queryDeposits(proposalID uint64) ([]rpc.Deposit, sdk.Error)
+	queryTally(proposalID uint64) (rpc.TallyResult, sdk.Error)
+}
+
+type querierV017 struct {
+	sdk.BaseClient
+}
+
+func (g querierV017) queryProposal(proposalID uint64) (rpc.Proposal, sdk.Error) {
+	param := struct {
+		ProposalID uint64
+	}{
+		ProposalID: proposalID,
+	}
+
+	res, err := g.Query("custom/gov/proposal", param)
+	if err != nil {
+		return nil, sdk.Wrap(err)
+	}
+
+	var proposal proposal
+	if err = cdc.UnmarshalJSON(res, &proposal); err != nil {
+		return nil, sdk.Wrap(err)
+	}
+
+	return proposal.Convert().(rpc.Proposal), nil
+}
+
+func (g querierV017) queryProposals(request rpc.ProposalRequest) ([]rpc.Proposal, sdk.Error) {
+	param := struct {
+		Voter          string
+		Depositor      string
+		ProposalStatus string
+		Limit          uint64
+	}{
+		Voter:          request.Voter,
+		Depositor:      request.Depositor,
+		ProposalStatus: request.ProposalStatus,
+		Limit:          request.Limit,
+	}
+
+	res, err := g.Query("custom/gov/proposals", param)
+	if err != nil {
+		return nil, sdk.Wrap(err)
+	}
+
+	var proposals proposals
+	if err := cdc.UnmarshalJSON(res, &proposals); err != nil {
+		return nil, sdk.Wrap(err)
+	}
+
+	var ps []rpc.Proposal
+	for _, p := range proposals {
+		ps = append(ps, p.Convert().(rpc.Proposal))
+	}
+	return ps, nil
+}
+
+func (g querierV017) queryVote(proposalID uint64, voter string) (rpc.Vote, sdk.Error) {
+	param := struct {
+		ProposalID uint64
+		Voter      string
+	}{
+		ProposalID: proposalID,
+		Voter:      voter,
+	}
+
+	var vote vote
+	if err := g.QueryWithResponse("custom/gov/vote", param, &vote); err != nil {
+		return rpc.Vote{}, sdk.Wrap(err)
+	}
+	return vote.Convert().(rpc.Vote), nil
+}
+
+func (g querierV017) queryVotes(proposalID uint64) ([]rpc.Vote, sdk.Error) {
+	param := struct {
+		ProposalID uint64
+	}{
+		ProposalID: proposalID,
+	}
+
+	var vs votes
+	err := g.QueryWithResponse("custom/gov/votes", param, &vs)
+	if err != nil {
+		return nil, sdk.Wrap(err)
+	}
+	return vs.Convert().([]rpc.Vote), nil
+}
+
+func (g querierV017) queryDeposit(proposalID uint64, depositor string) (rpc.Deposit, sdk.Error) {
+	param := struct {
+		ProposalID uint64
+		Depositor  string
+	}{
+		ProposalID: proposalID,
+		Depositor:  depositor,
+	}
+
+	var deposit deposit
+	if err := g.QueryWithResponse("custom/gov/deposit", param, &deposit); err != nil {
+		return rpc.Deposit{}, sdk.Wrap(err)
+	}
+	return deposit.Convert().(rpc.Deposit), nil
+}
+
+func (g querierV017) queryDeposits(proposalID uint64) ([]rpc.Deposit, sdk.Error) {
+	param := struct {
+		ProposalID uint64
+	}{
+		ProposalID: proposalID,
+	}
+
+	var deposits deposits
+	err := g.QueryWithResponse("custom/gov/deposits", param, &deposits)
+	if err != nil {
+		return nil, sdk.Wrap(err)
+	}
+	return deposits.Convert().([]rpc.Deposit), nil
+}
+
+func (g querierV017) queryTally(proposalID uint64) (rpc.TallyResult, sdk.Error) {
+	param := struct {
+		ProposalID uint64
+	}{
+		ProposalID: proposalID,
+	}
+
+	var tally tallyResult
+	err := g.QueryWithResponse("custom/gov/tally", param, &tally)
+	if err != nil {
+		return rpc.TallyResult{}, sdk.Wrap(err)
+	}
+	return tally.Convert().(rpc.TallyResult), nil
+}
+
+type querierV100 struct {
+	sdk.BaseClient
+}
+
+func (g querierV100) queryProposal(proposalID uint64) (rpc.Proposal, sdk.Error) {
+	req := new(proto.Encoder).Uint64(1, proposalID).Bytes()
+	res, err := g.QueryProto("/cosmos.gov.v1beta1.Query/Proposal", req)
+	if err != nil {
+		return nil, sdk.Wrap(err)
+	}
+	bz, _, err := proto.Lookup(res, 1)
+	if err != nil {
+		return nil, sdk.Wrap(err)
+	}
+	proposal, err := unmarshalProtoProposal(bz)
+	if err != nil {
+		return nil, sdk.Wrap(err)
+	}
+	return proposal, nil
+}
+
+func (g querierV100) queryProposals(request rpc.ProposalRequest) ([]rpc.Proposal, sdk.Error) {
+	status, err := protoProposalStatus(request.ProposalStatus)
+	if err != nil {
+		return nil, sdk.Wrap(err)
+	}
+	query := func(page []byte) ([]byte, error) {
+		req := new(proto.Encoder).
+			Uint64(1, status).
+			String(2, request.Voter).
+			String(3, request.Depositor).
+			Message(4, page)
+		return g.QueryProto("/cosmos.gov.v1beta1.Query/Proposals", req.Bytes())
+	}
+
+	var items [][]byte
+	if request.Limit > 0 {
+		// the first Limit proposals only
+		var res []byte
+		if res, err = query(proto.PageRequest(nil, 0, request.Limit)); err != nil {
+			return nil, sdk.Wrap(err)
+		}
+		var fields []proto.Field
+		if fields, err = proto.Decode(res); err != nil {
+			return nil, sdk.Wrap(err)
+		}
+		for _, f := range fields {
+			if f.Number == 1 {
+				items = append(items, f.Value)
+			}
+		}
+	} else if items, err = proto.Pages(query, 1, 2); err != nil {
+		return nil, sdk.Wrap(err)
+	}
+
+	var ps []rpc.Proposal
+	for _, item := range items {
+		p, err := unmarshalProtoProposal(item)
+		if err != nil {
+			return nil, sdk.Wrap(err)
+		}
+		ps = append(ps, p)
+	}
+	return ps, nil
+}
+
+func (g querierV100) queryVote(proposalID uint64, voter string) (rpc.Vote, sdk.Error) {
+	req := new(proto.Encoder).Uint64(1, proposalID).String(2, voter).Bytes()
+	res, err := g.QueryProto("/cosmos.gov.v1beta1.Query/Vote", req)
+	if err != nil {
+		return rpc.Vote{}, sdk.Wrap(err)
+	}
+	bz, _, err := proto.Lookup(res, 1)
+	if err != nil {
+		return rpc.Vote{}, sdk.Wrap(err)
+	}
+	vote, err := unmarshalProtoVote(bz)
+	if err != nil {
+		return rpc.Vote{}, sdk.Wrap(err)
+	}
+	return vote, nil
+}
+
+func (g querierV100) queryVotes(proposalID uint64) ([]rpc.Vote, sdk.Error) {
+	items, err := proto.Pages(func(page []byte) ([]byte, error) {
+		req := new(proto.Encoder).Uint64(1, proposalID).Message(2, page).Bytes()
+		return g.QueryProto("/cosmos.gov.v1beta1.Query/Votes", req)
+	}, 1, 2)
+	if err != nil {
+		return nil, sdk.Wrap(err)
+	}
+
+	votes := make([]rpc.Vote, 0, len(items))
+	for _, item := range items {
+		vote, err := unmarshalProtoVote(item)
+		if err != nil {
+			return nil, sdk.Wrap(err)
+		}
+		votes = append(votes, vote)
+	}
+	return votes, nil
+}
+
+func (g querierV100) queryDeposit(proposalID uint64, depositor string) (rpc.Deposit, sdk.Error) {
+	req := new(proto.Encoder).Uint64(1, proposalID).String(2, depositor).Bytes()
+	res, err := g.QueryProto("/cosmos.gov.v1beta1.Query/Deposit", req)
+	if err != nil {
+		return rpc.Deposit{}, sdk.Wrap(err)
+	}
+	bz, _, err := proto.Lookup(res, 1)
+	if err != nil {
+		return rpc.Deposit{}, sdk.Wrap(err)
+	}
+	deposit, err := unmarshalProtoDeposit(bz)
+	if err != nil {
+		return rpc.Deposit{}, sdk.Wrap(err)
+	}
+	return deposit, nil
+}
+
+func (g querierV100) queryDeposits(proposalID uint64) ([]rpc.Deposit, sdk.Error) {
+	items, err := proto.Pages(func(page []byte) ([]byte, error) {
+		req := new(proto.Encoder).Uint64(1, proposalID).Message(2, page).Bytes()
+		return g.QueryProto("/cosmos.gov.v1beta1.Query/Deposits", req)
+	}, 1, 2)
+	if err != nil {
+		return nil, sdk.Wrap(err)
+	}
+
+	deposits := make([]rpc.Deposit, 0, len(items))
+	for _, item := range items {
+		deposit, err := unmarshalProtoDeposit(item)
+		if err != nil {
+			return nil, sdk.Wrap(err)
+		}
+		deposits = append(deposits, deposit)
+	}
+	return deposits, nil
+}
+
+func (g querierV100) queryTally(proposalID uint64) (rpc.TallyResult, sdk.Error) {
+	req := new(proto.Encoder).Uint64(1, proposalID).Bytes()
+	res, err := g.QueryProto("/cosmos.gov.v1beta1.Query/TallyResult", req)
+	if err != nil {
+		return rpc.TallyResult{}, sdk.Wrap(err)
+	}
+	bz, _, err := proto.Lookup(res, 1)
+	if err != nil {
+		return rpc.TallyResult{}, sdk.Wrap(err)
+	}
+	tally, err := unmarshalProtoTally(bz)
+	if err != nil {
+		return rpc.TallyResult{}, sdk.Wrap(err)
+	}
+	return tally, nil
+}
@@ -4,11 +4,13 @@ import (
 	json2 "encoding/json"
 	"errors"
 	"fmt"
+	"strings"
 
 	"github.com/irisnet/irishub-sdk-go/rpc"
 
 	sdk "github.com/irisnet/irishub-sdk-go/types"
 	"github.com/irisnet/irishub-sdk-go/utils/json"
+	"github.com/irisnet/irishub-sdk-go/utils/proto"
 )
 
 const (
@@ -19,6 +21,9 @@ var (
 	_ sdk.Msg = MsgDeposit{}
 	_ sdk.Msg = MsgVote{}
 
+	_ sdk.ProtoMsg = MsgDeposit{}
+	_ sdk.ProtoMsg = MsgVote{}
+
 	cdc = sdk.NewAminoCodec()
 )
 
@@ -52,6 +57,15 @@ type MsgSubmitProposal struct {
 //nolint
 func (msg MsgSubmitProposal) Type() string { return "submit_proposal" }
 
+// Implements SpendMsg.
+func (msg MsgSubmitProposal) SpentCoins() sdk.Coins { return msg.InitialDeposit }
+
+// Implements SpendMsg.
+func (msg MsgSubmitProposal) Recipients() []sdk.AccAddress { return nil }
+
+// Implements NoValidatorMsg.
+func (msg MsgSubmitProposal) ActsOnNoValidator() {}
+
 // Implements Msg.
 func (msg MsgSubmitProposal) ValidateBasic() error {
 	return nil
@@ -66,6 +80,28 @@ func (msg MsgSubmitProposal) GetSignBytes() []byte {
 	return json.MustSort(b)
 }
 
+// Implements SignDocMsg.
+func (msg MsgSubmitProposal) SignDoc(config *sdk.AddrPrefixCfg) interface{} {
+	return proposalSignDoc{
+		Title:          msg.Title,
+		Description:    msg.Description,
+		ProposalType:   msg.ProposalType,
+		Proposer:       config.AccAddressString(msg.Proposer),
+		InitialDeposit: msg.InitialDeposit,
+		Params:         msg.Params,
+	}
+}
+
+// proposalSignDoc is a MsgSubmitProposal as signed
+type proposalSignDoc struct {
+	Title          string       `json:"title"`
+	Description    string       `json:"description"`
+	ProposalType   ProposalKind `json:"proposal_type"`
+	Proposer       string       `json:"proposer"`
+	InitialDeposit sdk.Coins    `json:"initial_deposit"`
+	Params         Params       `json:"params"`
+}
+
 // Implements Msg.
 func (msg MsgSubmitProposal) GetSigners() []sdk.AccAddress {
 	return []sdk.AccAddress{msg.Proposer}
@@ -91,6 +127,23 @@ func (msg MsgSubmitSoftwareUpgradeProposal) GetSignBytes() []byte {
 	return json.MustSort(b)
 }
 
+// Implements SignDocMsg, the embedded MsgSubmitProposal is signed as a field named after its type.
+func (msg MsgSubmitSoftwareUpgradeProposal) SignDoc(config *sdk.AddrPrefixCfg) interface{} {
+	return struct {
+		MsgSubmitProposal interface{}
+		Version           uint64  `json:"version"`
+		Software          string  `json:"software"`
+		SwitchHeight      uint64  `json:"switch_height"`
+		Threshold         sdk.Dec `json:"threshold"`
+	}{
+		MsgSubmitProposal: msg.MsgSubmitProposal.SignDoc(config),
+		Version:           msg.Version,
+		Software:          msg.Software,
+		SwitchHeight:      msg.SwitchHeight,
+		Threshold:         msg.Threshold,
+	}
+}
+
 type MsgSubmitCommunityTaxUsageProposal struct {
 	MsgSubmitProposal
 	Usage       UsageType      `json:"usage"`
@@ -110,6 +163,21 @@ func (msg MsgSubmitCommunityTaxUsageProposal) GetSignBytes() []byte {
 	return json.MustSort(b)
 }
 
+// Implements SignDocMsg, the embedded MsgSubmitProposal is signed as a field named after its type.
+func (msg MsgSubmitCommunityTaxUsageProposal) SignDoc(config *sdk.AddrPrefixCfg) interface{} {
+	return struct {
+		MsgSubmitProposal interface{}
+		Usage             UsageType `json:"usage"`
+		DestAddress       string    `json:"dest_address"`
+		Amount            sdk.Coins `json:"amount"`
+	}{
+		MsgSubmitProposal: msg.MsgSubmitProposal.SignDoc(config),
+		Usage:             msg.Usage,
+		DestAddress:       config.AccAddressString(msg.DestAddress),
+		Amount:            msg.Amount,
+	}
+}
+
 //-----------------------------------------------------------
 // MsgDeposit
 type MsgDeposit struct {
@@ -124,6 +192,15 @@ func (msg MsgDeposit) Route() string { return ModuleName }
 // nolint
 func (msg MsgDeposit) Type() string { return "deposit" }
 
+// Implements SpendMsg.
+func (msg MsgDeposit) SpentCoins() sdk.Coins { return msg.Amount }
+
+// Implements SpendMsg.
+func (msg MsgDeposit) Recipients() []sdk.AccAddress { return nil }
+
+// Implements NoValidatorMsg.
+func (msg MsgDeposit) ActsOnNoValidator() {}
+
 // Implements Msg.
 func (msg MsgDeposit) ValidateBasic() error {
 	if len(msg.Depositor) == 0 {
@@ -144,11 +221,38 @@ func (msg MsgDeposit) GetSignBytes() []byte {
 	return json.MustSort(b)
 }
 
+// Implements SignDocMsg.
+func (msg MsgDeposit) SignDoc(config *sdk.AddrPrefixCfg) interface{} {
+	return struct {
+		ProposalID uint64    `json:"proposal_id"`
+		Depositor  string    `json:"depositor"`
+		Amount     sdk.Coins `json:"amount"`
+	}{
+		ProposalID: msg.ProposalID,
+		Depositor:  config.AccAddressString(msg.Depositor),
+		Amount:     msg.Amount,
+	}
+}
+
 // Implements Msg.
 func (msg MsgDeposit) GetSigners() []sdk.AccAddress {
 	return []sdk.AccAddress{msg.Depositor}
 }
 
+// Implements ProtoMsg.
+func (msg MsgDeposit) ProtoTypeURL() string { return "/cosmos.gov.v1beta1.MsgDeposit" }
+
+// Implements ProtoMsg.
+func (msg MsgDeposit) MarshalProto(prefixes *sdk.AddrPrefixCfg) ([]byte, error) {
+	e := new(proto.Encoder).
+		Uint64(1, msg.ProposalID).
+		String(2, prefixes.AccAddressString(msg.Depositor))
+	for _, coin := range msg.Amount {
+		e.Message(3, sdk.MarshalProtoCoin(coin))
+	}
+	return e.Bytes(), nil
+}
+
 //-----------------------------------------------------------
 // MsgVote
 type MsgVote struct {
@@ -163,6 +267,15 @@ func (msg MsgVote) Route() string { return ModuleName }
 // nolint
 func (msg MsgVote) Type() string { return "vote" }
 
+// Implements NoSpendMsg.
+func (msg MsgVote) SpendsNoCoins() {}
+
+// Implements RecipientMsg.
+func (msg MsgVote) Recipients() []sdk.AccAddress { return nil }
+
+// Implements NoValidatorMsg.
+func (msg MsgVote) ActsOnNoValidator() {}
+
 // Implements Msg.
 func (msg MsgVote) ValidateBasic() error {
 	if len(msg.Voter) == 0 {
@@ -189,11 +302,36 @@ func (msg MsgVote) GetSignBytes() []byte {
 	return json.MustSort(b)
 }
 
+// Implements SignDocMsg.
+func (msg MsgVote) SignDoc(config *sdk.AddrPrefixCfg) interface{} {
+	return struct {
+		ProposalID uint64     `json:"proposal_id"`
+		Voter      string     `json:"voter"`
+		Option     VoteOption `json:"option"`
+	}{
+		ProposalID: msg.ProposalID,
+		Voter:      config.AccAddressString(msg.Voter),
+		Option:     msg.Option,
+	}
+}
+
 // Implements Msg.
 func (msg MsgVote) GetSigners() []sdk.AccAddress {
 	return []sdk.AccAddress{msg.Voter}
 }
 
+// Implements ProtoMsg, the options have the same values in the Cosmos SDK.
+func (msg MsgVote) ProtoTypeURL() string { return "/cosmos.gov.v1beta1.MsgVote" }
+
+// Implements ProtoMsg.
+func (msg MsgVote) MarshalProto(prefixes *sdk.AddrPrefixCfg) ([]byte, error) {
+	e := new(proto.Encoder).
+		Uint64(1, msg.ProposalID).
+		String(2, prefixes.AccAddressString(msg.Voter)).
+		Uint64(3, uint64(msg.Option))
+	return e.Bytes(), nil
+}
+
 // Type that represents VoteOption as a byte
 type VoteOption byte
 
@@ -264,14 +402,14 @@ func (t tallyResult) Convert() interface{} {
 
 //for query
 type vote struct {
-	Voter      sdk.AccAddress `json:"voter"`       //  address of the voter
-	ProposalID uint64         `json:"proposal_id"` //  proposalID of the proposal
-	Option     string         `json:"option"`      //  option from OptionSet chosen by the voter
+	Voter      string `json:"voter"`       //  address of the voter
+	ProposalID uint64 `json:"proposal_id"` //  proposalID of the proposal
+	Option     string `json:"option"`      //  option from OptionSet chosen by the voter
 }
 
 func (v vote) Convert() interface{} {
 	return rpc.Vote{
-		Voter:      v.Voter.String(),
+		Voter:      v.Voter,
 		ProposalID: v.ProposalID,
 		Option:     v.Option,
 	}
@@ -289,14 +427,14 @@ func (vs votes) Convert() interface{} {
 
 // deposit
 type deposit struct {
-	Depositor  sdk.AccAddress `json:"depositor"`   //  Address of the depositor
-	ProposalID uint64         `json:"proposal_id"` //  proposalID of the proposal
-	Amount     sdk.Coins      `json:"amount"`      //  deposit amount
+	Depositor  string    `json:"depositor"`   //  Address of the depositor
+	ProposalID uint64    `json:"proposal_id"` //  proposalID of the proposal
+	Amount     sdk.Coins `json:"amount"`      //  deposit amount
 }
 
 func (d deposit) Convert() interface{} {
 	return rpc.Deposit{
-		Depositor:  d.Depositor.String(),
+		Depositor:  d.Depositor,
 		ProposalID: d.ProposalID,
 		Amount:     d.Amount,
 	}
@@ -312,6 +450,152 @@ func (ds deposits) Convert() interface{} {
 	return deposits
 }
 
+// protoProposalStatuses are the names of the values of cosmos.gov.v1beta1.ProposalStatus
+var protoProposalStatuses = []string{"", "DepositPeriod", "VotingPeriod", "Passed", "Rejected", "Failed"}
+
+// protoProposalStatus returns the cosmos.gov.v1beta1.ProposalStatus of a status name, 0 for all the statuses
+func protoProposalStatus(status string) (uint64, error) {
+	for i, name := range protoProposalStatuses {
+		if name == status {
+			return uint64(i), nil
+		}
+	}
+	return 0, fmt.Errorf("invalid proposal status %s", status)
+}
+
+// unmarshalProtoProposal decodes a cosmos.gov.v1beta1.Proposal
+func unmarshalProtoProposal(bz []byte) (rpc.BasicProposal, error) {
+	var p rpc.BasicProposal
+	fields, err := proto.Decode(bz)
+	if err != nil {
+		return p, err
+	}
+	for _, f := range fields {
+		switch f.Number {
+		case 1:
+			p.ProposalID = f.Varint
+		case 2:
+			err = unmarshalProtoContent(f.Value, &p)
+		case 3:
+			if f.Varint < uint64(len(protoProposalStatuses)) {
+				p.ProposalStatus = protoProposalStatuses[f.Varint]
+			}
+		case 4:
+			p.TallyResult, err = unmarshalProtoTally(f.Value)
+		case 5:
+			p.SubmitTime, err = proto.Timestamp(f.Value)
+		case 6:
+			p.DepositEndTime, err = proto.Timestamp(f.Value)
+		case 7:
+			var coin sdk.Coin
+			if coin, err = sdk.UnmarshalProtoCoin(f.Value); err == nil {
+				p.TotalDeposit = p.TotalDeposit.Add(coin)
+			}
+		case 8:
+			p.VotingStartTime, err = proto.Timestamp(f.Value)
+		case 9:
+			p.VotingEndTime, err = proto.Timestamp(f.Value)
+		}
+		if err != nil {
+			return rpc.BasicProposal{}, err
+		}
+	}
+	return p, nil
+}
+
+// unmarshalProtoContent decodes the google.protobuf.Any content of a proposal into p, its type is the name of the
+// message without the Proposal suffix, PlainText for a cosmos.gov.v1beta1.TextProposal
+func unmarshalProtoContent(bz []byte, p *rpc.BasicProposal) error {
+	typeURL, value, err := proto.DecodeAny(bz)
+	if err != nil {
+		return err
+	}
+	p.ProposalType = strings.TrimSuffix(typeURL[strings.LastIndex(typeURL, ".")+1:], "Proposal")
+	if typeURL == "/cosmos.gov.v1beta1.TextProposal" {
+		p.ProposalType = "PlainText"
+	}
+
+	fields, err := proto.Decode(value)
+	if err != nil {
+		return err
+	}
+	for _, f := range fields {
+		switch f.Number {
+		case 1:
+			p.Title = string(f.Value)
+		case 2:
+			p.Description = string(f.Value)
+		}
+	}
+	return nil
+}
+
+// unmarshalProtoTally decodes a cosmos.gov.v1beta1.TallyResult
+func unmarshalProtoTally(bz []byte) (rpc.TallyResult, error) {
+	var t rpc.TallyResult
+	fields, err := proto.Decode(bz)
+	if err != nil {
+		return t, err
+	}
+	for _, f := range fields {
+		switch f.Number {
+		case 1:
+			t.Yes = string(f.Value)
+		case 2:
+			t.Abstain = string(f.Value)
+		case 3:
+			t.No = string(f.Value)
+		case 4:
+			t.NoWithVeto = string(f.Value)
+		}
+	}
+	return t, nil
+}
+
+// unmarshalProtoVote decodes a cosmos.gov.v1beta1.Vote
+func unmarshalProtoVote(bz []byte) (rpc.Vote, error) {
+	var v rpc.Vote
+	fields, err := proto.Decode(bz)
+	if err != nil {
+		return v, err
+	}
+	for _, f := range fields {
+		switch f.Number {
+		case 1:
+			v.ProposalID = f.Varint
+		case 2:
+			v.Voter = string(f.Value)
+		case 3:
+			v.Option = VoteOption(f.Varint).String()
+		}
+	}
+	return v, nil
+}
+
+// unmarshalProtoDeposit decodes a cosmos.gov.v1beta1.Deposit
+func unmarshalProtoDeposit(bz []byte) (rpc.Deposit, error) {
+	var d rpc.Deposit
+	fields, err := proto.Decode(bz)
+	if err != nil {
+		return d, err
+	}
+	for _, f := range fields {
+		switch f.Number {
+		case 1:
+			d.ProposalID = f.Varint
+		case 2:
+			d.Depositor = string(f.Value)
+		case 3:
+			coin, err := sdk.UnmarshalProtoCoin(f.Value)
+			if err != nil {
+				return rpc.Deposit{}, err
+			}
+			d.Amount = d.Amount.Add(coin)
+		}
+	}
+	return d, nil
+}
+
 func registerCodec(cdc sdk.Codec) {
 	cdc.RegisterConcrete(MsgSubmitProposal{}, "irishub/gov/MsgSubmitProposal")
 	cdc.RegisterConcrete(MsgSubmitCommunityTaxUsageProposal{}, "irishub/gov/MsgSubmitCommunityTaxUsageProposal")
@@ -14,4 +14,10 @@
 //	require.NotEmpty(client.T(), address)
 //	require.NotEmpty(client.T(), mnemonic)
 //
+// Derive the first three accounts of another seed, protected by a BIP39 passphrase.
+//
+//	accounts, err := client.Keys().RecoverAccounts(password, mnemonic,
+//		types.HDOptions{Account: 0, Index: 0, BIP39Passphrase: "secret"}, "acc0", "acc1", "acc2")
+//	require.NoError(client.T(), err)
+//
 package keys
@@ -1,6 +1,8 @@
 package keys
 
 import (
+	"time"
+
 	"github.com/irisnet/irishub-sdk-go/rpc"
 	sdk "github.com/irisnet/irishub-sdk-go/types"
 )
@@ -9,27 +11,102 @@ const ModuleName = "keys"
 
 type keysClient struct {
 	sdk.KeyManager
+	prefixes *sdk.AddrPrefixCfg
+	forget   func(names ...string)
 }
 
-func Create(keyManager sdk.KeyManager) rpc.Keys {
+// Create returns the keys module, whose addresses and public keys use the given prefixes.
+// forget is called with the names of the deleted and renamed keys, e.g. to remove their cached addresses.
+func Create(keyManager sdk.KeyManager, prefixes *sdk.AddrPrefixCfg, forget func(names ...string)) rpc.Keys {
+	if forget == nil {
+		forget = func(...string) {}
+	}
 	return keysClient{
 		KeyManager: keyManager,
+		prefixes:   prefixes,
+		forget:     forget,
 	}
 }
 
 func (k keysClient) Add(name, password string) (string, string, sdk.Error) {
-	address, mnemonic, err := k.Insert(name, password)
-	return address, mnemonic, sdk.Wrap(err)
+	address, mnemonic, err := k.KeyManager.Insert(name, password)
+	return k.address(address), mnemonic, sdk.Wrap(err)
+}
+
+func (k keysClient) AddWithOptions(name, password string, opts sdk.HDOptions) (string, string, sdk.Error) {
+	km, ok := k.KeyManager.(sdk.HDKeyManager)
+	if !ok {
+		return "", "", unsupported("AddWithOptions")
+	}
+	address, mnemonic, err := km.InsertWithOptions(name, password, opts)
+	return k.address(address), mnemonic, sdk.Wrap(err)
 }
 
 func (k keysClient) Recover(name, password, mnemonic string) (string, sdk.Error) {
 	address, err := k.KeyManager.Recover(name, password, mnemonic)
-	return address, sdk.Wrap(err)
+	return k.address(address), sdk.Wrap(err)
+}
+
+func (k keysClient) RecoverWithOptions(name, password, mnemonic string, opts sdk.HDOptions) (string, sdk.Error) {
+	km, ok := k.KeyManager.(sdk.HDKeyManager)
+	if !ok {
+		return "", unsupported("RecoverWithOptions")
+	}
+	address, err := km.RecoverWithOptions(name, password, mnemonic, opts)
+	return k.address(address), sdk.Wrap(err)
+}
+
+func (k keysClient) RecoverAccounts(password, mnemonic string, opts sdk.HDOptions, names ...string) ([]sdk.DerivedAccount, sdk.Error) {
+	km, ok := k.KeyManager.(sdk.HDKeyManager)
+	if !ok {
+		return nil, unsupported("RecoverAccounts")
+	}
+	accounts, err := km.RecoverAccounts(password, mnemonic, opts, names...)
+	for i := range accounts {
+		accounts[i].Address = k.address(accounts[i].Address)
+	}
+	return accounts, sdk.Wrap(err)
 }
 
 func (k keysClient) Import(name, password, keystore string) (string, sdk.Error) {
 	address, err := k.KeyManager.Import(name, password, keystore)
-	return address, sdk.Wrap(err)
+	return k.address(address), sdk.Wrap(err)
+}
+
+func (k keysClient) ImportPrivKey(name, password, privKey string) (string, sdk.Error) {
+	km, ok := k.KeyManager.(sdk.KeyImporter)
+	if !ok {
+		return "", unsupported("ImportPrivKey")
+	}
+	address, err := km.ImportPrivKey(name, password, privKey)
+	return k.address(address), sdk.Wrap(err)
+}
+
+func (k keysClient) ImportArmor(name, password, armor, passphrase string) (string, sdk.Error) {
+	km, ok := k.KeyManager.(sdk.ArmorKeyManager)
+	if !ok {
+		return "", unsupported("ImportArmor")
+	}
+	address, err := km.ImportArmor(name, password, armor, passphrase)
+	return k.address(address), sdk.Wrap(err)
+}
+
+func (k keysClient) RecoverFromShares(name, password string, shares []string) (string, sdk.Error) {
+	km, ok := k.KeyManager.(sdk.ShareKeyManager)
+	if !ok {
+		return "", unsupported("RecoverFromShares")
+	}
+	address, err := km.RecoverFromShares(name, password, shares)
+	return k.address(address), sdk.Wrap(err)
+}
+
+func (k keysClient) AddWatchOnly(name, address, pubKey string) (string, sdk.Error) {
+	km, ok := k.KeyManager.(sdk.KeyImporter)
+	if !ok {
+		return "", unsupported("AddWatchOnly")
+	}
+	address, err := km.AddWatchOnly(name, address, pubKey)
+	return k.address(address), sdk.Wrap(err)
 }
 
 func (k keysClient) Export(name, srcPwd, dstPwd string) (string, sdk.Error) {
@@ -37,8 +114,27 @@ func (k keysClient) Export(name, srcPwd, dstPwd string) (string, sdk.Error) {
 	return keystore, sdk.Wrap(err)
 }
 
+func (k keysClient) ExportArmor(name, password, passphrase string) (string, sdk.Error) {
+	km, ok := k.KeyManager.(sdk.ArmorKeyManager)
+	if !ok {
+		return "", unsupported("ExportArmor")
+	}
+	armor, err := km.ExportArmor(name, password, passphrase)
+	return armor, sdk.Wrap(err)
+}
+
+func (k keysClient) BackupShares(name, password string, threshold, shares int) ([]string, sdk.Error) {
+	km, ok := k.KeyManager.(sdk.ShareKeyManager)
+	if !ok {
+		return nil, unsupported("BackupShares")
+	}
+	result, err := km.BackupShares(name, password, threshold, shares)
+	return result, sdk.Wrap(err)
+}
+
 func (k keysClient) Delete(name string) sdk.Error {
 	err := k.KeyManager.Delete(name)
+	k.forget(name)
 	return sdk.Wrap(err)
 }
 
@@ -47,7 +143,47 @@ func (k keysClient) Show(name string) (string, sdk.Error) {
 	if err != nil {
 		return "", sdk.Wrap(err)
 	}
-	return address.String(), nil
+	return k.prefixes.AccAddressString(address), nil
+}
+
+func (k keysClient) ShowPubKey(name, password string) (string, sdk.Error) {
+	km, ok := k.KeyManager.(sdk.PubKeyQuerier)
+	if !ok {
+		return "", unsupported("ShowPubKey")
+	}
+	pubKey, err := km.QueryPubKey(name, password)
+	return k.pubKey(pubKey), sdk.Wrap(err)
+}
+
+func (k keysClient) List() ([]sdk.KeyInfo, sdk.Error) {
+	km, ok := k.KeyManager.(sdk.KeyLister)
+	if !ok {
+		return nil, unsupported("List")
+	}
+	infos, err := km.List()
+	for i := range infos {
+		infos[i].Address = k.address(infos[i].Address)
+	}
+	return infos, sdk.Wrap(err)
+}
+
+func (k keysClient) Rename(name, newName string) sdk.Error {
+	km, ok := k.KeyManager.(sdk.KeyEditor)
+	if !ok {
+		return unsupported("Rename")
+	}
+	err := km.Rename(name, newName)
+	k.forget(name, newName)
+	return sdk.Wrap(err)
+}
+
+func (k keysClient) ChangePassword(name, password, newPassword string) sdk.Error {
+	km, ok := k.KeyManager.(sdk.KeyEditor)
+	if !ok {
+		return unsupported("ChangePassword")
+	}
+	err := km.ChangePassword(name, password, newPassword)
+	return sdk.Wrap(err)
 }
 
 func (k keysClient) RegisterCodec(_ sdk.Codec) {
@@ -57,3 +193,51 @@ func (k keysClient) RegisterCodec(_ sdk.Codec) {
 func (k keysClient) Name() string {
 	return ModuleName
 }
+
+func (k keysClient) Unlock(name, password string, timeout time.Duration) sdk.Error {
+	km, ok := k.KeyManager.(sdk.KeySessions)
+	if !ok {
+		return unsupported("Unlock")
+	}
+	return sdk.Wrap(km.Unlock(name, password, timeout))
+}
+
+// Lock does nothing when the KeyManager does not keep the keys in memory
+func (k keysClient) Lock(name string) {
+	if km, ok := k.KeyManager.(sdk.KeySessions); ok {
+		km.Lock(name)
+	}
+}
+
+func (k keysClient) LockAll() {
+	if km, ok := k.KeyManager.(sdk.KeySessions); ok {
+		km.LockAll()
+	}
+}
+
+// unsupported returns the error of a method whose optional interface is not implemented by the KeyManager
+func unsupported(method string) sdk.Error {
+	return sdk.WrapWithMessage(sdk.ErrKeyManagerUnsupported, "%s", method)
+}
+
+// address returns an address returned by the KeyManager with the account prefix of the client, whatever its prefix
+func (k keysClient) address(address string) string {
+	bz, err := sdk.Bech32Bytes(address)
+	if err != nil {
+		return address
+	}
+	return k.prefixes.AccAddressString(bz)
+}
+
+// pubKey returns a public key returned by the KeyManager with the account public key prefix of the client, whatever its prefix
+func (k keysClient) pubKey(pubKey string) string {
+	pk, err := sdk.PubKeyFromBech32(pubKey)
+	if err != nil {
+		return pubKey
+	}
+	bech32PubKey, err := k.prefixes.Bech32ifyAccPub(pk)
+	if err != nil {
+		return pubKey
+	}
+	return bech32PubKey
+}
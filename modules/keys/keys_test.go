@@ -3,10 +3,13 @@ package keys_test
 import (
 	"github.com/stretchr/testify/require"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/suite"
 
+	"github.com/irisnet/irishub-sdk-go/crypto"
 	"github.com/irisnet/irishub-sdk-go/test"
+	"github.com/irisnet/irishub-sdk-go/types"
 )
 
 type KeysTestSuite struct {
@@ -53,3 +56,255 @@ func (kts *KeysTestSuite) TestKeys() {
 	require.NoError(kts.T(), err)
 	require.Equal(kts.T(), address, address3)
 }
+
+func (kts *KeysTestSuite) TestHDOptions() {
+	name, password := kts.RandStringOfLength(20), kts.RandStringOfLength(8)
+
+	address, mnemonic, err := kts.Keys().AddWithOptions(name, password, types.HDOptions{Index: 1})
+	require.NoError(kts.T(), err)
+	require.NoError(kts.T(), kts.Keys().Delete(name))
+
+	address1, err := kts.Keys().Recover(name, password, mnemonic)
+	require.NoError(kts.T(), err)
+	require.NotEqual(kts.T(), address, address1)
+	require.NoError(kts.T(), kts.Keys().Delete(name))
+
+	address2, err := kts.Keys().RecoverWithOptions(name, password, mnemonic, types.HDOptions{HDPath: "44'/118'/0'/0/1"})
+	require.NoError(kts.T(), err)
+	require.Equal(kts.T(), address, address2)
+	require.NoError(kts.T(), kts.Keys().Delete(name))
+
+	address3, err := kts.Keys().RecoverWithOptions(name, password, mnemonic, types.HDOptions{Index: 1, BIP39Passphrase: "passphrase"})
+	require.NoError(kts.T(), err)
+	require.NotEqual(kts.T(), address, address3)
+	require.NoError(kts.T(), kts.Keys().Delete(name))
+
+	_, err = kts.Keys().RecoverWithOptions(name, password, mnemonic, types.HDOptions{HDPath: "44'/118'/0'/2/0"})
+	require.Error(kts.T(), err)
+}
+
+func (kts *KeysTestSuite) TestRecoverAccounts() {
+	password, mnemonic := kts.RandStringOfLength(8), test.Mnemonic
+
+	names := []string{kts.RandStringOfLength(20), kts.RandStringOfLength(20), kts.RandStringOfLength(20)}
+	accounts, err := kts.Keys().RecoverAccounts(password, mnemonic, types.HDOptions{}, names...)
+	require.NoError(kts.T(), err)
+	require.Len(kts.T(), accounts, len(names))
+	require.Equal(kts.T(), "44'/118'/0'/0/0", accounts[0].HDPath)
+	require.Equal(kts.T(), "44'/118'/0'/0/2", accounts[2].HDPath)
+	for i, acc := range accounts {
+		require.Equal(kts.T(), names[i], acc.Name)
+		addr, err := kts.Keys().Show(acc.Name)
+		require.NoError(kts.T(), err)
+		require.Equal(kts.T(), acc.Address, addr)
+		require.NoError(kts.T(), kts.Keys().Delete(acc.Name))
+	}
+
+	name := kts.RandStringOfLength(20)
+	for i, acc := range accounts {
+		address, err := kts.Keys().RecoverWithOptions(name, password, mnemonic, types.HDOptions{Index: uint32(i)})
+		require.NoError(kts.T(), err)
+		require.Equal(kts.T(), acc.Address, address)
+		require.NoError(kts.T(), kts.Keys().Delete(name))
+	}
+
+	_, err = kts.Keys().RecoverAccounts(password, mnemonic, types.HDOptions{})
+	require.Error(kts.T(), err)
+}
+
+func (kts *KeysTestSuite) TestKeyManagement() {
+	name, password := kts.RandStringOfLength(20), kts.RandStringOfLength(8)
+	privKey := "2b8f7bd1a7b3d1eb4c8cb1cbe1d0e8b1f8a1e6f4b5c2d7a9e0f1a2b3c4d5e6f7"
+
+	address, err := kts.Keys().ImportPrivKey(name, password, privKey)
+	require.NoError(kts.T(), err)
+	_, err = kts.Keys().ImportPrivKey(name, password, privKey)
+	require.Error(kts.T(), err)
+
+	pubKey, err := kts.Keys().ShowPubKey(name, password)
+	require.NoError(kts.T(), err)
+	pk, e := types.GetAccPubKeyBech32(pubKey)
+	require.NoError(kts.T(), e)
+	require.Equal(kts.T(), address, types.AccAddress(pk.Address()).String())
+
+	infos, err := kts.Keys().List()
+	require.NoError(kts.T(), err)
+	require.Contains(kts.T(), infos, types.KeyInfo{Name: name, Address: address, StoreType: types.PrivKey})
+
+	newName := kts.RandStringOfLength(20)
+	require.NoError(kts.T(), kts.Keys().Rename(name, newName))
+	_, err = kts.Keys().Show(name)
+	require.Error(kts.T(), err)
+	address1, err := kts.Keys().Show(newName)
+	require.NoError(kts.T(), err)
+	require.Equal(kts.T(), address, address1)
+
+	newPwd := kts.RandStringOfLength(8)
+	require.Error(kts.T(), kts.Keys().ChangePassword(newName, newPwd, newPwd))
+	require.NoError(kts.T(), kts.Keys().ChangePassword(newName, password, newPwd))
+	pubKey1, err := kts.Keys().ShowPubKey(newName, newPwd)
+	require.NoError(kts.T(), err)
+	require.Equal(kts.T(), pubKey, pubKey1)
+
+	armor, err := kts.Keys().ExportArmor(newName, newPwd, "passphrase")
+	require.NoError(kts.T(), err)
+	armorName := kts.RandStringOfLength(20)
+	_, err = kts.Keys().ImportArmor(armorName, password, armor, "wrong passphrase")
+	require.Error(kts.T(), err)
+	address2, err := kts.Keys().ImportArmor(armorName, password, armor, "passphrase")
+	require.NoError(kts.T(), err)
+	require.Equal(kts.T(), address, address2)
+
+	require.NoError(kts.T(), kts.Keys().Delete(newName))
+	require.NoError(kts.T(), kts.Keys().Delete(armorName))
+}
+
+func (kts *KeysTestSuite) TestCachedAddress() {
+	name, newName, password := kts.RandStringOfLength(20), kts.RandStringOfLength(20), kts.RandStringOfLength(8)
+	queryAddress := func(name string) string {
+		address, err := kts.BaseClient().QueryAddress(name)
+		require.NoError(kts.T(), err)
+		return address.String()
+	}
+
+	address, _, err := kts.Keys().Add(name, password)
+	require.NoError(kts.T(), err)
+	require.Equal(kts.T(), address, queryAddress(name))
+
+	// the name is used again by another key after the deletion
+	require.NoError(kts.T(), kts.Keys().Delete(name))
+	address1, _, err := kts.Keys().Add(name, password)
+	require.NoError(kts.T(), err)
+	require.NotEqual(kts.T(), address, address1)
+	require.Equal(kts.T(), address1, queryAddress(name))
+
+	// and after the renaming
+	require.NoError(kts.T(), kts.Keys().Rename(name, newName))
+	require.Equal(kts.T(), address1, queryAddress(newName))
+	address2, _, err := kts.Keys().Add(name, password)
+	require.NoError(kts.T(), err)
+	require.Equal(kts.T(), address2, queryAddress(name))
+
+	require.NoError(kts.T(), kts.Keys().Delete(name))
+	require.NoError(kts.T(), kts.Keys().Delete(newName))
+}
+
+func (kts *KeysTestSuite) TestUnlock() {
+	name, password := kts.RandStringOfLength(20), kts.RandStringOfLength(8)
+	address, _, err := kts.Keys().Add(name, password)
+	require.NoError(kts.T(), err)
+	defer func() {
+		_ = kts.Keys().Delete(name)
+	}()
+
+	amount, e := types.ParseDecCoins("0.1iris")
+	require.NoError(kts.T(), e)
+	funds, e := types.ParseDecCoins("5iris")
+	require.NoError(kts.T(), e)
+	_, err = kts.Bank().Send(address, funds, types.BaseTx{
+		From:     kts.Account().Name,
+		Gas:      20000,
+		Mode:     types.Commit,
+		Password: kts.Account().Password,
+	})
+	require.NoError(kts.T(), err)
+	// the key signs without password while it is unlocked
+	send := func() error {
+		_, err := kts.Bank().Send(address, amount, types.BaseTx{From: name, Gas: 20000, Mode: types.Commit})
+		return err
+	}
+
+	// the public key is stored with the key, it is returned without password
+	pubKey, err := kts.Keys().ShowPubKey(name, "")
+	require.NoError(kts.T(), err)
+	pubKey1, err := kts.Keys().ShowPubKey(name, password)
+	require.NoError(kts.T(), err)
+	require.Equal(kts.T(), pubKey1, pubKey)
+
+	require.Error(kts.T(), send())
+	require.Error(kts.T(), kts.Keys().Unlock(name, "wrong password", 0))
+
+	require.NoError(kts.T(), kts.Keys().Unlock(name, password, 0))
+	require.NoError(kts.T(), send())
+
+	kts.Keys().Lock(name)
+	require.Error(kts.T(), send())
+
+	require.NoError(kts.T(), kts.Keys().Unlock(name, password, 100*time.Millisecond))
+	require.NoError(kts.T(), send())
+	time.Sleep(300 * time.Millisecond)
+	require.Error(kts.T(), send())
+}
+
+func (kts *KeysTestSuite) TestShares() {
+	name, password := kts.RandStringOfLength(20), kts.RandStringOfLength(8)
+	opts := types.HDOptions{Index: 1, BIP39Passphrase: "secret", KeepMnemonic: true}
+	address, mnemonic, err := kts.Keys().AddWithOptions(name, password, opts)
+	require.NoError(kts.T(), err)
+
+	// the keybase keeps the mnemonic of the key with its HD path and passphrase, they are split
+	shares, err := kts.Keys().BackupShares(name, password, 2, 3)
+	require.NoError(kts.T(), err)
+	require.Len(kts.T(), shares, 3)
+	secret, e := crypto.CombineShares(shares[:2])
+	require.NoError(kts.T(), e)
+	require.Equal(kts.T(), crypto.Secret{
+		Kind:            crypto.ShareMnemonic,
+		Mnemonic:        mnemonic,
+		BIP39Passphrase: opts.BIP39Passphrase,
+		HDPath:          "44'/118'/0'/0/1",
+	}, secret)
+	_, err = kts.Keys().BackupShares(name, "wrong password", 2, 3)
+	require.Error(kts.T(), err)
+
+	// the mnemonic is encrypted with the new password
+	newPassword := kts.RandStringOfLength(8)
+	require.NoError(kts.T(), kts.Keys().ChangePassword(name, password, newPassword))
+	_, err = kts.Keys().BackupShares(name, password, 2, 3)
+	require.Error(kts.T(), err)
+	shares, err = kts.Keys().BackupShares(name, newPassword, 2, 3)
+	require.NoError(kts.T(), err)
+	require.NoError(kts.T(), kts.Keys().Delete(name))
+
+	_, err = kts.Keys().RecoverFromShares(name, password, shares[:1])
+	require.Error(kts.T(), err)
+	address1, err := kts.Keys().RecoverFromShares(name, password, shares[1:])
+	require.NoError(kts.T(), err)
+	require.Equal(kts.T(), address, address1)
+
+	// the key recovered from the shares keeps the mnemonic too
+	shares, err = kts.Keys().BackupShares(name, password, 3, 5)
+	require.NoError(kts.T(), err)
+	secret, e = crypto.CombineShares([]string{shares[4], shares[0], shares[2]})
+	require.NoError(kts.T(), e)
+	require.Equal(kts.T(), mnemonic, secret.Mnemonic)
+	require.NoError(kts.T(), kts.Keys().Delete(name))
+
+	// the mnemonic is not kept unless the caller opts in, the private key is split
+	opts.KeepMnemonic = false
+	_, err = kts.Keys().RecoverWithOptions(name, password, mnemonic, opts)
+	require.NoError(kts.T(), err)
+	shares, err = kts.Keys().BackupShares(name, password, 2, 3)
+	require.NoError(kts.T(), err)
+	secret, e = crypto.CombineShares(shares[:2])
+	require.NoError(kts.T(), e)
+	require.Equal(kts.T(), crypto.SharePrivKey, secret.Kind)
+	require.NoError(kts.T(), kts.Keys().Delete(name))
+
+	// the mnemonic of an imported key is unknown, its private key is split
+	privKey, e := crypto.NewMnemonicKeyManagerWithHDPath(mnemonic, opts.BIP39Passphrase, "44'/118'/0'/0/1")
+	require.NoError(kts.T(), e)
+	hexKey, e := privKey.ExportAsPrivateKey()
+	require.NoError(kts.T(), e)
+	_, err = kts.Keys().ImportPrivKey(name, password, hexKey)
+	require.NoError(kts.T(), err)
+	shares, err = kts.Keys().BackupShares(name, password, 2, 3)
+	require.NoError(kts.T(), err)
+	secret, e = crypto.CombineShares(shares[1:])
+	require.NoError(kts.T(), e)
+	require.Equal(kts.T(), crypto.SharePrivKey, secret.Kind)
+	address2, err := kts.Keys().RecoverFromShares(kts.RandStringOfLength(20), password, shares[:2])
+	require.NoError(kts.T(), err)
+	require.Equal(kts.T(), address, address2)
+	require.NoError(kts.T(), kts.Keys().Delete(name))
+}
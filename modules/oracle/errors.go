@@ -0,0 +1,25 @@
+package oracle
+
+import (
+	sdk "github.com/irisnet/irishub-sdk-go/types"
+)
+
+// Codespace is the codespace of the errors of the oracle module
+const Codespace = ModuleName
+
+// the errors returned by the oracle module of IRIShub 1.x
+var (
+	ErrUnknownFeedName          = sdk.RegisterError(Codespace, 2, "unknown feed")
+	ErrInvalidFeedName          = sdk.RegisterError(Codespace, 3, "invalid feed name")
+	ErrExistedFeedName          = sdk.RegisterError(Codespace, 4, "feed already exists")
+	ErrUnauthorized             = sdk.RegisterError(Codespace, 5, "unauthorized owner")
+	ErrInvalidServiceName       = sdk.RegisterError(Codespace, 6, "invalid service name")
+	ErrInvalidDescription       = sdk.RegisterError(Codespace, 7, "invalid description")
+	ErrNotRegisterFunc          = sdk.RegisterError(Codespace, 8, "method not registered")
+	ErrInvalidFeedState         = sdk.RegisterError(Codespace, 9, "invalid state of the feed")
+	ErrInvalidServiceFeeCap     = sdk.RegisterError(Codespace, 10, "invalid service fee cap")
+	ErrInvalidResponseThreshold = sdk.RegisterError(Codespace, 11, "invalid response threshold")
+	ErrInvalidLatestHistory     = sdk.RegisterError(Codespace, 12, "invalid latest history")
+)
+
+// IRIShub 0.x has no oracle module, so no error of 0.x is registered here
@@ -8,7 +8,8 @@ import (
 
 type oracleClient struct {
 	sdk.BaseClient
-	*log.Logger
+	log.Logger
+	queries *sdk.VersionedQueries
 }
 
 func (o oracleClient) RegisterCodec(cdc sdk.Codec) {
@@ -20,9 +21,13 @@ func (o oracleClient) Name() string {
 }
 
 func Create(ac sdk.BaseClient) rpc.Oracle {
+	queries := new(sdk.VersionedQueries).
+		Register(sdk.V017, querierV017{ac}).
+		Register(sdk.V100, querierV100{ac})
 	return oracleClient{
 		BaseClient: ac,
 		Logger:     ac.Logger(),
+		queries:    queries,
 	}
 }
 
@@ -35,7 +40,7 @@ func (o oracleClient) CreateFeed(request rpc.FeedCreateRequest) (sdk.ResultTx, s
 
 	var providers []sdk.AccAddress
 	for _, provider := range request.Providers {
-		p, err := sdk.AccAddressFromBech32(provider)
+		p, err := o.AddrPrefixCfg().AccAddressFromBech32(provider)
 		if err != nil {
 			return sdk.ResultTx{}, sdk.Wrapf("%s invalid address", p)
 		}
@@ -88,7 +93,7 @@ func (o oracleClient) CreateAndStartFeed(request rpc.FeedCreateRequest) (sdk.Res
 
 	var providers []sdk.AccAddress
 	for _, provider := range request.Providers {
-		p, err := sdk.AccAddressFromBech32(provider)
+		p, err := o.AddrPrefixCfg().AccAddressFromBech32(provider)
 		if err != nil {
 			return sdk.ResultTx{}, sdk.Wrapf("%s invalid address", p)
 		}
@@ -146,7 +151,7 @@ func (o oracleClient) EditFeed(request rpc.FeedEditRequest) (sdk.ResultTx, sdk.E
 
 	var providers []sdk.AccAddress
 	for _, provider := range request.Providers {
-		p, err := sdk.AccAddressFromBech32(provider)
+		p, err := o.AddrPrefixCfg().AccAddressFromBech32(provider)
 		if err != nil {
 			return sdk.ResultTx{}, sdk.Wrapf("%s invalid address", p)
 		}
@@ -174,47 +179,17 @@ func (o oracleClient) EditFeed(request rpc.FeedEditRequest) (sdk.ResultTx, sdk.E
 
 //QueryFeed return the feed by feedName
 func (o oracleClient) QueryFeed(feedName string) (rpc.FeedContext, sdk.Error) {
-	param := struct {
-		FeedName string
-	}{
-		FeedName: feedName,
-	}
-
-	var ctx feedContext
-	if err := o.QueryWithResponse("custom/oracle/feed", param, &ctx); err != nil {
-		return rpc.FeedContext{}, sdk.Wrap(err)
-	}
-	return ctx.Convert().(rpc.FeedContext), nil
+	return o.querier().queryFeed(feedName)
 }
 
 //QueryFeeds return all feeds by state
 func (o oracleClient) QueryFeeds(state string) ([]rpc.FeedContext, sdk.Error) {
-	param := struct {
-		State string
-	}{
-		State: state,
-	}
-
-	var fcs feedContexts
-	if err := o.QueryWithResponse("custom/oracle/feeds", param, &fcs); err != nil {
-		return nil, sdk.Wrap(err)
-	}
-	return fcs.Convert().([]rpc.FeedContext), nil
+	return o.querier().queryFeeds(state)
 }
 
 //QueryFeedValue return all feed values by feedName
 func (o oracleClient) QueryFeedValue(feedName string) ([]rpc.FeedValue, sdk.Error) {
-	param := struct {
-		FeedName string
-	}{
-		FeedName: feedName,
-	}
-
-	var fvs feedValues
-	if err := o.QueryWithResponse("custom/oracle/feedValue", param, &fvs); err != nil {
-		return nil, sdk.Wrap(err)
-	}
-	return fvs.Convert().([]rpc.FeedValue), nil
+	return o.querier().queryFeedValue(feedName)
 }
 
 func (o oracleClient) SubscribeFeedValue(feedName string, handler func(value rpc.FeedValue)) sdk.Error {
@@ -235,8 +210,7 @@ func (o oracleClient) SubscribeFeedValue(feedName string, handler func(value rpc
 	}
 
 	handleResult := func(value string, sub1, sub2 sdk.Subscription) {
-		o.Info().Str("feed-value", value).
-			Msg("received feed value")
+		o.Info("received feed value", "feed-value", value)
 		var fv feedValue
 		if err := cdc.UnmarshalJSON([]byte(value), &fv); err == nil {
 			handler(fv.Convert().(rpc.FeedValue))
@@ -270,3 +244,77 @@ func (o oracleClient) SubscribeFeedValue(feedName string, handler func(value rpc
 	})
 	return err
 }
+
+// querier returns the queries of the version of the chain
+func (o oracleClient) querier() querier {
+	return o.queries.Route(o.ChainVersion()).(querier)
+}
+
+// querier is implemented by the queries of each version of the chain
+type querier interface {
+	queryFeed(feedName string) (rpc.FeedContext, sdk.Error)
+	queryFeeds(state string) ([]rpc.FeedContext, sdk.Error)
+	queryFeedValue(feedName string) ([]rpc.FeedValue, sdk.Error)
+}
+
+type querierV017 struct {
+	sdk.BaseClient
+}
+
+func (o querierV017) queryFeed(feedName string) (rpc.FeedContext, sdk.Error) {
+	param := struct {
+		FeedName string
+	}{
+		FeedName: feedName,
+	}
+
+	var ctx feedContext
+	if err := o.QueryWithResponse("custom/oracle/feed", param, &ctx); err != nil {
+		return rpc.FeedContext{}, sdk.Wrap(err)
+	}
+	return ctx.Convert().(rpc.FeedContext), nil
+}
+
+func (o querierV017) queryFeeds(state string) ([]rpc.FeedContext, sdk.Error) {
+	param := struct {
+		State string
+	}{
+		State: state,
+	}
+
+	var fcs feedContexts
+	if err := o.QueryWithResponse("custom/oracle/feeds", param, &fcs); err != nil {
+		return nil, sdk.Wrap(err)
+	}
+	return fcs.Convert().([]rpc.FeedContext), nil
+}
+
+func (o querierV017) queryFeedValue(feedName string) ([]rpc.FeedValue, sdk.Error) {
+	param := struct {
+		FeedName string
+	}{
+		FeedName: feedName,
+	}
+
+	var fvs feedValues
+	if err := o.QueryWithResponse("custom/oracle/feedValue", param, &fvs); err != nil {
+		return nil, sdk.Wrap(err)
+	}
+	return fvs.Convert().([]rpc.FeedValue), nil
+}
+
+type querierV100 struct {
+	sdk.BaseClient
+}
+
+func (o querierV100) queryFeed(feedName string) (rpc.FeedContext, sdk.Error) {
+	return rpc.FeedContext{}, sdk.UnsupportedByProtobuf("oracle.QueryFeed")
+}
+
+func (o querierV100) queryFeeds(state string) ([]rpc.FeedContext, sdk.Error) {
+	return nil, sdk.UnsupportedByProtobuf("oracle.QueryFeeds")
+}
+
+func (o querierV100) queryFeedValue(feedName string) ([]rpc.FeedValue, sdk.Error) {
+	return nil, sdk.UnsupportedByProtobuf("oracle.QueryFeedValue")
+}
@@ -16,7 +16,7 @@ import (
 type OracleTestSuite struct {
 	suite.Suite
 	*test.MockClient
-	*log.Logger
+	log.Logger
 	serviceName string
 	baseTx      sdk.BaseTx
 }
@@ -71,10 +71,7 @@ func (ots *OracleTestSuite) SetupService() {
 
 	_, err = ots.Service().SubscribeSingleServiceRequest(serviceName,
 		func(reqCtxID, reqID, input string) (string, string) {
-			ots.Info().Str("input", input).
-				Str("reqCtxID", reqCtxID).
-				Str("output", output).
-				Msg("Service received request")
+			ots.Info("Service received request", "input", input, "reqCtxID", reqCtxID, "output", output)
 			return output, testResult
 		}, baseTx)
 
@@ -120,10 +117,7 @@ func (ots *OracleTestSuite) TestFeed() {
 
 	ch := make(chan rpc.FeedValue)
 	err = ots.Oracle().SubscribeFeedValue(feedName, func(value rpc.FeedValue) {
-		ots.Info().
-			Str("feedName", feedName).
-			Str("feedValue", value.Data).
-			Msg("received feed value")
+		ots.Info("received feed value", "feedName", feedName, "feedValue", value.Data)
 		ch <- value
 	})
 
@@ -65,6 +65,15 @@ func (msg MsgCreateFeed) Type() string {
 	return "create_feed"
 }
 
+// Implements NoSpendMsg.
+func (msg MsgCreateFeed) SpendsNoCoins() {}
+
+// Implements RecipientMsg.
+func (msg MsgCreateFeed) Recipients() []sdk.AccAddress { return msg.Providers }
+
+// Implements NoValidatorMsg.
+func (msg MsgCreateFeed) ActsOnNoValidator() {}
+
 // ValidateBasic implements Msg.
 func (msg MsgCreateFeed) ValidateBasic() error {
 	feedName := strings.TrimSpace(msg.FeedName)
@@ -106,6 +115,42 @@ func (msg MsgCreateFeed) GetSignBytes() []byte {
 	return json.MustSort(b)
 }
 
+// Implements SignDocMsg.
+func (msg MsgCreateFeed) SignDoc(config *sdk.AddrPrefixCfg) interface{} {
+	return sdk.TypedSignDoc{
+		Type: "irishub/oracle/MsgCreateFeed",
+		Value: struct {
+			FeedName          string    `json:"feed_name"`
+			LatestHistory     uint64    `json:"latest_history"`
+			Description       string    `json:"description"`
+			Creator           string    `json:"creator"`
+			ServiceName       string    `json:"service_name"`
+			Providers         []string  `json:"providers"`
+			Input             string    `json:"input"`
+			Timeout           int64     `json:"timeout"`
+			ServiceFeeCap     sdk.Coins `json:"service_fee_cap"`
+			RepeatedFrequency uint64    `json:"repeated_frequency"`
+			AggregateFunc     string    `json:"aggregate_func"`
+			ValueJsonPath     string    `json:"value_json_path"`
+			ResponseThreshold uint16    `json:"response_threshold"`
+		}{
+			FeedName:          msg.FeedName,
+			LatestHistory:     msg.LatestHistory,
+			Description:       msg.Description,
+			Creator:           config.AccAddressString(msg.Creator),
+			ServiceName:       msg.ServiceName,
+			Providers:         config.AccAddressStrings(msg.Providers),
+			Input:             msg.Input,
+			Timeout:           msg.Timeout,
+			ServiceFeeCap:     msg.ServiceFeeCap,
+			RepeatedFrequency: msg.RepeatedFrequency,
+			AggregateFunc:     msg.AggregateFunc,
+			ValueJsonPath:     msg.ValueJsonPath,
+			ResponseThreshold: msg.ResponseThreshold,
+		},
+	}
+}
+
 // GetSigners implements Msg.
 func (msg MsgCreateFeed) GetSigners() []sdk.AccAddress {
 	return []sdk.AccAddress{msg.Creator}
@@ -126,6 +171,12 @@ func (msg MsgStartFeed) Type() string {
 	return "start_feed"
 }
 
+// Implements RecipientMsg, the providers of the feed are those of MsgCreateFeed and MsgEditFeed.
+func (msg MsgStartFeed) Recipients() []sdk.AccAddress { return nil }
+
+// Implements NoValidatorMsg.
+func (msg MsgStartFeed) ActsOnNoValidator() {}
+
 // ValidateBasic implements Msg.
 func (msg MsgStartFeed) ValidateBasic() error {
 	feedName := strings.TrimSpace(msg.FeedName)
@@ -147,6 +198,20 @@ func (msg MsgStartFeed) GetSignBytes() []byte {
 	return json.MustSort(b)
 }
 
+// Implements SignDocMsg.
+func (msg MsgStartFeed) SignDoc(config *sdk.AddrPrefixCfg) interface{} {
+	return sdk.TypedSignDoc{
+		Type: "irishub/oracle/MsgStartFeed",
+		Value: struct {
+			FeedName string `json:"feed_name"`
+			Creator  string `json:"creator"`
+		}{
+			FeedName: msg.FeedName,
+			Creator:  config.AccAddressString(msg.Creator),
+		},
+	}
+}
+
 // GetSigners implements Msg.
 func (msg MsgStartFeed) GetSigners() []sdk.AccAddress {
 	return []sdk.AccAddress{msg.Creator}
@@ -167,6 +232,15 @@ func (msg MsgPauseFeed) Type() string {
 	return "pause_feed"
 }
 
+// Implements NoSpendMsg.
+func (msg MsgPauseFeed) SpendsNoCoins() {}
+
+// Implements RecipientMsg.
+func (msg MsgPauseFeed) Recipients() []sdk.AccAddress { return nil }
+
+// Implements NoValidatorMsg.
+func (msg MsgPauseFeed) ActsOnNoValidator() {}
+
 // ValidateBasic implements Msg.
 func (msg MsgPauseFeed) ValidateBasic() error {
 	feedName := strings.TrimSpace(msg.FeedName)
@@ -188,6 +262,20 @@ func (msg MsgPauseFeed) GetSignBytes() []byte {
 	return json.MustSort(b)
 }
 
+// Implements SignDocMsg.
+func (msg MsgPauseFeed) SignDoc(config *sdk.AddrPrefixCfg) interface{} {
+	return sdk.TypedSignDoc{
+		Type: "irishub/oracle/MsgPauseFeed",
+		Value: struct {
+			FeedName string `json:"feed_name"`
+			Creator  string `json:"creator"`
+		}{
+			FeedName: msg.FeedName,
+			Creator:  config.AccAddressString(msg.Creator),
+		},
+	}
+}
+
 // GetSigners implements Msg.
 func (msg MsgPauseFeed) GetSigners() []sdk.AccAddress {
 	return []sdk.AccAddress{msg.Creator}
@@ -215,6 +303,15 @@ func (msg MsgEditFeed) Type() string {
 	return "edit_feed"
 }
 
+// Implements NoSpendMsg.
+func (msg MsgEditFeed) SpendsNoCoins() {}
+
+// Implements RecipientMsg.
+func (msg MsgEditFeed) Recipients() []sdk.AccAddress { return msg.Providers }
+
+// Implements NoValidatorMsg.
+func (msg MsgEditFeed) ActsOnNoValidator() {}
+
 // ValidateBasic implements Msg.
 func (msg MsgEditFeed) ValidateBasic() error {
 	feedName := strings.TrimSpace(msg.FeedName)
@@ -237,6 +334,34 @@ func (msg MsgEditFeed) GetSignBytes() []byte {
 	return json.MustSort(b)
 }
 
+// Implements SignDocMsg.
+func (msg MsgEditFeed) SignDoc(config *sdk.AddrPrefixCfg) interface{} {
+	return sdk.TypedSignDoc{
+		Type: "irishub/oracle/MsgEditFeed",
+		Value: struct {
+			FeedName          string    `json:"feed_name"`
+			Description       string    `json:"description"`
+			LatestHistory     uint64    `json:"latest_history"`
+			Providers         []string  `json:"providers"`
+			Timeout           int64     `json:"timeout"`
+			ServiceFeeCap     sdk.Coins `json:"service_fee_cap"`
+			RepeatedFrequency uint64    `json:"repeated_frequency"`
+			ResponseThreshold uint16    `json:"response_threshold"`
+			Creator           string    `json:"creator"`
+		}{
+			FeedName:          msg.FeedName,
+			Description:       msg.Description,
+			LatestHistory:     msg.LatestHistory,
+			Providers:         config.AccAddressStrings(msg.Providers),
+			Timeout:           msg.Timeout,
+			ServiceFeeCap:     msg.ServiceFeeCap,
+			RepeatedFrequency: msg.RepeatedFrequency,
+			ResponseThreshold: msg.ResponseThreshold,
+			Creator:           config.AccAddressString(msg.Creator),
+		},
+	}
+}
+
 // GetSigners implements Msg.
 func (msg MsgEditFeed) GetSigners() []sdk.AccAddress {
 	return []sdk.AccAddress{msg.Creator}
@@ -244,32 +369,28 @@ func (msg MsgEditFeed) GetSigners() []sdk.AccAddress {
 
 //-------------------------------for query--------------------------
 type feed struct {
-	FeedName         string         `json:"feed_name"`
-	Description      string         `json:"description"`
-	AggregateFunc    string         `json:"aggregate_func"`
-	ValueJsonPath    string         `json:"value_json_path"`
-	LatestHistory    uint64         `json:"latest_history"`
-	RequestContextID cmn.HexBytes   `json:"request_context_id"`
-	Creator          sdk.AccAddress `json:"creator"`
+	FeedName         string       `json:"feed_name"`
+	Description      string       `json:"description"`
+	AggregateFunc    string       `json:"aggregate_func"`
+	ValueJsonPath    string       `json:"value_json_path"`
+	LatestHistory    uint64       `json:"latest_history"`
+	RequestContextID cmn.HexBytes `json:"request_context_id"`
+	Creator          string       `json:"creator"`
 }
 
 type feedContext struct {
-	Feed              feed             `json:"feed"`
-	ServiceName       string           `json:"service_name"`
-	Providers         []sdk.AccAddress `json:"providers"`
-	Input             string           `json:"input"`
-	Timeout           int64            `json:"timeout"`
-	ServiceFeeCap     sdk.Coins        `json:"service_fee_cap"`
-	RepeatedFrequency uint64           `json:"repeated_frequency"`
-	ResponseThreshold uint16           `json:"response_threshold"`
-	State             string           `json:"state"`
+	Feed              feed      `json:"feed"`
+	ServiceName       string    `json:"service_name"`
+	Providers         []string  `json:"providers"`
+	Input             string    `json:"input"`
+	Timeout           int64     `json:"timeout"`
+	ServiceFeeCap     sdk.Coins `json:"service_fee_cap"`
+	RepeatedFrequency uint64    `json:"repeated_frequency"`
+	ResponseThreshold uint16    `json:"response_threshold"`
+	State             string    `json:"state"`
 }
 
 func (fc feedContext) Convert() interface{} {
-	var providers []string
-	for _, provider := range fc.Providers {
-		providers = append(providers, provider.String())
-	}
 	return rpc.FeedContext{
 		Feed: rpc.Feed{
 			FeedName:         fc.Feed.FeedName,
@@ -278,10 +399,10 @@ func (fc feedContext) Convert() interface{} {
 			ValueJsonPath:    fc.Feed.ValueJsonPath,
 			LatestHistory:    fc.Feed.LatestHistory,
 			RequestContextID: fc.Feed.RequestContextID.String(),
-			Creator:          fc.Feed.Creator.String(),
+			Creator:          fc.Feed.Creator,
 		},
 		ServiceName:       fc.ServiceName,
-		Providers:         providers,
+		Providers:         fc.Providers,
 		Input:             fc.Input,
 		Timeout:           fc.Timeout,
 		ServiceFeeCap:     fc.ServiceFeeCap,
@@ -2,7 +2,6 @@ package modules
 
 import (
 	"fmt"
-	"time"
 
 	sdk "github.com/irisnet/irishub-sdk-go/types"
 	"github.com/irisnet/irishub-sdk-go/utils/cache"
@@ -11,10 +10,30 @@ import (
 
 type paramsQuery struct {
 	sdk.Queries
-	*log.Logger
+	log.Logger
 	cache.Cache
-	cdc        sdk.Codec
-	expiration time.Duration
+	cdc     sdk.Codec
+	version sdk.Version
+	queries *sdk.VersionedQueries
+}
+
+// paramsQuerier is implemented by the queries of the params of each version of the chain
+type paramsQuerier interface {
+	queryParams(module string) ([]byte, error)
+}
+
+func newParamsQuery(q sdk.Queries, logger log.Logger, c cache.Cache, cdc sdk.Codec, version sdk.Version) paramsQuery {
+	queries := new(sdk.VersionedQueries).
+		Register(sdk.V017, paramsQuerierV017{q}).
+		Register(sdk.V100, paramsQuerierV100{})
+	return paramsQuery{
+		Queries: q,
+		Logger:  logger,
+		Cache:   c,
+		cdc:     cdc,
+		version: version,
+		queries: queries,
+	}
 }
 
 func (p paramsQuery) prefixKey(module string) string {
@@ -32,14 +51,7 @@ func (p paramsQuery) QueryParams(module string, res sdk.Response) sdk.Error {
 		return nil
 	}
 
-	params := struct {
-		Module string
-	}{
-		Module: module,
-	}
-
-	//path := fmt.Sprintf("custom/%s/parameters", module)
-	bz, err := p.Query("custom/params/module", params)
+	bz, err := p.queries.Route(p.version).(paramsQuerier).queryParams(module)
 	if err != nil {
 		return sdk.Wrap(err)
 	}
@@ -49,10 +61,31 @@ func (p paramsQuery) QueryParams(module string, res sdk.Response) sdk.Error {
 		return sdk.Wrap(err)
 	}
 
-	if err := p.SetWithExpire(p.prefixKey(module), bz, p.expiration); err != nil {
-		p.Warn().
-			Str("module", module).
-			Msg("params cache failed")
+	if err := p.Set(p.prefixKey(module), bz); err != nil {
+		p.Warn("params cache failed", "module", module)
 	}
 	return nil
 }
+
+type paramsQuerierV017 struct {
+	sdk.Queries
+}
+
+func (p paramsQuerierV017) queryParams(module string) ([]byte, error) {
+	params := struct {
+		Module string
+	}{
+		Module: module,
+	}
+
+	//path := fmt.Sprintf("custom/%s/parameters", module)
+	return p.Query("custom/params/module", params)
+}
+
+// paramsQuerierV100 refuses the params of a module as a whole, the params module of IRIShub 1.x
+// answers a single key of a subspace: the modules query their own params instead
+type paramsQuerierV100 struct{}
+
+func (p paramsQuerierV100) queryParams(module string) ([]byte, error) {
+	return nil, sdk.UnsupportedByProtobuf("QueryParams")
+}
@@ -9,13 +9,18 @@ import (
 
 type randomClient struct {
 	sdk.BaseClient
-	*log.Logger
+	log.Logger
+	queries *sdk.VersionedQueries
 }
 
 func Create(ac sdk.BaseClient) rpc.Random {
+	queries := new(sdk.VersionedQueries).
+		Register(sdk.V017, querierV017{ac}).
+		Register(sdk.V100, querierV100{ac})
 	return randomClient{
 		BaseClient: ac,
 		Logger:     ac.Logger(),
+		queries:    queries,
 	}
 }
 
@@ -58,10 +63,9 @@ func (r randomClient) Request(request rpc.RandomRequest, baseTx sdk.BaseTx) (str
 
 	requestID := result.Tags.GetValue(tagRequestID)
 	if needWatch {
-		_, err := r.SubscribeRandom(requestID, request.Callback)
-		r.Err(err).
-			Str(tagRequestID, requestID).
-			Msg("subscribe random failed")
+		if _, err := r.SubscribeRandom(requestID, request.Callback); err != nil {
+			r.Error("subscribe random failed", "err", err, tagRequestID, requestID)
+		}
 	}
 	return requestID, nil
 }
@@ -81,12 +85,11 @@ func (r randomClient) SubscribeRandom(requestID string,
 		//cancel subscribe
 		unsubscribe(sub1, sub2)
 
-		rand := block.ResultBeginBlock.Tags.GetValue(tagRand(tagRequestID))
-		r.Debug().
-			Int64("height", block.Block.Height).
-			Str("requestID", requestID).
-			Str("random", rand).
-			Msg("received random result")
+		rand := block.ResultBeginBlock.Tags.GetValue(tagRand(requestID))
+		r.Debug("received random result",
+			"height", block.Block.Height,
+			"requestID", requestID,
+			"random", rand)
 
 		callback(requestID, rand, nil)
 	})
@@ -98,12 +101,8 @@ func (r randomClient) SubscribeRandom(requestID string,
 		//cancel subscribe
 		unsubscribe(sub1, sub2)
 
-		rand := tx.Result.Tags.GetValue(tagRand(tagRequestID))
-		r.Debug().
-			Int64("height", tx.Height).
-			Str("requestID", requestID).
-			Str("random", rand).
-			Msg("received random result")
+		rand := tx.Result.Tags.GetValue(tagRand(requestID))
+		r.Debug("received random result", "height", tx.Height, "requestID", requestID, "random", rand)
 
 		callback(requestID, rand, nil)
 	})
@@ -112,6 +111,30 @@ func (r randomClient) SubscribeRandom(requestID string,
 
 // QueryRandom returns the random information of the specified reqID
 func (r randomClient) QueryRandom(reqID string) (rpc.ResponseRandom, sdk.Error) {
+	return r.querier().queryRandom(reqID)
+}
+
+// QueryRequests returns the list of request by the specified block height
+func (r randomClient) QueryRequests(height int64) ([]rpc.RequestRandom, sdk.Error) {
+	return r.querier().queryRequests(height)
+}
+
+// querier returns the queries of the version of the chain
+func (r randomClient) querier() querier {
+	return r.queries.Route(r.ChainVersion()).(querier)
+}
+
+// querier is implemented by the queries of each version of the chain
+type querier interface {
+	queryRandom(reqID string) (rpc.ResponseRandom, sdk.Error)
+	queryRequests(height int64) ([]rpc.RequestRandom, sdk.Error)
+}
+
+type querierV017 struct {
+	sdk.BaseClient
+}
+
+func (r querierV017) queryRandom(reqID string) (rpc.ResponseRandom, sdk.Error) {
 	param := struct {
 		ReqID string
 	}{
@@ -125,8 +148,7 @@ func (r randomClient) QueryRandom(reqID string) (rpc.ResponseRandom, sdk.Error)
 	return rand.Convert().(rpc.ResponseRandom), nil
 }
 
-// QueryRequests returns the list of request by the specified block height
-func (r randomClient) QueryRequests(height int64) ([]rpc.RequestRandom, sdk.Error) {
+func (r querierV017) queryRequests(height int64) ([]rpc.RequestRandom, sdk.Error) {
 	param := struct {
 		Height int64
 	}{
@@ -139,3 +161,15 @@ func (r randomClient) QueryRequests(height int64) ([]rpc.RequestRandom, sdk.Erro
 	}
 	return rs.Convert().([]rpc.RequestRandom), nil
 }
+
+type querierV100 struct {
+	sdk.BaseClient
+}
+
+func (r querierV100) queryRandom(reqID string) (rpc.ResponseRandom, sdk.Error) {
+	return rpc.ResponseRandom{}, sdk.UnsupportedByProtobuf("random.QueryRandom")
+}
+
+func (r querierV100) queryRequests(height int64) ([]rpc.RequestRandom, sdk.Error) {
+	return nil, sdk.UnsupportedByProtobuf("random.QueryRequests")
+}
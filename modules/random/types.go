@@ -32,6 +32,8 @@ func init() {
 }
 
 // MsgRequestRand represents a msg for requesting a random number
+//
+// It does not implement RecipientMsg: the providers paid for the random numbers of the oracle are chosen by the chain.
 type MsgRequestRand struct {
 	Consumer      sdk.AccAddress `json:"consumer"`        // request address
 	BlockInterval uint64         `json:"block_interval"`  // block interval after which the requested random number will be generated
@@ -63,11 +65,32 @@ func (msg MsgRequestRand) GetSignBytes() []byte {
 	return json.MustSort(b)
 }
 
+// Implements SignDocMsg.
+func (msg MsgRequestRand) SignDoc(config *sdk.AddrPrefixCfg) interface{} {
+	return sdk.TypedSignDoc{
+		Type: "irishub/rand/MsgRequestRand",
+		Value: struct {
+			Consumer      string    `json:"consumer"`
+			BlockInterval uint64    `json:"block_interval"`
+			Oracle        bool      `json:"oracle"`
+			ServiceFeeCap sdk.Coins `json:"service_fee_cap"`
+		}{
+			Consumer:      config.AccAddressString(msg.Consumer),
+			BlockInterval: msg.BlockInterval,
+			Oracle:        msg.Oracle,
+			ServiceFeeCap: msg.ServiceFeeCap,
+		},
+	}
+}
+
 // Implements Msg.
 func (msg MsgRequestRand) GetSigners() []sdk.AccAddress {
 	return []sdk.AccAddress{msg.Consumer}
 }
 
+// Implements NoValidatorMsg.
+func (msg MsgRequestRand) ActsOnNoValidator() {}
+
 //=======================for query=====================================================
 // rand represents a random number with related data
 type rand struct {
@@ -86,17 +109,17 @@ func (r rand) Convert() interface{} {
 
 // ServiceRequest represents a request for a random number
 type request struct {
-	Height        int64          `json:"height"`          // the height of the block in which the request tx is included
-	Consumer      sdk.AccAddress `json:"consumer"`        // the request address
-	TxHash        []byte         `json:"txhash"`          // the request tx hash
-	Oracle        bool           `json:"oracle"`          // oracle method
-	ServiceFeeCap sdk.Coins      `json:"service_fee_cap"` // service fee cap
+	Height        int64     `json:"height"`          // the height of the block in which the request tx is included
+	Consumer      string    `json:"consumer"`        // the request address
+	TxHash        []byte    `json:"txhash"`          // the request tx hash
+	Oracle        bool      `json:"oracle"`          // oracle method
+	ServiceFeeCap sdk.Coins `json:"service_fee_cap"` // service fee cap
 }
 
 func (r request) Convert() interface{} {
 	return rpc.RequestRandom{
 		Height:        r.Height,
-		Consumer:      r.Consumer.String(),
+		Consumer:      r.Consumer,
 		TxHash:        cmn.HexBytes(r.TxHash).String(),
 		Oracle:        r.Oracle,
 		ServiceFeeCap: r.ServiceFeeCap,
@@ -15,11 +15,11 @@ import (
 
 type rpcClient struct {
 	rpc.Client
-	*log.Logger
+	log.Logger
 	cdc sdk.Codec
 }
 
-func NewRPCClient(remote string, cdc sdk.Codec, log *log.Logger) sdk.TmClient {
+func NewRPCClient(remote string, cdc sdk.Codec, log log.Logger) sdk.TmClient {
 	client := rpc.NewHTTP(remote, "/websocket")
 	_ = client.Start()
 	return rpcClient{
@@ -76,16 +76,10 @@ func (r rpcClient) Resubscribe(subscription sdk.Subscription, handler sdk.EventH
 }
 
 func (r rpcClient) Unsubscribe(subscription sdk.Subscription) sdk.Error {
-	r.Info().
-		Str("query", subscription.Query).
-		Str("subscriber", subscription.ID).
-		Msg("end to subscribe event")
+	r.Info("end to subscribe event", "query", subscription.Query, "subscriber", subscription.ID)
 	err := r.Client.Unsubscribe(subscription.Ctx, subscription.ID, subscription.Query)
 	if err != nil {
-		r.Err(err).
-			Str("query", subscription.Query).
-			Str("subscriber", subscription.ID).
-			Msg("unsubscribe failed")
+		r.Error("unsubscribe failed", "err", err, "query", subscription.Query, "subscriber", subscription.ID)
 		return sdk.Wrap(err)
 	}
 	return nil
@@ -99,10 +93,7 @@ func (r rpcClient) SubscribeAny(query string, handler sdk.EventHandler) (subscri
 		return subscription, sdk.Wrap(e)
 	}
 
-	r.Info().
-		Str("query", query).
-		Str("subscriber", subscriber).
-		Msg("subscribe event")
+	r.Info("subscribe event", "query", query, "subscriber", subscriber)
 
 	subscription = sdk.Subscription{
 		Ctx:   ctx,
@@ -115,10 +106,9 @@ func (r rpcClient) SubscribeAny(query string, handler sdk.EventHandler) (subscri
 			data := <-ch
 			go func() {
 				defer sdk.CatchPanic(func(errMsg string) {
-					r.Error().
-						Str("query", query).
-						Str("subscriber", subscriber).
-						Msgf("subscribe event failed:%s", errMsg)
+					r.Error(fmt.Sprintf("subscribe event failed:%s", errMsg),
+						"query", query,
+						"subscriber", subscriber)
 				})
 
 				switch data := data.Data.(type) {
@@ -197,7 +187,7 @@ func (r rpcClient) parseNewBlockHeader(data sdk.EventData) sdk.EventDataNewBlock
 func (r rpcClient) parseValidatorSetUpdates(data sdk.EventData) sdk.EventDataValidatorSetUpdates {
 	validatorSet := data.(tmtypes.EventDataValidatorSetUpdates)
 	return sdk.EventDataValidatorSetUpdates{
-		ValidatorUpdates: sdk.ParseValidators(validatorSet.ValidatorUpdates),
+		ValidatorUpdates: sdk.ParseValidators(sdk.AddrPrefixCfgOf(r.cdc), validatorSet.ValidatorUpdates),
 	}
 }
 
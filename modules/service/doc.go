@@ -62,10 +62,7 @@
 //
 //	err = sts.Service().SubscribeSingleServiceRequest(definition.ServiceName,
 //		func(reqCtxID, reqID, input string) (string, string) {
-//			sts.Info().
-//				Str("input", input).
-//				Str("output", output).
-//				Msg("provider received request")
+//			sts.Info("provider received request", "input", input, "output", output)
 //			return output, testResult
 //		}, baseTx)
 //	require.NoError(sts.T(), err)
@@ -87,16 +84,13 @@
 //	requestContextID, err = sts.Service().InvokeService(invocation, func(reqCtxID, reqID, responses string) {
 //		require.Equal(sts.T(), reqCtxID, requestContextID)
 //		require.Equal(sts.T(), output, response)
-//		sts.Info().
-//			Str("requestContextID", requestContextID).
-//			Str("response", response).
-//			Msg("consumer received response")
+//		sts.Info("consumer received response",
+//			"requestContextID", requestContextID,
+//			"response", response)
 //		exit <- 1
 //	}, baseTx)
 //
-//	sts.Info().
-//		Str("requestContextID", requestContextID).
-//		Msg("ServiceRequest service success")
+//	sts.Info("ServiceRequest service success", "requestContextID", requestContextID)
 //	require.NoError(sts.T(), err)
 //
 //	request, err := sts.Service().QueryRequestContext(requestContextID)
@@ -0,0 +1,90 @@
+package service
+
+import (
+	sdk "github.com/irisnet/irishub-sdk-go/types"
+)
+
+// Codespace is the codespace of the errors of the service module
+const Codespace = ModuleName
+
+// the errors returned by the service module of IRIShub 1.x
+var (
+	ErrInvalidServiceName        = sdk.RegisterError(Codespace, 2, "invalid service name")
+	ErrInvalidDescription        = sdk.RegisterError(Codespace, 3, "invalid description")
+	ErrInvalidTags               = sdk.RegisterError(Codespace, 4, "invalid tags")
+	ErrInvalidSchemas            = sdk.RegisterError(Codespace, 5, "invalid schemas")
+	ErrUnknownServiceDefinition  = sdk.RegisterError(Codespace, 6, "unknown service definition")
+	ErrServiceDefinitionExists   = sdk.RegisterError(Codespace, 7, "service definition already exists")
+	ErrInvalidDeposit            = sdk.RegisterError(Codespace, 8, "invalid deposit")
+	ErrInvalidMinDeposit         = sdk.RegisterError(Codespace, 9, "invalid minimum deposit")
+	ErrInvalidPricing            = sdk.RegisterError(Codespace, 10, "invalid pricing")
+	ErrInvalidQoS                = sdk.RegisterError(Codespace, 11, "invalid QoS")
+	ErrInvalidOptions            = sdk.RegisterError(Codespace, 12, "invalid options")
+	ErrServiceBindingExists      = sdk.RegisterError(Codespace, 13, "service binding already exists")
+	ErrUnknownServiceBinding     = sdk.RegisterError(Codespace, 14, "unknown service binding")
+	ErrServiceBindingUnavailable = sdk.RegisterError(Codespace, 15, "service binding unavailable")
+	ErrServiceBindingAvailable   = sdk.RegisterError(Codespace, 16, "service binding available")
+	ErrIncorrectRefundTime       = sdk.RegisterError(Codespace, 17, "incorrect refund time")
+	ErrInvalidServiceFeeCap      = sdk.RegisterError(Codespace, 18, "invalid service fee cap")
+	ErrInvalidProviders          = sdk.RegisterError(Codespace, 19, "invalid providers")
+	ErrInvalidTimeout            = sdk.RegisterError(Codespace, 20, "invalid timeout")
+	ErrInvalidRepeatedFreq       = sdk.RegisterError(Codespace, 21, "invalid repeated frequency")
+	ErrInvalidRepeatedTotal      = sdk.RegisterError(Codespace, 22, "invalid repeated total count")
+	ErrInvalidThreshold          = sdk.RegisterError(Codespace, 23, "invalid threshold")
+	ErrInvalidResponse           = sdk.RegisterError(Codespace, 24, "invalid response")
+	ErrInvalidRequestID          = sdk.RegisterError(Codespace, 25, "invalid request ID")
+	ErrUnknownRequest            = sdk.RegisterError(Codespace, 26, "unknown request")
+	ErrUnknownResponse           = sdk.RegisterError(Codespace, 27, "unknown response")
+	ErrUnknownRequestContext     = sdk.RegisterError(Codespace, 28, "unknown request context")
+	ErrInvalidRequestContextID   = sdk.RegisterError(Codespace, 29, "invalid request context ID")
+	ErrRequestContextNonRepeated = sdk.RegisterError(Codespace, 30, "request context non repeated")
+	ErrRequestContextNotRunning  = sdk.RegisterError(Codespace, 31, "request context not running")
+	ErrRequestContextNotPaused   = sdk.RegisterError(Codespace, 32, "request context not paused")
+	ErrRequestContextCompleted   = sdk.RegisterError(Codespace, 33, "request context completed")
+	ErrCallbackRegistered        = sdk.RegisterError(Codespace, 34, "callback registered")
+	ErrCallbackNotRegistered     = sdk.RegisterError(Codespace, 35, "callback not registered")
+	ErrNoEarnedFees              = sdk.RegisterError(Codespace, 36, "no earned fees")
+	ErrInvalidRequestInput       = sdk.RegisterError(Codespace, 37, "invalid request input")
+	ErrInvalidResponseOutput     = sdk.RegisterError(Codespace, 38, "invalid response output")
+	ErrInvalidResponseResult     = sdk.RegisterError(Codespace, 39, "invalid response result")
+	ErrInvalidSchemaName         = sdk.RegisterError(Codespace, 40, "invalid service schema name")
+	ErrNotAuthorized             = sdk.RegisterError(Codespace, 41, "not authorized")
+	ErrModuleServiceRegistered   = sdk.RegisterError(Codespace, 42, "module service registered")
+	ErrInvalidModuleService      = sdk.RegisterError(Codespace, 43, "invalid module service")
+)
+
+// the errors returned by the service module of IRIShub 0.x
+var (
+	ErrLegacyInvalidIDL               = sdk.RegisterLegacyError(Codespace, 100, "invalid IDL")
+	ErrLegacySvcDefExists             = sdk.RegisterLegacyError(Codespace, 101, "service definition already exists")
+	ErrLegacySvcDefNotExists          = sdk.RegisterLegacyError(Codespace, 102, "service definition does not exist")
+	ErrLegacyInvalidOutputPrivacyEnum = sdk.RegisterLegacyError(Codespace, 103, "invalid output privacy")
+	ErrLegacyInvalidOutputCachedEnum  = sdk.RegisterLegacyError(Codespace, 104, "invalid output cached")
+	ErrLegacyInvalidServiceName       = sdk.RegisterLegacyError(Codespace, 105, "invalid service name")
+	ErrLegacyInvalidChainId           = sdk.RegisterLegacyError(Codespace, 106, "invalid chain id")
+	ErrLegacyInvalidAuthor            = sdk.RegisterLegacyError(Codespace, 107, "invalid author")
+	ErrLegacyInvalidMethodName        = sdk.RegisterLegacyError(Codespace, 108, "invalid method name")
+	ErrLegacySvcBindingExists         = sdk.RegisterLegacyError(Codespace, 109, "service binding already exists")
+	ErrLegacySvcBindingNotExists      = sdk.RegisterLegacyError(Codespace, 110, "service binding does not exist")
+	ErrLegacyInvalidDefChainId        = sdk.RegisterLegacyError(Codespace, 111, "invalid definition chain id")
+	ErrLegacyInvalidBindingType       = sdk.RegisterLegacyError(Codespace, 112, "invalid binding type")
+	ErrLegacyInvalidLevel             = sdk.RegisterLegacyError(Codespace, 113, "invalid level")
+	ErrLegacyInvalidPriceCount        = sdk.RegisterLegacyError(Codespace, 114, "invalid price count")
+	ErrLegacyInvalidRefundDeposit     = sdk.RegisterLegacyError(Codespace, 115, "invalid refund deposit")
+	ErrLegacyLtMinProviderDeposit     = sdk.RegisterLegacyError(Codespace, 116, "deposit less than the minimum provider deposit")
+	ErrLegacyInvalidDisable           = sdk.RegisterLegacyError(Codespace, 117, "invalid disable")
+	ErrLegacyInvalidEnable            = sdk.RegisterLegacyError(Codespace, 118, "invalid enable")
+	ErrLegacyMethodNotExists          = sdk.RegisterLegacyError(Codespace, 119, "method does not exist")
+	ErrLegacyRequestNotActive         = sdk.RegisterLegacyError(Codespace, 120, "request is not active")
+	ErrLegacyReturnFeeNotExists       = sdk.RegisterLegacyError(Codespace, 121, "return fee does not exist")
+	ErrLegacyWithdrawFeeNotExists     = sdk.RegisterLegacyError(Codespace, 122, "withdraw fee does not exist")
+	ErrLegacyLtServiceFee             = sdk.RegisterLegacyError(Codespace, 123, "less than the service fee")
+	ErrLegacyInvalidReqId             = sdk.RegisterLegacyError(Codespace, 124, "invalid request id")
+	ErrLegacySvcBindingNotAvailable   = sdk.RegisterLegacyError(Codespace, 125, "service binding is not available")
+	ErrLegacyNotMatchingProvider      = sdk.RegisterLegacyError(Codespace, 126, "provider does not match")
+	ErrLegacyInvalidReqChainId        = sdk.RegisterLegacyError(Codespace, 127, "invalid request chain id")
+	ErrLegacyInvalidBindChainId       = sdk.RegisterLegacyError(Codespace, 128, "invalid binding chain id")
+	ErrLegacyNotMatchingReqChainID    = sdk.RegisterLegacyError(Codespace, 129, "request chain id does not match")
+	ErrLegacyIntOverflow              = sdk.RegisterLegacyError(Codespace, 130, "integer overflow")
+	ErrLegacyInvalidInput             = sdk.RegisterLegacyError(Codespace, 131, "invalid input")
+)
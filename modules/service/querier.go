@@ -0,0 +1,227 @@
+package service
+
+import (
+	cmn "github.com/tendermint/tendermint/libs/common"
+
+	"github.com/irisnet/irishub-sdk-go/rpc"
+	sdk "github.com/irisnet/irishub-sdk-go/types"
+)
+
+// querier is implemented by the queries of each version of the chain
+type querier interface {
+	queryDefinition(serviceName string) (rpc.ServiceDefinition, sdk.Error)
+	queryBinding(serviceName string, provider sdk.AccAddress) (rpc.ServiceBinding, sdk.Error)
+	queryBindings(serviceName string) ([]rpc.ServiceBinding, sdk.Error)
+	queryRequest(requestID string) (rpc.ServiceRequest, sdk.Error)
+	queryRequests(serviceName string, provider sdk.AccAddress) ([]rpc.ServiceRequest, sdk.Error)
+	queryRequestsByReqCtx(reqCtxID string, batchCounter uint64) ([]rpc.ServiceRequest, sdk.Error)
+	queryResponse(requestID string) (rpc.ServiceResponse, sdk.Error)
+	queryResponses(reqCtxID string, batchCounter uint64) ([]rpc.ServiceResponse, sdk.Error)
+	queryRequestContext(reqCtxID string) (rpc.RequestContext, sdk.Error)
+	queryFees(provider string) (rpc.EarnedFees, sdk.Error)
+}
+
+type querierV017 struct {
+	sdk.BaseClient
+}
+
+func (s querierV017) queryDefinition(serviceName string) (rpc.ServiceDefinition, sdk.Error) {
+	param := struct {
+		ServiceName string
+	}{
+		ServiceName: serviceName,
+	}
+
+	var definition serviceDefinition
+	if err := s.QueryWithResponse("custom/service/definition", param, &definition); err != nil {
+		return rpc.ServiceDefinition{}, sdk.Wrap(err)
+	}
+	return definition.Convert().(rpc.ServiceDefinition), nil
+}
+
+func (s querierV017) queryBinding(serviceName string, provider sdk.AccAddress) (rpc.ServiceBinding, sdk.Error) {
+	param := struct {
+		ServiceName string
+		Provider    string
+	}{
+		ServiceName: serviceName,
+		Provider:    s.AddrPrefixCfg().AccAddressString(provider),
+	}
+
+	var binding serviceBinding
+	if err := s.QueryWithResponse("custom/service/binding", param, &binding); err != nil {
+		return rpc.ServiceBinding{}, sdk.Wrap(err)
+	}
+	return binding.Convert().(rpc.ServiceBinding), nil
+}
+
+func (s querierV017) queryBindings(serviceName string) ([]rpc.ServiceBinding, sdk.Error) {
+	param := struct {
+		ServiceName string
+	}{
+		ServiceName: serviceName,
+	}
+
+	var bindings serviceBindings
+	if err := s.QueryWithResponse("custom/service/bindings", param, &bindings); err != nil {
+		return nil, sdk.Wrap(err)
+	}
+	return bindings.Convert().([]rpc.ServiceBinding), nil
+}
+
+func (s querierV017) queryRequest(requestID string) (rpc.ServiceRequest, sdk.Error) {
+	param := struct {
+		RequestID []byte
+	}{
+		RequestID: hexBytesFrom(requestID),
+	}
+
+	var request request
+	if err := s.QueryWithResponse("custom/service/request", param, &request); request.Empty() {
+		request, err = s.queryRequestByTxQuery(requestID)
+		if err != nil {
+			return rpc.ServiceRequest{}, sdk.Wrap(err)
+		}
+	}
+	return request.Convert().(rpc.ServiceRequest), nil
+}
+
+func (s querierV017) queryRequests(serviceName string, provider sdk.AccAddress) ([]rpc.ServiceRequest, sdk.Error) {
+	param := struct {
+		ServiceName string
+		Provider    string
+	}{
+		ServiceName: serviceName,
+		Provider:    s.AddrPrefixCfg().AccAddressString(provider),
+	}
+
+	var rs requests
+	if err := s.QueryWithResponse("custom/service/requests", param, &rs); err != nil {
+		return nil, sdk.Wrap(err)
+	}
+	return rs.Convert().([]rpc.ServiceRequest), nil
+}
+
+func (s querierV017) queryRequestsByReqCtx(reqCtxID string, batchCounter uint64) ([]rpc.ServiceRequest, sdk.Error) {
+	param := struct {
+		RequestContextID cmn.HexBytes
+		BatchCounter     uint64
+	}{
+		RequestContextID: hexBytesFrom(reqCtxID),
+		BatchCounter:     batchCounter,
+	}
+
+	var rs requests
+	if err := s.QueryWithResponse("custom/service/requests_by_ctx", param, &rs); err != nil {
+		return nil, sdk.Wrap(err)
+	}
+	return rs.Convert().([]rpc.ServiceRequest), nil
+}
+
+func (s querierV017) queryResponse(requestID string) (rpc.ServiceResponse, sdk.Error) {
+	param := struct {
+		RequestID string
+	}{
+		RequestID: requestID,
+	}
+
+	var response response
+	if err := s.QueryWithResponse("custom/service/response", param, &response); response.Empty() {
+		response, err = s.queryResponseByTxQuery(requestID)
+		if err != nil {
+			return rpc.ServiceResponse{}, sdk.Wrap(nil)
+		}
+	}
+	return response.Convert().(rpc.ServiceResponse), nil
+}
+
+func (s querierV017) queryResponses(reqCtxID string, batchCounter uint64) ([]rpc.ServiceResponse, sdk.Error) {
+	param := struct {
+		RequestContextID cmn.HexBytes
+		BatchCounter     uint64
+	}{
+		RequestContextID: hexBytesFrom(reqCtxID),
+		BatchCounter:     batchCounter,
+	}
+	var rs responses
+	if err := s.QueryWithResponse("custom/service/responses", param, &rs); err != nil {
+		return nil, sdk.Wrap(err)
+	}
+	return rs.Convert().([]rpc.ServiceResponse), nil
+}
+
+func (s querierV017) queryRequestContext(reqCtxID string) (rpc.RequestContext, sdk.Error) {
+	param := struct {
+		RequestContextID cmn.HexBytes
+	}{
+		RequestContextID: hexBytesFrom(reqCtxID),
+	}
+
+	var reqCtx requestContext
+	if err := s.QueryWithResponse("custom/service/context", param, &reqCtx); reqCtx.Empty() {
+		reqCtx, err = s.queryRequestContextByTxQuery(reqCtxID)
+		if err != nil {
+			return rpc.RequestContext{}, sdk.Wrap(err)
+		}
+	}
+	return reqCtx.Convert().(rpc.RequestContext), nil
+}
+
+func (s querierV017) queryFees(provider string) (rpc.EarnedFees, sdk.Error) {
+	param := struct {
+		Address string
+	}{
+		Address: provider,
+	}
+
+	var fee earnedFees
+
+	if err := s.QueryWithResponse("custom/service/fees", param, &fee); err != nil {
+		return rpc.EarnedFees{}, sdk.Wrap(err)
+	}
+	return fee.Convert().(rpc.EarnedFees), nil
+}
+
+type querierV100 struct {
+	sdk.BaseClient
+}
+
+func (s querierV100) queryDefinition(serviceName string) (rpc.ServiceDefinition, sdk.Error) {
+	return rpc.ServiceDefinition{}, sdk.UnsupportedByProtobuf("service.QueryDefinition")
+}
+
+func (s querierV100) queryBinding(serviceName string, provider sdk.AccAddress) (rpc.ServiceBinding, sdk.Error) {
+	return rpc.ServiceBinding{}, sdk.UnsupportedByProtobuf("service.QueryBinding")
+}
+
+func (s querierV100) queryBindings(serviceName string) ([]rpc.ServiceBinding, sdk.Error) {
+	return nil, sdk.UnsupportedByProtobuf("service.QueryBindings")
+}
+
+func (s querierV100) queryRequest(requestID string) (rpc.ServiceRequest, sdk.Error) {
+	return rpc.ServiceRequest{}, sdk.UnsupportedByProtobuf("service.QueryRequest")
+}
+
+func (s querierV100) queryRequests(serviceName string, provider sdk.AccAddress) ([]rpc.ServiceRequest, sdk.Error) {
+	return nil, sdk.UnsupportedByProtobuf("service.QueryRequests")
+}
+
+func (s querierV100) queryRequestsByReqCtx(reqCtxID string, batchCounter uint64) ([]rpc.ServiceRequest, sdk.Error) {
+	return nil, sdk.UnsupportedByProtobuf("service.QueryRequestsByReqCtx")
+}
+
+func (s querierV100) queryResponse(requestID string) (rpc.ServiceResponse, sdk.Error) {
+	return rpc.ServiceResponse{}, sdk.UnsupportedByProtobuf("service.QueryResponse")
+}
+
+func (s querierV100) queryResponses(reqCtxID string, batchCounter uint64) ([]rpc.ServiceResponse, sdk.Error) {
+	return nil, sdk.UnsupportedByProtobuf("service.QueryResponses")
+}
+
+func (s querierV100) queryRequestContext(reqCtxID string) (rpc.RequestContext, sdk.Error) {
+	return rpc.RequestContext{}, sdk.UnsupportedByProtobuf("service.QueryRequestContext")
+}
+
+func (s querierV100) queryFees(provider string) (rpc.EarnedFees, sdk.Error) {
+	return rpc.EarnedFees{}, sdk.UnsupportedByProtobuf("service.QueryFees")
+}
@@ -11,7 +11,7 @@ import (
 )
 
 // queryRequestContextByTxQuery will query for a single request context via a direct txs tags query.
-func (s serviceClient) queryRequestContextByTxQuery(reqCtxID string) (requestContext, error) {
+func (s querierV017) queryRequestContextByTxQuery(reqCtxID string) (requestContext, error) {
 	txHash, msgIndex, err := splitRequestContextID(reqCtxID)
 	if err != nil {
 		return requestContext{}, err
@@ -50,14 +50,14 @@ func (s serviceClient) queryRequestContextByTxQuery(reqCtxID string) (requestCon
 }
 
 // queryRequestByTxQuery will query for a single request via a direct txs tags query.
-func (s serviceClient) queryRequestByTxQuery(requestID string) (request, error) {
+func (s querierV017) queryRequestByTxQuery(requestID string) (request, error) {
 	reqCtxID, _, requestHeight, batchRequestIndex, err := splitRequestID(requestID)
 	if err != nil {
 		return request{}, err
 	}
 
 	// query request context
-	reqCtx, err := s.QueryRequestContext(hex.EncodeToString(reqCtxID))
+	reqCtx, err := s.queryRequestContext(hex.EncodeToString(reqCtxID))
 	if err != nil {
 		return request{}, err
 	}
@@ -97,7 +97,7 @@ func (s serviceClient) queryRequestByTxQuery(requestID string) (request, error)
 }
 
 // queryResponseByTxQuery will query for a single request via a direct txs tags query.
-func (s serviceClient) queryResponseByTxQuery(requestID string) (response, error) {
+func (s querierV017) queryResponseByTxQuery(requestID string) (response, error) {
 	builder := sdk.NewEventQueryBuilder().
 		AddCondition(sdk.Cond(sdk.ActionKey).EQ("respond_service")).
 		AddCondition(sdk.Cond(tagRequestID).EQ(sdk.EventValue(requestID)))
@@ -117,7 +117,7 @@ func (s serviceClient) queryResponseByTxQuery(requestID string) (response, error
 	}
 
 	// query request context
-	reqCtx, err := s.QueryRequestContext(hex.EncodeToString(reqCtxID))
+	reqCtx, err := s.queryRequestContext(hex.EncodeToString(reqCtxID))
 	if err != nil {
 		return response{}, err
 	}
@@ -4,8 +4,6 @@ import (
 	"encoding/json"
 	"strings"
 
-	cmn "github.com/tendermint/tendermint/libs/common"
-
 	"github.com/irisnet/irishub-sdk-go/rpc"
 	sdk "github.com/irisnet/irishub-sdk-go/types"
 	"github.com/irisnet/irishub-sdk-go/utils/log"
@@ -13,7 +11,8 @@ import (
 
 type serviceClient struct {
 	sdk.BaseClient
-	*log.Logger
+	log.Logger
+	queries *sdk.VersionedQueries
 }
 
 func (s serviceClient) RegisterCodec(cdc sdk.Codec) {
@@ -25,13 +24,17 @@ func (s serviceClient) Name() string {
 }
 
 func Create(ac sdk.BaseClient) rpc.Service {
+	queries := new(sdk.VersionedQueries).
+		Register(sdk.V017, querierV017{ac}).
+		Register(sdk.V100, querierV100{ac})
 	return serviceClient{
 		BaseClient: ac,
 		Logger:     ac.Logger(),
+		queries:    queries,
 	}
 }
 
-//DefineService is responsible for creating a new service definition
+// DefineService is responsible for creating a new service definition
 func (s serviceClient) DefineService(request rpc.ServiceDefinitionRequest, baseTx sdk.BaseTx) (sdk.ResultTx, sdk.Error) {
 	author, err := s.QueryAddress(baseTx.From)
 	if err != nil {
@@ -48,7 +51,7 @@ func (s serviceClient) DefineService(request rpc.ServiceDefinitionRequest, baseT
 	return s.BuildAndSend([]sdk.Msg{msg}, baseTx)
 }
 
-//BindService is responsible for binding a new service definition
+// BindService is responsible for binding a new service definition
 func (s serviceClient) BindService(request rpc.ServiceBindingRequest, baseTx sdk.BaseTx) (sdk.ResultTx, sdk.Error) {
 	provider, err := s.QueryAddress(baseTx.From)
 	if err != nil {
@@ -70,7 +73,7 @@ func (s serviceClient) BindService(request rpc.ServiceBindingRequest, baseTx sdk
 	return s.BuildAndSend([]sdk.Msg{msg}, baseTx)
 }
 
-//UpdateServiceBinding updates the specified service binding
+// UpdateServiceBinding updates the specified service binding
 func (s serviceClient) UpdateServiceBinding(request rpc.ServiceBindingUpdateRequest, baseTx sdk.BaseTx) (sdk.ResultTx, sdk.Error) {
 	provider, err := s.QueryAddress(baseTx.From)
 	if err != nil {
@@ -124,7 +127,7 @@ func (s serviceClient) EnableServiceBinding(serviceName string, deposit sdk.DecC
 	return s.BuildAndSend([]sdk.Msg{msg}, baseTx)
 }
 
-//InvokeService is responsible for invoke a new service and callback `handler`
+// InvokeService is responsible for invoke a new service and callback `handler`
 func (s serviceClient) InvokeService(request rpc.ServiceInvocationRequest, baseTx sdk.BaseTx) (string, sdk.Error) {
 	consumer, err := s.QueryAddress(baseTx.From)
 	if err != nil {
@@ -133,7 +136,7 @@ func (s serviceClient) InvokeService(request rpc.ServiceInvocationRequest, baseT
 
 	var providers []sdk.AccAddress
 	for _, provider := range request.Providers {
-		p, err := sdk.AccAddressFromBech32(provider)
+		p, err := s.AddrPrefixCfg().AccAddressFromBech32(provider)
 		if err != nil {
 			return "", sdk.Wrapf("%s invalid address", p)
 		}
@@ -181,19 +184,16 @@ func (s serviceClient) SubscribeServiceResponse(reqCtxID string,
 		AddCondition(sdk.Cond(tagRequestContextID).EQ(sdk.EventValue(reqCtxID)))
 
 	return s.SubscribeTx(builder, func(tx sdk.EventDataTx) {
-		s.Debug().
-			Str("tx_hash", tx.Hash).
-			Int64("height", tx.Height).
-			Str("reqCtxID", reqCtxID).
-			Msg("consumer received response transaction sent by provider")
+		s.Debug("consumer received response transaction sent by provider",
+			"tx_hash", tx.Hash,
+			"height", tx.Height,
+			"reqCtxID", reqCtxID)
 		for _, msg := range tx.Tx.Msgs {
 			msg, ok := msg.(MsgRespondService)
 			if ok {
 				reqCtxID2, _, _, _, err := splitRequestID(msg.RequestID.String())
 				if err != nil {
-					s.Err(err).
-						Str("requestID", msg.RequestID.String()).
-						Msg("invalid requestID")
+					s.Error("invalid requestID", "err", err, "requestID", msg.RequestID.String())
 					continue
 				}
 				if reqCtxID2.String() == strings.ToUpper(reqCtxID) {
@@ -215,7 +215,7 @@ func (s serviceClient) SetWithdrawAddress(withdrawAddress string, baseTx sdk.Bas
 		return sdk.ResultTx{}, sdk.Wrap(err)
 	}
 
-	withdrawAddr, err := sdk.AccAddressFromBech32(withdrawAddress)
+	withdrawAddr, err := s.AddrPrefixCfg().AccAddressFromBech32(withdrawAddress)
 	if err != nil {
 		return sdk.ResultTx{}, sdk.Wrapf("%s invalid address", withdrawAddress)
 	}
@@ -287,7 +287,7 @@ func (s serviceClient) UpdateRequestContext(request rpc.UpdateContextRequest, ba
 
 	var providers []sdk.AccAddress
 	for _, provider := range request.Providers {
-		p, err := sdk.AccAddressFromBech32(provider)
+		p, err := s.AddrPrefixCfg().AccAddressFromBech32(provider)
 		if err != nil {
 			return sdk.ResultTx{}, sdk.Wrap(err)
 		}
@@ -331,7 +331,7 @@ func (s serviceClient) WithdrawTax(destAddress string, amount sdk.DecCoins, base
 		return sdk.ResultTx{}, sdk.Wrap(err)
 	}
 
-	receipt, err := sdk.AccAddressFromBech32(destAddress)
+	receipt, err := s.AddrPrefixCfg().AccAddressFromBech32(destAddress)
 	if err != nil {
 		return sdk.ResultTx{}, sdk.Wrapf("%s invalid address", destAddress)
 	}
@@ -349,7 +349,7 @@ func (s serviceClient) WithdrawTax(destAddress string, amount sdk.DecCoins, base
 	return s.BuildAndSend([]sdk.Msg{msg}, baseTx)
 }
 
-//SubscribeServiceRequest is responsible for registering a group of service handler
+// SubscribeServiceRequest is responsible for registering a group of service handler
 func (s serviceClient) SubscribeServiceRequest(serviceRegistry rpc.ServiceRegistry,
 	baseTx sdk.BaseTx) (subscription sdk.Subscription, err sdk.Error) {
 	provider, e := s.QueryAddress(baseTx.From)
@@ -363,7 +363,7 @@ func (s serviceClient) SubscribeServiceRequest(serviceRegistry rpc.ServiceRegist
 	builder := sdk.NewEventQueryBuilder().
 		AddCondition(sdk.Cond(
 			actionTagKey(actionNewBatchRequest, tagProvider)).
-			Contains(sdk.EventValue(provider.String())))
+			Contains(sdk.EventValue(s.AddrPrefixCfg().AccAddressString(provider))))
 	return s.SubscribeNewBlock(builder, func(block sdk.EventDataNewBlock) {
 		var msgs []sdk.Msg
 		for _, serviceName := range serviceNames {
@@ -378,12 +378,12 @@ func (s serviceClient) SubscribeServiceRequest(serviceRegistry rpc.ServiceRegist
 			return
 		}
 		if _, err = s.SendMsgBatch(msgs, baseTx); err != nil {
-			s.Err(err).Msg("provider respond failed")
+			s.Error("provider respond failed", "err", err)
 		}
 	})
 }
 
-//SubscribeSingleServiceRequest is responsible for registering a single service handler
+// SubscribeSingleServiceRequest is responsible for registering a single service handler
 func (s serviceClient) SubscribeSingleServiceRequest(serviceName string,
 	callback rpc.ServiceRespondCallback,
 	baseTx sdk.BaseTx) (subscription sdk.Subscription, err sdk.Error) {
@@ -395,7 +395,7 @@ func (s serviceClient) SubscribeSingleServiceRequest(serviceName string,
 	builder := sdk.NewEventQueryBuilder().
 		AddCondition(sdk.Cond(
 			actionTagKey(actionNewBatchRequest, tagProvider)).
-			Contains(sdk.EventValue(provider.String()))).
+			Contains(sdk.EventValue(s.AddrPrefixCfg().AccAddressString(provider)))).
 		AddCondition(sdk.Cond(
 			actionTagKey(actionNewBatchRequest, tagServiceName)).
 			Contains(sdk.EventValue(serviceName)),
@@ -403,214 +403,99 @@ func (s serviceClient) SubscribeSingleServiceRequest(serviceName string,
 	return s.SubscribeNewBlock(builder, func(block sdk.EventDataNewBlock) {
 		msgs := s.GenServiceResponseMsgs(block.ResultEndBlock.Tags, serviceName, provider, callback)
 		if _, err = s.SendMsgBatch(msgs, baseTx); err != nil {
-			s.Err(err).Msg("provider respond failed")
+			s.Error("provider respond failed", "err", err)
 		}
 	})
 }
 
 // QueryDefinition return a service definition of the specified name
 func (s serviceClient) QueryDefinition(serviceName string) (rpc.ServiceDefinition, sdk.Error) {
-	param := struct {
-		ServiceName string
-	}{
-		ServiceName: serviceName,
-	}
-
-	var definition serviceDefinition
-	if err := s.QueryWithResponse("custom/service/definition", param, &definition); err != nil {
-		return rpc.ServiceDefinition{}, sdk.Wrap(err)
-	}
-	return definition.Convert().(rpc.ServiceDefinition), nil
+	return s.querier().queryDefinition(serviceName)
 }
 
 // QueryBinding return the specified service binding
 func (s serviceClient) QueryBinding(serviceName string, provider sdk.AccAddress) (rpc.ServiceBinding, sdk.Error) {
-	param := struct {
-		ServiceName string
-		Provider    sdk.AccAddress
-	}{
-		ServiceName: serviceName,
-		Provider:    provider,
-	}
-
-	var binding serviceBinding
-	if err := s.QueryWithResponse("custom/service/binding", param, &binding); err != nil {
-		return rpc.ServiceBinding{}, sdk.Wrap(err)
-	}
-	return binding.Convert().(rpc.ServiceBinding), nil
+	return s.querier().queryBinding(serviceName, provider)
 }
 
 // QueryBindings returns all bindings of the specified service
 func (s serviceClient) QueryBindings(serviceName string) ([]rpc.ServiceBinding, sdk.Error) {
-	param := struct {
-		ServiceName string
-	}{
-		ServiceName: serviceName,
-	}
-
-	var bindings serviceBindings
-	if err := s.QueryWithResponse("custom/service/bindings", param, &bindings); err != nil {
-		return nil, sdk.Wrap(err)
-	}
-	return bindings.Convert().([]rpc.ServiceBinding), nil
+	return s.querier().queryBindings(serviceName)
 }
 
 // QueryRequest returns  the active request of the specified requestID
 func (s serviceClient) QueryRequest(requestID string) (rpc.ServiceRequest, sdk.Error) {
-	param := struct {
-		RequestID []byte
-	}{
-		RequestID: hexBytesFrom(requestID),
-	}
-
-	var request request
-	if err := s.QueryWithResponse("custom/service/request", param, &request); request.Empty() {
-		request, err = s.queryRequestByTxQuery(requestID)
-		if err != nil {
-			return rpc.ServiceRequest{}, sdk.Wrap(err)
-		}
-	}
-	return request.Convert().(rpc.ServiceRequest), nil
+	return s.querier().queryRequest(requestID)
 }
 
 // QueryRequest returns all the active requests of the specified service binding
 func (s serviceClient) QueryRequests(serviceName string, provider sdk.AccAddress) ([]rpc.ServiceRequest, sdk.Error) {
-	param := struct {
-		ServiceName string
-		Provider    sdk.AccAddress
-	}{
-		ServiceName: serviceName,
-		Provider:    provider,
-	}
-
-	var rs requests
-	if err := s.QueryWithResponse("custom/service/requests", param, &rs); err != nil {
-		return nil, sdk.Wrap(err)
-	}
-	return rs.Convert().([]rpc.ServiceRequest), nil
+	return s.querier().queryRequests(serviceName, provider)
 }
 
 // QueryRequestsByReqCtx returns all requests of the specified request context ID and batch counter
 func (s serviceClient) QueryRequestsByReqCtx(reqCtxID string, batchCounter uint64) ([]rpc.ServiceRequest, sdk.Error) {
-	param := struct {
-		RequestContextID cmn.HexBytes
-		BatchCounter     uint64
-	}{
-		RequestContextID: hexBytesFrom(reqCtxID),
-		BatchCounter:     batchCounter,
-	}
-
-	var rs requests
-	if err := s.QueryWithResponse("custom/service/requests_by_ctx", param, &rs); err != nil {
-		return nil, sdk.Wrap(err)
-	}
-	return rs.Convert().([]rpc.ServiceRequest), nil
+	return s.querier().queryRequestsByReqCtx(reqCtxID, batchCounter)
 }
 
 // QueryResponse returns a response with the speicified request ID
 func (s serviceClient) QueryResponse(requestID string) (rpc.ServiceResponse, sdk.Error) {
-	param := struct {
-		RequestID string
-	}{
-		RequestID: requestID,
-	}
-
-	var response response
-	if err := s.QueryWithResponse("custom/service/response", param, &response); response.Empty() {
-		response, err = s.queryResponseByTxQuery(requestID)
-		if err != nil {
-			return rpc.ServiceResponse{}, sdk.Wrap(nil)
-		}
-	}
-	return response.Convert().(rpc.ServiceResponse), nil
+	return s.querier().queryResponse(requestID)
 }
 
 // QueryResponses returns all responses of the specified request context and batch counter
 func (s serviceClient) QueryResponses(reqCtxID string, batchCounter uint64) ([]rpc.ServiceResponse, sdk.Error) {
-	param := struct {
-		RequestContextID cmn.HexBytes
-		BatchCounter     uint64
-	}{
-		RequestContextID: hexBytesFrom(reqCtxID),
-		BatchCounter:     batchCounter,
-	}
-	var rs responses
-	if err := s.QueryWithResponse("custom/service/responses", param, &rs); err != nil {
-		return nil, sdk.Wrap(err)
-	}
-	return rs.Convert().([]rpc.ServiceResponse), nil
+	return s.querier().queryResponses(reqCtxID, batchCounter)
 }
 
 // QueryRequestContext return the specified request context
 func (s serviceClient) QueryRequestContext(reqCtxID string) (rpc.RequestContext, sdk.Error) {
-	param := struct {
-		RequestContextID cmn.HexBytes
-	}{
-		RequestContextID: hexBytesFrom(reqCtxID),
-	}
-
-	var reqCtx requestContext
-	if err := s.QueryWithResponse("custom/service/context", param, &reqCtx); reqCtx.Empty() {
-		reqCtx, err = s.queryRequestContextByTxQuery(reqCtxID)
-		if err != nil {
-			return rpc.RequestContext{}, sdk.Wrap(err)
-		}
-	}
-	return reqCtx.Convert().(rpc.RequestContext), nil
+	return s.querier().queryRequestContext(reqCtxID)
 }
 
-//QueryFees return the earned fees for a provider
+// QueryFees return the earned fees for a provider
 func (s serviceClient) QueryFees(provider string) (rpc.EarnedFees, sdk.Error) {
-	address, err := sdk.AccAddressFromBech32(provider)
-	if err != nil {
+	if _, err := s.AddrPrefixCfg().AccAddressFromBech32(provider); err != nil {
 		return rpc.EarnedFees{}, sdk.Wrap(err)
 	}
+	return s.querier().queryFees(provider)
+}
 
-	param := struct {
-		Address sdk.AccAddress
-	}{
-		Address: address,
-	}
-
-	var fee earnedFees
-
-	if err := s.QueryWithResponse("custom/service/fees", param, &fee); err != nil {
-		return rpc.EarnedFees{}, sdk.Wrap(err)
-	}
-	return fee.Convert().(rpc.EarnedFees), nil
+// querier returns the queries of the version of the chain
+func (s serviceClient) querier() querier {
+	return s.queries.Route(s.ChainVersion()).(querier)
 }
 
 func (s serviceClient) GenServiceResponseMsgs(tags sdk.Tags, serviceName string, provider sdk.AccAddress, handler rpc.ServiceRespondCallback) (msgs []sdk.Msg) {
-	idsKey := actionTagKey(actionNewBatchRequest, serviceName, provider.String())
+	idsKey := actionTagKey(actionNewBatchRequest, serviceName, s.AddrPrefixCfg().AccAddressString(provider))
 	idsStr := tags.GetValue(string(idsKey))
 	if len(idsStr) == 0 {
 		return
 	}
 
-	s.Debug().
-		Str(tagServiceName, serviceName).
-		Str(tagProvider, provider.String()).
-		Str(tagRequestID, idsStr).
-		Msg("received service request")
+	s.Debug("received service request",
+		tagServiceName, serviceName,
+		tagProvider, s.AddrPrefixCfg().AccAddressString(provider),
+		tagRequestID, idsStr)
 
 	var ids []string
 	if err := json.Unmarshal([]byte(idsStr), &ids); err != nil {
-		s.Err(err).
-			Str(tagRequestID, idsStr).
-			Str(tagServiceName, serviceName).
-			Str(tagProvider, provider.String()).
-			Msg("service request don't exist")
+		s.Error("service request don't exist",
+			"err", err,
+			tagRequestID, idsStr,
+			tagServiceName, serviceName,
+			tagProvider, s.AddrPrefixCfg().AccAddressString(provider))
 		return
 	}
 
 	for _, reqID := range ids {
 		request, err := s.QueryRequest(reqID)
 		if err != nil {
-			s.Err(err).
-				Str(tagRequestID, reqID).
-				Str(tagServiceName, serviceName).
-				Str(tagProvider, provider.String()).
-				Msg("service request don't exist")
+			s.Error("service request don't exist",
+				"err", err,
+				tagRequestID, reqID,
+				tagServiceName, serviceName,
+				tagProvider, s.AddrPrefixCfg().AccAddressString(provider))
 			continue
 		}
 		if provider.Equals(request.Provider) && request.ServiceName == serviceName {
@@ -16,7 +16,7 @@ import (
 type ServiceTestSuite struct {
 	suite.Suite
 	*test.MockClient
-	*log.Logger
+	log.Logger
 }
 
 func TestKeeperTestSuite(t *testing.T) {
@@ -86,12 +86,11 @@ func (sts *ServiceTestSuite) TestService() {
 	var sub1 sdk.Subscription
 	router := rpc.ServiceRegistry{
 		definition.ServiceName: func(reqCtxID, reqID, input string) (string, string) {
-			sts.Info().
-				Str("reqCtxID", reqCtxID).
-				Str("reqID", reqID).
-				Str("input", input).
-				Str("output", output).
-				Msg("provider received request")
+			sts.Info("provider received request",
+				"reqCtxID", reqCtxID,
+				"reqID", reqID,
+				"input", input,
+				"output", output)
 			_, err := sts.Service().QueryResponse(reqID)
 			require.NoError(sts.T(), err)
 			return output, testResult
@@ -123,11 +122,7 @@ func (sts *ServiceTestSuite) TestService() {
 	require.NoError(sts.T(), err)
 
 	sub2, err = sts.Service().SubscribeServiceResponse(requestContextID, func(reqCtxID, reqID, responses string) {
-		sts.Info().
-			Str("reqCtxID", reqCtxID).
-			Str("reqID", reqID).
-			Str("response", responses).
-			Msg("consumer received response")
+		sts.Info("consumer received response", "reqCtxID", reqCtxID, "reqID", reqID, "response", responses)
 
 		require.Equal(sts.T(), reqCtxID, requestContextID)
 		require.Equal(sts.T(), output, responses)
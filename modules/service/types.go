@@ -72,6 +72,15 @@ func (msg MsgDefineService) Type() string {
 	return "define_service"
 }
 
+// Implements NoSpendMsg.
+func (msg MsgDefineService) SpendsNoCoins() {}
+
+// Implements RecipientMsg.
+func (msg MsgDefineService) Recipients() []sdk.AccAddress { return nil }
+
+// Implements NoValidatorMsg.
+func (msg MsgDefineService) ActsOnNoValidator() {}
+
 func (msg MsgDefineService) ValidateBasic() error {
 	if len(msg.Author) == 0 {
 		return errors.New("author missing")
@@ -88,11 +97,11 @@ func (msg MsgDefineService) ValidateBasic() error {
 	return nil
 }
 
+// the msg without tags is signed with null tags
 func (msg MsgDefineService) GetSignBytes() []byte {
 	if len(msg.Tags) == 0 {
 		msg.Tags = nil
 	}
-
 	b, err := cdc.MarshalJSON(msg)
 	if err != nil {
 		panic(err)
@@ -101,6 +110,31 @@ func (msg MsgDefineService) GetSignBytes() []byte {
 	return json.MustSort(b)
 }
 
+// Implements SignDocMsg, the msg without tags is signed with null tags.
+func (msg MsgDefineService) SignDoc(config *sdk.AddrPrefixCfg) interface{} {
+	if len(msg.Tags) == 0 {
+		msg.Tags = nil
+	}
+	return sdk.TypedSignDoc{
+		Type: "irishub/service/MsgDefineService",
+		Value: struct {
+			Name              string   `json:"name"`
+			Description       string   `json:"description"`
+			Tags              []string `json:"tags"`
+			Author            string   `json:"author"`
+			AuthorDescription string   `json:"author_description"`
+			Schemas           string   `json:"schemas"`
+		}{
+			Name:              msg.Name,
+			Description:       msg.Description,
+			Tags:              msg.Tags,
+			Author:            config.AccAddressString(msg.Author),
+			AuthorDescription: msg.AuthorDescription,
+			Schemas:           msg.Schemas,
+		},
+	}
+}
+
 func (msg MsgDefineService) GetSigners() []sdk.AccAddress {
 	return []sdk.AccAddress{msg.Author}
 }
@@ -118,6 +152,15 @@ func (msg MsgBindService) Type() string {
 	return "bind_service"
 }
 
+// Implements SpendMsg.
+func (msg MsgBindService) SpentCoins() sdk.Coins { return msg.Deposit }
+
+// Implements SpendMsg.
+func (msg MsgBindService) Recipients() []sdk.AccAddress { return nil }
+
+// Implements NoValidatorMsg.
+func (msg MsgBindService) ActsOnNoValidator() {}
+
 func (msg MsgBindService) Route() string { return ModuleName }
 
 func (msg MsgBindService) ValidateBasic() error {
@@ -144,6 +187,26 @@ func (msg MsgBindService) GetSignBytes() []byte {
 	return json.MustSort(b)
 }
 
+// Implements SignDocMsg.
+func (msg MsgBindService) SignDoc(config *sdk.AddrPrefixCfg) interface{} {
+	return sdk.TypedSignDoc{
+		Type: "irishub/service/MsgBindService",
+		Value: struct {
+			ServiceName string    `json:"service_name"`
+			Provider    string    `json:"provider"`
+			Deposit     sdk.Coins `json:"deposit"`
+			Pricing     string    `json:"pricing"`
+			MinRespTime uint64    `json:"min_resp_time"`
+		}{
+			ServiceName: msg.ServiceName,
+			Provider:    config.AccAddressString(msg.Provider),
+			Deposit:     msg.Deposit,
+			Pricing:     msg.Pricing,
+			MinRespTime: msg.MinRespTime,
+		},
+	}
+}
+
 func (msg MsgBindService) GetSigners() []sdk.AccAddress {
 	return []sdk.AccAddress{msg.Provider}
 }
@@ -168,6 +231,12 @@ func (msg MsgCallService) Type() string {
 	return "request_service"
 }
 
+// Implements RecipientMsg, the providers are paid the service fees.
+func (msg MsgCallService) Recipients() []sdk.AccAddress { return msg.Providers }
+
+// Implements NoValidatorMsg.
+func (msg MsgCallService) ActsOnNoValidator() {}
+
 func (msg MsgCallService) ValidateBasic() error {
 	if len(msg.Consumer) == 0 {
 		return errors.New("consumer missing")
@@ -195,6 +264,36 @@ func (msg MsgCallService) GetSignBytes() []byte {
 	return json.MustSort(b)
 }
 
+// Implements SignDocMsg.
+func (msg MsgCallService) SignDoc(config *sdk.AddrPrefixCfg) interface{} {
+	return sdk.TypedSignDoc{
+		Type: "irishub/service/MsgCallService",
+		Value: struct {
+			ServiceName       string    `json:"service_name"`
+			Providers         []string  `json:"providers"`
+			Consumer          string    `json:"consumer"`
+			Input             string    `json:"input"`
+			ServiceFeeCap     sdk.Coins `json:"service_fee_cap"`
+			Timeout           int64     `json:"timeout"`
+			SuperMode         bool      `json:"super_mode"`
+			Repeated          bool      `json:"repeated"`
+			RepeatedFrequency uint64    `json:"repeated_frequency"`
+			RepeatedTotal     int64     `json:"repeated_total"`
+		}{
+			ServiceName:       msg.ServiceName,
+			Providers:         config.AccAddressStrings(msg.Providers),
+			Consumer:          config.AccAddressString(msg.Consumer),
+			Input:             msg.Input,
+			ServiceFeeCap:     msg.ServiceFeeCap,
+			Timeout:           msg.Timeout,
+			SuperMode:         msg.SuperMode,
+			Repeated:          msg.Repeated,
+			RepeatedFrequency: msg.RepeatedFrequency,
+			RepeatedTotal:     msg.RepeatedTotal,
+		},
+	}
+}
+
 func (msg MsgCallService) GetSigners() []sdk.AccAddress {
 	return []sdk.AccAddress{msg.Consumer}
 }
@@ -213,6 +312,15 @@ func (msg MsgRespondService) Type() string {
 	return "respond_service"
 }
 
+// Implements NoSpendMsg.
+func (msg MsgRespondService) SpendsNoCoins() {}
+
+// Implements RecipientMsg.
+func (msg MsgRespondService) Recipients() []sdk.AccAddress { return nil }
+
+// Implements NoValidatorMsg.
+func (msg MsgRespondService) ActsOnNoValidator() {}
+
 func (msg MsgRespondService) ValidateBasic() error {
 	if len(msg.Provider) == 0 {
 		return errors.New("provider missing")
@@ -240,6 +348,24 @@ func (msg MsgRespondService) GetSignBytes() []byte {
 	return json.MustSort(b)
 }
 
+// Implements SignDocMsg.
+func (msg MsgRespondService) SignDoc(config *sdk.AddrPrefixCfg) interface{} {
+	return sdk.TypedSignDoc{
+		Type: "irishub/service/MsgRespondService",
+		Value: struct {
+			RequestID cmn.HexBytes `json:"request_id"`
+			Provider  string       `json:"provider"`
+			Result    string       `json:"result"`
+			Output    string       `json:"output"`
+		}{
+			RequestID: msg.RequestID,
+			Provider:  config.AccAddressString(msg.Provider),
+			Result:    msg.Result,
+			Output:    msg.Output,
+		},
+	}
+}
+
 func (msg MsgRespondService) GetSigners() []sdk.AccAddress {
 	return []sdk.AccAddress{msg.Provider}
 }
@@ -259,6 +385,15 @@ func (msg MsgUpdateServiceBinding) Route() string { return ModuleName }
 // Type implements Msg.
 func (msg MsgUpdateServiceBinding) Type() string { return "update_service_binding" }
 
+// Implements SpendMsg.
+func (msg MsgUpdateServiceBinding) SpentCoins() sdk.Coins { return msg.Deposit }
+
+// Implements SpendMsg.
+func (msg MsgUpdateServiceBinding) Recipients() []sdk.AccAddress { return nil }
+
+// Implements NoValidatorMsg.
+func (msg MsgUpdateServiceBinding) ActsOnNoValidator() {}
+
 // GetSignBytes implements Msg.
 func (msg MsgUpdateServiceBinding) GetSignBytes() []byte {
 	b, err := cdc.MarshalJSON(msg)
@@ -269,6 +404,24 @@ func (msg MsgUpdateServiceBinding) GetSignBytes() []byte {
 	return json.MustSort(b)
 }
 
+// Implements SignDocMsg.
+func (msg MsgUpdateServiceBinding) SignDoc(config *sdk.AddrPrefixCfg) interface{} {
+	return sdk.TypedSignDoc{
+		Type: "irishub/service/MsgUpdateServiceBinding",
+		Value: struct {
+			ServiceName string    `json:"service_name"`
+			Provider    string    `json:"provider"`
+			Deposit     sdk.Coins `json:"deposit"`
+			Pricing     string    `json:"pricing"`
+		}{
+			ServiceName: msg.ServiceName,
+			Provider:    config.AccAddressString(msg.Provider),
+			Deposit:     msg.Deposit,
+			Pricing:     msg.Pricing,
+		},
+	}
+}
+
 // ValidateBasic implements Msg.
 func (msg MsgUpdateServiceBinding) ValidateBasic() error {
 	if len(msg.Provider) == 0 {
@@ -304,6 +457,17 @@ func (msg MsgSetWithdrawAddress) Route() string { return ModuleName }
 // Type implements Msg.
 func (msg MsgSetWithdrawAddress) Type() string { return "set_withdraw_address" }
 
+// Implements NoSpendMsg.
+func (msg MsgSetWithdrawAddress) SpendsNoCoins() {}
+
+// Implements RecipientMsg.
+func (msg MsgSetWithdrawAddress) Recipients() []sdk.AccAddress {
+	return []sdk.AccAddress{msg.WithdrawAddress}
+}
+
+// Implements NoValidatorMsg.
+func (msg MsgSetWithdrawAddress) ActsOnNoValidator() {}
+
 // GetSignBytes implements Msg.
 func (msg MsgSetWithdrawAddress) GetSignBytes() []byte {
 	b, err := cdc.MarshalJSON(msg)
@@ -314,6 +478,20 @@ func (msg MsgSetWithdrawAddress) GetSignBytes() []byte {
 	return json.MustSort(b)
 }
 
+// Implements SignDocMsg.
+func (msg MsgSetWithdrawAddress) SignDoc(config *sdk.AddrPrefixCfg) interface{} {
+	return sdk.TypedSignDoc{
+		Type: "irishub/service/MsgSetWithdrawAddress",
+		Value: struct {
+			Provider        string `json:"provider"`
+			WithdrawAddress string `json:"withdraw_address"`
+		}{
+			Provider:        config.AccAddressString(msg.Provider),
+			WithdrawAddress: config.AccAddressString(msg.WithdrawAddress),
+		},
+	}
+}
+
 // ValidateBasic implements Msg.
 func (msg MsgSetWithdrawAddress) ValidateBasic() error {
 	if len(msg.Provider) == 0 {
@@ -345,6 +523,15 @@ func (msg MsgDisableServiceBinding) Route() string { return ModuleName }
 // Type implements Msg.
 func (msg MsgDisableServiceBinding) Type() string { return "disable_service" }
 
+// Implements NoSpendMsg.
+func (msg MsgDisableServiceBinding) SpendsNoCoins() {}
+
+// Implements RecipientMsg.
+func (msg MsgDisableServiceBinding) Recipients() []sdk.AccAddress { return nil }
+
+// Implements NoValidatorMsg.
+func (msg MsgDisableServiceBinding) ActsOnNoValidator() {}
+
 // GetSignBytes implements Msg.
 func (msg MsgDisableServiceBinding) GetSignBytes() []byte {
 	b, err := cdc.MarshalJSON(msg)
@@ -355,6 +542,20 @@ func (msg MsgDisableServiceBinding) GetSignBytes() []byte {
 	return json.MustSort(b)
 }
 
+// Implements SignDocMsg.
+func (msg MsgDisableServiceBinding) SignDoc(config *sdk.AddrPrefixCfg) interface{} {
+	return sdk.TypedSignDoc{
+		Type: "irishub/service/MsgDisableServiceBinding",
+		Value: struct {
+			ServiceName string `json:"service_name"`
+			Provider    string `json:"provider"`
+		}{
+			ServiceName: msg.ServiceName,
+			Provider:    config.AccAddressString(msg.Provider),
+		},
+	}
+}
+
 // ValidateBasic implements Msg.
 func (msg MsgDisableServiceBinding) ValidateBasic() error {
 	if len(msg.Provider) == 0 {
@@ -387,6 +588,15 @@ func (msg MsgEnableServiceBinding) Route() string { return ModuleName }
 // Type implements Msg.
 func (msg MsgEnableServiceBinding) Type() string { return "enable_service" }
 
+// Implements SpendMsg.
+func (msg MsgEnableServiceBinding) SpentCoins() sdk.Coins { return msg.Deposit }
+
+// Implements SpendMsg.
+func (msg MsgEnableServiceBinding) Recipients() []sdk.AccAddress { return nil }
+
+// Implements NoValidatorMsg.
+func (msg MsgEnableServiceBinding) ActsOnNoValidator() {}
+
 // GetSignBytes implements Msg.
 func (msg MsgEnableServiceBinding) GetSignBytes() []byte {
 	b, err := cdc.MarshalJSON(msg)
@@ -397,6 +607,22 @@ func (msg MsgEnableServiceBinding) GetSignBytes() []byte {
 	return json.MustSort(b)
 }
 
+// Implements SignDocMsg.
+func (msg MsgEnableServiceBinding) SignDoc(config *sdk.AddrPrefixCfg) interface{} {
+	return sdk.TypedSignDoc{
+		Type: "irishub/service/MsgEnableServiceBinding",
+		Value: struct {
+			ServiceName string    `json:"service_name"`
+			Provider    string    `json:"provider"`
+			Deposit     sdk.Coins `json:"deposit"`
+		}{
+			ServiceName: msg.ServiceName,
+			Provider:    config.AccAddressString(msg.Provider),
+			Deposit:     msg.Deposit,
+		},
+	}
+}
+
 // ValidateBasic implements Msg.
 func (msg MsgEnableServiceBinding) ValidateBasic() error {
 	if len(msg.Provider) == 0 {
@@ -432,6 +658,15 @@ func (msg MsgRefundServiceDeposit) Route() string { return ModuleName }
 // Type implements Msg.
 func (msg MsgRefundServiceDeposit) Type() string { return "refund_service_deposit" }
 
+// Implements NoSpendMsg.
+func (msg MsgRefundServiceDeposit) SpendsNoCoins() {}
+
+// Implements RecipientMsg.
+func (msg MsgRefundServiceDeposit) Recipients() []sdk.AccAddress { return nil }
+
+// Implements NoValidatorMsg.
+func (msg MsgRefundServiceDeposit) ActsOnNoValidator() {}
+
 // GetSignBytes implements Msg.
 func (msg MsgRefundServiceDeposit) GetSignBytes() []byte {
 	b, err := cdc.MarshalJSON(msg)
@@ -442,6 +677,20 @@ func (msg MsgRefundServiceDeposit) GetSignBytes() []byte {
 	return json.MustSort(b)
 }
 
+// Implements SignDocMsg.
+func (msg MsgRefundServiceDeposit) SignDoc(config *sdk.AddrPrefixCfg) interface{} {
+	return sdk.TypedSignDoc{
+		Type: "irishub/service/MsgRefundServiceDeposit",
+		Value: struct {
+			ServiceName string `json:"service_name"`
+			Provider    string `json:"provider"`
+		}{
+			ServiceName: msg.ServiceName,
+			Provider:    config.AccAddressString(msg.Provider),
+		},
+	}
+}
+
 // ValidateBasic implements Msg.
 func (msg MsgRefundServiceDeposit) ValidateBasic() error {
 	if len(msg.Provider) == 0 {
@@ -473,6 +722,15 @@ func (msg MsgPauseRequestContext) Route() string { return ModuleName }
 // Type implements Msg.
 func (msg MsgPauseRequestContext) Type() string { return "pause_request_context" }
 
+// Implements NoSpendMsg.
+func (msg MsgPauseRequestContext) SpendsNoCoins() {}
+
+// Implements RecipientMsg.
+func (msg MsgPauseRequestContext) Recipients() []sdk.AccAddress { return nil }
+
+// Implements NoValidatorMsg.
+func (msg MsgPauseRequestContext) ActsOnNoValidator() {}
+
 // GetSignBytes implements Msg.
 func (msg MsgPauseRequestContext) GetSignBytes() []byte {
 	b, err := cdc.MarshalJSON(msg)
@@ -483,6 +741,20 @@ func (msg MsgPauseRequestContext) GetSignBytes() []byte {
 	return json.MustSort(b)
 }
 
+// Implements SignDocMsg.
+func (msg MsgPauseRequestContext) SignDoc(config *sdk.AddrPrefixCfg) interface{} {
+	return sdk.TypedSignDoc{
+		Type: "irishub/service/MsgPauseRequestContext",
+		Value: struct {
+			RequestContextID cmn.HexBytes `json:"request_context_id"`
+			Consumer         string       `json:"consumer"`
+		}{
+			RequestContextID: msg.RequestContextID,
+			Consumer:         config.AccAddressString(msg.Consumer),
+		},
+	}
+}
+
 // ValidateBasic implements Msg.
 func (msg MsgPauseRequestContext) ValidateBasic() error {
 	if len(msg.Consumer) == 0 {
@@ -509,6 +781,12 @@ func (msg MsgStartRequestContext) Route() string { return ModuleName }
 // Type implements Msg.
 func (msg MsgStartRequestContext) Type() string { return "start_request_context" }
 
+// Implements RecipientMsg, the providers of the request context are those of MsgCallService and MsgUpdateRequestContext.
+func (msg MsgStartRequestContext) Recipients() []sdk.AccAddress { return nil }
+
+// Implements NoValidatorMsg.
+func (msg MsgStartRequestContext) ActsOnNoValidator() {}
+
 // GetSignBytes implements Msg.
 func (msg MsgStartRequestContext) GetSignBytes() []byte {
 	b, err := cdc.MarshalJSON(msg)
@@ -519,6 +797,20 @@ func (msg MsgStartRequestContext) GetSignBytes() []byte {
 	return json.MustSort(b)
 }
 
+// Implements SignDocMsg.
+func (msg MsgStartRequestContext) SignDoc(config *sdk.AddrPrefixCfg) interface{} {
+	return sdk.TypedSignDoc{
+		Type: "irishub/service/MsgStartRequestContext",
+		Value: struct {
+			RequestContextID cmn.HexBytes `json:"request_context_id"`
+			Consumer         string       `json:"consumer"`
+		}{
+			RequestContextID: msg.RequestContextID,
+			Consumer:         config.AccAddressString(msg.Consumer),
+		},
+	}
+}
+
 // ValidateBasic implements Msg.
 func (msg MsgStartRequestContext) ValidateBasic() error {
 	if len(msg.Consumer) == 0 {
@@ -545,6 +837,15 @@ func (msg MsgKillRequestContext) Route() string { return ModuleName }
 // Type implements Msg.
 func (msg MsgKillRequestContext) Type() string { return "kill_request_context" }
 
+// Implements NoSpendMsg.
+func (msg MsgKillRequestContext) SpendsNoCoins() {}
+
+// Implements RecipientMsg.
+func (msg MsgKillRequestContext) Recipients() []sdk.AccAddress { return nil }
+
+// Implements NoValidatorMsg.
+func (msg MsgKillRequestContext) ActsOnNoValidator() {}
+
 // GetSignBytes implements Msg.
 func (msg MsgKillRequestContext) GetSignBytes() []byte {
 	b, err := cdc.MarshalJSON(msg)
@@ -555,6 +856,20 @@ func (msg MsgKillRequestContext) GetSignBytes() []byte {
 	return json.MustSort(b)
 }
 
+// Implements SignDocMsg.
+func (msg MsgKillRequestContext) SignDoc(config *sdk.AddrPrefixCfg) interface{} {
+	return sdk.TypedSignDoc{
+		Type: "irishub/service/MsgKillRequestContext",
+		Value: struct {
+			RequestContextID cmn.HexBytes `json:"request_context_id"`
+			Consumer         string       `json:"consumer"`
+		}{
+			RequestContextID: msg.RequestContextID,
+			Consumer:         config.AccAddressString(msg.Consumer),
+		},
+	}
+}
+
 // ValidateBasic implements Msg.
 func (msg MsgKillRequestContext) ValidateBasic() error {
 	if len(msg.Consumer) == 0 {
@@ -587,6 +902,12 @@ func (msg MsgUpdateRequestContext) Route() string { return ModuleName }
 // Type implements Msg.
 func (msg MsgUpdateRequestContext) Type() string { return "update_request_context" }
 
+// Implements RecipientMsg.
+func (msg MsgUpdateRequestContext) Recipients() []sdk.AccAddress { return msg.Providers }
+
+// Implements NoValidatorMsg.
+func (msg MsgUpdateRequestContext) ActsOnNoValidator() {}
+
 // GetSignBytes implements Msg.
 func (msg MsgUpdateRequestContext) GetSignBytes() []byte {
 	b, err := cdc.MarshalJSON(msg)
@@ -597,6 +918,30 @@ func (msg MsgUpdateRequestContext) GetSignBytes() []byte {
 	return json.MustSort(b)
 }
 
+// Implements SignDocMsg.
+func (msg MsgUpdateRequestContext) SignDoc(config *sdk.AddrPrefixCfg) interface{} {
+	return sdk.TypedSignDoc{
+		Type: "irishub/service/MsgUpdateRequestContext",
+		Value: struct {
+			RequestContextID  cmn.HexBytes `json:"request_context_id"`
+			Providers         []string     `json:"providers"`
+			ServiceFeeCap     sdk.Coins    `json:"service_fee_cap"`
+			Timeout           int64        `json:"timeout"`
+			RepeatedFrequency uint64       `json:"repeated_frequency"`
+			RepeatedTotal     int64        `json:"repeated_total"`
+			Consumer          string       `json:"consumer"`
+		}{
+			RequestContextID:  msg.RequestContextID,
+			Providers:         config.AccAddressStrings(msg.Providers),
+			ServiceFeeCap:     msg.ServiceFeeCap,
+			Timeout:           msg.Timeout,
+			RepeatedFrequency: msg.RepeatedFrequency,
+			RepeatedTotal:     msg.RepeatedTotal,
+			Consumer:          config.AccAddressString(msg.Consumer),
+		},
+	}
+}
+
 // ValidateBasic implements Msg.
 func (msg MsgUpdateRequestContext) ValidateBasic() error {
 	if len(msg.Consumer) == 0 {
@@ -623,6 +968,15 @@ func (msg MsgWithdrawEarnedFees) Route() string { return ModuleName }
 // Type implements Msg.
 func (msg MsgWithdrawEarnedFees) Type() string { return "withdraw_earned_fees" }
 
+// Implements NoSpendMsg.
+func (msg MsgWithdrawEarnedFees) SpendsNoCoins() {}
+
+// Implements RecipientMsg.
+func (msg MsgWithdrawEarnedFees) Recipients() []sdk.AccAddress { return nil }
+
+// Implements NoValidatorMsg.
+func (msg MsgWithdrawEarnedFees) ActsOnNoValidator() {}
+
 // GetSignBytes implements Msg.
 func (msg MsgWithdrawEarnedFees) GetSignBytes() []byte {
 	b, err := cdc.MarshalJSON(msg)
@@ -633,6 +987,18 @@ func (msg MsgWithdrawEarnedFees) GetSignBytes() []byte {
 	return json.MustSort(b)
 }
 
+// Implements SignDocMsg.
+func (msg MsgWithdrawEarnedFees) SignDoc(config *sdk.AddrPrefixCfg) interface{} {
+	return sdk.TypedSignDoc{
+		Type: "irishub/service/MsgWithdrawEarnedFees",
+		Value: struct {
+			Provider string `json:"provider"`
+		}{
+			Provider: config.AccAddressString(msg.Provider),
+		},
+	}
+}
+
 // ValidateBasic implements Msg.
 func (msg MsgWithdrawEarnedFees) ValidateBasic() error {
 	if len(msg.Provider) == 0 {
@@ -661,6 +1027,15 @@ func (msg MsgWithdrawTax) Route() string { return ModuleName }
 // Type implements Msg.
 func (msg MsgWithdrawTax) Type() string { return "withdraw_tax" }
 
+// Implements NoSpendMsg.
+func (msg MsgWithdrawTax) SpendsNoCoins() {}
+
+// Implements RecipientMsg.
+func (msg MsgWithdrawTax) Recipients() []sdk.AccAddress { return []sdk.AccAddress{msg.DestAddress} }
+
+// Implements NoValidatorMsg.
+func (msg MsgWithdrawTax) ActsOnNoValidator() {}
+
 // GetSignBytes implements Msg.
 func (msg MsgWithdrawTax) GetSignBytes() []byte {
 	b, err := cdc.MarshalJSON(msg)
@@ -671,6 +1046,22 @@ func (msg MsgWithdrawTax) GetSignBytes() []byte {
 	return json.MustSort(b)
 }
 
+// Implements SignDocMsg.
+func (msg MsgWithdrawTax) SignDoc(config *sdk.AddrPrefixCfg) interface{} {
+	return sdk.TypedSignDoc{
+		Type: "irishub/service/MsgWithdrawTax",
+		Value: struct {
+			Trustee     string    `json:"trustee"`
+			DestAddress string    `json:"dest_address"`
+			Amount      sdk.Coins `json:"amount"`
+		}{
+			Trustee:     config.AccAddressString(msg.Trustee),
+			DestAddress: config.AccAddressString(msg.DestAddress),
+			Amount:      msg.Amount,
+		},
+	}
+}
+
 // ValidateBasic implements Msg.
 func (msg MsgWithdrawTax) ValidateBasic() error {
 	if len(msg.Trustee) == 0 {
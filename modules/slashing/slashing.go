@@ -7,32 +7,44 @@ import (
 
 	sdk "github.com/irisnet/irishub-sdk-go/types"
 	"github.com/irisnet/irishub-sdk-go/utils/log"
+	"github.com/irisnet/irishub-sdk-go/utils/proto"
 )
 
 type slashingClient struct {
 	sdk.BaseClient
-	*log.Logger
+	log.Logger
+	queries *sdk.VersionedQueries
+}
+
+// querier is implemented by the queries of each version of the chain
+type querier interface {
+	queryParams() (rpc.SlashingParams, sdk.Error)
+	querySigningInfo(pk crypto.PubKey) (rpc.ValidatorSigningInfo, sdk.Error)
 }
 
 func Create(ac sdk.BaseClient) rpc.Slashing {
+	queries := new(sdk.VersionedQueries).
+		Register(sdk.V017, querierV017{ac}).
+		Register(sdk.V100, querierV100{ac})
 	return slashingClient{
 		BaseClient: ac,
 		Logger:     ac.Logger(),
+		queries:    queries,
 	}
 }
 
 //QueryParams return parameter for slashing at genesis
 func (s slashingClient) QueryParams() (rpc.SlashingParams, sdk.Error) {
-	return s.queryParamsV017()
+	return s.querier().queryParams()
 }
 
 //QueryValidatorSigningInfo return the specified validator sign information
 func (s slashingClient) QueryValidatorSigningInfo(validatorConPubKey string) (rpc.ValidatorSigningInfo, sdk.Error) {
-	pk, err := sdk.GetConsPubKeyBech32(validatorConPubKey)
+	pk, err := s.AddrPrefixCfg().GetConsPubKeyBech32(validatorConPubKey)
 	if err != nil {
 		return rpc.ValidatorSigningInfo{}, sdk.Wrap(err)
 	}
-	return s.querySigningInfoV017(pk)
+	return s.querier().querySigningInfo(pk)
 }
 
 func (s slashingClient) RegisterCodec(cdc sdk.Codec) {
@@ -43,11 +55,20 @@ func (s slashingClient) Name() string {
 	return ModuleName
 }
 
-func (s slashingClient) queryParamsV017() (rpc.SlashingParams, sdk.Error) {
+// querier returns the queries of the version of the chain
+func (s slashingClient) querier() querier {
+	return s.queries.Route(s.ChainVersion()).(querier)
+}
+
+type querierV017 struct {
+	sdk.BaseClient
+}
+
+func (s querierV017) queryParams() (rpc.SlashingParams, sdk.Error) {
 	param := struct {
 		Module string
 	}{
-		Module: s.Name(),
+		Module: ModuleName,
 	}
 
 	var params paramsV017
@@ -57,18 +78,9 @@ func (s slashingClient) queryParamsV017() (rpc.SlashingParams, sdk.Error) {
 	return params.Convert().(rpc.SlashingParams), nil
 }
 
-func (s slashingClient) queryParamsV100() (rpc.SlashingParams, error) {
-	var params params
-	err := s.QueryWithResponse("custom/%s/parameters", s.Name(), &params)
-	if err != nil {
-		return rpc.SlashingParams{}, err
-	}
-	return params.Convert().(rpc.SlashingParams), nil
-}
-
-func (s slashingClient) querySigningInfoV017(pk crypto.PubKey) (rpc.ValidatorSigningInfo, sdk.Error) {
+func (s querierV017) querySigningInfo(pk crypto.PubKey) (rpc.ValidatorSigningInfo, sdk.Error) {
 	key := append([]byte{0x01}, pk.Address().Bytes()...)
-	res, err := s.QueryStore(key, s.Name())
+	res, err := s.QueryStore(key, ModuleName)
 	if err != nil {
 		return rpc.ValidatorSigningInfo{}, sdk.Wrap(err)
 	}
@@ -81,7 +93,7 @@ func (s slashingClient) querySigningInfoV017(pk crypto.PubKey) (rpc.ValidatorSig
 
 	consAddr := sdk.ConsAddress(pk.Address())
 	return rpc.ValidatorSigningInfo{
-		Address:             consAddr.String(),
+		Address:             s.AddrPrefixCfg().ConsAddressString(consAddr),
 		StartHeight:         signingInfo.StartHeight,
 		IndexOffset:         signingInfo.IndexOffset,
 		JailedUntil:         signingInfo.JailedUntil,
@@ -90,18 +102,32 @@ func (s slashingClient) querySigningInfoV017(pk crypto.PubKey) (rpc.ValidatorSig
 	}, nil
 }
 
-func (s slashingClient) querySigningInfoV100(pk crypto.PubKey) (rpc.ValidatorSigningInfo, error) {
-	consAddr := sdk.ConsAddress(pk.Address())
-	param := struct {
-		ConsAddress sdk.ConsAddress
-	}{
-		ConsAddress: consAddr,
+type querierV100 struct {
+	sdk.BaseClient
+}
+
+func (s querierV100) queryParams() (rpc.SlashingParams, sdk.Error) {
+	res, err := s.QueryProto("/cosmos.slashing.v1beta1.Query/Params", nil)
+	if err != nil {
+		return rpc.SlashingParams{}, sdk.Wrap(err)
+	}
+	params, err := unmarshalProtoParams(res)
+	if err != nil {
+		return rpc.SlashingParams{}, sdk.Wrap(err)
 	}
+	return params, nil
+}
 
-	var signingInfo validatorSigningInfo
-	err := s.QueryWithResponse("custom/slashing/signingInfo", param, &signingInfo)
+func (s querierV100) querySigningInfo(pk crypto.PubKey) (rpc.ValidatorSigningInfo, sdk.Error) {
+	consAddr := s.AddrPrefixCfg().ConsAddressString(sdk.ConsAddress(pk.Address()))
+	req := new(proto.Encoder).String(1, consAddr).Bytes()
+	res, err := s.QueryProto("/cosmos.slashing.v1beta1.Query/SigningInfo", req)
 	if err != nil {
-		return rpc.ValidatorSigningInfo{}, err
+		return rpc.ValidatorSigningInfo{}, sdk.Wrap(err)
+	}
+	signingInfo, err := unmarshalProtoSigningInfo(res)
+	if err != nil {
+		return rpc.ValidatorSigningInfo{}, sdk.Wrap(err)
 	}
-	return signingInfo.Convert().(rpc.ValidatorSigningInfo), err
+	return signingInfo, nil
 }
@@ -1,10 +1,19 @@
 package slashing_test
 
 import (
+	"fmt"
 	"github.com/stretchr/testify/require"
 	"testing"
+	"time"
 
+	"github.com/tendermint/tendermint/crypto/ed25519"
+
+	sdk "github.com/irisnet/irishub-sdk-go"
+	"github.com/irisnet/irishub-sdk-go/rpc"
 	"github.com/irisnet/irishub-sdk-go/test"
+	"github.com/irisnet/irishub-sdk-go/test/fakechain"
+	"github.com/irisnet/irishub-sdk-go/types"
+	"github.com/irisnet/irishub-sdk-go/utils/proto"
 	"github.com/stretchr/testify/suite"
 )
 
@@ -38,3 +47,110 @@ func (sts *SlashingTestSuite) TestQueryValidatorSigningInfo() {
 	require.NotEmpty(sts.T(), signingInfo)
 	require.NotEmpty(sts.T(), signingInfo.IndexOffset)
 }
+
+func TestQueryParamsOfVersion(t *testing.T) {
+	paramsV017 := `{"type":"irishub/slashing/Params","value":{"max_evidence_age":"10","signed_blocks_window":"100",` +
+		`"min_signed_per_window":"0.5","double_sign_jail_duration":"0","downtime_jail_duration":"0","censorship_jail_duration":"0",` +
+		`"slash_fraction_double_sign":"0.01","slash_fraction_downtime":"0.005","slash_fraction_censorship":"0"}}`
+	// the params of IRIShub 1.x are those of the gRPC query of the Cosmos SDK 0.40+
+	params := new(proto.Encoder).Message(1, new(proto.Encoder).
+		Uint64(1, 200).
+		String(2, "500000000000000000").
+		Message(3, new(proto.Encoder).Uint64(1, 600).Bytes()).
+		String(4, "50000000000000000").
+		String(5, "10000000000000000").
+		Bytes()).Bytes()
+
+	fees, e := types.ParseDecCoins("0.3iris")
+	require.NoError(t, e)
+
+	for _, tc := range []struct {
+		version string
+		window  int64
+		jail    string
+	}{
+		{"0.16.3", 100, "0s"},
+		{"1.0.0", 200, "10m0s"},
+	} {
+		chain := fakechain.New(fakechain.WithAppVersion(tc.version))
+		chain.RegisterQuerier("custom/params/module", func(fakechain.Context, []byte) ([]byte, error) {
+			return []byte(paramsV017), nil
+		})
+		chain.RegisterQuerier("/cosmos.slashing.v1beta1.Query/Params", func(fakechain.Context, []byte) ([]byte, error) {
+			return params, nil
+		})
+		client := sdk.NewClient(types.ClientConfig{
+			TmClient:   chain,
+			ChainID:    "test",
+			TxEncoding: types.Amino,
+			Fee:        fees,
+			KeyDAO:     types.NewMemoryDB(),
+		})
+		require.Equal(t, types.MustParseVersion(tc.version), client.ChainVersion())
+
+		res, err := client.Slashing().QueryParams()
+		require.NoError(t, err, tc.version)
+		require.Equal(t, tc.window, res.SignedBlocksWindow, tc.version)
+		require.Equal(t, tc.jail, res.DowntimeJailDuration, tc.version)
+	}
+
+	chain := fakechain.New(fakechain.WithAppVersion("1.0.0"))
+	chain.RegisterQuerier("/cosmos.slashing.v1beta1.Query/Params", func(fakechain.Context, []byte) ([]byte, error) {
+		return params, nil
+	})
+	client := sdk.NewClient(types.ClientConfig{
+		TmClient: chain,
+		ChainID:  "test",
+		Fee:      fees,
+		KeyDAO:   types.NewMemoryDB(),
+	})
+	res, err := client.Slashing().QueryParams()
+	require.NoError(t, err)
+	require.Equal(t, "0.500000000000000000", res.MinSignedPerWindow)
+	require.Equal(t, "0.050000000000000000", res.SlashFractionDoubleSign)
+	require.Equal(t, "0.010000000000000000", res.SlashFractionDowntime)
+}
+
+func TestQuerySigningInfoV100(t *testing.T) {
+	pk := ed25519.GenPrivKey().PubKey()
+	prefixes := types.Mainnet.AddrPrefixCfg()
+	consAddr := prefixes.ConsAddressString(types.ConsAddress(pk.Address()))
+	consPub, e := prefixes.Bech32ifyConsPub(pk)
+	require.NoError(t, e)
+
+	chain := fakechain.New(fakechain.WithChainID("irishub-1"), fakechain.WithAppVersion("1.0.0"))
+	chain.RegisterQuerier("/cosmos.slashing.v1beta1.Query/SigningInfo", func(_ fakechain.Context, data []byte) ([]byte, error) {
+		fields, err := proto.Decode(data)
+		if err != nil || len(fields) != 1 || string(fields[0].Value) != consAddr {
+			return nil, fmt.Errorf("unexpected request %x", data)
+		}
+		info := new(proto.Encoder).
+			String(1, consAddr).
+			Uint64(2, 10).
+			Uint64(3, 42).
+			Message(4, new(proto.Encoder).Uint64(1, 1600000000).Bytes()).
+			Uint64(5, 1).
+			Uint64(6, 3)
+		return new(proto.Encoder).Message(1, info.Bytes()).Bytes(), nil
+	})
+	fees, e := types.ParseDecCoins("0.3iris")
+	require.NoError(t, e)
+	client := sdk.NewClient(types.ClientConfig{
+		TmClient: chain,
+		Network:  types.Mainnet,
+		ChainID:  "irishub-1",
+		Fee:      fees,
+		KeyDAO:   types.NewMemoryDB(),
+	})
+
+	info, err := client.Slashing().QueryValidatorSigningInfo(consPub)
+	require.NoError(t, err)
+	require.Equal(t, rpc.ValidatorSigningInfo{
+		Address:             consAddr,
+		StartHeight:         10,
+		IndexOffset:         42,
+		JailedUntil:         time.Unix(1600000000, 0).UTC(),
+		Tombstoned:          true,
+		MissedBlocksCounter: 3,
+	}, info)
+}
@@ -9,6 +9,7 @@ import (
 
 	sdk "github.com/irisnet/irishub-sdk-go/types"
 	"github.com/irisnet/irishub-sdk-go/utils/json"
+	"github.com/irisnet/irishub-sdk-go/utils/proto"
 )
 
 const (
@@ -16,7 +17,8 @@ const (
 )
 
 var (
-	_ sdk.Msg = MsgUnjail{}
+	_ sdk.Msg      = MsgUnjail{}
+	_ sdk.ProtoMsg = MsgUnjail{}
 
 	cdc = sdk.NewAminoCodec()
 )
@@ -32,10 +34,30 @@ type MsgUnjail struct {
 //nolint
 func (msg MsgUnjail) Route() string { return ModuleName }
 func (msg MsgUnjail) Type() string  { return "unjail" }
+
+// Implements NoSpendMsg.
+func (msg MsgUnjail) SpendsNoCoins() {}
+
+// Implements RecipientMsg.
+func (msg MsgUnjail) Recipients() []sdk.AccAddress { return nil }
+
+// Implements ValidatorMsg.
+func (msg MsgUnjail) Validators() []sdk.ValAddress {
+	return []sdk.ValAddress{msg.ValidatorAddr}
+}
+
 func (msg MsgUnjail) GetSigners() []sdk.AccAddress {
 	return []sdk.AccAddress{sdk.AccAddress(msg.ValidatorAddr)}
 }
 
+// Implements ProtoMsg.
+func (msg MsgUnjail) ProtoTypeURL() string { return "/cosmos.slashing.v1beta1.MsgUnjail" }
+
+// Implements ProtoMsg.
+func (msg MsgUnjail) MarshalProto(prefixes *sdk.AddrPrefixCfg) ([]byte, error) {
+	return new(proto.Encoder).String(1, prefixes.ValAddressString(msg.ValidatorAddr)).Bytes(), nil
+}
+
 // get the bytes for the message signer to sign on
 func (msg MsgUnjail) GetSignBytes() []byte {
 	b, err := cdc.MarshalJSON(msg)
@@ -45,6 +67,18 @@ func (msg MsgUnjail) GetSignBytes() []byte {
 	return json.MustSort(b)
 }
 
+// Implements SignDocMsg.
+func (msg MsgUnjail) SignDoc(config *sdk.AddrPrefixCfg) interface{} {
+	return sdk.TypedSignDoc{
+		Type: "irishub/slashing/MsgUnjail",
+		Value: struct {
+			ValidatorAddr string `json:"address"`
+		}{
+			ValidatorAddr: config.ValAddressString(msg.ValidatorAddr),
+		},
+	}
+}
+
 // quick validity check
 func (msg MsgUnjail) ValidateBasic() error {
 	if msg.ValidatorAddr == nil {
@@ -77,24 +111,37 @@ func (p paramsV017) Convert() interface{} {
 	}
 }
 
-type params struct {
-	MaxEvidenceAge          time.Duration `json:"max_evidence_age"`
-	SignedBlocksWindow      int64         `json:"signed_blocks_window"`
-	MinSignedPerWindow      sdk.Dec       `json:"min_signed_per_window"`
-	DowntimeJailDuration    time.Duration `json:"downtime_jail_duration"`
-	SlashFractionDoubleSign sdk.Dec       `json:"slash_fraction_double_sign"`
-	SlashFractionDowntime   sdk.Dec       `json:"slash_fraction_downtime"`
-}
-
-func (params params) Convert() interface{} {
-	return rpc.SlashingParams{
-		MaxEvidenceAge:          params.MaxEvidenceAge.String(),
-		SignedBlocksWindow:      params.SignedBlocksWindow,
-		MinSignedPerWindow:      params.MinSignedPerWindow.String(),
-		DowntimeJailDuration:    params.DowntimeJailDuration.String(),
-		SlashFractionDoubleSign: params.SlashFractionDoubleSign.String(),
-		SlashFractionDowntime:   params.SlashFractionDowntime.String(),
+// unmarshalProtoParams decodes a cosmos.slashing.v1beta1.QueryParamsResponse
+func unmarshalProtoParams(bz []byte) (rpc.SlashingParams, error) {
+	var params rpc.SlashingParams
+	res, err := proto.Decode(bz)
+	if err != nil || len(res) == 0 {
+		return params, err
+	}
+	fields, err := proto.Decode(res[0].Value)
+	if err != nil {
+		return params, err
 	}
+	for _, f := range fields {
+		switch f.Number {
+		case 1:
+			params.SignedBlocksWindow = int64(f.Varint)
+		case 2:
+			params.MinSignedPerWindow, err = proto.Dec(f.Value)
+		case 3:
+			var d time.Duration
+			d, err = proto.Duration(f.Value)
+			params.DowntimeJailDuration = d.String()
+		case 4:
+			params.SlashFractionDoubleSign, err = proto.Dec(f.Value)
+		case 5:
+			params.SlashFractionDowntime, err = proto.Dec(f.Value)
+		}
+		if err != nil {
+			return rpc.SlashingParams{}, err
+		}
+	}
+	return params, nil
 }
 
 // Signing info for a validator
@@ -105,25 +152,36 @@ type validatorSigningInfoV017 struct {
 	MissedBlocksCounter int64     `json:"missed_blocks_counter"` // missed blocks counter (to avoid scanning the array every time)
 }
 
-// validatorSigningInfo defines the signing info for a validator
-type validatorSigningInfo struct {
-	Address             sdk.ConsAddress `json:"address"`               // validator consensus address
-	StartHeight         int64           `json:"start_height"`          // height at which validator was first a candidate OR was unjailed
-	IndexOffset         int64           `json:"index_offset"`          // index offset into signed block bit array
-	JailedUntil         time.Time       `json:"jailed_until"`          // timestamp validator cannot be unjailed until
-	Tombstoned          bool            `json:"tombstoned"`            // whether or not a validator has been tombstoned (killed out of validator set)
-	MissedBlocksCounter int64           `json:"missed_blocks_counter"` // missed blocks counter (to avoid scanning the array every time)
-}
-
-func (vsi validatorSigningInfo) Convert() interface{} {
-	return rpc.ValidatorSigningInfo{
-		Address:             vsi.Address.String(),
-		StartHeight:         vsi.StartHeight,
-		IndexOffset:         vsi.IndexOffset,
-		JailedUntil:         vsi.JailedUntil,
-		Tombstoned:          vsi.Tombstoned,
-		MissedBlocksCounter: vsi.MissedBlocksCounter,
+// unmarshalProtoSigningInfo decodes a cosmos.slashing.v1beta1.QuerySigningInfoResponse
+func unmarshalProtoSigningInfo(bz []byte) (rpc.ValidatorSigningInfo, error) {
+	var info rpc.ValidatorSigningInfo
+	res, err := proto.Decode(bz)
+	if err != nil || len(res) == 0 {
+		return info, err
+	}
+	fields, err := proto.Decode(res[0].Value)
+	if err != nil {
+		return info, err
+	}
+	for _, f := range fields {
+		switch f.Number {
+		case 1:
+			info.Address = string(f.Value)
+		case 2:
+			info.StartHeight = int64(f.Varint)
+		case 3:
+			info.IndexOffset = int64(f.Varint)
+		case 4:
+			if info.JailedUntil, err = proto.Timestamp(f.Value); err != nil {
+				return rpc.ValidatorSigningInfo{}, err
+			}
+		case 5:
+			info.Tombstoned = f.Varint != 0
+		case 6:
+			info.MissedBlocksCounter = int64(f.Varint)
+		}
 	}
+	return info, nil
 }
 
 func registerCodec(cdc sdk.Codec) {
@@ -0,0 +1,73 @@
+package staking
+
+import (
+	sdk "github.com/irisnet/irishub-sdk-go/types"
+)
+
+// Codespace is the codespace of the errors of the staking module, which differs from its name in IRIShub 0.x
+const Codespace = "staking"
+
+// the errors returned by the staking module of IRIShub 1.x
+var (
+	ErrEmptyValidatorAddr              = sdk.RegisterError(Codespace, 2, "empty validator address")
+	ErrBadValidatorAddr                = sdk.RegisterError(Codespace, 3, "validator address is invalid")
+	ErrNoValidatorFound                = sdk.RegisterError(Codespace, 4, "validator does not exist")
+	ErrValidatorOwnerExists            = sdk.RegisterError(Codespace, 5, "validator already exist for this operator address")
+	ErrValidatorPubKeyExists           = sdk.RegisterError(Codespace, 6, "validator already exist for this pubkey")
+	ErrValidatorPubKeyTypeNotSupported = sdk.RegisterError(Codespace, 7, "validator pubkey type is not supported")
+	ErrValidatorJailed                 = sdk.RegisterError(Codespace, 8, "validator for this address is currently jailed")
+	ErrBadRemoveValidator              = sdk.RegisterError(Codespace, 9, "failed to remove validator")
+	ErrCommissionNegative              = sdk.RegisterError(Codespace, 10, "commission must be positive")
+	ErrCommissionHuge                  = sdk.RegisterError(Codespace, 11, "commission cannot be more than 100%")
+	ErrCommissionGTMaxRate             = sdk.RegisterError(Codespace, 12, "commission cannot be more than the max rate")
+	ErrCommissionUpdateTime            = sdk.RegisterError(Codespace, 13, "commission cannot be changed more than once in 24h")
+	ErrCommissionChangeRateNegative    = sdk.RegisterError(Codespace, 14, "commission change rate must be positive")
+	ErrCommissionChangeRateGTMaxRate   = sdk.RegisterError(Codespace, 15, "commission change rate cannot be more than the max rate")
+	ErrCommissionGTMaxChangeRate       = sdk.RegisterError(Codespace, 16, "commission cannot be changed more than max change rate")
+	ErrSelfDelegationBelowMinimum      = sdk.RegisterError(Codespace, 17, "validator's self delegation must be greater than their minimum self delegation")
+	ErrMinSelfDelegationInvalid        = sdk.RegisterError(Codespace, 18, "minimum self delegation must be a positive integer")
+	ErrMinSelfDelegationDecreased      = sdk.RegisterError(Codespace, 19, "minimum self delegation cannot be decrease")
+	ErrEmptyDelegatorAddr              = sdk.RegisterError(Codespace, 20, "empty delegator address")
+	ErrBadDenom                        = sdk.RegisterError(Codespace, 21, "invalid coin denomination")
+	ErrBadDelegationAddr               = sdk.RegisterError(Codespace, 22, "invalid address for (address, validator) tuple")
+	ErrBadDelegationAmount             = sdk.RegisterError(Codespace, 23, "invalid delegation amount")
+	ErrNoDelegation                    = sdk.RegisterError(Codespace, 24, "no delegation for (address, validator) tuple")
+	ErrBadDelegatorAddr                = sdk.RegisterError(Codespace, 25, "delegator does not exist with address")
+	ErrNoDelegatorForAddress           = sdk.RegisterError(Codespace, 26, "delegator does not contain delegation")
+	ErrInsufficientShares              = sdk.RegisterError(Codespace, 27, "insufficient delegation shares")
+	ErrDelegationValidatorEmpty        = sdk.RegisterError(Codespace, 28, "cannot delegate to an empty validator")
+	ErrNotEnoughDelegationShares       = sdk.RegisterError(Codespace, 29, "not enough delegation shares")
+	ErrBadSharesAmount                 = sdk.RegisterError(Codespace, 30, "invalid shares amount")
+	ErrBadSharesPercent                = sdk.RegisterError(Codespace, 31, "Invalid shares percent")
+	ErrNotMature                       = sdk.RegisterError(Codespace, 32, "entry not mature")
+	ErrNoUnbondingDelegation           = sdk.RegisterError(Codespace, 33, "no unbonding delegation found")
+	ErrMaxUnbondingDelegationEntries   = sdk.RegisterError(Codespace, 34, "too many unbonding delegation entries for (delegator, validator) tuple")
+	ErrBadRedelegationAddr             = sdk.RegisterError(Codespace, 35, "invalid address for (address, src-validator, dst-validator) tuple")
+	ErrNoRedelegation                  = sdk.RegisterError(Codespace, 36, "no redelegation found")
+	ErrSelfRedelegation                = sdk.RegisterError(Codespace, 37, "cannot redelegate to the same validator")
+	ErrTinyRedelegationAmount          = sdk.RegisterError(Codespace, 38, "too few tokens to redelegate (truncates to zero tokens)")
+	ErrBadRedelegationDst              = sdk.RegisterError(Codespace, 39, "redelegation destination validator not found")
+	ErrTransitiveRedelegation          = sdk.RegisterError(Codespace, 40, "redelegation to this validator already in progress; first redelegation to this validator must complete before next redelegation")
+	ErrMaxRedelegationEntries          = sdk.RegisterError(Codespace, 41, "too many redelegation entries for (delegator, src-validator, dst-validator) tuple")
+	ErrDelegatorShareExRateInvalid     = sdk.RegisterError(Codespace, 42, "cannot delegate to validators with invalid (zero) ex-rate")
+	ErrBothShareMsgsGiven              = sdk.RegisterError(Codespace, 43, "both shares amount and shares percent provided")
+	ErrNeitherShareMsgsGiven           = sdk.RegisterError(Codespace, 44, "neither shares amount nor shares percent provided")
+	ErrInvalidHistoricalInfo           = sdk.RegisterError(Codespace, 45, "invalid historical info")
+	ErrNoHistoricalInfo                = sdk.RegisterError(Codespace, 46, "no historical info found")
+	ErrEmptyValidatorPubKey            = sdk.RegisterError(Codespace, 47, "empty validator public key")
+)
+
+// LegacyCodespace is the codespace of the errors of the staking module of IRIShub 0.x
+const LegacyCodespace = "stake"
+
+// the errors returned by the staking module of IRIShub 0.x
+var (
+	ErrLegacyInternal          = sdk.RegisterLegacyError(LegacyCodespace, 1, "internal error")
+	ErrLegacyUnauthorized      = sdk.RegisterLegacyError(LegacyCodespace, 4, "unauthorized")
+	ErrLegacyUnknownRequest    = sdk.RegisterLegacyError(LegacyCodespace, 6, "unknown request")
+	ErrLegacyInvalidAddress    = sdk.RegisterLegacyError(LegacyCodespace, 7, "invalid address")
+	ErrLegacyInvalidValidator  = sdk.RegisterLegacyError(LegacyCodespace, 101, "invalid validator")
+	ErrLegacyInvalidDelegation = sdk.RegisterLegacyError(LegacyCodespace, 102, "invalid delegation")
+	ErrLegacyInvalidInput      = sdk.RegisterLegacyError(LegacyCodespace, 103, "invalid input")
+	ErrLegacyValidatorJailed   = sdk.RegisterLegacyError(LegacyCodespace, 104, "validator is jailed")
+)
@@ -0,0 +1,425 @@
+package staking
+
+import (
+	"github.com/irisnet/irishub-sdk-go/rpc"
+	sdk "github.com/irisnet/irishub-sdk-go/types"
+	"github.com/irisnet/irishub-sdk-go/utils/proto"
+)
+
+// querier is implemented by the queries of each version of the chain
+type querier interface {
+	queryDelegation(delegatorAddr, validatorAddr string) (rpc.Delegation, sdk.Error)
+	queryDelegations(delegatorAddr string) (rpc.Delegations, sdk.Error)
+	queryUnbondingDelegation(delegatorAddr, validatorAddr string) (rpc.UnbondingDelegation, sdk.Error)
+	queryUnbondingDelegations(delegatorAddr string) (rpc.UnbondingDelegations, sdk.Error)
+	queryRedelegation(delegatorAddr, srcValidatorAddr, dstValidatorAddr string) (rpc.Redelegation, sdk.Error)
+	queryRedelegations(delegatorAddr string) (rpc.Redelegations, sdk.Error)
+	queryDelegationsTo(validatorAddr string) (rpc.Delegations, sdk.Error)
+	queryUnbondingDelegationsFrom(validatorAddr string) (rpc.UnbondingDelegations, sdk.Error)
+	queryRedelegationsFrom(validatorAddr string) (rpc.Redelegations, sdk.Error)
+	queryValidator(address string) (rpc.Validator, sdk.Error)
+	queryValidators(page uint64, size uint16) (rpc.Validators, sdk.Error)
+	queryPool() (rpc.StakePool, sdk.Error)
+	queryParams() (rpc.StakeParams, sdk.Error)
+}
+
+type querierV017 struct {
+	sdk.BaseClient
+}
+
+func (s querierV017) queryDelegation(delegatorAddr, validatorAddr string) (rpc.Delegation, sdk.Error) {
+	param := struct {
+		DelegatorAddr string
+		ValidatorAddr string
+	}{
+		DelegatorAddr: delegatorAddr,
+		ValidatorAddr: validatorAddr,
+	}
+
+	var delegation delegation
+	if err := s.QueryWithResponse("custom/stake/delegation", param, &delegation); err != nil {
+		return rpc.Delegation{}, sdk.Wrap(err)
+	}
+	return delegation.Convert().(rpc.Delegation), nil
+}
+
+func (s querierV017) queryDelegations(delegatorAddr string) (rpc.Delegations, sdk.Error) {
+	param := struct {
+		DelegatorAddr string
+	}{
+		DelegatorAddr: delegatorAddr,
+	}
+
+	var ds delegations
+	if err := s.QueryWithResponse("custom/stake/delegatorDelegations", param, &ds); err != nil {
+		return rpc.Delegations{}, sdk.Wrap(err)
+	}
+	return ds.Convert().(rpc.Delegations), nil
+}
+
+func (s querierV017) queryUnbondingDelegation(delegatorAddr, validatorAddr string) (rpc.UnbondingDelegation, sdk.Error) {
+	param := struct {
+		DelegatorAddr string
+		ValidatorAddr string
+	}{
+		DelegatorAddr: delegatorAddr,
+		ValidatorAddr: validatorAddr,
+	}
+
+	var ubd unbondingDelegation
+	if err := s.QueryWithResponse("custom/stake/unbondingDelegation", param, &ubd); err != nil {
+		return rpc.UnbondingDelegation{}, sdk.Wrap(err)
+	}
+	return ubd.Convert().(rpc.UnbondingDelegation), nil
+}
+
+func (s querierV017) queryUnbondingDelegations(delegatorAddr string) (rpc.UnbondingDelegations, sdk.Error) {
+	param := struct {
+		DelegatorAddr string
+	}{
+		DelegatorAddr: delegatorAddr,
+	}
+
+	var unds unbondingDelegations
+	if err := s.QueryWithResponse("custom/stake/delegatorUnbondingDelegations", param, &unds); err != nil {
+		return rpc.UnbondingDelegations{}, sdk.Wrap(err)
+	}
+	return unds.Convert().(rpc.UnbondingDelegations), nil
+}
+
+func (s querierV017) queryRedelegation(delegatorAddr, srcValidatorAddr, dstValidatorAddr string) (rpc.Redelegation, sdk.Error) {
+	param := struct {
+		DelegatorAddr string
+		ValSrcAddr    string
+		ValDstAddr    string
+	}{
+		DelegatorAddr: delegatorAddr,
+		ValSrcAddr:    srcValidatorAddr,
+		ValDstAddr:    dstValidatorAddr,
+	}
+
+	var rd redelegation
+	if err := s.QueryWithResponse("custom/stake/redelegation", param, &rd); err != nil {
+		return rpc.Redelegation{}, sdk.Wrap(err)
+	}
+	return rd.Convert().(rpc.Redelegation), nil
+}
+
+func (s querierV017) queryRedelegations(delegatorAddr string) (rpc.Redelegations, sdk.Error) {
+	param := struct {
+		DelegatorAddr string
+	}{
+		DelegatorAddr: delegatorAddr,
+	}
+
+	var rds redelegations
+	if err := s.QueryWithResponse("custom/stake/delegatorRedelegations", param, &rds); err != nil {
+		return rpc.Redelegations{}, sdk.Wrap(err)
+	}
+	return rds.Convert().(rpc.Redelegations), nil
+}
+
+func (s querierV017) queryDelegationsTo(validatorAddr string) (rpc.Delegations, sdk.Error) {
+	param := struct {
+		ValidatorAddr string
+	}{
+		ValidatorAddr: validatorAddr,
+	}
+
+	var ds delegations
+	if err := s.QueryWithResponse("custom/stake/validatorDelegations", param, &ds); err != nil {
+		return rpc.Delegations{}, sdk.Wrap(err)
+	}
+	return ds.Convert().(rpc.Delegations), nil
+}
+
+func (s querierV017) queryUnbondingDelegationsFrom(validatorAddr string) (rpc.UnbondingDelegations, sdk.Error) {
+	param := struct {
+		ValidatorAddr string
+	}{
+		ValidatorAddr: validatorAddr,
+	}
+
+	var ubds unbondingDelegations
+	if err := s.QueryWithResponse("custom/stake/validatorUnbondingDelegations", param, &ubds); err != nil {
+		return rpc.UnbondingDelegations{}, sdk.Wrap(err)
+	}
+	return ubds.Convert().(rpc.UnbondingDelegations), nil
+}
+
+func (s querierV017) queryRedelegationsFrom(validatorAddr string) (rpc.Redelegations, sdk.Error) {
+	param := struct {
+		ValidatorAddr string
+	}{
+		ValidatorAddr: validatorAddr,
+	}
+
+	var rds redelegations
+	if err := s.QueryWithResponse("custom/stake/validatorRedelegations", param, &rds); err != nil {
+		return rpc.Redelegations{}, sdk.Wrap(err)
+	}
+	return rds.Convert().(rpc.Redelegations), nil
+}
+
+func (s querierV017) queryValidator(address string) (rpc.Validator, sdk.Error) {
+	param := struct {
+		ValidatorAddr string
+	}{
+		ValidatorAddr: address,
+	}
+
+	var validator validator
+	if err := s.QueryWithResponse("custom/stake/validator", param, &validator); err != nil {
+		return rpc.Validator{}, sdk.Wrap(err)
+	}
+	return validator.Convert().(rpc.Validator), nil
+}
+
+func (s querierV017) queryValidators(page uint64, size uint16) (rpc.Validators, sdk.Error) {
+	param := struct {
+		Page uint64
+		Size uint16
+	}{
+		Page: page,
+		Size: size,
+	}
+
+	var validators validators
+	if err := s.QueryWithResponse("custom/stake/validators", param, &validators); err != nil {
+		return rpc.Validators{}, sdk.Wrap(err)
+	}
+	return validators.Convert().(rpc.Validators), nil
+}
+
+func (s querierV017) queryPool() (rpc.StakePool, sdk.Error) {
+	var pool Pool
+	if err := s.QueryWithResponse("custom/stake/pool", nil, &pool); err != nil {
+		return rpc.StakePool{}, sdk.Wrap(err)
+	}
+	return pool.Convert().(rpc.StakePool), nil
+}
+
+func (s querierV017) queryParams() (rpc.StakeParams, sdk.Error) {
+	var params params
+	if err := s.BaseClient.QueryParams(ModuleName, &params); err != nil {
+		return rpc.StakeParams{}, sdk.Wrap(err)
+	}
+	return params.Convert().(rpc.StakeParams), nil
+}
+
+type querierV100 struct {
+	sdk.BaseClient
+}
+
+func (s querierV100) queryDelegation(delegatorAddr, validatorAddr string) (rpc.Delegation, sdk.Error) {
+	req := new(proto.Encoder).String(1, delegatorAddr).String(2, validatorAddr).Bytes()
+	res, err := s.QueryProto("/cosmos.staking.v1beta1.Query/Delegation", req)
+	if err != nil {
+		return rpc.Delegation{}, sdk.Wrap(err)
+	}
+	bz, _, err := proto.Lookup(res, 1)
+	if err != nil {
+		return rpc.Delegation{}, sdk.Wrap(err)
+	}
+	delegation, err := unmarshalProtoDelegationResponse(bz)
+	if err != nil {
+		return rpc.Delegation{}, sdk.Wrap(err)
+	}
+	return delegation, nil
+}
+
+func (s querierV100) queryDelegations(delegatorAddr string) (rpc.Delegations, sdk.Error) {
+	return s.queryDelegationPages("/cosmos.staking.v1beta1.Query/DelegatorDelegations", delegatorAddr)
+}
+
+func (s querierV100) queryUnbondingDelegation(delegatorAddr, validatorAddr string) (rpc.UnbondingDelegation, sdk.Error) {
+	req := new(proto.Encoder).String(1, delegatorAddr).String(2, validatorAddr).Bytes()
+	res, err := s.QueryProto("/cosmos.staking.v1beta1.Query/UnbondingDelegation", req)
+	if err != nil {
+		return rpc.UnbondingDelegation{}, sdk.Wrap(err)
+	}
+	bz, _, err := proto.Lookup(res, 1)
+	if err != nil {
+		return rpc.UnbondingDelegation{}, sdk.Wrap(err)
+	}
+	ubds, err := unmarshalProtoUnbondingDelegation(bz)
+	if err != nil {
+		return rpc.UnbondingDelegation{}, sdk.Wrap(err)
+	}
+	if len(ubds) == 0 {
+		return rpc.UnbondingDelegation{}, sdk.Wrapf("unbonding delegation not found")
+	}
+	return sumUnbondingDelegations(ubds), nil
+}
+
+func (s querierV100) queryUnbondingDelegations(delegatorAddr string) (rpc.UnbondingDelegations, sdk.Error) {
+	return s.queryUnbondingDelegationPages("/cosmos.staking.v1beta1.Query/DelegatorUnbondingDelegations", delegatorAddr)
+}
+
+func (s querierV100) queryRedelegation(delegatorAddr, srcValidatorAddr, dstValidatorAddr string) (rpc.Redelegation, sdk.Error) {
+	rds, err := s.queryRedelegationPages(delegatorAddr, srcValidatorAddr, dstValidatorAddr)
+	if err != nil {
+		return rpc.Redelegation{}, err
+	}
+	if len(rds) == 0 {
+		return rpc.Redelegation{}, sdk.Wrapf("redelegation not found")
+	}
+	rd, e := sumRedelegations(rds)
+	if e != nil {
+		return rpc.Redelegation{}, sdk.Wrap(e)
+	}
+	return rd, nil
+}
+
+func (s querierV100) queryRedelegations(delegatorAddr string) (rpc.Redelegations, sdk.Error) {
+	return s.queryRedelegationPages(delegatorAddr, "", "")
+}
+
+func (s querierV100) queryDelegationsTo(validatorAddr string) (rpc.Delegations, sdk.Error) {
+	return s.queryDelegationPages("/cosmos.staking.v1beta1.Query/ValidatorDelegations", validatorAddr)
+}
+
+func (s querierV100) queryUnbondingDelegationsFrom(validatorAddr string) (rpc.UnbondingDelegations, sdk.Error) {
+	return s.queryUnbondingDelegationPages("/cosmos.staking.v1beta1.Query/ValidatorUnbondingDelegations", validatorAddr)
+}
+
+func (s querierV100) queryRedelegationsFrom(validatorAddr string) (rpc.Redelegations, sdk.Error) {
+	return s.queryRedelegationPages("", validatorAddr, "")
+}
+
+func (s querierV100) queryValidator(address string) (rpc.Validator, sdk.Error) {
+	req := new(proto.Encoder).String(1, address).Bytes()
+	res, err := s.QueryProto("/cosmos.staking.v1beta1.Query/Validator", req)
+	if err != nil {
+		return rpc.Validator{}, sdk.Wrap(err)
+	}
+	bz, _, err := proto.Lookup(res, 1)
+	if err != nil {
+		return rpc.Validator{}, sdk.Wrap(err)
+	}
+	validator, err := unmarshalProtoValidator(bz, s.AddrPrefixCfg())
+	if err != nil {
+		return rpc.Validator{}, sdk.Wrap(err)
+	}
+	return validator, nil
+}
+
+func (s querierV100) queryValidators(page uint64, size uint16) (rpc.Validators, sdk.Error) {
+	if page == 0 || size == 0 {
+		return nil, sdk.Wrapf("page and size must be greater than 0")
+	}
+	pagination := proto.PageRequest(nil, (page-1)*uint64(size), uint64(size))
+	req := new(proto.Encoder).Message(2, pagination).Bytes()
+	res, err := s.QueryProto("/cosmos.staking.v1beta1.Query/Validators", req)
+	if err != nil {
+		return nil, sdk.Wrap(err)
+	}
+	fields, err := proto.Decode(res)
+	if err != nil {
+		return nil, sdk.Wrap(err)
+	}
+
+	validators := rpc.Validators{}
+	for _, f := range fields {
+		if f.Number != 1 {
+			continue
+		}
+		validator, err := unmarshalProtoValidator(f.Value, s.AddrPrefixCfg())
+		if err != nil {
+			return nil, sdk.Wrap(err)
+		}
+		validators = append(validators, validator)
+	}
+	return validators, nil
+}
+
+func (s querierV100) queryPool() (rpc.StakePool, sdk.Error) {
+	res, err := s.QueryProto("/cosmos.staking.v1beta1.Query/Pool", nil)
+	if err != nil {
+		return rpc.StakePool{}, sdk.Wrap(err)
+	}
+	pool, err := unmarshalProtoPool(res)
+	if err != nil {
+		return rpc.StakePool{}, sdk.Wrap(err)
+	}
+	return pool, nil
+}
+
+func (s querierV100) queryParams() (rpc.StakeParams, sdk.Error) {
+	res, err := s.QueryProto("/cosmos.staking.v1beta1.Query/Params", nil)
+	if err != nil {
+		return rpc.StakeParams{}, sdk.Wrap(err)
+	}
+	params, err := unmarshalProtoParams(res)
+	if err != nil {
+		return rpc.StakeParams{}, sdk.Wrap(err)
+	}
+	return params, nil
+}
+
+// queryDelegationPages returns the delegations of all the pages of the query of a delegator or of a validator
+func (s querierV100) queryDelegationPages(path, address string) (rpc.Delegations, sdk.Error) {
+	items, err := proto.Pages(func(page []byte) ([]byte, error) {
+		return s.QueryProto(path, new(proto.Encoder).String(1, address).Message(2, page).Bytes())
+	}, 1, 2)
+	if err != nil {
+		return nil, sdk.Wrap(err)
+	}
+
+	delegations := rpc.Delegations{}
+	for _, item := range items {
+		d, err := unmarshalProtoDelegationResponse(item)
+		if err != nil {
+			return nil, sdk.Wrap(err)
+		}
+		delegations = append(delegations, d)
+	}
+	return delegations, nil
+}
+
+// queryUnbondingDelegationPages returns the unbonding delegations of all the pages of the query of a delegator
+// or of a validator, an unbonding delegation for each of their entries
+func (s querierV100) queryUnbondingDelegationPages(path, address string) (rpc.UnbondingDelegations, sdk.Error) {
+	items, err := proto.Pages(func(page []byte) ([]byte, error) {
+		return s.QueryProto(path, new(proto.Encoder).String(1, address).Message(2, page).Bytes())
+	}, 1, 2)
+	if err != nil {
+		return nil, sdk.Wrap(err)
+	}
+
+	ubds := rpc.UnbondingDelegations{}
+	for _, item := range items {
+		entries, err := unmarshalProtoUnbondingDelegation(item)
+		if err != nil {
+			return nil, sdk.Wrap(err)
+		}
+		ubds = append(ubds, entries...)
+	}
+	return ubds, nil
+}
+
+// queryRedelegationPages returns the redelegations of all the pages of the query of a delegator, of a source
+// validator or of both validators, a redelegation for each of their entries
+func (s querierV100) queryRedelegationPages(delegatorAddr, srcValidatorAddr, dstValidatorAddr string) (rpc.Redelegations, sdk.Error) {
+	items, err := proto.Pages(func(page []byte) ([]byte, error) {
+		req := new(proto.Encoder).
+			String(1, delegatorAddr).
+			String(2, srcValidatorAddr).
+			String(3, dstValidatorAddr).
+			Message(4, page)
+		return s.QueryProto("/cosmos.staking.v1beta1.Query/Redelegations", req.Bytes())
+	}, 1, 2)
+	if err != nil {
+		return nil, sdk.Wrap(err)
+	}
+
+	rds := rpc.Redelegations{}
+	for _, item := range items {
+		entries, err := unmarshalProtoRedelegationResponse(item)
+		if err != nil {
+			return nil, sdk.Wrap(err)
+		}
+		rds = append(rds, entries...)
+	}
+	return rds, nil
+}
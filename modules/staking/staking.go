@@ -10,7 +10,8 @@ import (
 
 type stakingClient struct {
 	sdk.BaseClient
-	*log.Logger
+	log.Logger
+	queries *sdk.VersionedQueries
 }
 
 func (s stakingClient) RegisterCodec(cdc sdk.Codec) {
@@ -22,9 +23,13 @@ func (s stakingClient) Name() string {
 }
 
 func Create(ac sdk.BaseClient) rpc.Staking {
+	queries := new(sdk.VersionedQueries).
+		Register(sdk.V017, querierV017{ac}).
+		Register(sdk.V100, querierV100{ac})
 	return stakingClient{
 		BaseClient: ac,
 		Logger:     ac.Logger(),
+		queries:    queries,
 	}
 }
 
@@ -35,7 +40,7 @@ func (s stakingClient) Delegate(valAddr string, amount sdk.DecCoin, baseTx sdk.B
 		return sdk.ResultTx{}, sdk.Wrap(err)
 	}
 
-	validator, err := sdk.ValAddressFromBech32(valAddr)
+	validator, err := s.AddrPrefixCfg().ValAddressFromBech32(valAddr)
 	if err != nil {
 		return sdk.ResultTx{}, sdk.Wrap(err)
 	}
@@ -51,10 +56,10 @@ func (s stakingClient) Delegate(valAddr string, amount sdk.DecCoin, baseTx sdk.B
 		Delegation:    amt[0],
 	}
 
-	s.Info().Str("delegator", delegator.String()).
-		Str("validator", validator.String()).
-		Str("amount", amount.String()).
-		Msg("execute delegate transaction")
+	s.Info("execute delegate transaction",
+		"delegator", s.AddrPrefixCfg().AccAddressString(delegator),
+		"validator", s.AddrPrefixCfg().ValAddressString(validator),
+		"amount", amount.String())
 	return s.BuildAndSend([]sdk.Msg{msg}, baseTx)
 }
 
@@ -82,7 +87,7 @@ func (s stakingClient) Undelegate(valAddr string, amount sdk.DecCoin, baseTx sdk
 	amountDec := sdk.NewDecFromInt(amt[0].Amount)
 	share := amountDec.Quo(exRate)
 
-	varAddr, err := sdk.ValAddressFromBech32(valAddr)
+	varAddr, err := s.AddrPrefixCfg().ValAddressFromBech32(valAddr)
 	if err != nil {
 		return sdk.ResultTx{}, sdk.Wrap(err)
 	}
@@ -93,10 +98,10 @@ func (s stakingClient) Undelegate(valAddr string, amount sdk.DecCoin, baseTx sdk
 		SharesAmount:  share,
 	}
 
-	s.Info().Str("delegator", delegator.String()).
-		Str("validator", valAddr).
-		Str("amount", amount.String()).
-		Msg("execute undelegate transaction")
+	s.Info("execute undelegate transaction",
+		"delegator", s.AddrPrefixCfg().AccAddressString(delegator),
+		"validator", valAddr,
+		"amount", amount.String())
 	return s.BuildAndSend([]sdk.Msg{msg}, baseTx)
 }
 
@@ -108,12 +113,12 @@ func (s stakingClient) Redelegate(srcValidatorAddr,
 		return sdk.ResultTx{}, sdk.Wrap(err)
 	}
 
-	srcValAddr, err := sdk.ValAddressFromBech32(srcValidatorAddr)
+	srcValAddr, err := s.AddrPrefixCfg().ValAddressFromBech32(srcValidatorAddr)
 	if err != nil {
 		return sdk.ResultTx{}, sdk.Wrap(err)
 	}
 
-	dstValAddr, err := sdk.ValAddressFromBech32(dstValidatorAddr)
+	dstValAddr, err := s.AddrPrefixCfg().ValAddressFromBech32(dstValidatorAddr)
 	if err != nil {
 		return sdk.ResultTx{}, sdk.Wrap(err)
 	}
@@ -142,274 +147,139 @@ func (s stakingClient) Redelegate(srcValidatorAddr,
 		SharesAmount:     share,
 	}
 
-	s.Info().Str("delegator", delAddr.String()).
-		Str("srcValidatorAddr", srcValidatorAddr).
-		Str("dstValidatorAddr", dstValidatorAddr).
-		Str("amount", amount.String()).
-		Msg("execute redelegate transaction")
+	s.Info("execute redelegate transaction",
+		"delegator", s.AddrPrefixCfg().AccAddressString(delAddr),
+		"srcValidatorAddr", srcValidatorAddr,
+		"dstValidatorAddr", dstValidatorAddr,
+		"amount", amount.String())
 	return s.BuildAndSend([]sdk.Msg{msg}, baseTx)
 }
 
 // QueryDelegation return the specified delegation by delegatorAddr and validatorAddr
 func (s stakingClient) QueryDelegation(delegatorAddr, validatorAddr string) (rpc.Delegation, sdk.Error) {
-	delAddr, err := sdk.AccAddressFromBech32(delegatorAddr)
-	if err != nil {
-		return rpc.Delegation{}, sdk.Wrap(err)
-	}
-
-	varAddr, err := sdk.ValAddressFromBech32(validatorAddr)
-	if err != nil {
+	if _, err := s.AddrPrefixCfg().AccAddressFromBech32(delegatorAddr); err != nil {
 		return rpc.Delegation{}, sdk.Wrap(err)
 	}
 
-	param := struct {
-		DelegatorAddr sdk.AccAddress
-		ValidatorAddr sdk.ValAddress
-	}{
-		DelegatorAddr: delAddr,
-		ValidatorAddr: varAddr,
-	}
-
-	var delegation delegation
-	if err := s.QueryWithResponse("custom/stake/delegation", param, &delegation); err != nil {
+	if _, err := s.AddrPrefixCfg().ValAddressFromBech32(validatorAddr); err != nil {
 		return rpc.Delegation{}, sdk.Wrap(err)
 	}
-	return delegation.Convert().(rpc.Delegation), nil
+	return s.querier().queryDelegation(delegatorAddr, validatorAddr)
 }
 
 // QueryDelegations return the specified delegations by delegatorAddr
 func (s stakingClient) QueryDelegations(delegatorAddr string) (rpc.Delegations, sdk.Error) {
-	delAddr, err := sdk.AccAddressFromBech32(delegatorAddr)
-	if err != nil {
+	if _, err := s.AddrPrefixCfg().AccAddressFromBech32(delegatorAddr); err != nil {
 		return rpc.Delegations{}, sdk.Wrap(err)
 	}
+	return s.querier().queryDelegations(delegatorAddr)
+}
 
-	param := struct {
-		DelegatorAddr sdk.AccAddress
-	}{
-		DelegatorAddr: delAddr,
-	}
-
-	var ds delegations
-	if err := s.QueryWithResponse("custom/stake/delegatorDelegations", param, &ds); err != nil {
-		return rpc.Delegations{}, sdk.Wrap(err)
-	}
-	return ds.Convert().(rpc.Delegations), nil
+// BatchQueryDelegations returns the delegations of the delegators in their order, with the error of each delegator
+func (s stakingClient) BatchQueryDelegations(delegatorAddrs ...string) []rpc.DelegationsResult {
+	results := make([]rpc.DelegationsResult, len(delegatorAddrs))
+	s.QueryBatch(len(delegatorAddrs), func(i int) {
+		res := rpc.DelegationsResult{Delegator: delegatorAddrs[i]}
+		res.Delegations, res.Err = s.QueryDelegations(delegatorAddrs[i])
+		results[i] = res
+	})
+	return results
 }
 
 // QueryUnbondingDelegation return the specified unbonding delegation by delegatorAddr and validatorAddr
 func (s stakingClient) QueryUnbondingDelegation(delegatorAddr, validatorAddr string) (rpc.UnbondingDelegation, sdk.Error) {
-	delAddr, err := sdk.AccAddressFromBech32(delegatorAddr)
-	if err != nil {
+	if _, err := s.AddrPrefixCfg().AccAddressFromBech32(delegatorAddr); err != nil {
 		return rpc.UnbondingDelegation{}, sdk.Wrap(err)
 	}
 
-	varAddr, err := sdk.ValAddressFromBech32(validatorAddr)
-	if err != nil {
+	if _, err := s.AddrPrefixCfg().ValAddressFromBech32(validatorAddr); err != nil {
 		return rpc.UnbondingDelegation{}, sdk.Wrap(err)
 	}
-
-	param := struct {
-		DelegatorAddr sdk.AccAddress
-		ValidatorAddr sdk.ValAddress
-	}{
-		DelegatorAddr: delAddr,
-		ValidatorAddr: varAddr,
-	}
-
-	var ubd unbondingDelegation
-	if err := s.QueryWithResponse("custom/stake/unbondingDelegation", param, &ubd); err != nil {
-		return rpc.UnbondingDelegation{}, sdk.Wrap(err)
-	}
-	return ubd.Convert().(rpc.UnbondingDelegation), nil
+	return s.querier().queryUnbondingDelegation(delegatorAddr, validatorAddr)
 }
 
 // QueryUnbondingDelegations return the specified unbonding delegations by delegatorAddr
 func (s stakingClient) QueryUnbondingDelegations(delegatorAddr string) (rpc.UnbondingDelegations, sdk.Error) {
-	delAddr, err := sdk.AccAddressFromBech32(delegatorAddr)
-	if err != nil {
-		return rpc.UnbondingDelegations{}, sdk.Wrap(err)
-	}
-
-	param := struct {
-		DelegatorAddr sdk.AccAddress
-	}{
-		DelegatorAddr: delAddr,
-	}
-
-	var unds unbondingDelegations
-	if err := s.QueryWithResponse("custom/stake/delegatorUnbondingDelegations", param, &unds); err != nil {
+	if _, err := s.AddrPrefixCfg().AccAddressFromBech32(delegatorAddr); err != nil {
 		return rpc.UnbondingDelegations{}, sdk.Wrap(err)
 	}
-	return unds.Convert().(rpc.UnbondingDelegations), nil
+	return s.querier().queryUnbondingDelegations(delegatorAddr)
 }
 
 // QueryRedelegation return the specified redelegation by delegatorAddr,srcValidatorAddr,dstValidatorAddr
 func (s stakingClient) QueryRedelegation(delegatorAddr, srcValidatorAddr, dstValidatorAddr string) (rpc.Redelegation, sdk.Error) {
-	delAddr, err := sdk.AccAddressFromBech32(delegatorAddr)
-	if err != nil {
+	if _, err := s.AddrPrefixCfg().AccAddressFromBech32(delegatorAddr); err != nil {
 		return rpc.Redelegation{}, sdk.Wrap(err)
 	}
 
-	srcVarAddr, err := sdk.ValAddressFromBech32(srcValidatorAddr)
-	if err != nil {
+	if _, err := s.AddrPrefixCfg().ValAddressFromBech32(srcValidatorAddr); err != nil {
 		return rpc.Redelegation{}, sdk.Wrap(err)
 	}
 
-	dstVarAddr, err := sdk.ValAddressFromBech32(dstValidatorAddr)
-	if err != nil {
+	if _, err := s.AddrPrefixCfg().ValAddressFromBech32(dstValidatorAddr); err != nil {
 		return rpc.Redelegation{}, sdk.Wrap(err)
 	}
-
-	param := struct {
-		DelegatorAddr sdk.AccAddress
-		ValSrcAddr    sdk.ValAddress
-		ValDstAddr    sdk.ValAddress
-	}{
-		DelegatorAddr: delAddr,
-		ValSrcAddr:    srcVarAddr,
-		ValDstAddr:    dstVarAddr,
-	}
-
-	var rd redelegation
-	if err := s.QueryWithResponse("custom/stake/redelegation", param, &rd); err != nil {
-		return rpc.Redelegation{}, sdk.Wrap(err)
-	}
-	return rd.Convert().(rpc.Redelegation), nil
+	return s.querier().queryRedelegation(delegatorAddr, srcValidatorAddr, dstValidatorAddr)
 }
 
 // QueryRedelegations return the specified redelegations by delegatorAddr
 func (s stakingClient) QueryRedelegations(delegatorAddr string) (rpc.Redelegations, sdk.Error) {
-	delAddr, err := sdk.AccAddressFromBech32(delegatorAddr)
-	if err != nil {
+	if _, err := s.AddrPrefixCfg().AccAddressFromBech32(delegatorAddr); err != nil {
 		return rpc.Redelegations{}, sdk.Wrap(err)
 	}
-	param := struct {
-		DelegatorAddr sdk.AccAddress
-	}{
-		DelegatorAddr: delAddr,
-	}
-
-	var rds redelegations
-	if err := s.QueryWithResponse("custom/stake/delegatorRedelegations", param, &rds); err != nil {
-		return rpc.Redelegations{}, sdk.Wrap(err)
-	}
-	return rds.Convert().(rpc.Redelegations), nil
+	return s.querier().queryRedelegations(delegatorAddr)
 }
 
 // QueryDelegationsTo return the specified delegations by validatorAddr
 func (s stakingClient) QueryDelegationsTo(validatorAddr string) (rpc.Delegations, sdk.Error) {
-	varAddr, err := sdk.ValAddressFromBech32(validatorAddr)
-	if err != nil {
-		return rpc.Delegations{}, sdk.Wrap(err)
-	}
-
-	param := struct {
-		ValidatorAddr sdk.ValAddress
-	}{
-		ValidatorAddr: varAddr,
-	}
-
-	var ds delegations
-	if err := s.QueryWithResponse("custom/stake/validatorDelegations", param, &ds); err != nil {
+	if _, err := s.AddrPrefixCfg().ValAddressFromBech32(validatorAddr); err != nil {
 		return rpc.Delegations{}, sdk.Wrap(err)
 	}
-	return ds.Convert().(rpc.Delegations), nil
+	return s.querier().queryDelegationsTo(validatorAddr)
 }
 
 // QueryUnbondingDelegationsFrom return the specified unbonding delegations by validatorAddr
 func (s stakingClient) QueryUnbondingDelegationsFrom(validatorAddr string) (rpc.UnbondingDelegations, sdk.Error) {
-	varAddr, err := sdk.ValAddressFromBech32(validatorAddr)
-	if err != nil {
+	if _, err := s.AddrPrefixCfg().ValAddressFromBech32(validatorAddr); err != nil {
 		return rpc.UnbondingDelegations{}, sdk.Wrap(err)
 	}
-
-	param := struct {
-		ValidatorAddr sdk.ValAddress
-	}{
-		ValidatorAddr: varAddr,
-	}
-
-	var ubds unbondingDelegations
-	if err := s.QueryWithResponse("custom/stake/validatorUnbondingDelegations", param, &ubds); err != nil {
-		return rpc.UnbondingDelegations{}, sdk.Wrap(err)
-	}
-	return ubds.Convert().(rpc.UnbondingDelegations), nil
+	return s.querier().queryUnbondingDelegationsFrom(validatorAddr)
 }
 
 // QueryRedelegationsFrom return the specified redelegations by validatorAddr
 func (s stakingClient) QueryRedelegationsFrom(validatorAddr string) (rpc.Redelegations, sdk.Error) {
-	varAddr, err := sdk.ValAddressFromBech32(validatorAddr)
-	if err != nil {
-		return rpc.Redelegations{}, sdk.Wrap(err)
-	}
-
-	param := struct {
-		ValidatorAddr sdk.ValAddress
-	}{
-		ValidatorAddr: varAddr,
-	}
-
-	var rds redelegations
-	if err := s.QueryWithResponse("custom/stake/validatorRedelegations", param, &rds); err != nil {
+	if _, err := s.AddrPrefixCfg().ValAddressFromBech32(validatorAddr); err != nil {
 		return rpc.Redelegations{}, sdk.Wrap(err)
 	}
-	return rds.Convert().(rpc.Redelegations), nil
+	return s.querier().queryRedelegationsFrom(validatorAddr)
 }
 
 // QueryValidator return the specified validator by validator address
 func (s stakingClient) QueryValidator(address string) (rpc.Validator, sdk.Error) {
-	varAddr, err := sdk.ValAddressFromBech32(address)
-	if err != nil {
+	if _, err := s.AddrPrefixCfg().ValAddressFromBech32(address); err != nil {
 		return rpc.Validator{}, sdk.Wrap(err)
 	}
-
-	param := struct {
-		ValidatorAddr sdk.ValAddress
-	}{
-		ValidatorAddr: varAddr,
-	}
-
-	var validator validator
-	if err := s.QueryWithResponse("custom/stake/validator", param, &validator); err != nil {
-		return rpc.Validator{}, sdk.Wrap(err)
-	}
-	return validator.Convert().(rpc.Validator), nil
+	return s.querier().queryValidator(address)
 }
 
 // QueryValidators return the specified validators by page and size
 func (s stakingClient) QueryValidators(page uint64, size uint16) (rpc.Validators, sdk.Error) {
-	param := struct {
-		Page uint64
-		Size uint16
-	}{
-		Page: page,
-		Size: size,
-	}
-
-	var validators validators
-	if err := s.QueryWithResponse("custom/stake/validators", param, &validators); err != nil {
-		return rpc.Validators{}, sdk.Wrap(err)
-	}
-	return validators.Convert().(rpc.Validators), nil
+	return s.querier().queryValidators(page, size)
 }
 
 // QueryValidators return the staking pool status
 func (s stakingClient) QueryPool() (rpc.StakePool, sdk.Error) {
-	var pool Pool
-	if err := s.QueryWithResponse("custom/stake/pool", nil, &pool); err != nil {
-		return rpc.StakePool{}, sdk.Wrap(err)
-	}
-	return pool.Convert().(rpc.StakePool), nil
+	return s.querier().queryPool()
 }
 
 // QueryValidators return the staking gov params
 func (s stakingClient) QueryParams() (rpc.StakeParams, sdk.Error) {
-	var params params
-	if err := s.BaseClient.QueryParams(s.Name(), &params); err != nil {
-		return rpc.StakeParams{}, sdk.Wrap(err)
-	}
-	return params.Convert().(rpc.StakeParams), nil
+	return s.querier().queryParams()
+}
+
+// querier returns the queries of the version of the chain
+func (s stakingClient) querier() querier {
+	return s.queries.Route(s.ChainVersion()).(querier)
 }
 
 //
@@ -418,7 +288,7 @@ func (s stakingClient) SubscribeValidatorInfoUpdates(validator string,
 	var builder = sdk.NewEventQueryBuilder().
 		AddCondition(sdk.Cond(sdk.ActionKey).EQ("edit_validator"))
 
-	s.Info().Str("validator", validator).Msg("subscribe validator update event")
+	s.Info("subscribe validator update event", "validator", validator)
 	validator = strings.TrimSpace(validator)
 	if len(validator) != 0 {
 		builder.AddCondition(sdk.Cond("destination-validator").EQ(sdk.EventValue(validator)))
@@ -426,7 +296,7 @@ func (s stakingClient) SubscribeValidatorInfoUpdates(validator string,
 	return s.SubscribeTx(builder, func(tx sdk.EventDataTx) {
 		for _, msg := range tx.Tx.Msgs {
 			msg, ok := msg.(MsgEditValidator)
-			if ok && validator == msg.ValidatorAddr.String() {
+			if ok && validator == s.AddrPrefixCfg().ValAddressString(msg.ValidatorAddr) {
 				data := rpc.EventDataMsgEditValidator{
 					Height: tx.Height,
 					Hash:   tx.Hash,
@@ -436,7 +306,7 @@ func (s stakingClient) SubscribeValidatorInfoUpdates(validator string,
 						Website:  msg.Website,
 						Details:  msg.Details,
 					},
-					Address:        msg.ValidatorAddr.String(),
+					Address:        s.AddrPrefixCfg().ValAddressString(msg.ValidatorAddr),
 					CommissionRate: msg.CommissionRate.String(),
 				}
 				callback(data)
@@ -1,14 +1,24 @@
 package staking_test
 
 import (
+	"bytes"
+	"fmt"
 	"github.com/stretchr/testify/require"
 	"testing"
+	"time"
 
+	"github.com/tendermint/tendermint/crypto/ed25519"
+	"github.com/tendermint/tendermint/crypto/secp256k1"
+
+	"github.com/irisnet/irishub-sdk-go/rpc"
 	sdk "github.com/irisnet/irishub-sdk-go/types"
+	"github.com/irisnet/irishub-sdk-go/utils/proto"
 
 	"github.com/stretchr/testify/suite"
 
+	client "github.com/irisnet/irishub-sdk-go"
 	"github.com/irisnet/irishub-sdk-go/test"
+	"github.com/irisnet/irishub-sdk-go/test/fakechain"
 )
 
 type StakingTestSuite struct {
@@ -215,3 +225,200 @@ func (sts *StakingTestSuite) TestQueryParams() {
 	require.NoError(sts.T(), err)
 	require.NotEmpty(sts.T(), p)
 }
+
+func TestBatchQueryDelegations(t *testing.T) {
+	fees, e := sdk.ParseDecCoins("0.6iris")
+	require.NoError(t, e)
+	chain := fakechain.New(fakechain.WithChainID("test"))
+	c := client.NewClient(sdk.ClientConfig{
+		TmClient:         chain,
+		ChainID:          "test",
+		Fee:              fees,
+		Mode:             sdk.Commit,
+		KeyDAO:           sdk.NewMemoryDB(),
+		BatchConcurrency: 4,
+	})
+	funds := sdk.NewCoins(sdk.NewCoin("iris-atto", sdk.NewIntWithDecimal(100, 18)))
+
+	operator, err := c.Keys().RecoverWithOptions("validator", "1234567890", test.Mnemonic, sdk.HDOptions{Index: 100})
+	require.NoError(t, err)
+	require.NoError(t, chain.Fund(operator, funds))
+	require.NoError(t, chain.AddValidator(operator, sdk.NewCoin("iris-atto", sdk.NewIntWithDecimal(10, 18))))
+	valAddr, e := c.AddrPrefixCfg().AccAddressFromBech32(operator)
+	require.NoError(t, e)
+	validator := c.AddrPrefixCfg().ValAddressString(sdk.ValAddress(valAddr))
+
+	// the delegator 3 has no delegation
+	var delegators []string
+	for i := 0; i < 6; i++ {
+		name := fmt.Sprintf("delegator%d", i)
+		address, err := c.Keys().RecoverWithOptions(name, "1234567890", test.Mnemonic, sdk.HDOptions{Index: uint32(i)})
+		require.NoError(t, err)
+		require.NoError(t, chain.Fund(address, funds))
+		if i != 3 {
+			amount, e := sdk.ParseDecCoin(fmt.Sprintf("%diris", i+1))
+			require.NoError(t, e)
+			_, err = c.Staking().Delegate(validator, amount, sdk.BaseTx{From: name, Password: "1234567890", Gas: 20000})
+			require.NoError(t, err)
+		}
+		delegators = append(delegators, address)
+	}
+	// an invalid address and the address of a validator
+	delegators = append(delegators, "iaa1invalid", validator)
+
+	results := c.Staking().BatchQueryDelegations(delegators...)
+	require.Len(t, results, len(delegators))
+	for i, res := range results {
+		require.Equal(t, delegators[i], res.Delegator)
+		if i >= 6 {
+			require.Error(t, res.Err, i)
+			require.Empty(t, res.Delegations)
+			continue
+		}
+		require.NoError(t, res.Err)
+		if i == 3 {
+			require.Empty(t, res.Delegations)
+			continue
+		}
+		require.Len(t, res.Delegations, 1)
+		require.Equal(t, delegators[i], res.Delegations[0].DelegatorAddr)
+		require.Equal(t, validator, res.Delegations[0].ValidatorAddr)
+		shares, e := sdk.NewDecFromStr(res.Delegations[0].Shares)
+		require.NoError(t, e)
+		require.Equal(t, sdk.NewDecFromInt(sdk.NewIntWithDecimal(int64(i+1), 18)), shares)
+	}
+	require.Empty(t, c.Staking().BatchQueryDelegations())
+}
+
+func TestQueryV100(t *testing.T) {
+	prefixes := sdk.Mainnet.AddrPrefixCfg()
+	consKey := ed25519.GenPrivKey().PubKey().(ed25519.PubKeyEd25519)
+	consPub, e := prefixes.Bech32ifyConsPub(consKey)
+	require.NoError(t, e)
+	accAddr := sdk.AccAddress(secp256k1.GenPrivKey().PubKey().Address())
+	delegator := prefixes.AccAddressString(accAddr)
+	validator := prefixes.ValAddressString(sdk.ValAddress(accAddr))
+	dstValidator := prefixes.ValAddressString(sdk.ValAddress(consKey.Address()))
+	timestamp := new(proto.Encoder).Uint64(1, 1600000000).Bytes()
+
+	chain := fakechain.New(fakechain.WithChainID("irishub-1"), fakechain.WithAppVersion("1.0.0"))
+	chain.RegisterQuerier("/cosmos.staking.v1beta1.Query/Validators", func(_ fakechain.Context, data []byte) ([]byte, error) {
+		page, _, err := proto.Lookup(data, 2)
+		if err != nil || !bytes.Equal(page, proto.PageRequest(nil, 10, 10)) {
+			return nil, fmt.Errorf("unexpected request %x", data)
+		}
+		v := new(proto.Encoder).
+			String(1, validator).
+			Any(2, "/cosmos.crypto.ed25519.PubKey", new(proto.Encoder).Raw(1, consKey[:]).Bytes()).
+			Uint64(4, 3).
+			String(5, "1000").
+			String(6, "1000000000000000000000").
+			Message(7, new(proto.Encoder).String(1, "moniker").String(5, "details").Bytes()).
+			Message(10, new(proto.Encoder).
+				Message(1, new(proto.Encoder).String(1, "100000000000000000").Bytes()).
+				Message(2, timestamp).
+				Bytes())
+		return new(proto.Encoder).Message(1, v.Bytes()).Bytes(), nil
+	})
+	chain.RegisterQuerier("/cosmos.staking.v1beta1.Query/DelegatorDelegations", func(_ fakechain.Context, data []byte) ([]byte, error) {
+		page, _, err := proto.Lookup(data, 2)
+		if err != nil {
+			return nil, err
+		}
+		key, _, err := proto.Lookup(page, 1)
+		if err != nil {
+			return nil, err
+		}
+		d := new(proto.Encoder).
+			Message(1, new(proto.Encoder).
+				String(1, delegator).
+				String(2, validator).
+				String(3, fmt.Sprintf("%d000000000000000000", len(key)+1)).
+				Bytes())
+		res := new(proto.Encoder).Message(1, d.Bytes())
+		if len(key) == 0 {
+			res.Message(2, new(proto.Encoder).Raw(1, []byte{1}).Bytes())
+		}
+		return res.Bytes(), nil
+	})
+	chain.RegisterQuerier("/cosmos.staking.v1beta1.Query/UnbondingDelegation", func(_ fakechain.Context, data []byte) ([]byte, error) {
+		entry := func(height uint64, amount string) []byte {
+			return new(proto.Encoder).Uint64(1, height).Message(2, timestamp).String(3, amount).String(4, amount).Bytes()
+		}
+		ubd := new(proto.Encoder).
+			String(1, delegator).
+			String(2, validator).
+			Message(3, entry(5, "10")).
+			Message(3, entry(7, "20"))
+		return new(proto.Encoder).Message(1, ubd.Bytes()).Bytes(), nil
+	})
+	chain.RegisterQuerier("/cosmos.staking.v1beta1.Query/Redelegations", func(_ fakechain.Context, data []byte) ([]byte, error) {
+		entry := func(height uint64, amount, shares string) []byte {
+			e := new(proto.Encoder).Uint64(1, height).Message(2, timestamp).String(3, amount).String(4, shares)
+			return new(proto.Encoder).Message(1, e.Bytes()).String(4, amount).Bytes()
+		}
+		rd := new(proto.Encoder).
+			Message(1, new(proto.Encoder).String(1, delegator).String(2, validator).String(3, dstValidator).Bytes()).
+			Message(2, entry(5, "10", "500000000000000000")).
+			Message(2, entry(7, "20", "1250000000000000000"))
+		return new(proto.Encoder).Message(1, rd.Bytes()).Bytes(), nil
+	})
+	chain.RegisterQuerier("/cosmos.staking.v1beta1.Query/Pool", func(_ fakechain.Context, _ []byte) ([]byte, error) {
+		pool := new(proto.Encoder).String(1, "300").String(2, "700")
+		return new(proto.Encoder).Message(1, pool.Bytes()).Bytes(), nil
+	})
+	chain.RegisterQuerier("/cosmos.staking.v1beta1.Query/Params", func(_ fakechain.Context, _ []byte) ([]byte, error) {
+		params := new(proto.Encoder).Message(1, new(proto.Encoder).Uint64(1, 1814400).Bytes()).Uint64(2, 100)
+		return new(proto.Encoder).Message(1, params.Bytes()).Bytes(), nil
+	})
+	fees, e := sdk.ParseDecCoins("0.3iris")
+	require.NoError(t, e)
+	c := client.NewClient(sdk.ClientConfig{
+		TmClient: chain,
+		Network:  sdk.Mainnet,
+		ChainID:  "irishub-1",
+		Fee:      fees,
+		KeyDAO:   sdk.NewMemoryDB(),
+	})
+
+	validators, err := c.Staking().QueryValidators(2, 10)
+	require.NoError(t, err)
+	require.Len(t, validators, 1)
+	require.Equal(t, validator, validators[0].OperatorAddress)
+	require.Equal(t, consPub, validators[0].ConsensusPubkey)
+	require.Equal(t, "Bonded", validators[0].Status)
+	require.Equal(t, "1000", validators[0].Tokens)
+	require.Equal(t, "1000.000000000000000000", validators[0].DelegatorShares)
+	require.Equal(t, rpc.Description{Moniker: "moniker", Details: "details"}, validators[0].Description)
+	require.Equal(t, "0.100000000000000000", validators[0].Commission.Rate)
+	require.Equal(t, time.Unix(1600000000, 0).UTC().String(), validators[0].Commission.UpdateTime)
+	_, err = c.Staking().QueryValidators(0, 10)
+	require.Error(t, err)
+
+	delegations, err := c.Staking().QueryDelegations(delegator)
+	require.NoError(t, err)
+	require.Equal(t, rpc.Delegations{
+		{DelegatorAddr: delegator, ValidatorAddr: validator, Shares: "1.000000000000000000"},
+		{DelegatorAddr: delegator, ValidatorAddr: validator, Shares: "2.000000000000000000"},
+	}, delegations)
+
+	ubd, err := c.Staking().QueryUnbondingDelegation(delegator, validator)
+	require.NoError(t, err)
+	require.Equal(t, int64(7), ubd.CreationHeight)
+	require.Equal(t, sdk.NewCoin("uiris", sdk.NewInt(30)), ubd.Balance)
+
+	rd, err := c.Staking().QueryRedelegation(delegator, validator, dstValidator)
+	require.NoError(t, err)
+	require.Equal(t, dstValidator, rd.ValidatorDstAddr)
+	require.Equal(t, int64(7), rd.CreationHeight)
+	require.Equal(t, sdk.NewCoin("uiris", sdk.NewInt(30)), rd.InitialBalance)
+	require.Equal(t, "1.750000000000000000", rd.SharesDst)
+
+	pool, err := c.Staking().QueryPool()
+	require.NoError(t, err)
+	require.Equal(t, rpc.StakePool{LooseTokens: "300", BondedTokens: "700"}, pool)
+
+	params, err := c.Staking().QueryParams()
+	require.NoError(t, err)
+	require.Equal(t, rpc.StakeParams{UnbondingTime: "504h0m0s", MaxValidators: 100}, params)
+}
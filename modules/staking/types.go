@@ -3,14 +3,18 @@ package staking
 import (
 	"bytes"
 	"errors"
+	"fmt"
+	"math/big"
 	"time"
 
 	"github.com/tendermint/tendermint/crypto"
+	"github.com/tendermint/tendermint/crypto/ed25519"
 
 	"github.com/irisnet/irishub-sdk-go/rpc"
 
 	sdk "github.com/irisnet/irishub-sdk-go/types"
 	"github.com/irisnet/irishub-sdk-go/utils/json"
+	"github.com/irisnet/irishub-sdk-go/utils/proto"
 )
 
 const (
@@ -22,6 +26,8 @@ var (
 	_ sdk.Msg = MsgUndelegate{}
 	_ sdk.Msg = MsgBeginRedelegate{}
 
+	_ sdk.ProtoMsg = MsgDelegate{}
+
 	cdc = sdk.NewAminoCodec()
 )
 
@@ -56,30 +62,48 @@ func (msg MsgCreateValidator) GetSigners() []sdk.AccAddress {
 	return addrs
 }
 
+// Implements SpendMsg, the self delegation leaves the account.
+func (msg MsgCreateValidator) SpentCoins() sdk.Coins { return sdk.Coins{msg.Delegation} }
+
+// Implements SpendMsg.
+func (msg MsgCreateValidator) Recipients() []sdk.AccAddress { return nil }
+
+// Implements ValidatorMsg.
+func (msg MsgCreateValidator) Validators() []sdk.ValAddress {
+	return []sdk.ValAddress{msg.ValidatorAddr}
+}
+
 // get the bytes for the message signer to sign on
 func (msg MsgCreateValidator) GetSignBytes() []byte {
-	pukKey, err := sdk.Bech32ifyConsPub(msg.PubKey)
+	b, err := cdc.MarshalJSON(msg.SignDoc(sdk.GetAddrPrefixCfg()))
 	if err != nil {
 		panic(err)
 	}
+	return json.MustSort(b)
+}
 
-	b, err := cdc.MarshalJSON(struct {
+// Implements SignDocMsg.
+func (msg MsgCreateValidator) SignDoc(config *sdk.AddrPrefixCfg) interface{} {
+	pukKey, err := config.Bech32ifyConsPub(msg.PubKey)
+	if err != nil {
+		panic(err)
+	}
+
+	return struct {
 		Description
 		Commission    CommissionMsg
-		DelegatorAddr sdk.AccAddress `json:"delegator_address"`
-		ValidatorAddr sdk.ValAddress `json:"validator_address"`
-		PubKey        string         `json:"pubkey"`
-		Delegation    sdk.Coin       `json:"delegation"`
+		DelegatorAddr string   `json:"delegator_address"`
+		ValidatorAddr string   `json:"validator_address"`
+		PubKey        string   `json:"pubkey"`
+		Delegation    sdk.Coin `json:"delegation"`
 	}{
-		Description:   msg.Description,
-		ValidatorAddr: msg.ValidatorAddr,
+		Description: msg.Description,
+		// the delegator is signed empty, as by IRIShub
+		DelegatorAddr: config.AccAddressString(nil),
+		ValidatorAddr: config.ValAddressString(msg.ValidatorAddr),
 		PubKey:        pukKey,
 		Delegation:    msg.Delegation,
-	})
-	if err != nil {
-		panic(err)
 	}
-	return json.MustSort(b)
 }
 
 // quick validity check
@@ -104,6 +128,29 @@ func (msg MsgDelegate) GetSigners() []sdk.AccAddress {
 	return []sdk.AccAddress{msg.DelegatorAddr}
 }
 
+// Implements SpendMsg.
+func (msg MsgDelegate) SpentCoins() sdk.Coins { return sdk.Coins{msg.Delegation} }
+
+// Implements SpendMsg.
+func (msg MsgDelegate) Recipients() []sdk.AccAddress { return nil }
+
+// Implements ValidatorMsg.
+func (msg MsgDelegate) Validators() []sdk.ValAddress {
+	return []sdk.ValAddress{msg.ValidatorAddr}
+}
+
+// Implements ProtoMsg.
+func (msg MsgDelegate) ProtoTypeURL() string { return "/cosmos.staking.v1beta1.MsgDelegate" }
+
+// Implements ProtoMsg.
+func (msg MsgDelegate) MarshalProto(prefixes *sdk.AddrPrefixCfg) ([]byte, error) {
+	e := new(proto.Encoder).
+		String(1, prefixes.AccAddressString(msg.DelegatorAddr)).
+		String(2, prefixes.ValAddressString(msg.ValidatorAddr)).
+		Message(3, sdk.MarshalProtoCoin(msg.Delegation))
+	return e.Bytes(), nil
+}
+
 // get the bytes for the message signer to sign on
 func (msg MsgDelegate) GetSignBytes() []byte {
 	b, err := cdc.MarshalJSON(msg)
@@ -113,6 +160,22 @@ func (msg MsgDelegate) GetSignBytes() []byte {
 	return json.MustSort(b)
 }
 
+// Implements SignDocMsg.
+func (msg MsgDelegate) SignDoc(config *sdk.AddrPrefixCfg) interface{} {
+	return sdk.TypedSignDoc{
+		Type: "irishub/stake/MsgDelegate",
+		Value: struct {
+			DelegatorAddr string   `json:"delegator_addr"`
+			ValidatorAddr string   `json:"validator_addr"`
+			Delegation    sdk.Coin `json:"delegation"`
+		}{
+			DelegatorAddr: config.AccAddressString(msg.DelegatorAddr),
+			ValidatorAddr: config.ValAddressString(msg.ValidatorAddr),
+			Delegation:    msg.Delegation,
+		},
+	}
+}
+
 // quick validity check
 func (msg MsgDelegate) ValidateBasic() error {
 	if msg.DelegatorAddr == nil {
@@ -121,7 +184,7 @@ func (msg MsgDelegate) ValidateBasic() error {
 	if msg.ValidatorAddr == nil {
 		return errors.New("validator address is nil")
 	}
-	if !msg.Delegation.IsValidIrisAtto() {
+	if !msg.Delegation.IsValidIris() {
 		return errors.New("amount must be greater than 0")
 	}
 	return nil
@@ -139,25 +202,42 @@ type MsgUndelegate struct {
 //nolint
 func (msg MsgUndelegate) Route() string                { return ModuleName }
 func (msg MsgUndelegate) Type() string                 { return "begin_unbonding" }
+
+// Implements NoSpendMsg.
+func (msg MsgUndelegate) SpendsNoCoins() {}
+
+// Implements RecipientMsg.
+func (msg MsgUndelegate) Recipients() []sdk.AccAddress { return nil }
+
 func (msg MsgUndelegate) GetSigners() []sdk.AccAddress { return []sdk.AccAddress{msg.DelegatorAddr} }
 
+// Implements ValidatorMsg.
+func (msg MsgUndelegate) Validators() []sdk.ValAddress {
+	return []sdk.ValAddress{msg.ValidatorAddr}
+}
+
 // get the bytes for the message signer to sign on
 func (msg MsgUndelegate) GetSignBytes() []byte {
-	b, err := cdc.MarshalJSON(struct {
-		DelegatorAddr sdk.AccAddress `json:"delegator_addr"`
-		ValidatorAddr sdk.ValAddress `json:"validator_addr"`
-		SharesAmount  string         `json:"shares_amount"`
-	}{
-		DelegatorAddr: msg.DelegatorAddr,
-		ValidatorAddr: msg.ValidatorAddr,
-		SharesAmount:  msg.SharesAmount.String(),
-	})
+	b, err := cdc.MarshalJSON(msg.SignDoc(sdk.GetAddrPrefixCfg()))
 	if err != nil {
 		panic(err)
 	}
 	return json.MustSort(b)
 }
 
+// Implements SignDocMsg.
+func (msg MsgUndelegate) SignDoc(config *sdk.AddrPrefixCfg) interface{} {
+	return struct {
+		DelegatorAddr string `json:"delegator_addr"`
+		ValidatorAddr string `json:"validator_addr"`
+		SharesAmount  string `json:"shares_amount"`
+	}{
+		DelegatorAddr: config.AccAddressString(msg.DelegatorAddr),
+		ValidatorAddr: config.ValAddressString(msg.ValidatorAddr),
+		SharesAmount:  msg.SharesAmount.String(),
+	}
+}
+
 // quick validity check
 func (msg MsgUndelegate) ValidateBasic() error {
 	if msg.DelegatorAddr == nil {
@@ -195,29 +275,46 @@ func NewMsgBeginRedelegate(delAddr sdk.AccAddress, valSrcAddr,
 //nolint
 func (msg MsgBeginRedelegate) Route() string { return ModuleName }
 func (msg MsgBeginRedelegate) Type() string  { return "begin_redelegate" }
+
+// Implements NoSpendMsg.
+func (msg MsgBeginRedelegate) SpendsNoCoins() {}
+
+// Implements RecipientMsg.
+func (msg MsgBeginRedelegate) Recipients() []sdk.AccAddress { return nil }
+
 func (msg MsgBeginRedelegate) GetSigners() []sdk.AccAddress {
 	return []sdk.AccAddress{msg.DelegatorAddr}
 }
 
+// Implements ValidatorMsg.
+func (msg MsgBeginRedelegate) Validators() []sdk.ValAddress {
+	return []sdk.ValAddress{msg.ValidatorSrcAddr, msg.ValidatorDstAddr}
+}
+
 // get the bytes for the message signer to sign on
 func (msg MsgBeginRedelegate) GetSignBytes() []byte {
-	b, err := cdc.MarshalJSON(struct {
-		DelegatorAddr    sdk.AccAddress `json:"delegator_addr"`
-		ValidatorSrcAddr sdk.ValAddress `json:"validator_src_addr"`
-		ValidatorDstAddr sdk.ValAddress `json:"validator_dst_addr"`
-		SharesAmount     string         `json:"shares"`
-	}{
-		DelegatorAddr:    msg.DelegatorAddr,
-		ValidatorSrcAddr: msg.ValidatorSrcAddr,
-		ValidatorDstAddr: msg.ValidatorDstAddr,
-		SharesAmount:     msg.SharesAmount.String(),
-	})
+	b, err := cdc.MarshalJSON(msg.SignDoc(sdk.GetAddrPrefixCfg()))
 	if err != nil {
 		panic(err)
 	}
 	return json.MustSort(b)
 }
 
+// Implements SignDocMsg.
+func (msg MsgBeginRedelegate) SignDoc(config *sdk.AddrPrefixCfg) interface{} {
+	return struct {
+		DelegatorAddr    string `json:"delegator_addr"`
+		ValidatorSrcAddr string `json:"validator_src_addr"`
+		ValidatorDstAddr string `json:"validator_dst_addr"`
+		SharesAmount     string `json:"shares"`
+	}{
+		DelegatorAddr:    config.AccAddressString(msg.DelegatorAddr),
+		ValidatorSrcAddr: config.ValAddressString(msg.ValidatorSrcAddr),
+		ValidatorDstAddr: config.ValAddressString(msg.ValidatorDstAddr),
+		SharesAmount:     msg.SharesAmount.String(),
+	}
+}
+
 // quick validity check
 func (msg MsgBeginRedelegate) ValidateBasic() error {
 	if msg.DelegatorAddr == nil {
@@ -253,25 +350,42 @@ type MsgEditValidator struct {
 //nolint
 func (msg MsgEditValidator) Route() string { return ModuleName }
 func (msg MsgEditValidator) Type() string  { return "edit_validator" }
+
+// Implements NoSpendMsg.
+func (msg MsgEditValidator) SpendsNoCoins() {}
+
+// Implements RecipientMsg.
+func (msg MsgEditValidator) Recipients() []sdk.AccAddress { return nil }
+
+// Implements ValidatorMsg.
+func (msg MsgEditValidator) Validators() []sdk.ValAddress {
+	return []sdk.ValAddress{msg.ValidatorAddr}
+}
+
 func (msg MsgEditValidator) GetSigners() []sdk.AccAddress {
 	return []sdk.AccAddress{sdk.AccAddress(msg.ValidatorAddr)}
 }
 
 // get the bytes for the message signer to sign on
 func (msg MsgEditValidator) GetSignBytes() []byte {
-	b, err := cdc.MarshalJSON(struct {
-		Description
-		ValidatorAddr sdk.ValAddress `json:"address"`
-	}{
-		Description:   msg.Description,
-		ValidatorAddr: msg.ValidatorAddr,
-	})
+	b, err := cdc.MarshalJSON(msg.SignDoc(sdk.GetAddrPrefixCfg()))
 	if err != nil {
 		panic(err)
 	}
 	return json.MustSort(b)
 }
 
+// Implements SignDocMsg.
+func (msg MsgEditValidator) SignDoc(config *sdk.AddrPrefixCfg) interface{} {
+	return struct {
+		Description
+		ValidatorAddr string `json:"address"`
+	}{
+		Description:   msg.Description,
+		ValidatorAddr: config.ValAddressString(msg.ValidatorAddr),
+	}
+}
+
 // quick validity check
 func (msg MsgEditValidator) ValidateBasic() error {
 	if msg.ValidatorAddr == nil {
@@ -289,16 +403,16 @@ func (msg MsgEditValidator) ValidateBasic() error {
 // owned by one delegator, and is associated with the voting power of one
 // pubKey.
 type delegation struct {
-	DelegatorAddr sdk.AccAddress `json:"delegator_addr"`
-	ValidatorAddr sdk.ValAddress `json:"validator_addr"`
-	Shares        sdk.Dec        `json:"shares"`
-	Height        int64          `json:"height"` // Last height bond updated
+	DelegatorAddr string  `json:"delegator_addr"`
+	ValidatorAddr string  `json:"validator_addr"`
+	Shares        sdk.Dec `json:"shares"`
+	Height        int64   `json:"height"` // Last height bond updated
 }
 
 func (d delegation) Convert() interface{} {
 	return rpc.Delegation{
-		DelegatorAddr: d.DelegatorAddr.String(),
-		ValidatorAddr: d.ValidatorAddr.String(),
+		DelegatorAddr: d.DelegatorAddr,
+		ValidatorAddr: d.ValidatorAddr,
 		Shares:        d.Shares.String(),
 		Height:        d.Height,
 	}
@@ -307,7 +421,7 @@ func (d delegation) Convert() interface{} {
 type delegations []delegation
 
 func (ds delegations) Convert() interface{} {
-	delegations := make(rpc.Delegations, len(ds))
+	delegations := make(rpc.Delegations, 0, len(ds))
 	for _, d := range ds {
 		delegations = append(delegations, d.Convert().(rpc.Delegation))
 	}
@@ -316,20 +430,20 @@ func (ds delegations) Convert() interface{} {
 
 // unbondingDelegation reflects a delegation's passive unbonding queue.
 type unbondingDelegation struct {
-	TxHash         string         `json:"tx_hash"`
-	DelegatorAddr  sdk.AccAddress `json:"delegator_addr"`  // delegator
-	ValidatorAddr  sdk.ValAddress `json:"validator_addr"`  // validator unbonding from operator addr
-	CreationHeight int64          `json:"creation_height"` // height which the unbonding took place
-	MinTime        time.Time      `json:"min_time"`        // unix time for unbonding completion
-	InitialBalance sdk.Coin       `json:"initial_balance"` // atoms initially scheduled to receive at completion
-	Balance        sdk.Coin       `json:"balance"`         // atoms to receive at completion
+	TxHash         string    `json:"tx_hash"`
+	DelegatorAddr  string    `json:"delegator_addr"`  // delegator
+	ValidatorAddr  string    `json:"validator_addr"`  // validator unbonding from operator addr
+	CreationHeight int64     `json:"creation_height"` // height which the unbonding took place
+	MinTime        time.Time `json:"min_time"`        // unix time for unbonding completion
+	InitialBalance sdk.Coin  `json:"initial_balance"` // atoms initially scheduled to receive at completion
+	Balance        sdk.Coin  `json:"balance"`         // atoms to receive at completion
 }
 
 func (ubd unbondingDelegation) Convert() interface{} {
 	return rpc.UnbondingDelegation{
 		TxHash:         ubd.TxHash,
-		DelegatorAddr:  ubd.DelegatorAddr.String(),
-		ValidatorAddr:  ubd.ValidatorAddr.String(),
+		DelegatorAddr:  ubd.DelegatorAddr,
+		ValidatorAddr:  ubd.ValidatorAddr,
 		CreationHeight: ubd.CreationHeight,
 		MinTime:        ubd.MinTime.String(),
 		InitialBalance: ubd.InitialBalance,
@@ -340,7 +454,7 @@ func (ubd unbondingDelegation) Convert() interface{} {
 type unbondingDelegations []unbondingDelegation
 
 func (ubds unbondingDelegations) Convert() interface{} {
-	uds := make(rpc.UnbondingDelegations, len(ubds))
+	uds := make(rpc.UnbondingDelegations, 0, len(ubds))
 	for _, d := range ubds {
 		uds = append(uds, d.Convert().(rpc.UnbondingDelegation))
 	}
@@ -349,22 +463,22 @@ func (ubds unbondingDelegations) Convert() interface{} {
 
 // redelegation reflects a delegation's passive re-delegation queue.
 type redelegation struct {
-	DelegatorAddr    sdk.AccAddress `json:"delegator_addr"`     // delegator
-	ValidatorSrcAddr sdk.ValAddress `json:"validator_src_addr"` // validator redelegation source operator addr
-	ValidatorDstAddr sdk.ValAddress `json:"validator_dst_addr"` // validator redelegation destination operator addr
-	CreationHeight   int64          `json:"creation_height"`    // height which the redelegation took place
-	MinTime          time.Time      `json:"min_time"`           // unix time for redelegation completion
-	InitialBalance   sdk.Coin       `json:"initial_balance"`    // initial balance when redelegation started
-	Balance          sdk.Coin       `json:"balance"`            // current balance
-	SharesSrc        sdk.Dec        `json:"shares_src"`         // amount of source shares redelegating
-	SharesDst        sdk.Dec        `json:"shares_dst"`         // amount of destination shares redelegating
+	DelegatorAddr    string    `json:"delegator_addr"`     // delegator
+	ValidatorSrcAddr string    `json:"validator_src_addr"` // validator redelegation source operator addr
+	ValidatorDstAddr string    `json:"validator_dst_addr"` // validator redelegation destination operator addr
+	CreationHeight   int64     `json:"creation_height"`    // height which the redelegation took place
+	MinTime          time.Time `json:"min_time"`           // unix time for redelegation completion
+	InitialBalance   sdk.Coin  `json:"initial_balance"`    // initial balance when redelegation started
+	Balance          sdk.Coin  `json:"balance"`            // current balance
+	SharesSrc        sdk.Dec   `json:"shares_src"`         // amount of source shares redelegating
+	SharesDst        sdk.Dec   `json:"shares_dst"`         // amount of destination shares redelegating
 }
 
 func (d redelegation) Convert() interface{} {
 	return rpc.Redelegation{
-		DelegatorAddr:    d.DelegatorAddr.String(),
-		ValidatorSrcAddr: d.ValidatorDstAddr.String(),
-		ValidatorDstAddr: d.ValidatorDstAddr.String(),
+		DelegatorAddr:    d.DelegatorAddr,
+		ValidatorSrcAddr: d.ValidatorSrcAddr,
+		ValidatorDstAddr: d.ValidatorDstAddr,
 		CreationHeight:   d.CreationHeight,
 		MinTime:          d.MinTime.String(),
 		InitialBalance:   d.InitialBalance,
@@ -377,7 +491,7 @@ func (d redelegation) Convert() interface{} {
 type redelegations []redelegation
 
 func (ds redelegations) Convert() interface{} {
-	rds := make(rpc.Redelegations, len(ds))
+	rds := make(rpc.Redelegations, 0, len(ds))
 	for _, d := range ds {
 		rds = append(rds, d.Convert().(rpc.Redelegation))
 	}
@@ -510,6 +624,393 @@ func (p params) Convert() interface{} {
 	}
 }
 
+// unmarshalProtoDelegationResponse decodes a cosmos.staking.v1beta1.DelegationResponse
+func unmarshalProtoDelegationResponse(bz []byte) (rpc.Delegation, error) {
+	var d rpc.Delegation
+	delegation, _, err := proto.Lookup(bz, 1)
+	if err != nil {
+		return d, err
+	}
+	fields, err := proto.Decode(delegation)
+	if err != nil {
+		return d, err
+	}
+	for _, f := range fields {
+		switch f.Number {
+		case 1:
+			d.DelegatorAddr = string(f.Value)
+		case 2:
+			d.ValidatorAddr = string(f.Value)
+		case 3:
+			if d.Shares, err = proto.Dec(f.Value); err != nil {
+				return rpc.Delegation{}, err
+			}
+		}
+	}
+	return d, nil
+}
+
+// unmarshalProtoUnbondingDelegation decodes a cosmos.staking.v1beta1.UnbondingDelegation, an unbonding
+// delegation for each of its entries
+func unmarshalProtoUnbondingDelegation(bz []byte) (rpc.UnbondingDelegations, error) {
+	fields, err := proto.Decode(bz)
+	if err != nil {
+		return nil, err
+	}
+
+	var delegatorAddr, validatorAddr string
+	var entries [][]byte
+	for _, f := range fields {
+		switch f.Number {
+		case 1:
+			delegatorAddr = string(f.Value)
+		case 2:
+			validatorAddr = string(f.Value)
+		case 3:
+			entries = append(entries, f.Value)
+		}
+	}
+
+	ubds := make(rpc.UnbondingDelegations, 0, len(entries))
+	for _, entry := range entries {
+		fields, err := proto.Decode(entry)
+		if err != nil {
+			return nil, err
+		}
+		ubd := rpc.UnbondingDelegation{
+			DelegatorAddr:  delegatorAddr,
+			ValidatorAddr:  validatorAddr,
+			InitialBalance: sdk.NewCoin(sdk.IRISv1.MinUnit, sdk.ZeroInt()),
+			Balance:        sdk.NewCoin(sdk.IRISv1.MinUnit, sdk.ZeroInt()),
+		}
+		for _, f := range fields {
+			switch f.Number {
+			case 1:
+				ubd.CreationHeight = int64(f.Varint)
+			case 2:
+				t, err := proto.Timestamp(f.Value)
+				if err != nil {
+					return nil, err
+				}
+				ubd.MinTime = t.String()
+			case 3:
+				ubd.InitialBalance, err = protoIRISCoin(f.Value)
+			case 4:
+				ubd.Balance, err = protoIRISCoin(f.Value)
+			}
+			if err != nil {
+				return nil, err
+			}
+		}
+		ubds = append(ubds, ubd)
+	}
+	return ubds, nil
+}
+
+// sumUnbondingDelegations returns the unbonding delegation of all the entries of an unbonding delegation, with the
+// height and the completion time of the last one
+func sumUnbondingDelegations(ubds rpc.UnbondingDelegations) rpc.UnbondingDelegation {
+	sum := ubds[len(ubds)-1]
+	for _, ubd := range ubds[:len(ubds)-1] {
+		sum.InitialBalance = sum.InitialBalance.Add(ubd.InitialBalance)
+		sum.Balance = sum.Balance.Add(ubd.Balance)
+	}
+	return sum
+}
+
+// unmarshalProtoRedelegationResponse decodes a cosmos.staking.v1beta1.RedelegationResponse, a redelegation for
+// each of its entries
+func unmarshalProtoRedelegationResponse(bz []byte) (rpc.Redelegations, error) {
+	fields, err := proto.Decode(bz)
+	if err != nil {
+		return nil, err
+	}
+
+	var redelegation []byte
+	var entries [][]byte
+	for _, f := range fields {
+		switch f.Number {
+		case 1:
+			redelegation = f.Value
+		case 2:
+			entries = append(entries, f.Value)
+		}
+	}
+
+	fields, err = proto.Decode(redelegation)
+	if err != nil {
+		return nil, err
+	}
+	var delegatorAddr, srcValidatorAddr, dstValidatorAddr string
+	for _, f := range fields {
+		switch f.Number {
+		case 1:
+			delegatorAddr = string(f.Value)
+		case 2:
+			srcValidatorAddr = string(f.Value)
+		case 3:
+			dstValidatorAddr = string(f.Value)
+		}
+	}
+
+	rds := make(rpc.Redelegations, 0, len(entries))
+	for _, entry := range entries {
+		rd := rpc.Redelegation{
+			DelegatorAddr:    delegatorAddr,
+			ValidatorSrcAddr: srcValidatorAddr,
+			ValidatorDstAddr: dstValidatorAddr,
+			InitialBalance:   sdk.NewCoin(sdk.IRISv1.MinUnit, sdk.ZeroInt()),
+			Balance:          sdk.NewCoin(sdk.IRISv1.MinUnit, sdk.ZeroInt()),
+		}
+		fields, err := proto.Decode(entry)
+		if err != nil {
+			return nil, err
+		}
+		for _, f := range fields {
+			switch f.Number {
+			case 1:
+				err = unmarshalProtoRedelegationEntry(f.Value, &rd)
+			case 4:
+				rd.Balance, err = protoIRISCoin(f.Value)
+			}
+			if err != nil {
+				return nil, err
+			}
+		}
+		rds = append(rds, rd)
+	}
+	return rds, nil
+}
+
+// unmarshalProtoRedelegationEntry decodes a cosmos.staking.v1beta1.RedelegationEntry into rd
+func unmarshalProtoRedelegationEntry(bz []byte, rd *rpc.Redelegation) error {
+	fields, err := proto.Decode(bz)
+	if err != nil {
+		return err
+	}
+	for _, f := range fields {
+		switch f.Number {
+		case 1:
+			rd.CreationHeight = int64(f.Varint)
+		case 2:
+			var t time.Time
+			t, err = proto.Timestamp(f.Value)
+			rd.MinTime = t.String()
+		case 3:
+			rd.InitialBalance, err = protoIRISCoin(f.Value)
+		case 4:
+			rd.SharesDst, err = proto.Dec(f.Value)
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// sumRedelegations returns the redelegation of all the entries of a redelegation, with the height and the
+// completion time of the last one
+func sumRedelegations(rds rpc.Redelegations) (rpc.Redelegation, error) {
+	sum := rds[len(rds)-1]
+	shares, ok := new(big.Rat).SetString(sum.SharesDst)
+	if !ok {
+		return rpc.Redelegation{}, fmt.Errorf("invalid shares %s", sum.SharesDst)
+	}
+	for _, rd := range rds[:len(rds)-1] {
+		sum.InitialBalance = sum.InitialBalance.Add(rd.InitialBalance)
+		sum.Balance = sum.Balance.Add(rd.Balance)
+		s, ok := new(big.Rat).SetString(rd.SharesDst)
+		if !ok {
+			return rpc.Redelegation{}, fmt.Errorf("invalid shares %s", rd.SharesDst)
+		}
+		shares.Add(shares, s)
+	}
+	sum.SharesDst = shares.FloatString(proto.DecPrecision)
+	return sum, nil
+}
+
+// unmarshalProtoValidator decodes a cosmos.staking.v1beta1.Validator
+func unmarshalProtoValidator(bz []byte, prefixes *sdk.AddrPrefixCfg) (rpc.Validator, error) {
+	var v rpc.Validator
+	fields, err := proto.Decode(bz)
+	if err != nil {
+		return v, err
+	}
+	for _, f := range fields {
+		switch f.Number {
+		case 1:
+			v.OperatorAddress = string(f.Value)
+		case 2:
+			v.ConsensusPubkey, err = protoConsPubKey(f.Value, prefixes)
+		case 3:
+			v.Jailed = f.Varint != 0
+		case 4:
+			v.Status = protoBondStatus(f.Varint)
+		case 5:
+			v.Tokens = string(f.Value)
+		case 6:
+			v.DelegatorShares, err = proto.Dec(f.Value)
+		case 7:
+			v.Description, err = unmarshalProtoDescription(f.Value)
+		case 8:
+			v.UnbondingHeight = int64(f.Varint)
+		case 9:
+			var t time.Time
+			t, err = proto.Timestamp(f.Value)
+			v.UnbondingTime = t.String()
+		case 10:
+			v.Commission, err = unmarshalProtoCommission(f.Value)
+		}
+		if err != nil {
+			return rpc.Validator{}, err
+		}
+	}
+	return v, nil
+}
+
+// protoConsPubKey returns the Bech32 encoding of the consensus public key of a google.protobuf.Any
+func protoConsPubKey(bz []byte, prefixes *sdk.AddrPrefixCfg) (string, error) {
+	typeURL, value, err := proto.DecodeAny(bz)
+	if err != nil {
+		return "", err
+	}
+	if typeURL != "/cosmos.crypto.ed25519.PubKey" {
+		return "", fmt.Errorf("unsupported consensus public key %s", typeURL)
+	}
+	key, _, err := proto.Lookup(value, 1)
+	if err != nil {
+		return "", err
+	}
+	var pk ed25519.PubKeyEd25519
+	copy(pk[:], key)
+	return prefixes.Bech32ifyConsPub(pk)
+}
+
+// protoBondStatus returns the name of a cosmos.staking.v1beta1.BondStatus
+func protoBondStatus(status uint64) string {
+	if status < 1 || status > 3 {
+		return "Unspecified"
+	}
+	return bondStatus(status - 1).String()
+}
+
+// unmarshalProtoDescription decodes a cosmos.staking.v1beta1.Description
+func unmarshalProtoDescription(bz []byte) (rpc.Description, error) {
+	var d rpc.Description
+	fields, err := proto.Decode(bz)
+	if err != nil {
+		return d, err
+	}
+	for _, f := range fields {
+		switch f.Number {
+		case 1:
+			d.Moniker = string(f.Value)
+		case 2:
+			d.Identity = string(f.Value)
+		case 3:
+			d.Website = string(f.Value)
+		case 5:
+			d.Details = string(f.Value)
+		}
+	}
+	return d, nil
+}
+
+// unmarshalProtoCommission decodes a cosmos.staking.v1beta1.Commission
+func unmarshalProtoCommission(bz []byte) (rpc.Commission, error) {
+	var c rpc.Commission
+	fields, err := proto.Decode(bz)
+	if err != nil {
+		return c, err
+	}
+	for _, f := range fields {
+		switch f.Number {
+		case 1:
+			var rates []proto.Field
+			if rates, err = proto.Decode(f.Value); err != nil {
+				return rpc.Commission{}, err
+			}
+			for _, r := range rates {
+				switch r.Number {
+				case 1:
+					c.Rate, err = proto.Dec(r.Value)
+				case 2:
+					c.MaxRate, err = proto.Dec(r.Value)
+				case 3:
+					c.MaxChangeRate, err = proto.Dec(r.Value)
+				}
+				if err != nil {
+					return rpc.Commission{}, err
+				}
+			}
+		case 2:
+			t, err := proto.Timestamp(f.Value)
+			if err != nil {
+				return rpc.Commission{}, err
+			}
+			c.UpdateTime = t.String()
+		}
+	}
+	return c, nil
+}
+
+// unmarshalProtoPool decodes a cosmos.staking.v1beta1.QueryPoolResponse
+func unmarshalProtoPool(bz []byte) (rpc.StakePool, error) {
+	var pool rpc.StakePool
+	bz, _, err := proto.Lookup(bz, 1)
+	if err != nil {
+		return pool, err
+	}
+	fields, err := proto.Decode(bz)
+	if err != nil {
+		return pool, err
+	}
+	for _, f := range fields {
+		switch f.Number {
+		case 1:
+			pool.LooseTokens = string(f.Value)
+		case 2:
+			pool.BondedTokens = string(f.Value)
+		}
+	}
+	return pool, nil
+}
+
+// unmarshalProtoParams decodes a cosmos.staking.v1beta1.QueryParamsResponse
+func unmarshalProtoParams(bz []byte) (rpc.StakeParams, error) {
+	var params rpc.StakeParams
+	bz, _, err := proto.Lookup(bz, 1)
+	if err != nil {
+		return params, err
+	}
+	fields, err := proto.Decode(bz)
+	if err != nil {
+		return params, err
+	}
+	for _, f := range fields {
+		switch f.Number {
+		case 1:
+			d, err := proto.Duration(f.Value)
+			if err != nil {
+				return rpc.StakeParams{}, err
+			}
+			params.UnbondingTime = d.String()
+		case 2:
+			params.MaxValidators = int(f.Varint)
+		}
+	}
+	return params, nil
+}
+
+// protoIRISCoin returns the coin of an amount of the staking token of 1.x
+func protoIRISCoin(bz []byte) (sdk.Coin, error) {
+	amount, ok := sdk.NewIntFromString(string(bz))
+	if !ok {
+		return sdk.Coin{}, fmt.Errorf("invalid amount %s", bz)
+	}
+	return sdk.NewCoin(sdk.IRISv1.MinUnit, amount), nil
+}
+
 func registerCodec(cdc sdk.Codec) {
 	//cdc.RegisterConcrete(Pool{}, "irishub/stake/Pool")
 	cdc.RegisterConcrete(&params{}, "irishub/stake/Params")
@@ -32,6 +32,9 @@ func (t tmClient) Name() string {
 }
 
 func (t tmClient) QueryBlock(height int64) (sdk.Block, sdk.Error) {
+	if err := sdk.RequireAmino(t.TxEncoding(), "tendermint.QueryBlock"); err != nil {
+		return sdk.Block{}, err
+	}
 	block, err := t.Block(&height)
 	if err != nil {
 		return sdk.Block{}, sdk.Wrap(err)
@@ -70,6 +73,6 @@ func (t tmClient) QueryValidators(height int64) (rpc.ResultQueryValidators, sdk.
 	}
 	return rpc.ResultQueryValidators{
 		BlockHeight: rs.BlockHeight,
-		Validators:  sdk.ParseValidators(rs.Validators),
+		Validators:  sdk.ParseValidators(t.AddrPrefixCfg(), rs.Validators),
 	}, nil
 }
@@ -1,8 +1,10 @@
 package modules
 
 import (
+	"encoding/json"
 	"fmt"
 	"strings"
+	"sync"
 
 	sdk "github.com/irisnet/irishub-sdk-go/types"
 	"github.com/irisnet/irishub-sdk-go/utils/cache"
@@ -11,45 +13,110 @@ import (
 
 type tokenQuery struct {
 	q sdk.Queries
-	*log.Logger
+	log.Logger
 	cache.Cache
+	encoding sdk.TxEncoding
+	version  sdk.Version
+	queries  *sdk.VersionedQueries
+	inflight *tokenFlights
 }
 
-func (l tokenQuery) QueryToken(symbol string) (sdk.Token, error) {
-	symbol = strings.ToLower(symbol)
-	if symbol == sdk.IRIS.Symbol || symbol == sdk.IRIS.MinUnit {
-		return sdk.IRIS, nil
+// tokenQuerier is implemented by the queries of the tokens of each version of the chain
+type tokenQuerier interface {
+	queryToken(symbol string) (sdk.Token, error)
+}
+
+func newTokenQuery(q sdk.Queries, logger log.Logger, c cache.Cache, encoding sdk.TxEncoding, version sdk.Version) tokenQuery {
+	queries := new(sdk.VersionedQueries).
+		Register(sdk.V017, tokenQuerierV017{q}).
+		Register(sdk.V100, tokenQuerierV100{})
+	return tokenQuery{
+		q:        q,
+		Logger:   logger,
+		Cache:    c,
+		encoding: encoding,
+		version:  version,
+		queries:  queries,
+		inflight: newTokenFlights(),
 	}
+}
+
+// tokenFlights shares the query of a token missing from the cache between the goroutines asking for it at once,
+// e.g. the conversions of a batch query
+type tokenFlights struct {
+	mu      sync.Mutex
+	queries map[string]*tokenFlight
+}
+
+type tokenFlight struct {
+	done  chan struct{}
+	token sdk.Token
+	err   error
+}
+
+func newTokenFlights() *tokenFlights {
+	return &tokenFlights{queries: make(map[string]*tokenFlight)}
+}
 
-	token, err := l.Get(l.prefixKey(symbol))
-	if err == nil {
-		return token.(sdk.Token), nil
+// do calls query unless a query of the symbol is running, whose result is returned
+func (f *tokenFlights) do(symbol string, query func() (sdk.Token, error)) (sdk.Token, error) {
+	f.mu.Lock()
+	if flight, ok := f.queries[symbol]; ok {
+		f.mu.Unlock()
+		<-flight.done
+		return flight.token, flight.err
 	}
+	flight := &tokenFlight{done: make(chan struct{})}
+	f.queries[symbol] = flight
+	f.mu.Unlock()
 
-	param := struct {
-		Symbol string
-	}{
-		Symbol: symbol,
+	flight.token, flight.err = query()
+	f.mu.Lock()
+	delete(f.queries, symbol)
+	f.mu.Unlock()
+	close(flight.done)
+	return flight.token, flight.err
+}
+
+func (l tokenQuery) QueryToken(symbol string) (sdk.Token, error) {
+	symbol = strings.ToLower(symbol)
+	native := sdk.IRIS
+	if l.encoding == sdk.Protobuf {
+		native = sdk.IRISv1
+	}
+	if symbol == native.Symbol || symbol == native.MinUnit {
+		return native, nil
 	}
 
-	symbol = strings.TrimSuffix(symbol, "-min")
-	var t sdk.Token
-	if err := l.q.QueryWithResponse("custom/asset/token", param, &t); err != nil {
-		return sdk.Token{}, err
+	if bz, err := l.Get(l.prefixKey(symbol)); err == nil {
+		var token sdk.Token
+		if err := json.Unmarshal(bz.([]byte), &token); err == nil {
+			return token, nil
+		}
 	}
 
-	l.SaveTokens(t)
-	return t, nil
+	return l.inflight.do(symbol, func() (sdk.Token, error) {
+		t, err := l.queries.Route(l.version).(tokenQuerier).queryToken(symbol)
+		if err != nil {
+			return sdk.Token{}, err
+		}
+
+		l.SaveTokens(t)
+		return t, nil
+	})
 }
 
 func (l tokenQuery) SaveTokens(tokens ...sdk.Token) {
 	for _, t := range tokens {
-		err1 := l.Set(l.prefixKey(t.Symbol), t)
-		err2 := l.Set(l.prefixKey(t.GetMinUnit()), t)
+		bz, err := json.Marshal(t)
+		if err != nil {
+			l.Warn("cache token failed", "symbol", t.Symbol)
+			continue
+		}
+		err1 := l.Set(l.prefixKey(t.Symbol), bz)
+		err2 := l.Set(l.prefixKey(t.GetMinUnit()), bz)
 		if err1 != nil || err2 != nil {
-			l.Warn().
-				Str("symbol", t.Symbol).
-				Msg("cache token failed")
+			l.Warn("cache token failed", "symbol", t.Symbol)
 		}
 	}
 }
@@ -89,3 +156,27 @@ func (l tokenQuery) ToMainCoin(coins ...sdk.Coin) (dstCoins sdk.DecCoins, err sd
 func (l tokenQuery) prefixKey(symbol string) string {
 	return fmt.Sprintf("token:%s", symbol)
 }
+
+type tokenQuerierV017 struct {
+	sdk.Queries
+}
+
+func (t tokenQuerierV017) queryToken(symbol string) (sdk.Token, error) {
+	param := struct {
+		Symbol string
+	}{
+		Symbol: symbol,
+	}
+
+	var token sdk.Token
+	if err := t.QueryWithResponse("custom/asset/token", param, &token); err != nil {
+		return sdk.Token{}, err
+	}
+	return token, nil
+}
+
+type tokenQuerierV100 struct{}
+
+func (t tokenQuerierV100) queryToken(symbol string) (sdk.Token, error) {
+	return sdk.Token{}, sdk.UnsupportedByProtobuf("QueryToken")
+}
@@ -1,16 +1,23 @@
 package modules
 
 import (
+	"context"
 	"encoding/hex"
 	"errors"
 	"time"
 
 	sdk "github.com/irisnet/irishub-sdk-go/types"
+	"github.com/irisnet/irishub-sdk-go/utils/log"
+	"github.com/irisnet/irishub-sdk-go/utils/trace"
+	"github.com/tendermint/tendermint/crypto"
 	ctypes "github.com/tendermint/tendermint/rpc/core/types"
 )
 
 // QueryTx returns the tx info
 func (base baseClient) QueryTx(hash string) (sdk.ResultQueryTx, error) {
+	if err := sdk.RequireAmino(base.encoder.Encoding(), "QueryTx"); err != nil {
+		return sdk.ResultQueryTx{}, err
+	}
 	tx, err := hex.DecodeString(hash)
 	if err != nil {
 		return sdk.ResultQueryTx{}, err
@@ -29,6 +36,9 @@ func (base baseClient) QueryTx(hash string) (sdk.ResultQueryTx, error) {
 }
 
 func (base baseClient) QueryTxs(builder *sdk.EventQueryBuilder, page, size int) (sdk.ResultSearchTxs, error) {
+	if err := sdk.RequireAmino(base.encoder.Encoding(), "QueryTxs"); err != nil {
+		return sdk.ResultSearchTxs{}, err
+	}
 
 	query := builder.Build()
 	if len(query) == 0 {
@@ -60,52 +70,198 @@ func (base baseClient) QueryTxs(builder *sdk.EventQueryBuilder, page, size int)
 	}, nil
 }
 
-func (base *baseClient) buildTx(msg []sdk.Msg, baseTx sdk.BaseTx) ([]byte, *sdk.TxContext, sdk.Error) {
-	ctx, err := base.prepare(baseTx)
+func (base *baseClient) buildTx(ctx context.Context, msg []sdk.Msg, baseTx sdk.BaseTx) ([]byte, *sdk.TxContext, sdk.Error) {
+	ctx, span := base.tracer.Start(ctx, "buildTx", trace.Int64("msgs", int64(len(msg))))
+	defer span.End()
+
+	txCtx, err := base.prepare(ctx, baseTx, true)
+	if err != nil {
+		span.RecordError(err)
+		return nil, txCtx, sdk.Wrap(err)
+	}
+	span.SetAttributes(
+		trace.Uint64("account_number", txCtx.AccountNumber()),
+		trace.Uint64("sequence", txCtx.Sequence()),
+	)
+
+	if len(txCtx.Password()) == 0 && !txCtx.Simulate() {
+		password, err := base.password(baseTx.From)
+		if err != nil {
+			_ = base.removeCache(txCtx.Address())
+			span.RecordError(err)
+			return nil, txCtx, sdk.Wrap(err)
+		}
+		txCtx.WithPassword(password)
+	}
+
+	tx, err := txCtx.BuildAndSign(baseTx.From, msg)
+	if err != nil {
+		// the transaction is not sent, the sequence increased by prepare is not used
+		_ = base.removeCache(txCtx.Address())
+		span.RecordError(err)
+		return nil, txCtx, sdk.Wrap(err)
+	}
+
+	log.ForContext(ctx, base.Logger()).Debug("sign transaction success", "data", tx.GetSignBytes())
+
+	txByte, err := base.encoder.EncodeTx(tx)
+	if err != nil {
+		// the transaction is signed but not sent, neither its sequence nor its policy usage are used
+		_ = base.removeCache(txCtx.Address())
+		txCtx.ReleasePolicy()
+		span.RecordError(err)
+		return nil, txCtx, sdk.Wrap(err)
+	}
+
+	return txByte, txCtx, nil
+}
+
+// password asks the PasswordProvider of the config for the password of a key which is not unlocked
+func (base *baseClient) password(name string) (string, error) {
+	if base.cfg.PasswordProvider == nil {
+		return "", nil
+	}
+	if km, ok := base.KeyManager.(sdk.KeySessions); ok && km.Unlocked(name) {
+		return "", nil
+	}
+	return base.cfg.PasswordProvider.Password(name)
+}
+
+// BuildUnsignedTx builds the transaction of baseTx.From without signing it, so that it can be signed
+// outside of the SDK. The cached sequence of the account is not changed, Broadcast updates it once the
+// signed transaction is sent: the transactions built again before use the same sequence.
+func (base *baseClient) BuildUnsignedTx(msgs []sdk.Msg, baseTx sdk.BaseTx) (sdk.UnsignedTx, sdk.Error) {
+	if len(msgs) == 0 {
+		return sdk.UnsignedTx{}, sdk.Wrapf("must have at least one message in list")
+	}
+	for _, m := range msgs {
+		if err := m.ValidateBasic(); err != nil {
+			return sdk.UnsignedTx{}, sdk.Wrap(err)
+		}
+	}
+
+	ctx, span := base.tracer.Start(baseTx.Ctx, "BuildUnsignedTx", trace.String("from", baseTx.From))
+	defer span.End()
+
+	base.l.Lock(baseTx.From)
+	defer base.l.Unlock(baseTx.From)
+
+	txCtx, err := base.prepare(ctx, baseTx, false)
 	if err != nil {
-		return nil, ctx, sdk.Wrap(err)
+		span.RecordError(err)
+		return sdk.UnsignedTx{}, sdk.Wrap(err)
 	}
 
-	tx, err := ctx.BuildAndSign(baseTx.From, msg)
+	msg, err := txCtx.Build(msgs)
 	if err != nil {
-		return nil, ctx, sdk.Wrap(err)
+		span.RecordError(err)
+		return sdk.UnsignedTx{}, sdk.Wrap(err)
 	}
 
-	base.Logger().Debug().
-		Strs("data", tx.GetSignBytes()).
-		Msg("sign transaction success")
+	var pubKey crypto.PubKey
+	if base.encoder.Encoding() == sdk.Protobuf {
+		// SIGN_MODE_DIRECT signs the public key, which a watch-only key must have
+		if pubKey, err = base.signerPubKey(baseTx.From); err != nil {
+			span.RecordError(err)
+			return sdk.UnsignedTx{}, sdk.Wrap(err)
+		}
+	}
+	signBytes, err := base.encoder.SignBytes(msg, pubKey)
+	if err != nil {
+		span.RecordError(err)
+		return sdk.UnsignedTx{}, sdk.Wrap(err)
+	}
+	return sdk.UnsignedTx{
+		StdSignMsg: msg,
+		SignBytes:  signBytes,
+	}, nil
+}
+
+// signerPubKey returns the public key of the key name, which may be watch-only
+func (base *baseClient) signerPubKey(name string) (crypto.PubKey, error) {
+	pubKey, err := sdk.QueryPubKey(base.KeyManager, name, "")
+	if err != nil {
+		return nil, err
+	}
+	return sdk.PubKeyFromBech32(pubKey)
+}
 
-	txByte, err := base.cdc.MarshalBinaryLengthPrefixed(tx)
+// AttachSignature checks that the signature is made by a signer of the messages and returns the signed transaction
+func (base *baseClient) AttachSignature(tx sdk.UnsignedTx, pubKey crypto.PubKey, signature []byte) (sdk.StdTx, sdk.Error) {
+	if pubKey == nil {
+		return sdk.StdTx{}, sdk.Wrapf("public key is required")
+	}
+	// the sign bytes are computed again, the ones of tx may have been altered
+	signBytes, err := base.encoder.SignBytes(tx.StdSignMsg, pubKey)
 	if err != nil {
-		return nil, ctx, sdk.Wrap(err)
+		return sdk.StdTx{}, sdk.Wrap(err)
+	}
+	if !pubKey.VerifyBytes(signBytes, signature) {
+		return sdk.StdTx{}, sdk.Wrapf("signature verification failed")
 	}
 
-	return txByte, ctx, nil
+	signer := sdk.AccAddress(pubKey.Address())
+	var isSigner bool
+	for _, msg := range tx.Msgs {
+		for _, addr := range msg.GetSigners() {
+			isSigner = isSigner || addr.Equals(signer)
+		}
+	}
+	if !isSigner {
+		return sdk.StdTx{}, sdk.Wrapf("%s is not a signer of the transaction", base.AddrPrefixCfg().AccAddressString(signer))
+	}
+
+	sig := sdk.StdSignature{
+		PubKey:        pubKey,
+		Signature:     signature,
+		AccountNumber: tx.AccountNumber,
+		Sequence:      tx.Sequence,
+	}
+	return sdk.NewStdTx(tx.Msgs, tx.Fee, []sdk.StdSignature{sig}, tx.Memo), nil
 }
 
-func (base baseClient) broadcastTx(txBytes []byte, mode sdk.BroadcastMode) (res sdk.ResultTx, err sdk.Error) {
-	ch := make(chan sdk.ResultTx, 1)
+func (base baseClient) broadcastTx(ctx context.Context, txBytes []byte, mode sdk.BroadcastMode) (sdk.ResultTx, sdk.Error) {
+	ctx, span := base.tracer.Start(ctx, "broadcastTx", trace.String("mode", string(mode)))
+	defer span.End()
+	logger := log.ForContext(ctx, base.Logger())
+
+	// the goroutine owns its result, it may still be running when the broadcast times out
+	type broadcastResult struct {
+		res sdk.ResultTx
+		err sdk.Error
+	}
+	ch := make(chan broadcastResult, 1)
 
 	go func() {
+		var r broadcastResult
 		switch mode {
 		case sdk.Commit:
-			res, err = base.broadcastTxCommit(txBytes)
+			r.res, r.err = base.broadcastTxCommit(txBytes)
 		case sdk.Async:
-			res, err = base.broadcastTxAsync(txBytes)
+			r.res, r.err = base.broadcastTxAsync(txBytes)
 		case sdk.Sync:
-			res, err = base.broadcastTxSync(txBytes)
+			r.res, r.err = base.broadcastTxSync(txBytes)
 		default:
-			err = sdk.Wrapf("commit mode(%s) not supported", base.cfg.Mode)
+			r.err = sdk.Wrapf("commit mode(%s) not supported", base.cfg.Mode)
 		}
-		ch <- res
+		ch <- r
 	}()
 
 	select {
-	case result := <-ch:
-		return result, err
+	case r := <-ch:
+		if r.err != nil {
+			span.RecordError(r.err)
+			logger.Debug("broadcast tx failed", "mode", mode, "err", r.err)
+			return r.res, r.err
+		}
+		span.SetAttributes(trace.String("hash", r.res.Hash))
+		logger.Debug("broadcast tx", "mode", mode, "hash", r.res.Hash)
+		return r.res, nil
 	case <-time.After(base.cfg.Timeout):
-		return res, sdk.Wrap(errors.New("commit transaction timed out"))
-
+		err := sdk.Wrap(errors.New("commit transaction timed out"))
+		span.RecordError(err)
+		logger.Debug("broadcast tx timed out", "mode", mode, "timeout", base.cfg.Timeout)
+		return sdk.ResultTx{}, err
 	}
 }
 
@@ -118,12 +274,12 @@ func (base baseClient) broadcastTxCommit(tx []byte) (sdk.ResultTx, sdk.Error) {
 	}
 
 	if !res.CheckTx.IsOK() {
-		return sdk.ResultTx{}, sdk.GetError(res.CheckTx.Codespace,
+		return sdk.ResultTx{}, sdk.GetErrorOf(base.version, res.CheckTx.Codespace,
 			res.CheckTx.Code, res.CheckTx.Log)
 	}
 
 	if !res.DeliverTx.IsOK() {
-		return sdk.ResultTx{}, sdk.GetError(res.DeliverTx.Codespace,
+		return sdk.ResultTx{}, sdk.GetErrorOf(base.version, res.DeliverTx.Codespace,
 			res.DeliverTx.Code, res.DeliverTx.Log)
 	}
 
@@ -145,7 +301,8 @@ func (base baseClient) broadcastTxSync(tx []byte) (sdk.ResultTx, sdk.Error) {
 	}
 
 	if res.Code != 0 {
-		return sdk.ResultTx{}, sdk.GetError(sdk.RootCodespace,
+		// the result of tendermint 0.31 has no codespace, it is parsed from the log
+		return sdk.ResultTx{}, sdk.GetErrorOf(base.version, "",
 			res.Code, res.Log)
 	}
 
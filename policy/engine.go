@@ -0,0 +1,226 @@
+package policy
+
+import (
+	"bytes"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/irisnet/irishub-sdk-go/types"
+	"github.com/irisnet/irishub-sdk-go/utils/bech32"
+)
+
+var _ types.SigningPolicy = &Engine{}
+
+// Engine checks the transactions against the policy of their key and tracks the daily usage
+type Engine struct {
+	policies map[string]Policy
+	usage    UsageStore
+	now      func() time.Time
+
+	// mu makes the check and the update of the daily usage atomic
+	mu sync.Mutex
+}
+
+// NewEngine returns an engine enforcing the policies by key name, the keys without policy
+// get the one of DefaultKey if any, or are not restricted
+func NewEngine(policies map[string]Policy, usage UsageStore) (*Engine, error) {
+	for name, p := range policies {
+		if err := p.Validate(); err != nil {
+			return nil, fmt.Errorf("invalid policy of %s: %s", name, err.Error())
+		}
+	}
+	if usage == nil {
+		usage = NewMemoryUsageStore()
+	}
+	return &Engine{
+		policies: policies,
+		usage:    usage,
+		now:      time.Now,
+	}, nil
+}
+
+// Check returns a *ViolationError if the transaction violates the policy of the key name,
+// otherwise its amounts are reserved in the daily usage of the key until release is called.
+// When the amounts are limited, the msgs implementing neither types.SpendMsg nor types.NoSpendMsg
+// are refused since the coins they move are not known, when the recipients are limited, the msgs
+// which do not implement types.RecipientMsg, and when the validators are limited, the msgs implementing
+// neither types.ValidatorMsg nor types.NoValidatorMsg.
+func (e *Engine) Check(name string, msgs []types.Msg, fee types.StdFee) (release func(), err error) {
+	release = func() {}
+	p, ok := e.policies[name]
+	if !ok {
+		if p, ok = e.policies[DefaultKey]; !ok {
+			return release, nil
+		}
+	}
+	violation := func(rule Rule, format string, args ...interface{}) error {
+		return &ViolationError{Key: name, Rule: rule, Reason: fmt.Sprintf(format, args...)}
+	}
+
+	spent := make(amounts)
+	for _, msg := range msgs {
+		if !p.allowsMsg(msg) {
+			return release, violation(RuleAllowedMsgs, "%s/%s is not allowed", msg.Route(), msg.Type())
+		}
+
+		switch m := msg.(type) {
+		case types.SpendMsg:
+			spent.add(m.SpentCoins())
+		case types.NoSpendMsg:
+		default:
+			if len(p.MaxPerTx) > 0 {
+				return release, violation(RuleMaxPerTx, "the coins spent by %s/%s are unknown", msg.Route(), msg.Type())
+			}
+			if len(p.MaxPerDay) > 0 {
+				return release, violation(RuleMaxPerDay, "the coins spent by %s/%s are unknown", msg.Route(), msg.Type())
+			}
+		}
+
+		if len(p.AllowedRecipients) > 0 {
+			m, ok := msg.(types.RecipientMsg)
+			if !ok {
+				return release, violation(RuleAllowedRecipients, "the recipients of %s/%s are unknown", msg.Route(), msg.Type())
+			}
+			for _, recipient := range m.Recipients() {
+				if !containsAddress(p.AllowedRecipients, recipient) {
+					return release, violation(RuleAllowedRecipients, "%s is not allowed", recipient)
+				}
+			}
+		}
+
+		if len(p.AllowedValidators) > 0 {
+			switch m := msg.(type) {
+			case types.ValidatorMsg:
+				for _, validator := range m.Validators() {
+					if !containsAddress(p.AllowedValidators, validator) {
+						return release, violation(RuleAllowedValidators, "%s is not allowed", validator)
+					}
+				}
+			case types.NoValidatorMsg:
+			default:
+				return release, violation(RuleAllowedValidators, "the validators of %s/%s are unknown", msg.Route(), msg.Type())
+			}
+		}
+	}
+
+	if len(p.MaxFee) > 0 {
+		if denom, ok := make(amounts).add(fee.Amount).within(p.MaxFee); !ok {
+			return release, violation(RuleMaxFee, "the fee %s exceeds %s", fee.Amount, denom)
+		}
+	}
+	if len(p.MaxPerTx) > 0 {
+		if denom, ok := spent.within(p.MaxPerTx); !ok {
+			return release, violation(RuleMaxPerTx, "%s exceeds the maximum of %s", spent.coins(), denom)
+		}
+	}
+	if len(p.MaxPerDay) == 0 || len(spent) == 0 {
+		return release, nil
+	}
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	day := e.now().UTC().Format("2006-01-02")
+	usage, err := e.usage.Usage(name, day)
+	if err != nil {
+		return release, err
+	}
+	total := make(amounts).add(usage)
+	total.add(spent.coins())
+	if denom, ok := total.within(p.MaxPerDay); !ok {
+		return release, violation(RuleMaxPerDay, "%s spent on %s exceeds the maximum of %s", total.coins(), day, denom)
+	}
+	if err := e.usage.SetUsage(name, day, total.coins()); err != nil {
+		return release, err
+	}
+	return e.releaser(name, day, spent.coins()), nil
+}
+
+// releaser returns a func removing spent from the usage of the key name on day, once
+func (e *Engine) releaser(name, day string, spent types.Coins) func() {
+	var once sync.Once
+	return func() {
+		once.Do(func() {
+			e.mu.Lock()
+			defer e.mu.Unlock()
+
+			usage, err := e.usage.Usage(name, day)
+			if err != nil {
+				return
+			}
+			_ = e.usage.SetUsage(name, day, make(amounts).add(usage).sub(spent).coins())
+		})
+	}
+}
+
+// amounts sums coins by denom
+type amounts map[string]types.Int
+
+func (a amounts) add(coins types.Coins) amounts {
+	for _, coin := range coins {
+		if amount, ok := a[coin.Denom]; ok {
+			a[coin.Denom] = amount.Add(coin.Amount)
+		} else {
+			a[coin.Denom] = coin.Amount
+		}
+	}
+	return a
+}
+
+// within returns the denom whose amount exceeds max, the denoms missing in max can not be used
+func (a amounts) within(max types.Coins) (string, bool) {
+	for denom, amount := range a {
+		if amount.IsZero() {
+			continue
+		}
+		limit, ok := amountOf(max, denom)
+		if !ok || amount.GT(limit) {
+			return fmt.Sprintf("%s%s", limit, denom), false
+		}
+	}
+	return "", true
+}
+
+// sub removes coins from the amounts, down to zero
+func (a amounts) sub(coins types.Coins) amounts {
+	for _, coin := range coins {
+		if amount, ok := a[coin.Denom]; ok {
+			if amount.GT(coin.Amount) {
+				a[coin.Denom] = amount.Sub(coin.Amount)
+			} else {
+				delete(a, coin.Denom)
+			}
+		}
+	}
+	return a
+}
+
+func (a amounts) coins() types.Coins {
+	coins := make(types.Coins, 0, len(a))
+	for denom, amount := range a {
+		coins = append(coins, types.Coin{Denom: denom, Amount: amount})
+	}
+	sort.Sort(coins)
+	return coins
+}
+
+func amountOf(coins types.Coins, denom string) (types.Int, bool) {
+	for _, coin := range coins {
+		if coin.Denom == denom {
+			return coin.Amount, true
+		}
+	}
+	return types.ZeroInt(), false
+}
+
+// containsAddress compares the bytes of the addresses, the bech32 prefixes of the list may be those of any network
+func containsAddress(list []string, addr []byte) bool {
+	for _, item := range list {
+		if _, bz, err := bech32.DecodeAndConvert(item); err == nil && bytes.Equal(bz, addr) {
+			return true
+		}
+	}
+	return false
+}
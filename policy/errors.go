@@ -0,0 +1,26 @@
+package policy
+
+import "fmt"
+
+// Rule is the part of a Policy violated by a transaction
+type Rule string
+
+const (
+	RuleAllowedMsgs       Rule = "allowed_msgs"
+	RuleMaxPerTx          Rule = "max_per_tx"
+	RuleMaxPerDay         Rule = "max_per_day"
+	RuleAllowedRecipients Rule = "allowed_recipients"
+	RuleAllowedValidators Rule = "allowed_validators"
+	RuleMaxFee            Rule = "max_fee"
+)
+
+// ViolationError is returned when a transaction violates the policy of its key
+type ViolationError struct {
+	Key    string
+	Rule   Rule
+	Reason string
+}
+
+func (e *ViolationError) Error() string {
+	return fmt.Sprintf("signing policy of %s violated, %s: %s", e.Key, e.Rule, e.Reason)
+}
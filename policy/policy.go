@@ -0,0 +1,116 @@
+// Package policy restricts what the hot keys may sign. The policies are declared per key name,
+// usually in a JSON file, and the Engine checks them in TxContext.Sign when it is the
+// ClientConfig.SigningPolicy:
+//
+// 	policies, err := policy.Load("policies.json")
+// 	usage, err := policy.NewLevelDBUsageStore(filepath.Join(home, "policy"))
+// 	engine, err := policy.NewEngine(policies, usage)
+// 	client := sdk.NewClient(types.ClientConfig{SigningPolicy: engine, ...})
+//
+// with policies.json:
+//
+// 	{
+// 		"hot": {
+// 			"allowed_msgs": ["bank/send", "distr/*"],
+// 			"max_per_tx": [{"denom": "iris-atto", "amount": "10000000000000000000"}],
+// 			"max_per_day": [{"denom": "iris-atto", "amount": "100000000000000000000"}],
+// 			"allowed_recipients": ["faa1hp29kuh22vpjjlnctmyml5s75evsnsd8r4x0mm"],
+// 			"max_fee": [{"denom": "iris-atto", "amount": "1000000000000000000"}]
+// 		}
+// 	}
+//
+// The amounts are in the min denoms. The amounts and the recipients are known from the msgs
+// implementing types.SpendMsg, and the validators from the msgs implementing types.ValidatorMsg.
+// When MaxPerTx or MaxPerDay is set, the msgs implementing neither types.SpendMsg nor types.NoSpendMsg,
+// e.g. a service call whose fees depend on its responses, are refused. Likewise when AllowedValidators
+// is set, the msgs implementing neither types.ValidatorMsg nor types.NoValidatorMsg are refused.
+//
+// The amounts of a transaction are reserved in the daily usage when it is checked, and released
+// when it is not signed or is refused by the chain. They are kept after a broadcast timeout.
+package policy
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"strings"
+
+	"github.com/irisnet/irishub-sdk-go/types"
+	"github.com/irisnet/irishub-sdk-go/utils/bech32"
+)
+
+// DefaultKey is the name of the policy applied to the keys without their own policy
+const DefaultKey = "*"
+
+// Policy is what a key may sign, the empty fields are not restricted
+type Policy struct {
+	// AllowedMsgs are the msgs as route/type, e.g. bank/send, or route/* for all the msgs of a module
+	AllowedMsgs []string `json:"allowed_msgs,omitempty"`
+	// MaxPerTx is the maximum amount spent by a transaction, the denoms which are not listed can not be spent
+	MaxPerTx types.Coins `json:"max_per_tx,omitempty"`
+	// MaxPerDay is the maximum amount spent per day (UTC), the denoms which are not listed can not be spent
+	MaxPerDay types.Coins `json:"max_per_day,omitempty"`
+	// AllowedRecipients are the bech32 accounts which may receive coins, tokens or rights, e.g. become the
+	// withdraw address or the owner of a token, with the prefixes of any network
+	AllowedRecipients []string `json:"allowed_recipients,omitempty"`
+	// AllowedValidators are the bech32 validator operators the msgs may act on, with the prefixes of any network
+	AllowedValidators []string `json:"allowed_validators,omitempty"`
+	// MaxFee is the maximum fee of a transaction, the denoms which are not listed can not be paid
+	MaxFee types.Coins `json:"max_fee,omitempty"`
+}
+
+// Validate checks the addresses and the amounts of the policy
+func (p Policy) Validate() error {
+	for _, msg := range p.AllowedMsgs {
+		if parts := strings.Split(msg, "/"); len(parts) != 2 || len(parts[0]) == 0 || len(parts[1]) == 0 {
+			return fmt.Errorf("invalid msg %s, expected route/type", msg)
+		}
+	}
+	for _, coins := range []types.Coins{p.MaxPerTx, p.MaxPerDay, p.MaxFee} {
+		if coins.IsAnyNegative() {
+			return fmt.Errorf("invalid amount %s", coins)
+		}
+	}
+	for _, recipient := range p.AllowedRecipients {
+		if _, _, err := bech32.DecodeAndConvert(recipient); err != nil {
+			return fmt.Errorf("invalid recipient %s: %s", recipient, err.Error())
+		}
+	}
+	for _, validator := range p.AllowedValidators {
+		if _, _, err := bech32.DecodeAndConvert(validator); err != nil {
+			return fmt.Errorf("invalid validator %s: %s", validator, err.Error())
+		}
+	}
+	return nil
+}
+
+func (p Policy) allowsMsg(msg types.Msg) bool {
+	if len(p.AllowedMsgs) == 0 {
+		return true
+	}
+	for _, allowed := range p.AllowedMsgs {
+		if allowed == msg.Route()+"/"+msg.Type() || allowed == msg.Route()+"/*" {
+			return true
+		}
+	}
+	return false
+}
+
+// Load reads the policies by key name from a JSON file
+func Load(path string) (map[string]Policy, error) {
+	bz, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var policies map[string]Policy
+	if err := json.Unmarshal(bz, &policies); err != nil {
+		return nil, fmt.Errorf("invalid policies %s: %s", path, err.Error())
+	}
+	for name, p := range policies {
+		if err := p.Validate(); err != nil {
+			return nil, fmt.Errorf("invalid policy of %s: %s", name, err.Error())
+		}
+	}
+	return policies, nil
+}
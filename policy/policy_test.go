@@ -0,0 +1,303 @@
+package policy_test
+
+import (
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/irisnet/irishub-sdk-go/adapter"
+	"github.com/irisnet/irishub-sdk-go/modules/asset"
+	"github.com/irisnet/irishub-sdk-go/modules/bank"
+	"github.com/irisnet/irishub-sdk-go/modules/distribution"
+	"github.com/irisnet/irishub-sdk-go/modules/gov"
+	"github.com/irisnet/irishub-sdk-go/modules/oracle"
+	"github.com/irisnet/irishub-sdk-go/modules/random"
+	"github.com/irisnet/irishub-sdk-go/modules/service"
+	"github.com/irisnet/irishub-sdk-go/modules/slashing"
+	"github.com/irisnet/irishub-sdk-go/modules/staking"
+	"github.com/irisnet/irishub-sdk-go/policy"
+	"github.com/irisnet/irishub-sdk-go/types"
+)
+
+// msgUnknown only implements types.Msg
+type msgUnknown struct {
+	types.Msg
+}
+
+type PolicyTestSuite struct {
+	suite.Suite
+	from, to, other types.AccAddress
+	validator       types.ValAddress
+}
+
+func TestPolicyTestSuite(t *testing.T) {
+	suite.Run(t, new(PolicyTestSuite))
+}
+
+func (pts *PolicyTestSuite) SetupTest() {
+	pts.from = types.AccAddress([]byte("from________________"))
+	pts.to = types.AccAddress([]byte("to__________________"))
+	pts.other = types.AccAddress([]byte("other_______________"))
+	pts.validator = types.ValAddress([]byte("validator___________"))
+}
+
+func (pts *PolicyTestSuite) TestCheck() {
+	engine, err := policy.NewEngine(map[string]policy.Policy{
+		"hot": {
+			AllowedMsgs:       []string{"bank/send", "stake/*"},
+			MaxPerTx:          coins(100),
+			MaxPerDay:         coins(150),
+			AllowedRecipients: []string{pts.to.String()},
+			AllowedValidators: []string{pts.validator.String()},
+			MaxFee:            coins(10),
+		},
+	}, policy.NewMemoryUsageStore())
+	require.NoError(pts.T(), err)
+
+	fee := types.NewStdFee(20000, coins(5)...)
+	require.NoError(pts.T(), pts.check(engine, "hot", fee, pts.send(pts.to, 80)))
+	require.NoError(pts.T(), pts.check(engine, "cold", fee, bank.NewMsgBurn(pts.from, coins(1000))))
+
+	pts.requireViolation(policy.RuleAllowedMsgs, pts.check(engine, "hot", fee, bank.NewMsgBurn(pts.from, coins(1))))
+	pts.requireViolation(policy.RuleAllowedRecipients, pts.check(engine, "hot", fee, pts.send(pts.other, 1)))
+	pts.requireViolation(policy.RuleMaxPerTx, pts.check(engine, "hot", fee, pts.send(pts.to, 60), pts.send(pts.to, 60)))
+	pts.requireViolation(policy.RuleMaxFee, pts.check(engine, "hot", types.NewStdFee(20000, coins(11)...), pts.send(pts.to, 1)))
+
+	other := types.ValAddress([]byte("other_validator_____"))
+	delegate := staking.MsgDelegate{DelegatorAddr: pts.from, ValidatorAddr: other, Delegation: coins(1)[0]}
+	pts.requireViolation(policy.RuleAllowedValidators, pts.check(engine, "hot", fee, delegate))
+
+	// 80 of the 150 of the day are already spent, the rejected transactions are not counted
+	require.NoError(pts.T(), pts.check(engine, "hot", fee, pts.send(pts.to, 70)))
+	pts.requireViolation(policy.RuleMaxPerDay, pts.check(engine, "hot", fee, pts.send(pts.to, 1)))
+}
+
+func (pts *PolicyTestSuite) TestRelease() {
+	engine, err := policy.NewEngine(map[string]policy.Policy{
+		"hot": {MaxPerDay: coins(100)},
+	}, nil)
+	require.NoError(pts.T(), err)
+
+	release, err := engine.Check("hot", []types.Msg{pts.send(pts.to, 80)}, types.StdFee{})
+	require.NoError(pts.T(), err)
+	pts.requireViolation(policy.RuleMaxPerDay, pts.check(engine, "hot", types.StdFee{}, pts.send(pts.to, 30)))
+
+	// the released amount can be spent again, a second release changes nothing
+	release()
+	release()
+	require.NoError(pts.T(), pts.check(engine, "hot", types.StdFee{}, pts.send(pts.to, 30)))
+	require.NoError(pts.T(), pts.check(engine, "hot", types.StdFee{}, pts.send(pts.to, 70)))
+	pts.requireViolation(policy.RuleMaxPerDay, pts.check(engine, "hot", types.StdFee{}, pts.send(pts.to, 1)))
+}
+
+func (pts *PolicyTestSuite) TestUnknownSpending() {
+	engine, err := policy.NewEngine(map[string]policy.Policy{
+		"tx":  {MaxPerTx: coins(100)},
+		"day": {MaxPerDay: coins(100)},
+		"any": {},
+	}, nil)
+	require.NoError(pts.T(), err)
+
+	// the coins moved by a service call are not known from the msg
+	call := service.MsgCallService{Consumer: pts.from, ServiceFeeCap: coins(10)}
+	pts.requireViolation(policy.RuleMaxPerTx, pts.check(engine, "tx", types.StdFee{}, call))
+	pts.requireViolation(policy.RuleMaxPerDay, pts.check(engine, "day", types.StdFee{}, call))
+	require.NoError(pts.T(), pts.check(engine, "any", types.StdFee{}, call))
+
+	// a vote moves no coins, a service binding spends its deposit
+	vote := gov.MsgVote{Voter: pts.from}
+	require.NoError(pts.T(), pts.check(engine, "tx", types.StdFee{}, vote))
+	bind := service.MsgBindService{Provider: pts.from, Deposit: coins(101)}
+	pts.requireViolation(policy.RuleMaxPerTx, pts.check(engine, "tx", types.StdFee{}, bind))
+}
+
+func (pts *PolicyTestSuite) TestRecipients() {
+	engine, err := policy.NewEngine(map[string]policy.Policy{
+		"hot": {AllowedRecipients: []string{pts.to.String()}},
+	}, nil)
+	require.NoError(pts.T(), err)
+	validator := types.ValAddress(pts.from)
+
+	// the msgs giving coins, tokens or rights to an account which is not allowed
+	for _, msg := range []types.Msg{
+		distribution.MsgSetWithdrawAddress{DelegatorAddr: pts.from, WithdrawAddr: pts.other},
+		service.MsgSetWithdrawAddress{Provider: pts.from, WithdrawAddress: pts.other},
+		service.MsgWithdrawTax{Trustee: pts.from, DestAddress: pts.other},
+		service.MsgCallService{Consumer: pts.from, Providers: []types.AccAddress{pts.to, pts.other}},
+		asset.MsgTransferTokenOwner{SrcOwner: pts.from, DstOwner: pts.other},
+		asset.MsgMintToken{Owner: pts.from, To: pts.other},
+		oracle.MsgCreateFeed{Creator: pts.from, Providers: []types.AccAddress{pts.other}},
+	} {
+		pts.requireViolation(policy.RuleAllowedRecipients, pts.check(engine, "hot", types.StdFee{}, msg))
+	}
+
+	for _, msg := range []types.Msg{
+		distribution.MsgSetWithdrawAddress{DelegatorAddr: pts.from, WithdrawAddr: pts.to},
+		service.MsgCallService{Consumer: pts.from, Providers: []types.AccAddress{pts.to}},
+		asset.MsgMintToken{Owner: pts.from},
+		staking.MsgUndelegate{DelegatorAddr: pts.from, ValidatorAddr: validator},
+		gov.MsgVote{Voter: pts.from},
+	} {
+		require.NoError(pts.T(), pts.check(engine, "hot", types.StdFee{}, msg))
+	}
+
+	// the providers paid for a random number of the oracle are not known
+	rand := random.MsgRequestRand{Consumer: pts.from, Oracle: true}
+	pts.requireViolation(policy.RuleAllowedRecipients, pts.check(engine, "hot", types.StdFee{}, rand))
+}
+
+func (pts *PolicyTestSuite) TestValidators() {
+	engine, err := policy.NewEngine(map[string]policy.Policy{
+		"hot": {AllowedValidators: []string{pts.validator.String()}},
+	}, nil)
+	require.NoError(pts.T(), err)
+	other := types.ValAddress([]byte("other_validator_____"))
+
+	for _, msg := range []types.Msg{
+		staking.MsgDelegate{DelegatorAddr: pts.from, ValidatorAddr: pts.validator, Delegation: coins(1)[0]},
+		distribution.MsgWithdrawDelegatorReward{DelegatorAddr: pts.from, ValidatorAddr: pts.validator},
+		slashing.MsgUnjail{ValidatorAddr: pts.validator},
+		pts.send(pts.to, 1),
+		gov.MsgVote{Voter: pts.from},
+		random.MsgRequestRand{Consumer: pts.from},
+	} {
+		require.NoError(pts.T(), pts.check(engine, "hot", types.StdFee{}, msg))
+	}
+
+	for _, msg := range []types.Msg{
+		staking.MsgBeginRedelegate{DelegatorAddr: pts.from, ValidatorSrcAddr: pts.validator, ValidatorDstAddr: other},
+		staking.MsgEditValidator{ValidatorAddr: other},
+		distribution.MsgWithdrawValidatorRewardsAll{ValidatorAddr: other},
+		// the validators of a msg implementing neither ValidatorMsg nor NoValidatorMsg are not known
+		msgUnknown{pts.send(pts.to, 1)},
+	} {
+		pts.requireViolation(policy.RuleAllowedValidators, pts.check(engine, "hot", types.StdFee{}, msg))
+	}
+}
+
+func (pts *PolicyTestSuite) TestDefaultPolicy() {
+	engine, err := policy.NewEngine(map[string]policy.Policy{
+		policy.DefaultKey: {AllowedMsgs: []string{"bank/send"}},
+		"admin":           {},
+	}, nil)
+	require.NoError(pts.T(), err)
+
+	burn := []types.Msg{bank.NewMsgBurn(pts.from, coins(1))}
+	pts.requireViolation(policy.RuleAllowedMsgs, pts.check(engine, "any", types.StdFee{}, burn...))
+	require.NoError(pts.T(), pts.check(engine, "admin", types.StdFee{}, burn...))
+
+	_, err = policy.NewEngine(map[string]policy.Policy{"hot": {AllowedRecipients: []string{"invalid"}}}, nil)
+	require.Error(pts.T(), err)
+}
+
+func (pts *PolicyTestSuite) TestLevelDBUsageStore() {
+	dir, err := ioutil.TempDir("", "policy")
+	require.NoError(pts.T(), err)
+	defer os.RemoveAll(dir)
+
+	store, err := policy.NewLevelDBUsageStore(dir)
+	require.NoError(pts.T(), err)
+	usage, err := store.Usage("hot", "2020-01-01")
+	require.NoError(pts.T(), err)
+	require.Empty(pts.T(), usage)
+
+	require.NoError(pts.T(), store.SetUsage("hot", "2020-01-01", coins(10)))
+	usage, err = store.Usage("hot", "2020-01-01")
+	require.NoError(pts.T(), err)
+	require.True(pts.T(), coins(10).IsEqual(usage))
+}
+
+func (pts *PolicyTestSuite) TestLoad() {
+	dir, err := ioutil.TempDir("", "policy")
+	require.NoError(pts.T(), err)
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, "policies.json")
+	require.NoError(pts.T(), ioutil.WriteFile(path, []byte(`{
+		"hot": {
+			"allowed_msgs": ["bank/send"],
+			"max_per_tx": [{"denom": "iris-atto", "amount": "100"}]
+		}
+	}`), 0600))
+	policies, err := policy.Load(path)
+	require.NoError(pts.T(), err)
+	require.Equal(pts.T(), []string{"bank/send"}, policies["hot"].AllowedMsgs)
+	require.True(pts.T(), coins(100).IsEqual(policies["hot"].MaxPerTx))
+
+	require.NoError(pts.T(), ioutil.WriteFile(path, []byte(`{"hot": {"allowed_msgs": ["send"]}}`), 0600))
+	_, err = policy.Load(path)
+	require.Error(pts.T(), err)
+}
+
+func (pts *PolicyTestSuite) TestTxContextSign() {
+	km := adapter.NewDAOAdapter(types.NewMemoryDB(), types.PrivKey)
+	_, _, err := km.Insert("hot", "password")
+	require.NoError(pts.T(), err)
+
+	engine, err := policy.NewEngine(map[string]policy.Policy{
+		"hot": {AllowedMsgs: []string{"bank/send"}},
+	}, nil)
+	require.NoError(pts.T(), err)
+
+	txCtx := &types.TxContext{}
+	txCtx.WithCodec(types.NewAminoCodec()).
+		WithChainID("test").
+		WithKeyManager(km).
+		WithPassword("password").
+		WithSigningPolicy(engine)
+
+	_, err = txCtx.BuildAndSign("hot", []types.Msg{pts.send(pts.to, 1)})
+	require.NoError(pts.T(), err)
+
+	_, err = txCtx.BuildAndSign("hot", []types.Msg{bank.NewMsgBurn(pts.from, coins(1))})
+	var violation *policy.ViolationError
+	require.True(pts.T(), errors.As(err, &violation))
+	require.Equal(pts.T(), "hot", violation.Key)
+}
+
+func (pts *PolicyTestSuite) TestTxContextSignFailure() {
+	engine, err := policy.NewEngine(map[string]policy.Policy{
+		policy.DefaultKey: {MaxPerDay: coins(100)},
+	}, nil)
+	require.NoError(pts.T(), err)
+
+	txCtx := &types.TxContext{}
+	txCtx.WithCodec(types.NewAminoCodec()).
+		WithChainID("test").
+		WithKeyManager(adapter.NewDAOAdapter(types.NewMemoryDB(), types.PrivKey)).
+		WithSigningPolicy(engine)
+
+	// the key does not exist, the amount reserved by the policy is released
+	_, err = txCtx.BuildAndSign("missing", []types.Msg{pts.send(pts.to, 100)})
+	require.Error(pts.T(), err)
+	require.NoError(pts.T(), pts.check(engine, "missing", types.StdFee{}, pts.send(pts.to, 100)))
+}
+
+func (pts *PolicyTestSuite) send(to types.AccAddress, amount int64) types.Msg {
+	return bank.NewMsgSend(
+		[]bank.Input{bank.NewInput(pts.from, coins(amount))},
+		[]bank.Output{bank.NewOutput(to, coins(amount))},
+	)
+}
+
+// check checks the msgs and keeps what the engine reserved
+func (pts *PolicyTestSuite) check(engine *policy.Engine, name string, fee types.StdFee, msgs ...types.Msg) error {
+	release, err := engine.Check(name, msgs, fee)
+	require.NotNil(pts.T(), release)
+	return err
+}
+
+func (pts *PolicyTestSuite) requireViolation(rule policy.Rule, err error) {
+	var violation *policy.ViolationError
+	require.True(pts.T(), errors.As(err, &violation), "expected a violation of %s, got %v", rule, err)
+	require.Equal(pts.T(), rule, violation.Rule)
+}
+
+func coins(amount int64) types.Coins {
+	return types.NewCoins(types.NewCoin("iris-atto", types.NewInt(amount)))
+}
@@ -0,0 +1,82 @@
+package policy
+
+import (
+	"encoding/json"
+	"fmt"
+	"sync"
+
+	dbm "github.com/tendermint/tm-db"
+
+	"github.com/irisnet/irishub-sdk-go/types"
+)
+
+const usageDBName = "usage"
+
+// UsageStore keeps the amounts spent by the keys per day (YYYY-MM-DD in UTC)
+type UsageStore interface {
+	Usage(name, day string) (types.Coins, error)
+	SetUsage(name, day string, spent types.Coins) error
+}
+
+type memoryUsageStore struct {
+	mu    sync.Mutex
+	usage map[string]types.Coins
+}
+
+// NewMemoryUsageStore returns a UsageStore which is lost when the process stops
+func NewMemoryUsageStore() UsageStore {
+	return &memoryUsageStore{
+		usage: make(map[string]types.Coins),
+	}
+}
+
+func (m *memoryUsageStore) Usage(name, day string) (types.Coins, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.usage[usageKey(name, day)], nil
+}
+
+func (m *memoryUsageStore) SetUsage(name, day string, spent types.Coins) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.usage[usageKey(name, day)] = spent
+	return nil
+}
+
+type levelDBUsageStore struct {
+	db dbm.DB
+}
+
+// NewLevelDBUsageStore returns a UsageStore persisted in dir, which can only be used by one process
+func NewLevelDBUsageStore(dir string) (UsageStore, error) {
+	db, err := dbm.NewGoLevelDB(usageDBName, dir)
+	if err != nil {
+		return nil, err
+	}
+	return levelDBUsageStore{db: db}, nil
+}
+
+func (l levelDBUsageStore) Usage(name, day string) (types.Coins, error) {
+	bz, err := l.db.Get([]byte(usageKey(name, day)))
+	if bz == nil || err != nil {
+		return nil, err
+	}
+
+	var spent types.Coins
+	if err := json.Unmarshal(bz, &spent); err != nil {
+		return nil, err
+	}
+	return spent, nil
+}
+
+func (l levelDBUsageStore) SetUsage(name, day string, spent types.Coins) error {
+	bz, err := json.Marshal(spent)
+	if err != nil {
+		return err
+	}
+	return l.db.SetSync([]byte(usageKey(name, day)), bz)
+}
+
+func usageKey(name, day string) string {
+	return fmt.Sprintf("%s/%s", day, name)
+}
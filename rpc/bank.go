@@ -8,6 +8,7 @@ import (
 type Bank interface {
 	sdk.Module
 	QueryAccount(address string) (sdk.BaseAccount, sdk.Error)
+	BatchQueryAccounts(addresses ...string) []AccountResult
 	QueryTokenStats(tokenID string) (TokenStats, sdk.Error)
 	Send(to string, amount sdk.DecCoins, baseTx sdk.BaseTx) (sdk.ResultTx, sdk.Error)
 	MultiSend(receipts Receipts, baseTx sdk.BaseTx) ([]sdk.ResultTx, sdk.Error)
@@ -31,6 +32,16 @@ func (r Receipts) Sub(begin, end int) sdk.SplitAble {
 	return r[begin:end]
 }
 
+// AccountResult is the account of an address queried by BatchQueryAccounts
+type AccountResult struct {
+	Address string          `json:"address"`
+	Account sdk.BaseAccount `json:"account"`
+	// Balance is Account.Coins converted by ToMainCoin
+	Balance sdk.DecCoins `json:"balance"`
+	// Err is the error of the query or of the conversion of the coins
+	Err sdk.Error `json:"-"`
+}
+
 type TokenStats struct {
 	LooseTokens  sdk.Coins `json:"loose_tokens"`
 	BondedTokens sdk.Coins `json:"bonded_tokens"`
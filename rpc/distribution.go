@@ -7,6 +7,7 @@ import (
 type Distribution interface {
 	sdk.Module
 	QueryRewards(delegator string) (Rewards, sdk.Error)
+	BatchQueryRewards(delegators ...string) []RewardsResult
 	SetWithdrawAddr(withdrawAddr string, baseTx sdk.BaseTx) (sdk.ResultTx, sdk.Error)
 	WithdrawRewards(isValidator bool, onlyFromValidator string, baseTx sdk.BaseTx) (sdk.ResultTx, sdk.Error)
 }
@@ -17,6 +18,16 @@ type Rewards struct {
 	Commission  sdk.Coins           `json:"commission"`
 }
 
+// RewardsResult is the rewards of a delegator queried by BatchQueryRewards
+type RewardsResult struct {
+	Delegator string  `json:"delegator"`
+	Rewards   Rewards `json:"rewards"`
+	// Total is Rewards.Total converted by ToMainCoin
+	Total sdk.DecCoins `json:"total"`
+	// Err is the error of the query or of the conversion of the coins
+	Err sdk.Error `json:"-"`
+}
+
 type DelegationRewards struct {
 	Validator string    `json:"validator"`
 	Reward    sdk.Coins `json:"reward"`
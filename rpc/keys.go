@@ -1,15 +1,35 @@
 package rpc
 
 import (
+	"time"
+
 	sdk "github.com/irisnet/irishub-sdk-go/types"
 )
 
 type Keys interface {
 	sdk.Module
 	Add(name, password string) (address string, mnemonic string, err sdk.Error)
+	AddWithOptions(name, password string, opts sdk.HDOptions) (address string, mnemonic string, err sdk.Error)
 	Recover(name, password, mnemonic string) (address string, err sdk.Error)
+	RecoverWithOptions(name, password, mnemonic string, opts sdk.HDOptions) (address string, err sdk.Error)
+	RecoverAccounts(password, mnemonic string, opts sdk.HDOptions, names ...string) ([]sdk.DerivedAccount, sdk.Error)
 	Import(name, password, keystore string) (address string, err sdk.Error)
+	ImportPrivKey(name, password, privKey string) (address string, err sdk.Error)
+	ImportArmor(name, password, armor, passphrase string) (address string, err sdk.Error)
+	RecoverFromShares(name, password string, shares []string) (address string, err sdk.Error)
+	AddWatchOnly(name, address, pubKey string) (string, sdk.Error)
 	Export(name, password, encryptKeystorePwd string) (keystore string, err sdk.Error)
+	ExportArmor(name, password, passphrase string) (armor string, err sdk.Error)
+	BackupShares(name, password string, threshold, shares int) ([]string, sdk.Error)
 	Delete(name string) sdk.Error
 	Show(name string) (string, sdk.Error)
+	ShowPubKey(name, password string) (pubKey string, err sdk.Error)
+	List() ([]sdk.KeyInfo, sdk.Error)
+	Rename(name, newName string) sdk.Error
+	ChangePassword(name, password, newPassword string) sdk.Error
+	// Unlock keeps the decrypted key in memory for timeout (until Lock if 0),
+	// its transactions are then sent without BaseTx.Password
+	Unlock(name, password string, timeout time.Duration) sdk.Error
+	Lock(name string)
+	LockAll()
 }
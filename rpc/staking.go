@@ -16,6 +16,7 @@ type StakingTx interface {
 type StakingQueries interface {
 	QueryDelegation(delAddr, valAddr string) (Delegation, sdk.Error)
 	QueryDelegations(delAddr string) (Delegations, sdk.Error)
+	BatchQueryDelegations(delAddrs ...string) []DelegationsResult
 
 	QueryUnbondingDelegation(delAddr, valAddr string) (UnbondingDelegation, sdk.Error)
 	QueryUnbondingDelegations(delAddr string) (UnbondingDelegations, sdk.Error)
@@ -54,6 +55,13 @@ type Delegation struct {
 }
 type Delegations []Delegation
 
+// DelegationsResult is the delegations of a delegator queried by BatchQueryDelegations
+type DelegationsResult struct {
+	Delegator   string      `json:"delegator"`
+	Delegations Delegations `json:"delegations"`
+	Err         sdk.Error   `json:"-"`
+}
+
 type UnbondingDelegations []UnbondingDelegation
 type UnbondingDelegation struct {
 	TxHash         string   `json:"tx_hash"`
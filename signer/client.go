@@ -0,0 +1,196 @@
+package signer
+
+import (
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"net"
+	"sync"
+	"time"
+
+	cryptoAmino "github.com/tendermint/tendermint/crypto/encoding/amino"
+
+	sdk "github.com/irisnet/irishub-sdk-go/types"
+)
+
+const defaultTimeout = 10 * time.Second
+
+var (
+	_ sdk.KeyManager    = &Client{}
+	_ sdk.PubKeyQuerier = &Client{}
+)
+
+// ErrUnsupported is returned by the key management methods which are only available on the signer host,
+// the optional interfaces of types.KeyManager are not implemented
+var ErrUnsupported = errors.New("not supported by the remote signer, manage the keys on the signer host")
+
+// Client is a types.KeyManager which delegates Sign and Query to a remote Server.
+// The requests are sent one at a time on a single connection, which is reopened after a failure.
+type Client struct {
+	network   string
+	address   string
+	tlsConfig *tls.Config
+	timeout   time.Duration
+
+	mu     sync.Mutex
+	conn   net.Conn
+	nextID uint64
+}
+
+// NewClient returns a client of the signer listening on addr, e.g. unix:///var/run/signer.sock
+// or tcp://127.0.0.1:26659. tlsConfig must hold the client certificate and the CA of the server.
+func NewClient(addr string, tlsConfig *tls.Config, timeout time.Duration) (*Client, error) {
+	network, address, err := parseAddress(addr)
+	if err != nil {
+		return nil, err
+	}
+	if tlsConfig == nil || len(tlsConfig.Certificates) == 0 {
+		return nil, errors.New("the client certificate is required")
+	}
+	if timeout <= 0 {
+		timeout = defaultTimeout
+	}
+	return &Client{
+		network:   network,
+		address:   address,
+		tlsConfig: tlsConfig,
+		timeout:   timeout,
+	}, nil
+}
+
+func (c *Client) Sign(name, password string, data []byte) (sdk.Signature, error) {
+	res, err := c.call(Request{
+		Method:   MethodSign,
+		Name:     name,
+		Password: password,
+		Data:     data,
+	})
+	if err != nil {
+		return sdk.Signature{}, err
+	}
+
+	pubKey, err := cryptoAmino.PubKeyFromBytes(res.PubKey)
+	if err != nil {
+		return sdk.Signature{}, err
+	}
+	if !pubKey.VerifyBytes(data, res.Signature) {
+		return sdk.Signature{}, errors.New("invalid signature returned by the remote signer")
+	}
+	return sdk.Signature{
+		PubKey:    pubKey,
+		Signature: res.Signature,
+	}, nil
+}
+
+func (c *Client) Query(name string) (sdk.AccAddress, error) {
+	res, err := c.call(Request{
+		Method: MethodQuery,
+		Name:   name,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return sdk.Bech32Bytes(res.Address)
+}
+
+func (c *Client) QueryPubKey(name, password string) (string, error) {
+	res, err := c.call(Request{
+		Method:   MethodPubKey,
+		Name:     name,
+		Password: password,
+	})
+	if err != nil {
+		return "", err
+	}
+
+	pubKey, err := cryptoAmino.PubKeyFromBytes(res.PubKey)
+	if err != nil {
+		return "", err
+	}
+	return sdk.Bech32ifyAccPub(pubKey)
+}
+
+func (c *Client) Insert(name, password string) (string, string, error) {
+	return "", "", ErrUnsupported
+}
+
+func (c *Client) Recover(name, password, mnemonic string) (string, error) {
+	return "", ErrUnsupported
+}
+
+func (c *Client) Import(name, password string, keystore string) (string, error) {
+	return "", ErrUnsupported
+}
+
+func (c *Client) Export(name, password, encryptKeystorePwd string) (string, error) {
+	return "", ErrUnsupported
+}
+
+func (c *Client) Delete(name string) error {
+	return ErrUnsupported
+}
+
+// Close closes the connection to the signer
+func (c *Client) Close() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.conn == nil {
+		return nil
+	}
+	err := c.conn.Close()
+	c.conn = nil
+	return err
+}
+
+func (c *Client) call(req Request) (Response, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.conn == nil {
+		conn, err := c.dial()
+		if err != nil {
+			return Response{}, err
+		}
+		c.conn = conn
+	}
+
+	c.nextID++
+	req.Version = ProtocolVersion
+	req.ID = c.nextID
+
+	res, err := c.roundTrip(req)
+	if err != nil {
+		_ = c.conn.Close()
+		c.conn = nil
+		return Response{}, err
+	}
+	if len(res.Error) > 0 {
+		return res, errors.New(res.Error)
+	}
+	return res, nil
+}
+
+func (c *Client) roundTrip(req Request) (res Response, err error) {
+	if err := c.conn.SetDeadline(time.Now().Add(c.timeout)); err != nil {
+		return res, err
+	}
+	if err := writeFrame(c.conn, req); err != nil {
+		return res, err
+	}
+	if err := readFrame(c.conn, &res); err != nil {
+		return res, err
+	}
+	if res.ID != req.ID {
+		return res, fmt.Errorf("unexpected response id %d, expected %d", res.ID, req.ID)
+	}
+	return res, nil
+}
+
+func (c *Client) dial() (net.Conn, error) {
+	dialer := &net.Dialer{Timeout: c.timeout}
+	conn, err := tls.DialWithDialer(dialer, c.network, c.address, c.tlsConfig)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to the signer %s://%s: %s", c.network, c.address, err.Error())
+	}
+	return conn, nil
+}
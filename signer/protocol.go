@@ -0,0 +1,148 @@
+// Package signer keeps the private keys out of the application process.
+//
+// A Server owns a types.KeyManager (usually backed by the local keybase) and answers the
+// Sign and Query requests of the Client, a types.KeyManager which can be set in
+// types.ClientConfig.KeyManager. They talk over a Unix socket or TCP, always with mutual TLS.
+//
+// Wire protocol: every message is a frame made of a 4-byte big-endian length followed by
+// a JSON encoded Request or Response. The Version of a request must be ProtocolVersion,
+// the server answers every request in order on the same connection.
+//
+// The reference daemon is cmd/signer.
+package signer
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"strings"
+)
+
+const (
+	// ProtocolVersion is the version of the wire protocol
+	ProtocolVersion uint32 = 1
+
+	MethodSign   = "sign"
+	MethodQuery  = "query"
+	MethodPubKey = "pubkey"
+
+	maxFrameSize = 1 << 20
+)
+
+// Request is sent by the client
+type Request struct {
+	Version  uint32 `json:"version"`
+	ID       uint64 `json:"id"`
+	Method   string `json:"method"`
+	Name     string `json:"name"`
+	Password string `json:"password,omitempty"`
+	Data     []byte `json:"data,omitempty"`
+}
+
+// Response is sent by the server, Error is set if the request failed
+type Response struct {
+	Version uint32 `json:"version"`
+	ID      uint64 `json:"id"`
+	Address string `json:"address,omitempty"`
+	// PubKey is the amino encoded public key of the signer
+	PubKey    []byte `json:"pub_key,omitempty"`
+	Signature []byte `json:"signature,omitempty"`
+	Error     string `json:"error,omitempty"`
+}
+
+func writeFrame(w io.Writer, msg interface{}) error {
+	bz, err := json.Marshal(msg)
+	if err != nil {
+		return err
+	}
+	if len(bz) > maxFrameSize {
+		return fmt.Errorf("frame too large: %d bytes", len(bz))
+	}
+
+	frame := make([]byte, 4+len(bz))
+	binary.BigEndian.PutUint32(frame, uint32(len(bz)))
+	copy(frame[4:], bz)
+	_, err = w.Write(frame)
+	return err
+}
+
+func readFrame(r io.Reader, msg interface{}) error {
+	var header [4]byte
+	if _, err := io.ReadFull(r, header[:]); err != nil {
+		return err
+	}
+
+	size := binary.BigEndian.Uint32(header[:])
+	if size > maxFrameSize {
+		return fmt.Errorf("frame too large: %d bytes", size)
+	}
+	bz := make([]byte, size)
+	if _, err := io.ReadFull(r, bz); err != nil {
+		return err
+	}
+	return json.Unmarshal(bz, msg)
+}
+
+// parseAddress splits an address such as unix:///var/run/signer.sock or tcp://127.0.0.1:26659
+func parseAddress(addr string) (network, address string, err error) {
+	parts := strings.SplitN(addr, "://", 2)
+	if len(parts) != 2 || len(parts[1]) == 0 {
+		return "", "", fmt.Errorf("invalid signer address %s, expected unix://<path> or tcp://<host:port>", addr)
+	}
+	switch parts[0] {
+	case "unix", "tcp":
+		return parts[0], parts[1], nil
+	}
+	return "", "", fmt.Errorf("unsupported network %s, expected unix or tcp", parts[0])
+}
+
+// ServerTLSConfig returns a TLS config which requires a client certificate signed by the CA
+func ServerTLSConfig(certFile, keyFile, caFile string) (*tls.Config, error) {
+	cert, pool, err := loadCerts(certFile, keyFile, caFile)
+	if err != nil {
+		return nil, err
+	}
+	return &tls.Config{
+		Certificates: []tls.Certificate{cert},
+		ClientCAs:    pool,
+		ClientAuth:   tls.RequireAndVerifyClientCert,
+		MinVersion:   tls.VersionTLS12,
+	}, nil
+}
+
+// ClientTLSConfig returns a TLS config which presents the client certificate
+// and verifies that the server certificate is signed by the CA and issued to serverName
+func ClientTLSConfig(certFile, keyFile, caFile, serverName string) (*tls.Config, error) {
+	cert, pool, err := loadCerts(certFile, keyFile, caFile)
+	if err != nil {
+		return nil, err
+	}
+	return &tls.Config{
+		Certificates: []tls.Certificate{cert},
+		RootCAs:      pool,
+		ServerName:   serverName,
+		MinVersion:   tls.VersionTLS12,
+	}, nil
+}
+
+func loadCerts(certFile, keyFile, caFile string) (tls.Certificate, *x509.CertPool, error) {
+	cert, err := tls.LoadX509KeyPair(certFile, keyFile)
+	if err != nil {
+		return cert, nil, err
+	}
+
+	ca, err := ioutil.ReadFile(caFile)
+	if err != nil {
+		return cert, nil, err
+	}
+	pool := x509.NewCertPool()
+	if !pool.AppendCertsFromPEM(ca) {
+		return cert, nil, errors.New("no certificate found in the CA file")
+	}
+	return cert, pool, nil
+}
@@ -0,0 +1,206 @@
+package signer
+
+import (
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"os"
+	"sync"
+
+	sdk "github.com/irisnet/irishub-sdk-go/types"
+	"github.com/irisnet/irishub-sdk-go/utils/log"
+)
+
+// Server answers the requests of the clients with its KeyManager
+type Server struct {
+	keyManager sdk.KeyManager
+	tlsConfig  *tls.Config
+	logger     log.Logger
+
+	mu        sync.Mutex
+	listeners []net.Listener
+	conns     map[net.Conn]struct{}
+	closed    bool
+}
+
+// NewServer returns a server which requires the clients to present a certificate verified by tlsConfig.ClientCAs
+func NewServer(keyManager sdk.KeyManager, tlsConfig *tls.Config, logger log.Logger) (*Server, error) {
+	if tlsConfig == nil || len(tlsConfig.Certificates) == 0 || tlsConfig.ClientCAs == nil {
+		return nil, errors.New("the server certificate and the client CAs are required")
+	}
+	cfg := tlsConfig.Clone()
+	cfg.ClientAuth = tls.RequireAndVerifyClientCert
+
+	if logger == nil {
+		logger = log.NewLogger("info")
+	}
+	return &Server{
+		keyManager: keyManager,
+		tlsConfig:  cfg,
+		logger:     log.Redact(logger.With("module", "signer")),
+		conns:      make(map[net.Conn]struct{}),
+	}, nil
+}
+
+// ListenAndServe listens on the address, e.g. unix:///var/run/signer.sock or tcp://127.0.0.1:26659,
+// and serves until Close is called
+func (s *Server) ListenAndServe(addr string) error {
+	network, address, err := parseAddress(addr)
+	if err != nil {
+		return err
+	}
+	if network == "unix" {
+		// remove the socket left by a previous run
+		if err := os.Remove(address); err != nil && !os.IsNotExist(err) {
+			return err
+		}
+	}
+
+	l, err := net.Listen(network, address)
+	if err != nil {
+		return err
+	}
+	if network == "unix" {
+		if err := os.Chmod(address, 0600); err != nil {
+			_ = l.Close()
+			return err
+		}
+	}
+	return s.Serve(l)
+}
+
+// Serve accepts the connections of the listener until Close is called
+func (s *Server) Serve(l net.Listener) error {
+	s.mu.Lock()
+	if s.closed {
+		s.mu.Unlock()
+		return errors.New("server closed")
+	}
+	s.listeners = append(s.listeners, l)
+	s.mu.Unlock()
+
+	l = tls.NewListener(l, s.tlsConfig)
+	for {
+		conn, err := l.Accept()
+		if err != nil {
+			s.mu.Lock()
+			closed := s.closed
+			s.mu.Unlock()
+			if closed {
+				return nil
+			}
+			return err
+		}
+		go s.serveConn(conn)
+	}
+}
+
+// Close stops the listeners and closes the open connections
+func (s *Server) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.closed = true
+
+	var err error
+	for _, l := range s.listeners {
+		if e := l.Close(); e != nil {
+			err = e
+		}
+	}
+	for conn := range s.conns {
+		_ = conn.Close()
+		delete(s.conns, conn)
+	}
+	return err
+}
+
+// track adds the connection to the open ones, it returns false once the server is closed
+func (s *Server) track(conn net.Conn) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.closed {
+		return false
+	}
+	s.conns[conn] = struct{}{}
+	return true
+}
+
+func (s *Server) untrack(conn net.Conn) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.conns, conn)
+}
+
+func (s *Server) serveConn(conn net.Conn) {
+	defer conn.Close()
+	if !s.track(conn) {
+		return
+	}
+	defer s.untrack(conn)
+
+	for {
+		var req Request
+		if err := readFrame(conn, &req); err != nil {
+			if err != io.EOF {
+				s.logger.Warn("connection closed", "err", err, "remote", conn.RemoteAddr().String())
+			}
+			return
+		}
+
+		res := s.handle(req)
+		if err := writeFrame(conn, res); err != nil {
+			s.logger.Warn("failed to write the response", "err", err, "remote", conn.RemoteAddr().String())
+			return
+		}
+	}
+}
+
+func (s *Server) handle(req Request) Response {
+	res := Response{
+		Version: ProtocolVersion,
+		ID:      req.ID,
+	}
+	if req.Version != ProtocolVersion {
+		res.Error = fmt.Sprintf("unsupported protocol version %d, expected %d", req.Version, ProtocolVersion)
+		return res
+	}
+
+	s.logger.Info("request received", "method", req.Method, "name", req.Name)
+
+	switch req.Method {
+	case MethodSign:
+		sig, err := s.keyManager.Sign(req.Name, req.Password, req.Data)
+		if err != nil {
+			res.Error = err.Error()
+			return res
+		}
+		res.PubKey = sig.PubKey.Bytes()
+		res.Signature = sig.Signature
+		res.Address = sdk.AccAddress(sig.PubKey.Address()).String()
+	case MethodQuery:
+		address, err := s.keyManager.Query(req.Name)
+		if err != nil {
+			res.Error = err.Error()
+			return res
+		}
+		res.Address = address.String()
+	case MethodPubKey:
+		pubKey, err := sdk.QueryPubKey(s.keyManager, req.Name, req.Password)
+		if err != nil {
+			res.Error = err.Error()
+			return res
+		}
+		pk, err := sdk.PubKeyFromBech32(pubKey)
+		if err != nil {
+			res.Error = err.Error()
+			return res
+		}
+		res.PubKey = pk.Bytes()
+		res.Address = sdk.AccAddress(pk.Address()).String()
+	default:
+		res.Error = fmt.Sprintf("unknown method %s", req.Method)
+	}
+	return res
+}
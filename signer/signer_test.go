@@ -0,0 +1,193 @@
+package signer_test
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"math/big"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/irisnet/irishub-sdk-go/adapter"
+	"github.com/irisnet/irishub-sdk-go/signer"
+	"github.com/irisnet/irishub-sdk-go/types"
+)
+
+const (
+	name     = "test"
+	password = "11111111"
+	privKey  = "2b8f7bd1a7b3d1eb4c8cb1cbe1d0e8b1f8a1e6f4b5c2d7a9e0f1a2b3c4d5e6f7"
+)
+
+type SignerTestSuite struct {
+	suite.Suite
+	server  *signer.Server
+	addr    string
+	address string
+
+	ca         *x509.Certificate
+	caKey      *ecdsa.PrivateKey
+	clientCert tls.Certificate
+}
+
+func TestSignerTestSuite(t *testing.T) {
+	suite.Run(t, new(SignerTestSuite))
+}
+
+func (sts *SignerTestSuite) SetupTest() {
+	sts.ca, sts.caKey = sts.newCA()
+	serverCert := sts.newCert(sts.ca, sts.caKey, "signer")
+	sts.clientCert = sts.newCert(sts.ca, sts.caKey, "client")
+
+	km := adapter.NewDAOAdapter(types.NewMemoryDB(), types.PrivKey)
+	address, err := km.ImportPrivKey(name, password, privKey)
+	require.NoError(sts.T(), err)
+	sts.address = address
+
+	server, err := signer.NewServer(km, &tls.Config{
+		Certificates: []tls.Certificate{serverCert},
+		ClientCAs:    sts.pool(sts.ca),
+	}, nil)
+	require.NoError(sts.T(), err)
+	sts.server = server
+
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(sts.T(), err)
+	sts.addr = "tcp://" + l.Addr().String()
+	go func() {
+		_ = server.Serve(l)
+	}()
+}
+
+func (sts *SignerTestSuite) TearDownTest() {
+	_ = sts.server.Close()
+}
+
+func (sts *SignerTestSuite) TestSignAndQuery() {
+	client := sts.newClient(sts.clientCert, sts.ca)
+	defer client.Close()
+
+	address, err := client.Query(name)
+	require.NoError(sts.T(), err)
+	require.Equal(sts.T(), sts.address, address.String())
+
+	data := []byte("data")
+	sig, err := client.Sign(name, password, data)
+	require.NoError(sts.T(), err)
+	require.True(sts.T(), sig.PubKey.VerifyBytes(data, sig.Signature))
+	require.Equal(sts.T(), sts.address, types.AccAddress(sig.PubKey.Address()).String())
+
+	pubKey, err := client.QueryPubKey(name, password)
+	require.NoError(sts.T(), err)
+	bech32PubKey, err := types.Bech32ifyAccPub(sig.PubKey)
+	require.NoError(sts.T(), err)
+	require.Equal(sts.T(), bech32PubKey, pubKey)
+
+	_, err = client.Sign(name, "wrong password", data)
+	require.Error(sts.T(), err)
+	_, err = client.Query("unknown")
+	require.Error(sts.T(), err)
+
+	// the connection is still usable after the errors
+	_, err = client.Query(name)
+	require.NoError(sts.T(), err)
+
+	_, _, err = client.Insert(name, password)
+	require.Equal(sts.T(), signer.ErrUnsupported, err)
+}
+
+func (sts *SignerTestSuite) TestCloseConnections() {
+	client := sts.newClient(sts.clientCert, sts.ca)
+	defer client.Close()
+
+	_, err := client.Query(name)
+	require.NoError(sts.T(), err)
+
+	// the open connection is closed with the server
+	require.NoError(sts.T(), sts.server.Close())
+	_, err = client.Query(name)
+	require.Error(sts.T(), err)
+}
+
+func (sts *SignerTestSuite) TestUnknownClientRejected() {
+	otherCA, otherKey := sts.newCA()
+	client := sts.newClient(sts.newCert(otherCA, otherKey, "client"), sts.ca)
+	defer client.Close()
+
+	_, err := client.Query(name)
+	require.Error(sts.T(), err)
+}
+
+func (sts *SignerTestSuite) TestUnknownServerRejected() {
+	otherCA, _ := sts.newCA()
+	client := sts.newClient(sts.clientCert, otherCA)
+	defer client.Close()
+
+	_, err := client.Query(name)
+	require.Error(sts.T(), err)
+}
+
+func (sts *SignerTestSuite) newClient(cert tls.Certificate, serverCA *x509.Certificate) *signer.Client {
+	// the default timeout, the keys are decrypted with scrypt which is slow under the race detector
+	client, err := signer.NewClient(sts.addr, &tls.Config{
+		Certificates: []tls.Certificate{cert},
+		RootCAs:      sts.pool(serverCA),
+		ServerName:   "signer",
+	}, 0)
+	require.NoError(sts.T(), err)
+	return client
+}
+
+func (sts *SignerTestSuite) pool(ca *x509.Certificate) *x509.CertPool {
+	pool := x509.NewCertPool()
+	pool.AddCert(ca)
+	return pool
+}
+
+func (sts *SignerTestSuite) newCA() (*x509.Certificate, *ecdsa.PrivateKey) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(sts.T(), err)
+
+	tmpl := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "ca"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  true,
+		KeyUsage:              x509.KeyUsageCertSign,
+		BasicConstraintsValid: true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, tmpl, &key.PublicKey, key)
+	require.NoError(sts.T(), err)
+	ca, err := x509.ParseCertificate(der)
+	require.NoError(sts.T(), err)
+	return ca, key
+}
+
+func (sts *SignerTestSuite) newCert(ca *x509.Certificate, caKey *ecdsa.PrivateKey, commonName string) tls.Certificate {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(sts.T(), err)
+
+	tmpl := &x509.Certificate{
+		SerialNumber: big.NewInt(time.Now().UnixNano()),
+		Subject:      pkix.Name{CommonName: commonName},
+		DNSNames:     []string{commonName},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, ca, &key.PublicKey, caKey)
+	require.NoError(sts.T(), err)
+	return tls.Certificate{
+		Certificate: [][]byte{der},
+		PrivateKey:  key,
+	}
+}
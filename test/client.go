@@ -1,32 +1,49 @@
 package test
 
 import (
+	"fmt"
 	"io/ioutil"
 	"math/rand"
 	"os"
 	"path/filepath"
-	"strings"
 	"sync"
 	"time"
 
 	sdk "github.com/irisnet/irishub-sdk-go"
+	"github.com/irisnet/irishub-sdk-go/test/fakechain"
+	"github.com/irisnet/irishub-sdk-go/test/recorder"
 	"github.com/irisnet/irishub-sdk-go/types"
 )
 
 const (
-	nodeURI = "localhost:26657"
 	chainID = "test"
 	network = types.Testnet
 	mode    = types.Commit
 	fee     = "0.6iris"
 	gas     = 20000
 
+	// rpc address of the node, e.g. localhost:26657. When it is not set,
+	// the tests run against an in-process chain
+	nodeEnv = "SDK_TEST_NODE"
+	// golden file to which the traffic with the node is recorded, relative to the package under test
+	recordEnv = "SDK_TEST_RECORD"
+	// golden file replayed instead of a node, relative to the package under test
+	replayEnv   = "SDK_TEST_REPLAY"
+	rootBalance = 100000000
+	// tokens self delegated by the root user, which operates the validator of the in-process chain
+	rootSelfDelegation = 1000000
+
 	letterBytes   = "abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ"
 	letterIdxBits = 6                    // 6 bits to represent a letter index
 	letterIdxMask = 1<<letterIdxBits - 1 // All 1-bits, as many as letterIdxBits
 	letterIdxMax  = 63 / letterIdxBits   // # of letter indices fitting in 63 bits
 )
 
+// Mnemonic derives the accounts created by the tests: a recording is only replayed if the tests
+// send the same transactions from the same addresses at each run
+const Mnemonic = "diary narrow around pigeon someone health syrup ask seek among search element canoe wheat " +
+	"repair brisk blanket patient rigid episode strong cable shield excite"
+
 var (
 	mock *MockClient
 	lock sync.Mutex
@@ -36,6 +53,7 @@ var (
 type MockClient struct {
 	sdk.Client
 	rootUser *MockAccount
+	chain    *fakechain.Chain
 }
 
 type MockAccount struct {
@@ -60,9 +78,32 @@ func newMockClient() MockClient {
 		panic(err)
 	}
 
+	var chain *fakechain.Chain
+	var tmClient types.TmClient
+	node := os.Getenv(nodeEnv)
+	switch {
+	case len(os.Getenv(replayEnv)) > 0:
+		replayer, err := recorder.NewReplayer(os.Getenv(replayEnv))
+		if err != nil {
+			panic(err)
+		}
+		tmClient = replayer
+	case len(os.Getenv(recordEnv)) > 0:
+		if len(node) == 0 {
+			panic(fmt.Sprintf("%s is set without %s, the recordings are made against a node", recordEnv, nodeEnv))
+		}
+		tmClient = recorder.NewRemote(node, os.Getenv(recordEnv))
+	case len(node) == 0:
+		chain = fakechain.New(fakechain.WithChainID(chainID))
+		tmClient = chain
+	}
+
+	// the addresses of the tests are printed with the prefixes of the network of the mock client
+	types.SetNetwork(network)
 	path := filepath.Join(getPWD(), "test")
 	c := sdk.NewClient(types.ClientConfig{
-		NodeURI:   nodeURI,
+		NodeURI:   node,
+		TmClient:  tmClient,
 		Network:   network,
 		ChainID:   chainID,
 		Gas:       gas,
@@ -81,6 +122,7 @@ func newMockClient() MockClient {
 			Name:     "test1",
 			Password: "11111111",
 		},
+		chain: chain,
 	}
 
 	tc.init()
@@ -97,6 +139,26 @@ func (tc MockClient) init() {
 		}
 	}
 	tc.rootUser.Address = types.MustAccAddressFromBech32(address)
+
+	if tc.chain != nil {
+		balance := types.NewCoin(types.IRIS.MinUnit, types.NewIntWithDecimal(rootBalance, int(types.IRIS.Scale)))
+		if err := tc.chain.Fund(address, types.NewCoins(balance)); err != nil {
+			panic(err)
+		}
+
+		selfDelegation := types.NewCoin(types.IRIS.MinUnit, types.NewIntWithDecimal(rootSelfDelegation, int(types.IRIS.Scale)))
+		if err := tc.chain.AddValidator(address, selfDelegation); err != nil {
+			panic(err)
+		}
+		if err := tc.chain.AddGuardian(address); err != nil {
+			panic(err)
+		}
+	}
+}
+
+// Chain returns the in-process chain, or nil when the tests run against a node
+func (tc MockClient) Chain() *fakechain.Chain {
+	return tc.chain
 }
 
 func (tc MockClient) Account() MockAccount {
@@ -129,12 +191,20 @@ func getKeystore() string {
 	return string(bz)
 }
 
+// getPWD returns the root directory of the repository, where go.mod is located
 func getPWD() string {
 	path, err := os.Getwd()
 	if err != nil {
 		panic(err)
 	}
-	path = filepath.Dir(path)
-	path = strings.TrimRight(path, "modules")
-	return path
+	for {
+		if _, err := os.Stat(filepath.Join(path, "go.mod")); err == nil {
+			return path
+		}
+		parent := filepath.Dir(path)
+		if parent == path {
+			panic("go.mod not found")
+		}
+		path = parent
+	}
 }
@@ -0,0 +1,273 @@
+package fakechain
+
+import (
+	"fmt"
+	"time"
+
+	abci "github.com/tendermint/tendermint/abci/types"
+	cmn "github.com/tendermint/tendermint/libs/common"
+	rpcclient "github.com/tendermint/tendermint/rpc/client"
+	ctypes "github.com/tendermint/tendermint/rpc/core/types"
+	tmtypes "github.com/tendermint/tendermint/types"
+
+	sdk "github.com/irisnet/irishub-sdk-go/types"
+)
+
+func (c *Chain) ABCIInfo() (*ctypes.ResultABCIInfo, error) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	last := c.blocks[len(c.blocks)-1].block
+	return &ctypes.ResultABCIInfo{
+		Response: abci.ResponseInfo{
+			Data:             "irishub",
+			Version:          c.appVersion,
+			LastBlockHeight:  last.Height,
+			LastBlockAppHash: last.AppHash,
+		},
+	}, nil
+}
+
+func (c *Chain) ABCIQuery(path string, data cmn.HexBytes) (*ctypes.ResultABCIQuery, error) {
+	return c.ABCIQueryWithOptions(path, data, rpcclient.DefaultABCIQueryOptions)
+}
+
+func (c *Chain) ABCIQueryWithOptions(path string, data cmn.HexBytes,
+	opts rpcclient.ABCIQueryOptions) (*ctypes.ResultABCIQuery, error) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	resp := abci.ResponseQuery{
+		Height: c.height(),
+	}
+
+	querier, ok := c.queriers[path]
+	if !ok {
+		resp.Code = uint32(sdk.UnknownRequest)
+		resp.Log = fmt.Sprintf("unknown query path: %s", path)
+		return &ctypes.ResultABCIQuery{Response: resp}, nil
+	}
+
+	bz, err := querier(c.context(), data)
+	if err != nil {
+		resp.Code, resp.Log = errorCode(err, sdk.UnknownRequest)
+		return &ctypes.ResultABCIQuery{Response: resp}, nil
+	}
+	resp.Value = bz
+	return &ctypes.ResultABCIQuery{Response: resp}, nil
+}
+
+func (c *Chain) BroadcastTxCommit(tx tmtypes.Tx) (*ctypes.ResultBroadcastTxCommit, error) {
+	checkTx, deliverTx, height, err := c.broadcast(tx)
+	if err != nil {
+		return nil, err
+	}
+	return &ctypes.ResultBroadcastTxCommit{
+		CheckTx:   checkTx,
+		DeliverTx: deliverTx,
+		Hash:      tx.Hash(),
+		Height:    height,
+	}, nil
+}
+
+func (c *Chain) BroadcastTxAsync(tx tmtypes.Tx) (*ctypes.ResultBroadcastTx, error) {
+	if _, _, _, err := c.broadcast(tx); err != nil {
+		return nil, err
+	}
+	return &ctypes.ResultBroadcastTx{Hash: tx.Hash()}, nil
+}
+
+func (c *Chain) BroadcastTxSync(tx tmtypes.Tx) (*ctypes.ResultBroadcastTx, error) {
+	checkTx, _, _, err := c.broadcast(tx)
+	if err != nil {
+		return nil, err
+	}
+	return &ctypes.ResultBroadcastTx{
+		Code: checkTx.Code,
+		Data: checkTx.Data,
+		Log:  checkTx.Log,
+		Hash: tx.Hash(),
+	}, nil
+}
+
+// broadcast checks the transaction and, if it passes, commits it in a new block
+func (c *Chain) broadcast(txBytes tmtypes.Tx) (checkTx abci.ResponseCheckTx, deliverTx abci.ResponseDeliverTx, height int64, err error) {
+	c.mu.Lock()
+
+	if c.cdc == nil {
+		c.mu.Unlock()
+		return checkTx, deliverTx, 0, errNoCodec
+	}
+
+	var tx sdk.StdTx
+	if err := c.cdc.UnmarshalBinaryLengthPrefixed(txBytes, &tx); err != nil {
+		c.mu.Unlock()
+		checkTx.Codespace = sdk.RootCodespace
+		checkTx.Code, checkTx.Log = errorCode(err, sdk.TxDecode)
+		return checkTx, deliverTx, 0, nil
+	}
+
+	if err := c.ante(tx); err != nil {
+		c.mu.Unlock()
+		checkTx.Codespace = sdk.RootCodespace
+		checkTx.Code, checkTx.Log = errorCode(err, sdk.Unauthorized)
+		return checkTx, deliverTx, 0, nil
+	}
+	checkTx.GasWanted = int64(tx.Fee.Gas)
+
+	blockTime := c.blockTime(time.Now().UTC())
+	deliverTx, msgTags := c.deliver(tx, txBytes, blockTime)
+	block := c.commitBlock(blockTime, txBytes, &deliverTx)
+	height = block.Height
+	tags := indexTags(txBytes, height, msgTags)
+	c.txs = append(c.txs, txRecord{
+		result: &ctypes.ResultTx{
+			Hash:     txBytes.Hash(),
+			Height:   height,
+			TxResult: deliverTx,
+			Tx:       txBytes,
+		},
+		tags: tags,
+	})
+	subs := c.subscriptions()
+	c.mu.Unlock()
+
+	c.publishTx(subs, txBytes, height, tx, deliverTx, tags)
+	c.publishBlock(subs, block)
+	return checkTx, deliverTx, height, nil
+}
+
+// ante verifies the signatures, account numbers and sequences, then deducts the fee and
+// increases the sequences. It leaves the state untouched when failing.
+func (c *Chain) ante(tx sdk.StdTx) error {
+	if len(tx.Msgs) == 0 {
+		return NewError(sdk.TxDecode, "must contain at least one message")
+	}
+
+	var signers []sdk.AccAddress
+	seen := make(map[string]bool)
+	for _, msg := range tx.Msgs {
+		if err := msg.ValidateBasic(); err != nil {
+			return NewError(sdk.UnknownRequest, err.Error())
+		}
+		for _, signer := range msg.GetSigners() {
+			if !seen[signer.String()] {
+				seen[signer.String()] = true
+				signers = append(signers, signer)
+			}
+		}
+	}
+
+	if len(tx.Signatures) == 0 {
+		return NewError(sdk.Unauthorized, "no signatures supplied")
+	}
+	if len(tx.Signatures) != len(signers) {
+		return NewError(sdk.Unauthorized, "wrong number of signers; expected %d, got %d", len(signers), len(tx.Signatures))
+	}
+
+	accounts := make([]*sdk.BaseAccount, len(signers))
+	for i, signer := range signers {
+		acc, ok := c.state.Account(signer)
+		if !ok {
+			return NewError(sdk.UnknownAddress, "account %s does not exist", sdk.AddrPrefixCfgOf(c.cdc).AccAddressString(signer))
+		}
+
+		sig := tx.Signatures[i]
+		if sig.AccountNumber != acc.AccountNumber {
+			return NewError(sdk.InvalidSequence, "invalid account number; got %d, expected %d", sig.AccountNumber, acc.AccountNumber)
+		}
+		if sig.Sequence != acc.Sequence {
+			return NewError(sdk.InvalidSequence, "invalid sequence; got %d, expected %d", sig.Sequence, acc.Sequence)
+		}
+		if sig.PubKey == nil || !sdk.AccAddress(sig.PubKey.Address()).Equals(signer) {
+			return NewError(sdk.InvalidPubkey, "invalid pubkey for signer %s", sdk.AddrPrefixCfgOf(c.cdc).AccAddressString(signer))
+		}
+
+		signBytes := sdk.StdSignMsg{
+			ChainID:       c.chainID,
+			AccountNumber: sig.AccountNumber,
+			Sequence:      sig.Sequence,
+			Fee:           tx.Fee,
+			Msgs:          tx.Msgs,
+			Memo:          tx.Memo,
+		}.Bytes(c.cdc)
+		if !sig.PubKey.VerifyBytes(signBytes, sig.Signature) {
+			return NewError(sdk.Unauthorized, "signature verification failed")
+		}
+		accounts[i] = acc
+	}
+
+	if !tx.Fee.Amount.Empty() {
+		if err := c.state.SubtractCoins(signers[0], tx.Fee.Amount); err != nil {
+			return err
+		}
+	}
+
+	for i, acc := range accounts {
+		acc.Sequence++
+		if acc.PubKey == nil {
+			acc.PubKey = tx.Signatures[i].PubKey
+		}
+	}
+	return nil
+}
+
+// deliver runs the messages, the state changes are reverted if any of them fails
+func (c *Chain) deliver(tx sdk.StdTx, txBytes tmtypes.Tx, blockTime time.Time) (abci.ResponseDeliverTx, []sdk.Tags) {
+	result := abci.ResponseDeliverTx{
+		GasWanted: int64(tx.Fee.Gas),
+		GasUsed:   int64(tx.Fee.Gas),
+		Codespace: sdk.RootCodespace,
+	}
+
+	ctx := c.context()
+	ctx.Height++
+	ctx.Time = blockTime
+	ctx.Memo = tx.Memo
+	ctx.TxHash = txBytes.Hash()
+
+	snapshot := c.state.snapshot()
+	var tags sdk.Tags
+	var msgTags []sdk.Tags
+	for _, msg := range tx.Msgs {
+		handler, ok := c.handlers[msg.Route()]
+		if !ok {
+			c.state.restore(snapshot)
+			result.Code = uint32(sdk.UnknownRequest)
+			result.Log = fmt.Sprintf("unrecognized message route: %s", msg.Route())
+			return result, nil
+		}
+
+		mt, err := handler(ctx, msg)
+		if err != nil {
+			c.state.restore(snapshot)
+			result.Code, result.Log = errorCode(err, sdk.UnknownRequest)
+			return result, nil
+		}
+		tags = append(tags, mt...)
+		msgTags = append(msgTags, mt)
+	}
+	result.Tags = toKVPairs(tags)
+	return result, msgTags
+}
+
+// indexTags returns one tag map per message, they are used to match event queries and tx searches
+func indexTags(txBytes tmtypes.Tx, height int64, msgTags []sdk.Tags) []map[string]string {
+	if len(msgTags) == 0 {
+		// a failed transaction can still be found by hash and height
+		msgTags = []sdk.Tags{nil}
+	}
+
+	var tags []map[string]string
+	for _, mt := range msgTags {
+		m := map[string]string{
+			"tx.hash":   cmn.HexBytes(txBytes.Hash()).String(),
+			"tx.height": fmt.Sprintf("%d", height),
+		}
+		for _, tag := range mt {
+			m[tag.Key] = tag.Value
+		}
+		tags = append(tags, m)
+	}
+	return tags
+}
@@ -0,0 +1,148 @@
+package fakechain
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/tendermint/tendermint/libs/pubsub/query"
+	"github.com/tendermint/tendermint/p2p"
+	ctypes "github.com/tendermint/tendermint/rpc/core/types"
+	tmtypes "github.com/tendermint/tendermint/types"
+)
+
+const defaultPerPage = 30
+
+func (c *Chain) Block(height *int64) (*ctypes.ResultBlock, error) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	record, err := c.getBlock(height)
+	if err != nil {
+		return nil, err
+	}
+	return &ctypes.ResultBlock{
+		BlockMeta: tmtypes.NewBlockMeta(record.block, record.block.MakePartSet(tmtypes.BlockPartSizeBytes)),
+		Block:     record.block,
+	}, nil
+}
+
+func (c *Chain) BlockResults(height *int64) (*ctypes.ResultBlockResults, error) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	record, err := c.getBlock(height)
+	if err != nil {
+		return nil, err
+	}
+	return &ctypes.ResultBlockResults{
+		Height:  record.block.Height,
+		Results: record.results,
+	}, nil
+}
+
+func (c *Chain) Commit(height *int64) (*ctypes.ResultCommit, error) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	record, err := c.getBlock(height)
+	if err != nil {
+		return nil, err
+	}
+
+	commit := &tmtypes.Commit{
+		BlockID: tmtypes.BlockID{Hash: record.block.Hash()},
+	}
+	return ctypes.NewResultCommit(&record.block.Header, commit, true), nil
+}
+
+// Status reports the chain-id as the network of the node, whose validator is the only one of the chain
+func (c *Chain) Status() (*ctypes.ResultStatus, error) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	latest := c.blocks[len(c.blocks)-1].block
+	validator := c.validators[0]
+	return &ctypes.ResultStatus{
+		NodeInfo: p2p.DefaultNodeInfo{
+			Network: c.chainID,
+			Moniker: "fakechain",
+		},
+		SyncInfo: ctypes.SyncInfo{
+			LatestBlockHash:   latest.Hash(),
+			LatestAppHash:     latest.AppHash,
+			LatestBlockHeight: latest.Height,
+			LatestBlockTime:   latest.Time,
+		},
+		ValidatorInfo: ctypes.ValidatorInfo{
+			Address:     validator.Address,
+			PubKey:      validator.PubKey,
+			VotingPower: validator.VotingPower,
+		},
+	}, nil
+}
+
+func (c *Chain) Validators(height *int64) (*ctypes.ResultValidators, error) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	record, err := c.getBlock(height)
+	if err != nil {
+		return nil, err
+	}
+	return &ctypes.ResultValidators{
+		BlockHeight: record.block.Height,
+		Validators:  c.validators,
+	}, nil
+}
+
+func (c *Chain) Tx(hash []byte, prove bool) (*ctypes.ResultTx, error) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	for _, tx := range c.txs {
+		if bytes.Equal(tx.result.Hash, hash) {
+			return tx.result, nil
+		}
+	}
+	return nil, fmt.Errorf("tx (%X) not found", hash)
+}
+
+func (c *Chain) TxSearch(q string, prove bool, page, perPage int) (*ctypes.ResultTxSearch, error) {
+	parsed, err := query.New(q)
+	if err != nil {
+		return nil, err
+	}
+
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	var matched []*ctypes.ResultTx
+	for _, tx := range c.txs {
+		for _, tags := range tx.tags {
+			if parsed.Matches(tags) {
+				matched = append(matched, tx.result)
+				break
+			}
+		}
+	}
+
+	if perPage <= 0 {
+		perPage = defaultPerPage
+	}
+	if page <= 0 {
+		page = 1
+	}
+	start := (page - 1) * perPage
+	if start > len(matched) {
+		start = len(matched)
+	}
+	end := start + perPage
+	if end > len(matched) {
+		end = len(matched)
+	}
+
+	return &ctypes.ResultTxSearch{
+		Txs:        matched[start:end],
+		TotalCount: len(matched),
+	}, nil
+}
@@ -0,0 +1,472 @@
+// Package fakechain provides an in-process irishub chain which implements types.TmClient,
+// so that applications and the SDK's own module tests can run under plain `go test`.
+//
+// Every broadcast transaction is checked (signatures, account number, sequence and fee)
+// and committed in its own block at once, whatever the broadcast mode is.
+//
+// The chain serves the bank, stake, distr, slashing, random, service and oracle modules and the acc, params
+// and asset queries. While requests of the service module are pending, e.g. those of a running feed, empty
+// blocks are committed at a short interval so that the providers are sent the next batches.
+//
+// As a quick start:
+//
+// 	chain := fakechain.New(fakechain.WithChainID("test"))
+// 	client := sdk.NewClient(types.ClientConfig{
+// 		ChainID:  "test",
+// 		TmClient: chain,
+// 		...
+// 	})
+// 	chain.Fund(address, types.NewCoins(types.NewCoin("iris-atto", amount)))
+package fakechain
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	abci "github.com/tendermint/tendermint/abci/types"
+	"github.com/tendermint/tendermint/crypto/ed25519"
+	cmn "github.com/tendermint/tendermint/libs/common"
+	ctypes "github.com/tendermint/tendermint/rpc/core/types"
+	"github.com/tendermint/tendermint/state"
+	tmtypes "github.com/tendermint/tendermint/types"
+
+	sdk "github.com/irisnet/irishub-sdk-go/types"
+)
+
+const (
+	defaultChainID = "test"
+	blockInterval  = 5 * time.Second
+	// time without any block after which an empty block is committed, in real time
+	emptyBlockInterval = 500 * time.Millisecond
+	validatorPower     = 100
+)
+
+var (
+	_ sdk.TmClient    = &Chain{}
+	_ sdk.CodecSetter = &Chain{}
+
+	errNoCodec = errors.New("codec of the chain is not set")
+)
+
+// Handler executes a message against the chain state and returns the tags of the message
+type Handler func(ctx Context, msg sdk.Msg) (sdk.Tags, error)
+
+// Querier answers a custom ABCI query, data is the JSON encoded query parameter
+type Querier func(ctx Context, data []byte) ([]byte, error)
+
+// beginBlocker runs at the beginning of each block and returns the tags of the begin block
+type beginBlocker func(ctx Context) sdk.Tags
+
+// endBlocker runs at the end of each block, after the transaction, and returns the tags of the end block
+type endBlocker func(ctx Context) sdk.Tags
+
+// awaiter reports whether a module awaits a later block, the chain then commits empty blocks
+type awaiter func(ctx Context) bool
+
+// Context is passed to handlers and queriers
+type Context struct {
+	*State
+	Codec  sdk.Codec
+	Height int64
+	Time   time.Time
+	Memo   string
+	TxHash []byte
+}
+
+// AddrPrefixCfg returns the bech32 prefixes of the chain, those of the codec
+func (ctx Context) AddrPrefixCfg() *sdk.AddrPrefixCfg {
+	return sdk.AddrPrefixCfgOf(ctx.Codec)
+}
+
+// EncodeJSON returns the JSON of o encoded with the codec of the chain. The results encode their addresses
+// as strings with the prefixes of the chain, see AddrPrefixCfg.
+func (ctx Context) EncodeJSON(o interface{}) ([]byte, error) {
+	if ctx.Codec == nil {
+		return nil, errNoCodec
+	}
+	return ctx.Codec.MarshalJSON(o)
+}
+
+// DecodeJSON decodes the JSON into ptr with the codec of the chain, the addresses are accepted with any prefix
+func (ctx Context) DecodeJSON(bz []byte, ptr interface{}) error {
+	if ctx.Codec == nil {
+		return errNoCodec
+	}
+	return ctx.Codec.UnmarshalJSON(bz, ptr)
+}
+
+// registered is a value whose type is registered in the codec under the name but unexported by its module,
+// such as the params of some modules. Its JSON is wrapped with the name as amino expects.
+type registered struct {
+	Name  string
+	Value interface{}
+}
+
+// encodeRegistered returns the JSON of o wrapped with the name under which the result type is registered
+func (ctx Context) encodeRegistered(name string, o interface{}) ([]byte, error) {
+	bz, err := ctx.EncodeJSON(o)
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(struct {
+		Type  string          `json:"type"`
+		Value json.RawMessage `json:"value"`
+	}{
+		Type:  name,
+		Value: bz,
+	})
+}
+
+type Option func(*Chain)
+
+// WithChainID sets the chain-id used to verify the signatures, default: test
+func WithChainID(chainID string) Option {
+	return func(c *Chain) {
+		c.chainID = chainID
+	}
+}
+
+// WithAppVersion sets the version of the application reported by ABCIInfo, default: empty
+func WithAppVersion(version string) Option {
+	return func(c *Chain) {
+		c.appVersion = version
+	}
+}
+
+// WithCodec sets the codec used to decode transactions, the codec of the client is used by default
+func WithCodec(cdc sdk.Codec) Option {
+	return func(c *Chain) {
+		c.cdc = cdc
+	}
+}
+
+type Chain struct {
+	mu sync.RWMutex
+
+	cdc         sdk.Codec
+	chainID     string
+	appVersion  string
+	state       *State
+	handlers    map[string]Handler
+	queriers    map[string]Querier
+	blockers    []beginBlocker
+	endBlockers []endBlocker
+	awaiters    []awaiter
+	validators  []*tmtypes.Validator
+	// whether empty blocks are being committed, and the real time of the latest block
+	producing  bool
+	lastCommit time.Time
+
+	blocks []blockRecord
+	txs    []txRecord
+	subs   map[string]subscription
+}
+
+type blockRecord struct {
+	block   *tmtypes.Block
+	results *state.ABCIResponses
+}
+
+type txRecord struct {
+	result *ctypes.ResultTx
+	tags   []map[string]string
+}
+
+// New returns a chain with one validator and an empty genesis block
+func New(opts ...Option) *Chain {
+	c := &Chain{
+		chainID:  defaultChainID,
+		state:    NewState(),
+		handlers: make(map[string]Handler),
+		queriers: make(map[string]Querier),
+		subs:     make(map[string]subscription),
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+
+	pubKey := ed25519.GenPrivKeyFromSecret([]byte(c.chainID)).PubKey()
+	c.validators = []*tmtypes.Validator{tmtypes.NewValidator(pubKey, validatorPower)}
+
+	registerBank(c)
+	registerQueriers(c)
+	registerStaking(c)
+	registerDistribution(c)
+	registerSlashing(c)
+	registerRandom(c)
+	registerService(c)
+	registerOracle(c)
+
+	c.commitBlock(time.Now().UTC(), nil, nil)
+	return c
+}
+
+// SetCodec implements types.CodecSetter
+func (c *Chain) SetCodec(cdc sdk.Codec) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.cdc == nil {
+		c.cdc = cdc
+	}
+}
+
+// RegisterHandler adds the handler of the messages whose route is the given one
+func (c *Chain) RegisterHandler(route string, handler Handler) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.handlers[route] = handler
+}
+
+// RegisterQuerier adds the querier of the ABCI query path, such as custom/bank/xxx
+func (c *Chain) RegisterQuerier(path string, querier Querier) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.queriers[path] = querier
+}
+
+// Fund mints the coins to the address, the account is created if it does not exist
+func (c *Chain) Fund(address string, coins sdk.Coins) error {
+	addr, err := sdk.AddrPrefixCfgOf(c.cdc).AccAddressFromBech32(address)
+	if err != nil {
+		return err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.state.Mint(addr, coins)
+	return nil
+}
+
+// AddTokens saves the tokens which can be queried through the asset module
+func (c *Chain) AddTokens(tokens ...sdk.Token) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for _, t := range tokens {
+		c.state.SetToken(t)
+	}
+}
+
+// AddValidator bonds a validator operated by the account in a new block, the self delegation is taken from
+// the balance of the account. The first one is given the key of the genesis validator, the next ones join
+// the validator set. The validators earn the block provisions and sign every block.
+func (c *Chain) AddValidator(operator string, selfDelegation sdk.Coin) error {
+	addr, err := sdk.AddrPrefixCfgOf(c.cdc).AccAddressFromBech32(operator)
+	if err != nil {
+		return err
+	}
+
+	c.mu.Lock()
+	ctx := c.context()
+	ctx.Height++
+	ctx.Time = c.blockTime(time.Now().UTC())
+	consPubKey := c.validators[0].PubKey
+	joining := len(c.state.validators) > 0
+	if joining {
+		consPubKey = ed25519.GenPrivKeyFromSecret(addr).PubKey()
+	}
+	if err := c.state.CreateValidator(ctx, addr, consPubKey, selfDelegation); err != nil {
+		c.mu.Unlock()
+		return err
+	}
+	if joining {
+		c.validators = append(c.validators, tmtypes.NewValidator(consPubKey, validatorPower))
+	}
+	block := c.commitBlock(ctx.Time, nil, nil)
+	subs := c.subscriptions()
+	c.mu.Unlock()
+
+	c.publishBlock(subs, block)
+	return nil
+}
+
+// AddGuardian makes the account a profiler and a trustee, the profilers create the feeds of the oracle module
+// and the trustees withdraw the service tax
+func (c *Chain) AddGuardian(address string) error {
+	addr, err := sdk.AddrPrefixCfgOf(c.cdc).AccAddressFromBech32(address)
+	if err != nil {
+		return err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.state.guardians[addr.String()] = true
+	return nil
+}
+
+// SetParams replaces the params of the module, params must be registered in the codec
+func (c *Chain) SetParams(module string, params interface{}) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.state.SetParams(module, params)
+}
+
+// EndBlock commits an empty block whose end block has the tags, e.g. those of a passed proposal, and publishes it
+func (c *Chain) EndBlock(tags sdk.Tags) int64 {
+	c.mu.Lock()
+	block := c.commitBlock(time.Now().UTC(), nil, nil)
+	results := c.blocks[block.Height-1].results
+	results.EndBlock.Tags = append(results.EndBlock.Tags, toKVPairs(tags)...)
+	subs := c.subscriptions()
+	c.mu.Unlock()
+
+	c.publishBlock(subs, block)
+	return block.Height
+}
+
+// Account returns a copy of the account stored on the chain
+func (c *Chain) Account(address string) (sdk.BaseAccount, bool) {
+	addr, err := sdk.AddrPrefixCfgOf(c.cdc).AccAddressFromBech32(address)
+	if err != nil {
+		return sdk.BaseAccount{}, false
+	}
+
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	acc, ok := c.state.Account(addr)
+	if !ok {
+		return sdk.BaseAccount{}, false
+	}
+	return *acc, true
+}
+
+// Height returns the height of the latest block
+func (c *Chain) Height() int64 {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.height()
+}
+
+func (c *Chain) height() int64 {
+	return int64(len(c.blocks))
+}
+
+func (c *Chain) context() Context {
+	return Context{
+		State:  c.state,
+		Codec:  c.cdc,
+		Height: c.height(),
+	}
+}
+
+// blockTime returns the time of the next block, which is after the time of the latest block
+func (c *Chain) blockTime(t time.Time) time.Time {
+	if h := c.height(); h > 0 && !t.After(c.blocks[h-1].block.Time) {
+		return c.blocks[h-1].block.Time.Add(blockInterval)
+	}
+	return t
+}
+
+// commitBlock appends a block containing the given transaction, must be called with the lock held
+func (c *Chain) commitBlock(blockTime time.Time, tx tmtypes.Tx, result *abci.ResponseDeliverTx) *tmtypes.Block {
+	height := c.height() + 1
+	blockTime = c.blockTime(blockTime)
+
+	var txs []tmtypes.Tx
+	var deliverTxs []*abci.ResponseDeliverTx
+	if tx != nil {
+		txs = append(txs, tx)
+		deliverTxs = append(deliverTxs, result)
+	}
+
+	var lastCommit = &tmtypes.Commit{}
+	if height > 1 {
+		last := c.blocks[height-2].block
+		lastCommit.BlockID = tmtypes.BlockID{Hash: last.Hash()}
+	}
+
+	ctx := c.context()
+	ctx.Height = height
+	ctx.Time = blockTime
+	var tags, endTags sdk.Tags
+	for _, blocker := range c.blockers {
+		tags = append(tags, blocker(ctx)...)
+	}
+	for _, blocker := range c.endBlockers {
+		endTags = append(endTags, blocker(ctx)...)
+	}
+
+	block := tmtypes.MakeBlock(height, txs, lastCommit, nil)
+	block.ChainID = c.chainID
+	block.Time = blockTime
+	block.TotalTxs = int64(len(c.txs) + len(txs))
+	block.ValidatorsHash = tmtypes.NewValidatorSet(c.validators).Hash()
+	block.ProposerAddress = c.validators[0].Address
+
+	c.blocks = append(c.blocks, blockRecord{
+		block: block,
+		results: &state.ABCIResponses{
+			DeliverTx:  deliverTxs,
+			EndBlock:   &abci.ResponseEndBlock{Tags: toKVPairs(endTags)},
+			BeginBlock: &abci.ResponseBeginBlock{Tags: toKVPairs(tags)},
+		},
+	})
+
+	c.lastCommit = time.Now()
+	if !c.producing && c.awaited() {
+		c.producing = true
+		go c.produceEmptyBlocks()
+	}
+	return block
+}
+
+// awaited reports whether any module awaits a later block, must be called with the lock held
+func (c *Chain) awaited() bool {
+	ctx := c.context()
+	for _, await := range c.awaiters {
+		if await(ctx) {
+			return true
+		}
+	}
+	return false
+}
+
+// produceEmptyBlocks commits and publishes an empty block whenever no block is committed for the interval,
+// as long as a module awaits them
+func (c *Chain) produceEmptyBlocks() {
+	wait := emptyBlockInterval
+	for {
+		time.Sleep(wait)
+
+		c.mu.Lock()
+		if idle := time.Since(c.lastCommit); idle < emptyBlockInterval {
+			wait = emptyBlockInterval - idle
+			c.mu.Unlock()
+			continue
+		}
+		wait = emptyBlockInterval
+		if !c.awaited() {
+			c.producing = false
+			c.mu.Unlock()
+			return
+		}
+		block := c.commitBlock(time.Now().UTC(), nil, nil)
+		subs := c.subscriptions()
+		c.mu.Unlock()
+
+		c.publishBlock(subs, block)
+	}
+}
+
+func (c *Chain) getBlock(height *int64) (blockRecord, error) {
+	h := c.height()
+	if height != nil && *height != 0 {
+		h = *height
+	}
+	if h <= 0 || h > c.height() {
+		return blockRecord{}, fmt.Errorf("height %d must be less than or equal to the current blockchain height %d", h, c.height())
+	}
+	return c.blocks[h-1], nil
+}
+
+func toKVPairs(tags sdk.Tags) (pairs []cmn.KVPair) {
+	for _, tag := range tags {
+		pairs = append(pairs, cmn.KVPair{
+			Key:   []byte(tag.Key),
+			Value: []byte(tag.Value),
+		})
+	}
+	return
+}
@@ -0,0 +1,102 @@
+package fakechain_test
+
+import (
+	"encoding/hex"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/irisnet/irishub-sdk-go/rpc"
+	"github.com/irisnet/irishub-sdk-go/test"
+	"github.com/irisnet/irishub-sdk-go/types"
+)
+
+type ChainTestSuite struct {
+	suite.Suite
+	*test.MockClient
+}
+
+func TestChainTestSuite(t *testing.T) {
+	suite.Run(t, new(ChainTestSuite))
+}
+
+func (cts *ChainTestSuite) SetupTest() {
+	cts.MockClient = test.GetMock()
+	if cts.Chain() == nil {
+		cts.T().Skip("the tests run against a node")
+	}
+}
+
+func (cts *ChainTestSuite) baseTx() types.BaseTx {
+	return types.BaseTx{
+		From:     cts.Account().Name,
+		Gas:      20000,
+		Memo:     "test",
+		Mode:     types.Commit,
+		Password: cts.Account().Password,
+	}
+}
+
+func (cts *ChainTestSuite) TestSendAndSubscribe() {
+	to := "faa1hp29kuh22vpjjlnctmyml5s75evsnsd8r4x0mm"
+	ch := make(chan rpc.EventDataMsgSend, 1)
+	sub := cts.Bank().SubscribeSendTx(cts.Account().Address.String(), to, func(data rpc.EventDataMsgSend) {
+		ch <- data
+	})
+	defer func() {
+		_ = cts.Unsubscribe(sub)
+	}()
+
+	coins, err := types.ParseDecCoins("1iris")
+	require.NoError(cts.T(), err)
+	result, err := cts.Bank().Send(to, coins, cts.baseTx())
+	require.NoError(cts.T(), err)
+	require.Equal(cts.T(), cts.Chain().Height(), result.Height)
+
+	acc, ok := cts.Chain().Account(to)
+	require.True(cts.T(), ok)
+	require.False(cts.T(), acc.Coins.Empty())
+
+	select {
+	case data := <-ch:
+		require.Equal(cts.T(), result.Hash, data.Hash)
+		require.Equal(cts.T(), to, data.To)
+	case <-time.After(5 * time.Second):
+		cts.T().Fatal("event of the transaction not received")
+	}
+}
+
+func (cts *ChainTestSuite) TestReplayRejected() {
+	coins, err := types.ParseDecCoins("1iris")
+	require.NoError(cts.T(), err)
+	result, err := cts.Bank().Send("faa1hp29kuh22vpjjlnctmyml5s75evsnsd8r4x0mm", coins, cts.baseTx())
+	require.NoError(cts.T(), err)
+
+	hash, err := hex.DecodeString(result.Hash)
+	require.NoError(cts.T(), err)
+	tx, err := cts.Chain().Tx(hash, false)
+	require.NoError(cts.T(), err)
+
+	res, err := cts.Chain().BroadcastTxCommit(tx.Tx)
+	require.NoError(cts.T(), err)
+	require.Equal(cts.T(), uint32(types.InvalidSequence), res.CheckTx.Code)
+}
+
+func (cts *ChainTestSuite) TestRewardsAccrue() {
+	delegator := cts.Account().Address.String()
+	before, err := cts.Distr().QueryRewards(delegator)
+	require.NoError(cts.T(), err)
+	require.NotEmpty(cts.T(), before.Commission)
+
+	cts.Chain().EndBlock(nil)
+	after, err := cts.Distr().QueryRewards(delegator)
+	require.NoError(cts.T(), err)
+	require.True(cts.T(), after.Total[0].Amount.GT(before.Total[0].Amount), "%s <= %s", after.Total, before.Total)
+}
+
+func (cts *ChainTestSuite) TestUnknownQuery() {
+	_, err := cts.Service().QueryDefinition("unknown")
+	require.Error(cts.T(), err)
+}
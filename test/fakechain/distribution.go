@@ -0,0 +1,163 @@
+package fakechain
+
+import (
+	"github.com/irisnet/irishub-sdk-go/modules/distribution"
+	sdk "github.com/irisnet/irishub-sdk-go/types"
+)
+
+// iris minted at each block and shared by the validators in proportion to their tokens
+var blockProvision = sdk.NewDecFromInt(sdk.NewIntWithDecimal(1, int(sdk.IRIS.Scale)))
+
+// WithdrawAddress returns the address to which the rewards of the delegator are paid
+func (s *State) WithdrawAddress(delegator sdk.AccAddress) sdk.AccAddress {
+	if addr, ok := s.withdrawAddrs[delegator.String()]; ok {
+		return addr
+	}
+	return delegator
+}
+
+// allocateProvision gives the commission of each validator to its operator and the rest to its delegators
+func (s *State) allocateProvision() {
+	bonded, _ := s.BondedTokens()
+	if bonded.IsZero() {
+		return
+	}
+
+	for _, val := range s.Validators() {
+		if val.Shares.IsZero() {
+			continue
+		}
+		reward := blockProvision.Mul(val.Tokens).Quo(bonded)
+		commission := reward.Mul(val.Commission)
+		val.AccumCommission = val.AccumCommission.Add(commission)
+
+		remaining := reward.Sub(commission)
+		for _, d := range s.Delegations(func(d *delegation) bool { return d.Validator.Equals(val.Operator) }) {
+			d.Rewards = d.Rewards.Add(remaining.Mul(d.Shares).Quo(val.Shares))
+		}
+	}
+}
+
+// withdrawDelegationRewards pays the whole part of the rewards of the delegation and returns it
+func (s *State) withdrawDelegationRewards(d *delegation) sdk.Coins {
+	amount := d.Rewards.TruncateInt()
+	d.Rewards = d.Rewards.Sub(sdk.NewDecFromInt(amount))
+	return s.pay(s.WithdrawAddress(d.Delegator), amount)
+}
+
+// withdrawCommission pays the whole part of the commission of the validator to its operator and returns it
+func (s *State) withdrawCommission(val *validator) sdk.Coins {
+	amount := val.AccumCommission.TruncateInt()
+	val.AccumCommission = val.AccumCommission.Sub(sdk.NewDecFromInt(amount))
+	return s.pay(s.WithdrawAddress(sdk.AccAddress(val.Operator)), amount)
+}
+
+func (s *State) pay(addr sdk.AccAddress, amount sdk.Int) sdk.Coins {
+	if !amount.IsPositive() {
+		return nil
+	}
+	coins := sdk.NewCoins(sdk.NewCoin(sdk.IRIS.MinUnit, amount))
+	s.Mint(addr, coins)
+	return coins
+}
+
+func registerDistribution(c *Chain) {
+	c.handlers[distribution.ModuleName] = func(ctx Context, msg sdk.Msg) (sdk.Tags, error) {
+		switch msg := msg.(type) {
+		case distribution.MsgSetWithdrawAddress:
+			return handleMsgSetWithdrawAddress(ctx, msg)
+		case distribution.MsgWithdrawDelegatorReward:
+			return handleMsgWithdrawDelegatorReward(ctx, msg)
+		case distribution.MsgWithdrawDelegatorRewardsAll:
+			return handleMsgWithdrawDelegatorRewardsAll(ctx, msg)
+		case distribution.MsgWithdrawValidatorRewardsAll:
+			return handleMsgWithdrawValidatorRewardsAll(ctx, msg)
+		}
+		return nil, NewError(sdk.UnknownRequest, "unrecognized distr message type: %s", msg.Type())
+	}
+	c.queriers["custom/distr/rewards"] = queryRewards
+	c.blockers = append(c.blockers, func(ctx Context) sdk.Tags {
+		ctx.allocateProvision()
+		return nil
+	})
+}
+
+func handleMsgSetWithdrawAddress(ctx Context, msg distribution.MsgSetWithdrawAddress) (sdk.Tags, error) {
+	ctx.withdrawAddrs[msg.DelegatorAddr.String()] = msg.WithdrawAddr
+	return sdk.Tags{
+		{Key: string(sdk.ActionKey), Value: msg.Type()},
+		{Key: "delegator", Value: ctx.AddrPrefixCfg().AccAddressString(msg.DelegatorAddr)},
+	}, nil
+}
+
+func handleMsgWithdrawDelegatorReward(ctx Context, msg distribution.MsgWithdrawDelegatorReward) (sdk.Tags, error) {
+	d, ok := ctx.Delegation(msg.DelegatorAddr, msg.ValidatorAddr)
+	if !ok {
+		return nil, NewError(sdk.InvalidRequest, "no delegation of %s to %s",
+			ctx.AddrPrefixCfg().AccAddressString(msg.DelegatorAddr), ctx.AddrPrefixCfg().ValAddressString(msg.ValidatorAddr))
+	}
+	return withdrawTags(ctx, msg, msg.DelegatorAddr, ctx.withdrawDelegationRewards(d)), nil
+}
+
+func handleMsgWithdrawDelegatorRewardsAll(ctx Context, msg distribution.MsgWithdrawDelegatorRewardsAll) (sdk.Tags, error) {
+	var rewards sdk.Coins
+	for _, d := range ctx.Delegations(func(d *delegation) bool { return d.Delegator.Equals(msg.DelegatorAddr) }) {
+		rewards = rewards.Add(ctx.withdrawDelegationRewards(d)...)
+	}
+	return withdrawTags(ctx, msg, msg.DelegatorAddr, rewards), nil
+}
+
+func handleMsgWithdrawValidatorRewardsAll(ctx Context, msg distribution.MsgWithdrawValidatorRewardsAll) (sdk.Tags, error) {
+	val, ok := ctx.Validator(msg.ValidatorAddr)
+	if !ok {
+		return nil, NewError(sdk.InvalidRequest, "validator %s does not exist", ctx.AddrPrefixCfg().ValAddressString(msg.ValidatorAddr))
+	}
+
+	operator := sdk.AccAddress(msg.ValidatorAddr)
+	rewards := ctx.withdrawCommission(val)
+	for _, d := range ctx.Delegations(func(d *delegation) bool { return d.Delegator.Equals(operator) }) {
+		rewards = rewards.Add(ctx.withdrawDelegationRewards(d)...)
+	}
+	return withdrawTags(ctx, msg, operator, rewards), nil
+}
+
+func withdrawTags(ctx Context, msg sdk.Msg, delegator sdk.AccAddress, rewards sdk.Coins) sdk.Tags {
+	return sdk.Tags{
+		{Key: string(sdk.ActionKey), Value: msg.Type()},
+		{Key: "delegator", Value: ctx.AddrPrefixCfg().AccAddressString(delegator)},
+		{Key: "withdraw-reward-total", Value: rewards.String()},
+	}
+}
+
+func queryRewards(ctx Context, data []byte) ([]byte, error) {
+	var params struct {
+		Address sdk.AccAddress
+	}
+	if err := ctx.DecodeJSON(data, &params); err != nil {
+		return nil, NewError(sdk.ErrJsonUnmarshal, err.Error())
+	}
+
+	type delegationRewards struct {
+		Validator string    `json:"validator"`
+		Reward    sdk.Coins `json:"reward"`
+	}
+	var res struct {
+		Total       sdk.Coins           `json:"total"`
+		Delegations []delegationRewards `json:"delegations"`
+		Commission  sdk.Coins           `json:"commission"`
+	}
+
+	for _, d := range ctx.Delegations(func(d *delegation) bool { return d.Delegator.Equals(params.Address) }) {
+		reward := sdk.NewCoins(sdk.NewCoin(sdk.IRIS.MinUnit, d.Rewards.TruncateInt()))
+		res.Delegations = append(res.Delegations, delegationRewards{
+			Validator: ctx.AddrPrefixCfg().ValAddressString(d.Validator),
+			Reward:    reward,
+		})
+		res.Total = res.Total.Add(reward...)
+	}
+	if val, ok := ctx.Validator(sdk.ValAddress(params.Address)); ok {
+		res.Commission = sdk.NewCoins(sdk.NewCoin(sdk.IRIS.MinUnit, val.AccumCommission.TruncateInt()))
+		res.Total = res.Total.Add(res.Commission...)
+	}
+	return ctx.EncodeJSON(res)
+}
@@ -0,0 +1,173 @@
+package fakechain
+
+import (
+	"context"
+	"fmt"
+
+	abci "github.com/tendermint/tendermint/abci/types"
+	"github.com/tendermint/tendermint/libs/pubsub/query"
+	tmtypes "github.com/tendermint/tendermint/types"
+
+	sdk "github.com/irisnet/irishub-sdk-go/types"
+	"github.com/irisnet/irishub-sdk-go/utils/uuid"
+)
+
+type subscription struct {
+	sdk.Subscription
+	query   *query.Query
+	handler sdk.EventHandler
+}
+
+func (c *Chain) SubscribeNewBlock(builder *sdk.EventQueryBuilder, handler sdk.EventNewBlockHandler) (sdk.Subscription, sdk.Error) {
+	if builder == nil {
+		builder = sdk.NewEventQueryBuilder()
+	}
+	builder.AddCondition(sdk.Cond(sdk.TypeKey).EQ(tmtypes.EventNewBlock))
+	return c.subscribe(builder.Build(), func(data sdk.EventData) {
+		handler(data.(sdk.EventDataNewBlock))
+	})
+}
+
+func (c *Chain) SubscribeTx(builder *sdk.EventQueryBuilder, handler sdk.EventTxHandler) (sdk.Subscription, sdk.Error) {
+	if builder == nil {
+		builder = sdk.NewEventQueryBuilder()
+	}
+	q := builder.AddCondition(sdk.Cond(sdk.TypeKey).EQ(sdk.TxValue)).Build()
+	return c.subscribe(q, func(data sdk.EventData) {
+		handler(data.(sdk.EventDataTx))
+	})
+}
+
+func (c *Chain) SubscribeNewBlockHeader(handler sdk.EventNewBlockHeaderHandler) (sdk.Subscription, sdk.Error) {
+	q := tmtypes.QueryForEvent(tmtypes.EventNewBlockHeader).String()
+	return c.subscribe(q, func(data sdk.EventData) {
+		handler(data.(sdk.EventDataNewBlockHeader))
+	})
+}
+
+// SubscribeValidatorSetUpdates never publishes any event, the validator set of the chain is fixed
+func (c *Chain) SubscribeValidatorSetUpdates(handler sdk.EventValidatorSetUpdatesHandler) (sdk.Subscription, sdk.Error) {
+	q := tmtypes.QueryForEvent(tmtypes.EventValidatorSetUpdates).String()
+	return c.subscribe(q, func(data sdk.EventData) {
+		handler(data.(sdk.EventDataValidatorSetUpdates))
+	})
+}
+
+func (c *Chain) Unsubscribe(subscription sdk.Subscription) sdk.Error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if _, ok := c.subs[subscription.ID]; !ok {
+		return sdk.Wrapf("subscription %s not found", subscription.ID)
+	}
+	delete(c.subs, subscription.ID)
+	return nil
+}
+
+func (c *Chain) subscribe(q string, handler sdk.EventHandler) (sdk.Subscription, sdk.Error) {
+	parsed, err := query.New(q)
+	if err != nil {
+		return sdk.Subscription{}, sdk.Wrap(err)
+	}
+
+	id := "fakechain"
+	if uid, err := uuid.NewV4(); err == nil {
+		id = fmt.Sprintf("%s-%s", id, uid.String())
+	}
+
+	sub := subscription{
+		Subscription: sdk.Subscription{
+			Ctx:   context.Background(),
+			Query: q,
+			ID:    id,
+		},
+		query:   parsed,
+		handler: handler,
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.subs[id] = sub
+	return sub.Subscription, nil
+}
+
+// subscriptions returns a copy of the subscriptions, must be called with the lock held
+func (c *Chain) subscriptions() []subscription {
+	subs := make([]subscription, 0, len(c.subs))
+	for _, sub := range c.subs {
+		subs = append(subs, sub)
+	}
+	return subs
+}
+
+func (c *Chain) publishTx(subs []subscription, txBytes tmtypes.Tx, height int64,
+	tx sdk.StdTx, result abci.ResponseDeliverTx, tags []map[string]string) {
+	data := sdk.EventDataTx{
+		Hash:   fmt.Sprintf("%X", txBytes.Hash()),
+		Height: height,
+		Tx:     tx,
+		Result: sdk.TxResult{
+			Code:      result.Code,
+			Log:       result.Log,
+			GasWanted: result.GasWanted,
+			GasUsed:   result.GasUsed,
+			Tags:      sdk.ParseTags(result.Tags),
+		},
+	}
+
+	for _, sub := range subs {
+		for _, t := range tags {
+			if sub.query.Matches(withEvent(t, tmtypes.EventTx)) {
+				dispatch(sub, data)
+				break
+			}
+		}
+	}
+}
+
+func (c *Chain) publishBlock(subs []subscription, block *tmtypes.Block) {
+	c.mu.RLock()
+	cdc := c.cdc
+	results := c.blocks[block.Height-1].results
+	c.mu.RUnlock()
+
+	beginBlock := sdk.ResultBeginBlock{Tags: sdk.ParseTags(results.BeginBlock.Tags)}
+	endBlock := sdk.ResultEndBlock{Tags: sdk.ParseTags(results.EndBlock.Tags)}
+
+	// as tendermint does, the new block events are matched against the tags of the begin and end blocks
+	tags := make(map[string]string)
+	for _, tag := range append(beginBlock.Tags, endBlock.Tags...) {
+		tags[tag.Key] = tag.Value
+	}
+
+	for _, sub := range subs {
+		switch {
+		case sub.query.Matches(withEvent(tags, tmtypes.EventNewBlock)):
+			dispatch(sub, sdk.EventDataNewBlock{
+				Block:            sdk.ParseBlock(cdc, block),
+				ResultBeginBlock: beginBlock,
+				ResultEndBlock:   endBlock,
+			})
+		case sub.query.Matches(withEvent(tags, tmtypes.EventNewBlockHeader)):
+			dispatch(sub, sdk.EventDataNewBlockHeader{
+				Header:           block.Header,
+				ResultBeginBlock: beginBlock,
+				ResultEndBlock:   endBlock,
+			})
+		}
+	}
+}
+
+func withEvent(tags map[string]string, event string) map[string]string {
+	m := map[string]string{string(sdk.TypeKey): event}
+	for k, v := range tags {
+		m[k] = v
+	}
+	return m
+}
+
+func dispatch(sub subscription, data sdk.EventData) {
+	go func() {
+		defer sdk.CatchPanic(func(errMsg string) {})
+		sub.handler(data)
+	}()
+}
@@ -0,0 +1,254 @@
+package fakechain
+
+import (
+	"encoding/json"
+	"regexp"
+	"time"
+
+	"github.com/irisnet/irishub-sdk-go/modules/bank"
+	"github.com/irisnet/irishub-sdk-go/modules/service"
+	"github.com/irisnet/irishub-sdk-go/modules/slashing"
+	"github.com/irisnet/irishub-sdk-go/modules/staking"
+	sdk "github.com/irisnet/irishub-sdk-go/types"
+)
+
+const (
+	defaultTxSizeLimit        = 1000
+	defaultServiceTxSizeLimit = 4000
+)
+
+var (
+	// issue fee of a token whose symbol has 3 characters, the mint fee is 10% of it
+	defaultIssueFee = sdk.NewCoin(sdk.IRIS.MinUnit, sdk.NewIntWithDecimal(60000, int(sdk.IRIS.Scale)))
+)
+
+func defaultParams() map[string]interface{} {
+	return map[string]interface{}{
+		"auth": &bank.Params{
+			GasPriceThreshold: sdk.NewIntWithDecimal(6, 12),
+			TxSizeLimit:       defaultTxSizeLimit,
+		},
+		service.ModuleName: &service.Params{
+			MaxRequestTimeout:    100,
+			MinDepositMultiple:   200,
+			MinDeposit:           sdk.NewCoins(sdk.NewCoin(sdk.IRIS.MinUnit, sdk.NewIntWithDecimal(6000, int(sdk.IRIS.Scale)))),
+			ServiceFeeTax:        sdk.NewDecWithPrec(1, 1),
+			SlashFraction:        sdk.NewDecWithPrec(1, 3),
+			ComplaintRetrospect:  15 * 24 * time.Hour,
+			ArbitrationTimeLimit: 5 * 24 * time.Hour,
+			TxSizeLimit:          defaultServiceTxSizeLimit,
+		},
+		staking.ModuleName: registered{
+			Name: "irishub/stake/Params",
+			Value: stakeParams{
+				UnbondingTime: defaultUnbondingTime,
+				MaxValidators: defaultMaxValidators,
+			},
+		},
+		slashing.ModuleName: registered{
+			Name:  "irishub/slashing/Params",
+			Value: defaultSlashingParams,
+		},
+	}
+}
+
+func registerBank(c *Chain) {
+	c.handlers[bank.ModuleName] = func(ctx Context, msg sdk.Msg) (sdk.Tags, error) {
+		switch msg := msg.(type) {
+		case bank.MsgSend:
+			return handleMsgSend(ctx, msg)
+		case bank.MsgBurn:
+			return handleMsgBurn(ctx, msg)
+		case bank.MsgSetMemoRegexp:
+			return handleMsgSetMemoRegexp(ctx, msg)
+		}
+		return nil, NewError(sdk.UnknownRequest, "unrecognized bank message type: %s", msg.Type())
+	}
+}
+
+func handleMsgSend(ctx Context, msg bank.MsgSend) (tags sdk.Tags, err error) {
+	for _, out := range msg.Outputs {
+		if acc, ok := ctx.Account(out.Address); ok && len(acc.MemoRegexp) > 0 {
+			if matched, _ := regexp.MatchString(acc.MemoRegexp, ctx.Memo); !matched {
+				return nil, NewError(sdk.InvalidRequest, "memo does not match %s", acc.MemoRegexp)
+			}
+		}
+	}
+
+	for _, in := range msg.Inputs {
+		if err := ctx.SubtractCoins(in.Address, in.Coins); err != nil {
+			return nil, err
+		}
+		tags = append(tags, sdk.Tag{Key: string(sdk.SenderKey), Value: ctx.AddrPrefixCfg().AccAddressString(in.Address)})
+	}
+	for _, out := range msg.Outputs {
+		ctx.AddCoins(out.Address, out.Coins)
+		tags = append(tags, sdk.Tag{Key: string(sdk.RecipientKey), Value: ctx.AddrPrefixCfg().AccAddressString(out.Address)})
+	}
+	return append(tags, sdk.Tag{Key: string(sdk.ActionKey), Value: msg.Type()}), nil
+}
+
+func handleMsgBurn(ctx Context, msg bank.MsgBurn) (sdk.Tags, error) {
+	if err := ctx.Burn(msg.Owner, msg.Coins); err != nil {
+		return nil, err
+	}
+	return sdk.Tags{
+		{Key: string(sdk.ActionKey), Value: msg.Type()},
+		{Key: string(sdk.SenderKey), Value: ctx.AddrPrefixCfg().AccAddressString(msg.Owner)},
+	}, nil
+}
+
+func handleMsgSetMemoRegexp(ctx Context, msg bank.MsgSetMemoRegexp) (sdk.Tags, error) {
+	acc, ok := ctx.Account(msg.Owner)
+	if !ok {
+		return nil, NewError(sdk.UnknownAddress, "account %s does not exist", ctx.AddrPrefixCfg().AccAddressString(msg.Owner))
+	}
+	acc.MemoRegexp = msg.MemoRegexp
+	return sdk.Tags{
+		{Key: string(sdk.ActionKey), Value: msg.Type()},
+		{Key: string(sdk.SenderKey), Value: ctx.AddrPrefixCfg().AccAddressString(msg.Owner)},
+	}, nil
+}
+
+func registerQueriers(c *Chain) {
+	c.queriers["custom/acc/account"] = queryAccount
+	c.queriers["custom/acc/tokenStats"] = queryTokenStats
+	c.queriers["custom/params/module"] = queryParams
+	c.queriers["custom/asset/token"] = queryToken
+	c.queriers["custom/asset/tokens"] = queryTokens
+	c.queriers["custom/asset/fees"] = queryTokenFees
+}
+
+func queryAccount(ctx Context, data []byte) ([]byte, error) {
+	var params struct {
+		Address sdk.AccAddress
+	}
+	if err := ctx.DecodeJSON(data, &params); err != nil {
+		return nil, NewError(sdk.ErrJsonUnmarshal, err.Error())
+	}
+
+	acc, ok := ctx.Account(params.Address)
+	if !ok {
+		return nil, NewError(sdk.UnknownAddress, "account %s does not exist", ctx.AddrPrefixCfg().AccAddressString(params.Address))
+	}
+	return ctx.EncodeJSON(acc)
+}
+
+func queryTokenStats(ctx Context, data []byte) ([]byte, error) {
+	var params struct {
+		TokenId string
+	}
+	if err := json.Unmarshal(data, &params); err != nil {
+		return nil, NewError(sdk.ErrJsonUnmarshal, err.Error())
+	}
+
+	supply, burned := ctx.Supply()
+	if len(params.TokenId) > 0 {
+		token, ok := ctx.Token(params.TokenId)
+		if !ok {
+			return nil, NewError(sdk.InvalidCoins, "token %s does not exist", params.TokenId)
+		}
+		supply, burned = filterDenom(supply, token.GetMinUnit()), filterDenom(burned, token.GetMinUnit())
+	}
+
+	loose, _ := supply.SafeAdd(negative(burned))
+	return ctx.EncodeJSON(struct {
+		LooseTokens  sdk.Coins `json:"loose_tokens"`
+		BondedTokens sdk.Coins `json:"bonded_tokens"`
+		BurnedTokens sdk.Coins `json:"burned_tokens"`
+		TotalSupply  sdk.Coins `json:"total_supply"`
+	}{
+		LooseTokens:  loose,
+		BurnedTokens: burned,
+		TotalSupply:  supply,
+	})
+}
+
+func queryParams(ctx Context, data []byte) ([]byte, error) {
+	var params struct {
+		Module string
+	}
+	if err := json.Unmarshal(data, &params); err != nil {
+		return nil, NewError(sdk.ErrJsonUnmarshal, err.Error())
+	}
+
+	p, ok := ctx.Params(params.Module)
+	if !ok {
+		return nil, NewError(sdk.UnknownRequest, "params of module %s not found", params.Module)
+	}
+	if r, ok := p.(registered); ok {
+		return ctx.encodeRegistered(r.Name, r.Value)
+	}
+	return ctx.EncodeJSON(p)
+}
+
+func queryToken(ctx Context, data []byte) ([]byte, error) {
+	var params struct {
+		Symbol string
+	}
+	if err := json.Unmarshal(data, &params); err != nil {
+		return nil, NewError(sdk.ErrJsonUnmarshal, err.Error())
+	}
+
+	token, ok := ctx.Token(params.Symbol)
+	if !ok {
+		return nil, NewError(sdk.InvalidCoins, "token %s does not exist", params.Symbol)
+	}
+	return ctx.EncodeJSON(token)
+}
+
+func queryTokens(ctx Context, data []byte) ([]byte, error) {
+	var params struct {
+		Owner string
+	}
+	if err := json.Unmarshal(data, &params); err != nil {
+		return nil, NewError(sdk.ErrJsonUnmarshal, err.Error())
+	}
+
+	tokens := sdk.Tokens{}
+	for _, t := range ctx.Tokens() {
+		if len(params.Owner) == 0 || t.Owner == params.Owner {
+			tokens = append(tokens, t)
+		}
+	}
+	return ctx.EncodeJSON(tokens)
+}
+
+func queryTokenFees(ctx Context, data []byte) ([]byte, error) {
+	var params struct {
+		Symbol string
+	}
+	if err := json.Unmarshal(data, &params); err != nil {
+		return nil, NewError(sdk.ErrJsonUnmarshal, err.Error())
+	}
+
+	_, exist := ctx.Token(params.Symbol)
+	mintFee := sdk.NewCoin(defaultIssueFee.Denom, defaultIssueFee.Amount.DivRaw(10))
+	return ctx.EncodeJSON(struct {
+		Exist    bool     `json:"exist"`
+		IssueFee sdk.Coin `json:"issue_fee"`
+		MintFee  sdk.Coin `json:"mint_fee"`
+	}{
+		Exist:    exist,
+		IssueFee: defaultIssueFee,
+		MintFee:  mintFee,
+	})
+}
+
+func filterDenom(coins sdk.Coins, denom string) sdk.Coins {
+	var filtered sdk.Coins
+	for _, c := range coins {
+		if c.Denom == denom {
+			filtered = append(filtered, c)
+		}
+	}
+	return filtered
+}
+
+func amountOf(coins sdk.Coins, denom string) sdk.Int {
+	amount := sdk.ZeroInt()
+	for _, c := range filterDenom(coins, denom) {
+		amount = amount.Add(c.Amount)
+	}
+	return amount
+}
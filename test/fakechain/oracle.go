@@ -0,0 +1,342 @@
+package fakechain
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	cmn "github.com/tendermint/tendermint/libs/common"
+
+	"github.com/irisnet/irishub-sdk-go/modules/oracle"
+	sdk "github.com/irisnet/irishub-sdk-go/types"
+)
+
+// feed aggregates the responses of each batch of its request context into a value, its state is that of the request context
+type feed struct {
+	FeedName         string       `json:"feed_name"`
+	Description      string       `json:"description"`
+	AggregateFunc    string       `json:"aggregate_func"`
+	ValueJsonPath    string       `json:"value_json_path"`
+	LatestHistory    uint64       `json:"latest_history"`
+	RequestContextID cmn.HexBytes `json:"request_context_id"`
+	Creator          string       `json:"creator"`
+
+	creator sdk.AccAddress
+	// the latest values first
+	values []feedValue
+}
+
+type feedValue struct {
+	Data      string    `json:"data"`
+	Timestamp time.Time `json:"timestamp"`
+}
+
+type feedContext struct {
+	Feed              feed      `json:"feed"`
+	ServiceName       string    `json:"service_name"`
+	Providers         []string  `json:"providers"`
+	Input             string    `json:"input"`
+	Timeout           int64     `json:"timeout"`
+	ServiceFeeCap     sdk.Coins `json:"service_fee_cap"`
+	RepeatedFrequency uint64    `json:"repeated_frequency"`
+	ResponseThreshold uint16    `json:"response_threshold"`
+	State             string    `json:"state"`
+}
+
+// aggregateFuncs are those of IRIShub, the values are the numbers found at the value json path of the responses
+var aggregateFuncs = map[string]func(values []float64) float64{
+	"max": func(values []float64) float64 {
+		max := values[0]
+		for _, v := range values[1:] {
+			if v > max {
+				max = v
+			}
+		}
+		return max
+	},
+	"min": func(values []float64) float64 {
+		min := values[0]
+		for _, v := range values[1:] {
+			if v < min {
+				min = v
+			}
+		}
+		return min
+	},
+	"avg": func(values []float64) float64 {
+		var sum float64
+		for _, v := range values {
+			sum += v
+		}
+		return sum / float64(len(values))
+	},
+}
+
+func registerOracle(c *Chain) {
+	c.handlers[oracle.ModuleName] = func(ctx Context, msg sdk.Msg) (sdk.Tags, error) {
+		switch msg := msg.(type) {
+		case oracle.MsgCreateFeed:
+			return handleMsgCreateFeed(ctx, msg)
+		case oracle.MsgStartFeed:
+			return handleMsgStartFeed(ctx, msg)
+		case oracle.MsgPauseFeed:
+			return handleMsgPauseFeed(ctx, msg)
+		case oracle.MsgEditFeed:
+			return handleMsgEditFeed(ctx, msg)
+		}
+		return nil, NewError(sdk.UnknownRequest, "unrecognized oracle message type: %s", msg.Type())
+	}
+	c.queriers["custom/oracle/feed"] = queryFeed
+	c.queriers["custom/oracle/feeds"] = queryFeeds
+	c.queriers["custom/oracle/feedValue"] = queryFeedValue
+}
+
+// handleMsgCreateFeed saves a paused feed whose request context is owned by the module, only the guardians may create feeds
+func handleMsgCreateFeed(ctx Context, msg oracle.MsgCreateFeed) (sdk.Tags, error) {
+	if !ctx.guardians[msg.Creator.String()] {
+		return nil, NewError(sdk.Unauthorized, "%s is not a profiler", ctx.AddrPrefixCfg().AccAddressString(msg.Creator))
+	}
+	if _, ok := ctx.feeds[msg.FeedName]; ok {
+		return nil, NewError(sdk.InvalidRequest, "feed %s already exists", msg.FeedName)
+	}
+	if _, ok := aggregateFuncs[msg.AggregateFunc]; !ok {
+		return nil, NewError(sdk.InvalidRequest, "aggregate function %s does not exist", msg.AggregateFunc)
+	}
+	if msg.LatestHistory == 0 {
+		return nil, NewError(sdk.InvalidRequest, "latest history must be positive")
+	}
+
+	reqCtx, err := newRequestContext(ctx, msg.ServiceName, msg.Providers, msg.Creator, msg.Input,
+		msg.ServiceFeeCap, msg.Timeout, true, msg.RepeatedFrequency, -1)
+	if err != nil {
+		return nil, err
+	}
+	reqCtx.ResponseThreshold = msg.ResponseThreshold
+	reqCtx.ModuleName = oracle.ModuleName
+
+	ctx.feeds[msg.FeedName] = &feed{
+		FeedName:         msg.FeedName,
+		Description:      msg.Description,
+		AggregateFunc:    msg.AggregateFunc,
+		ValueJsonPath:    msg.ValueJsonPath,
+		LatestHistory:    msg.LatestHistory,
+		RequestContextID: reqCtx.id,
+		Creator:          ctx.AddrPrefixCfg().AccAddressString(msg.Creator),
+		creator:          msg.Creator,
+	}
+	return feedTags(msg, msg.FeedName), nil
+}
+
+func handleMsgStartFeed(ctx Context, msg oracle.MsgStartFeed) (sdk.Tags, error) {
+	_, reqCtx, err := ownFeed(ctx, msg.FeedName, msg.Creator)
+	if err != nil {
+		return nil, err
+	}
+	if reqCtx.State != pausedState {
+		return nil, NewError(sdk.InvalidRequest, "feed %s is not paused", msg.FeedName)
+	}
+	reqCtx.State = runningState
+	reqCtx.nextBatchHeight = ctx.Height
+	return feedTags(msg, msg.FeedName), nil
+}
+
+func handleMsgPauseFeed(ctx Context, msg oracle.MsgPauseFeed) (sdk.Tags, error) {
+	_, reqCtx, err := ownFeed(ctx, msg.FeedName, msg.Creator)
+	if err != nil {
+		return nil, err
+	}
+	if reqCtx.State != runningState {
+		return nil, NewError(sdk.InvalidRequest, "feed %s is not running", msg.FeedName)
+	}
+	reqCtx.State = pausedState
+	reqCtx.nextBatchHeight = 0
+	return feedTags(msg, msg.FeedName), nil
+}
+
+func handleMsgEditFeed(ctx Context, msg oracle.MsgEditFeed) (sdk.Tags, error) {
+	f, reqCtx, err := ownFeed(ctx, msg.FeedName, msg.Creator)
+	if err != nil {
+		return nil, err
+	}
+	if err := updateRequestContext(ctx, reqCtx, msg.Providers, msg.ServiceFeeCap, msg.Timeout,
+		msg.RepeatedFrequency, 0); err != nil {
+		return nil, err
+	}
+	if msg.ResponseThreshold > 0 {
+		reqCtx.ResponseThreshold = msg.ResponseThreshold
+	}
+	if len(msg.Description) > 0 {
+		f.Description = msg.Description
+	}
+	if msg.LatestHistory > 0 {
+		f.LatestHistory = msg.LatestHistory
+		if uint64(len(f.values)) > f.LatestHistory {
+			f.values = f.values[:f.LatestHistory]
+		}
+	}
+	return feedTags(msg, msg.FeedName), nil
+}
+
+// updateFeedValue aggregates the responses of the completed batch into a new value of the feed of the request context,
+// provided the responses reach the threshold. The value is published in the tags.
+func (ctx Context) updateFeedValue(reqCtx *requestContext) sdk.Tags {
+	var f *feed
+	for _, fd := range ctx.feeds {
+		if bytes.Equal(fd.RequestContextID, reqCtx.id) {
+			f = fd
+		}
+	}
+	if f == nil {
+		return nil
+	}
+
+	var values []float64
+	for _, req := range ctx.serviceRequestsOf(func(req *serviceRequest) bool {
+		return bytes.Equal(req.RequestContextID, reqCtx.id) && req.RequestContextBatchCounter == reqCtx.BatchCounter
+	}) {
+		resp, ok := ctx.serviceResponses[req.ID]
+		if !ok {
+			continue
+		}
+		if v, err := valueAt(resp.Output, f.ValueJsonPath); err == nil {
+			values = append(values, v)
+		}
+	}
+	if len(values) == 0 || len(values) < int(reqCtx.ResponseThreshold) {
+		return nil
+	}
+
+	value := feedValue{
+		Data:      strconv.FormatFloat(aggregateFuncs[f.AggregateFunc](values), 'f', -1, 64),
+		Timestamp: ctx.Time,
+	}
+	f.values = append([]feedValue{value}, f.values...)
+	if uint64(len(f.values)) > f.LatestHistory {
+		f.values = f.values[:f.LatestHistory]
+	}
+
+	bz, err := ctx.EncodeJSON(value)
+	if err != nil {
+		return nil
+	}
+	return sdk.Tags{
+		{Key: "feed-name", Value: f.FeedName},
+		{Key: fmt.Sprintf("feed-name.%s", f.FeedName), Value: string(bz)},
+	}
+}
+
+// valueAt returns the number found at the dot separated path of the JSON output, the number may be quoted
+func valueAt(output, path string) (float64, error) {
+	var v interface{}
+	if err := json.Unmarshal([]byte(output), &v); err != nil {
+		return 0, err
+	}
+	for _, key := range strings.Split(path, ".") {
+		m, ok := v.(map[string]interface{})
+		if !ok {
+			return 0, fmt.Errorf("no value at %s", path)
+		}
+		v = m[key]
+	}
+
+	switch v := v.(type) {
+	case float64:
+		return v, nil
+	case string:
+		return strconv.ParseFloat(v, 64)
+	}
+	return 0, fmt.Errorf("no number at %s", path)
+}
+
+func ownFeed(ctx Context, feedName string, creator sdk.AccAddress) (*feed, *requestContext, error) {
+	f, ok := ctx.feeds[feedName]
+	if !ok {
+		return nil, nil, NewError(sdk.InvalidRequest, "feed %s does not exist", feedName)
+	}
+	if !f.creator.Equals(creator) {
+		return nil, nil, NewError(sdk.Unauthorized, "feed %s is not owned by %s",
+			feedName, ctx.AddrPrefixCfg().AccAddressString(creator))
+	}
+	return f, ctx.requestContexts[f.RequestContextID.String()], nil
+}
+
+func feedTags(msg sdk.Msg, feedName string) sdk.Tags {
+	return sdk.Tags{
+		{Key: string(sdk.ActionKey), Value: msg.Type()},
+		{Key: "feed-name", Value: feedName},
+	}
+}
+
+func (ctx Context) feedContext(f *feed) feedContext {
+	reqCtx := ctx.requestContexts[f.RequestContextID.String()]
+	return feedContext{
+		Feed:              *f,
+		ServiceName:       reqCtx.ServiceName,
+		Providers:         ctx.AddrPrefixCfg().AccAddressStrings(reqCtx.Providers),
+		Input:             reqCtx.Input,
+		Timeout:           reqCtx.Timeout,
+		ServiceFeeCap:     reqCtx.ServiceFeeCap,
+		RepeatedFrequency: reqCtx.RepeatedFrequency,
+		ResponseThreshold: reqCtx.ResponseThreshold,
+		State:             reqCtx.State,
+	}
+}
+
+func queryFeed(ctx Context, data []byte) ([]byte, error) {
+	var params struct {
+		FeedName string
+	}
+	if err := ctx.DecodeJSON(data, &params); err != nil {
+		return nil, NewError(sdk.ErrJsonUnmarshal, err.Error())
+	}
+
+	f, ok := ctx.feeds[params.FeedName]
+	if !ok {
+		return nil, NewError(sdk.InvalidRequest, "feed %s does not exist", params.FeedName)
+	}
+	return ctx.encodeRegistered("irishub/oracle/FeedContext", ctx.feedContext(f))
+}
+
+func queryFeeds(ctx Context, data []byte) ([]byte, error) {
+	var params struct {
+		State string
+	}
+	if err := ctx.DecodeJSON(data, &params); err != nil {
+		return nil, NewError(sdk.ErrJsonUnmarshal, err.Error())
+	}
+
+	names := make([]string, 0, len(ctx.feeds))
+	for name := range ctx.feeds {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	fcs := []feedContext{}
+	for _, name := range names {
+		fc := ctx.feedContext(ctx.feeds[name])
+		if len(params.State) == 0 || fc.State == params.State {
+			fcs = append(fcs, fc)
+		}
+	}
+	return ctx.EncodeJSON(fcs)
+}
+
+func queryFeedValue(ctx Context, data []byte) ([]byte, error) {
+	var params struct {
+		FeedName string
+	}
+	if err := ctx.DecodeJSON(data, &params); err != nil {
+		return nil, NewError(sdk.ErrJsonUnmarshal, err.Error())
+	}
+
+	f, ok := ctx.feeds[params.FeedName]
+	if !ok {
+		return nil, NewError(sdk.InvalidRequest, "feed %s does not exist", params.FeedName)
+	}
+	values := append([]feedValue{}, f.values...)
+	return ctx.EncodeJSON(values)
+}
@@ -0,0 +1,164 @@
+package fakechain
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
+	"math/big"
+	"sort"
+	"strconv"
+
+	"github.com/irisnet/irishub-sdk-go/modules/random"
+	sdk "github.com/irisnet/irishub-sdk-go/types"
+)
+
+// digits of the fractional part of the random numbers
+const randPrecision = 20
+
+// randRequest is the request of a random number, generated at the beginning of the block of the generate height
+type randRequest struct {
+	ID             string
+	Height         int64
+	Consumer       sdk.AccAddress
+	TxHash         []byte
+	ServiceFeeCap  sdk.Coins
+	GenerateHeight int64
+
+	// the random number, empty until it is generated
+	Value string
+}
+
+// randRequestsOf returns the requests which match the filter, sorted by id
+func (s *State) randRequestsOf(filter func(req *randRequest) bool) []*randRequest {
+	ids := make([]string, 0, len(s.randRequests))
+	for id, req := range s.randRequests {
+		if filter(req) {
+			ids = append(ids, id)
+		}
+	}
+	sort.Strings(ids)
+
+	reqs := make([]*randRequest, len(ids))
+	for i, id := range ids {
+		reqs[i] = s.randRequests[id]
+	}
+	return reqs
+}
+
+func registerRandom(c *Chain) {
+	c.handlers[random.ModuleName] = func(ctx Context, msg sdk.Msg) (sdk.Tags, error) {
+		if msg, ok := msg.(random.MsgRequestRand); ok {
+			return handleMsgRequestRand(ctx, msg)
+		}
+		return nil, NewError(sdk.UnknownRequest, "unrecognized random message type: %s", msg.Type())
+	}
+	c.queriers["custom/rand/rand"] = queryRand
+	c.queriers["custom/rand/queue"] = queryRandQueue
+	c.blockers = append(c.blockers, generateRands)
+}
+
+// handleMsgRequestRand queues the request, the random numbers requested from the oracle
+// need the providers of the random service which the chain does not bind
+func handleMsgRequestRand(ctx Context, msg random.MsgRequestRand) (sdk.Tags, error) {
+	if msg.Oracle {
+		return nil, NewError(sdk.InvalidRequest, "random numbers from the oracle are not supported")
+	}
+
+	interval := int64(msg.BlockInterval)
+	if interval <= 0 {
+		interval = 1
+	}
+
+	var heightBz [8]byte
+	binary.BigEndian.PutUint64(heightBz[:], uint64(ctx.Height))
+	id := sha256.Sum256(append(append(heightBz[:], msg.Consumer...), ctx.TxHash...))
+	req := &randRequest{
+		ID:             hex.EncodeToString(id[:]),
+		Height:         ctx.Height,
+		Consumer:       msg.Consumer,
+		TxHash:         ctx.TxHash,
+		ServiceFeeCap:  msg.ServiceFeeCap,
+		GenerateHeight: ctx.Height + interval,
+	}
+	ctx.randRequests[req.ID] = req
+
+	return sdk.Tags{
+		{Key: string(sdk.ActionKey), Value: msg.Type()},
+		{Key: "consumer", Value: ctx.AddrPrefixCfg().AccAddressString(msg.Consumer)},
+		{Key: "request-id", Value: req.ID},
+		{Key: "generate-height", Value: strconv.FormatInt(req.GenerateHeight, 10)},
+	}, nil
+}
+
+// generateRands generates the random numbers of the requests of the block, derived from the request tx hash and the height
+func generateRands(ctx Context) (tags sdk.Tags) {
+	for _, req := range ctx.randRequestsOf(func(req *randRequest) bool { return req.GenerateHeight == ctx.Height }) {
+		var heightBz [8]byte
+		binary.BigEndian.PutUint64(heightBz[:], uint64(ctx.Height))
+		seed := sha256.Sum256(append(append([]byte{}, req.TxHash...), heightBz[:]...))
+
+		precision := new(big.Int).Exp(big.NewInt(10), big.NewInt(randPrecision), nil)
+		fraction := new(big.Int).Mod(new(big.Int).SetBytes(seed[:]), precision)
+		req.Value = fmt.Sprintf("0.%0*s", randPrecision, fraction.String())
+
+		tags = append(tags,
+			sdk.Tag{Key: "request-id", Value: req.ID},
+			sdk.Tag{Key: fmt.Sprintf("rand.%s", req.ID), Value: req.Value},
+		)
+	}
+	return tags
+}
+
+func queryRand(ctx Context, data []byte) ([]byte, error) {
+	var params struct {
+		ReqID string
+	}
+	if err := ctx.DecodeJSON(data, &params); err != nil {
+		return nil, NewError(sdk.ErrJsonUnmarshal, err.Error())
+	}
+
+	req, ok := ctx.randRequests[params.ReqID]
+	if !ok || len(req.Value) == 0 {
+		return nil, NewError(sdk.InvalidRequest, "random number of request %s does not exist", params.ReqID)
+	}
+	return ctx.encodeRegistered("irishub/rand/Rand", struct {
+		RequestTxHash []byte `json:"request_tx_hash"`
+		Height        int64  `json:"height"`
+		Value         string `json:"value"`
+	}{
+		RequestTxHash: req.TxHash,
+		Height:        req.GenerateHeight,
+		Value:         req.Value,
+	})
+}
+
+// queryRandQueue returns the requests whose random numbers are generated at the height, or all the pending ones
+func queryRandQueue(ctx Context, data []byte) ([]byte, error) {
+	var params struct {
+		Height int64
+	}
+	if err := ctx.DecodeJSON(data, &params); err != nil {
+		return nil, NewError(sdk.ErrJsonUnmarshal, err.Error())
+	}
+
+	type request struct {
+		Height        int64     `json:"height"`
+		Consumer      string    `json:"consumer"`
+		TxHash        []byte    `json:"txhash"`
+		Oracle        bool      `json:"oracle"`
+		ServiceFeeCap sdk.Coins `json:"service_fee_cap"`
+	}
+	res := []request{}
+	for _, req := range ctx.randRequestsOf(func(req *randRequest) bool {
+		return len(req.Value) == 0 && (params.Height == 0 || req.GenerateHeight == params.Height)
+	}) {
+		res = append(res, request{
+			Height:        req.Height,
+			Consumer:      ctx.AddrPrefixCfg().AccAddressString(req.Consumer),
+			TxHash:        req.TxHash,
+			ServiceFeeCap: req.ServiceFeeCap,
+		})
+	}
+	return ctx.EncodeJSON(res)
+}
@@ -0,0 +1,969 @@
+package fakechain
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	cmn "github.com/tendermint/tendermint/libs/common"
+
+	"github.com/irisnet/irishub-sdk-go/modules/oracle"
+	"github.com/irisnet/irishub-sdk-go/modules/service"
+	sdk "github.com/irisnet/irishub-sdk-go/types"
+)
+
+const (
+	runningState   = "running"
+	pausedState    = "paused"
+	completedState = "completed"
+
+	actionNewBatchRequest = "new-batch-request"
+)
+
+type serviceDefinition struct {
+	Name              string         `json:"name"`
+	Description       string         `json:"description"`
+	Tags              []string       `json:"tags"`
+	Author            sdk.AccAddress `json:"author"`
+	AuthorDescription string         `json:"author_description"`
+	Schemas           string         `json:"schemas"`
+}
+
+type serviceBinding struct {
+	ServiceName     string         `json:"service_name"`
+	Provider        sdk.AccAddress `json:"provider"`
+	Deposit         sdk.Coins      `json:"deposit"`
+	Pricing         string         `json:"pricing"`
+	WithdrawAddress sdk.AccAddress `json:"withdraw_address"`
+	Available       bool           `json:"available"`
+	DisabledTime    time.Time      `json:"disabled_time"`
+}
+
+// requestContext starts a batch of requests, one per provider, every repeated frequency blocks while it is running
+type requestContext struct {
+	ServiceName        string           `json:"service_name"`
+	Providers          []sdk.AccAddress `json:"providers"`
+	Consumer           sdk.AccAddress   `json:"consumer"`
+	Input              string           `json:"input"`
+	ServiceFeeCap      sdk.Coins        `json:"service_fee_cap"`
+	Timeout            int64            `json:"timeout"`
+	SuperMode          bool             `json:"super_mode"`
+	Repeated           bool             `json:"repeated"`
+	RepeatedFrequency  uint64           `json:"repeated_frequency"`
+	RepeatedTotal      int64            `json:"repeated_total"`
+	BatchCounter       uint64           `json:"batch_counter"`
+	BatchRequestCount  uint16           `json:"batch_request_count"`
+	BatchResponseCount uint16           `json:"batch_response_count"`
+	BatchState         string           `json:"batch_state"`
+	State              string           `json:"state"`
+	ResponseThreshold  uint16           `json:"response_threshold"`
+	ModuleName         string           `json:"module_name"`
+
+	id cmn.HexBytes
+	// height at which the next batch starts, 0 if none is scheduled
+	nextBatchHeight int64
+}
+
+type serviceRequest struct {
+	ID                         string         `json:"id"`
+	ServiceName                string         `json:"service_name"`
+	Provider                   sdk.AccAddress `json:"provider"`
+	Consumer                   sdk.AccAddress `json:"consumer"`
+	Input                      string         `json:"input"`
+	ServiceFee                 sdk.Coins      `json:"service_fee"`
+	SuperMode                  bool           `json:"super_mode"`
+	RequestHeight              int64          `json:"request_height"`
+	ExpirationHeight           int64          `json:"expiration_height"`
+	RequestContextID           cmn.HexBytes   `json:"request_context_id"`
+	RequestContextBatchCounter uint64         `json:"request_context_batch_counter"`
+
+	// the request is done once it is responded or expired, its service fee is then paid or refunded
+	done bool
+}
+
+type serviceResponse struct {
+	Provider                   sdk.AccAddress `json:"provider"`
+	Consumer                   sdk.AccAddress `json:"consumer"`
+	Output                     string         `json:"output"`
+	Result                     string         `json:"error"`
+	RequestContextID           cmn.HexBytes   `json:"request_context_id"`
+	RequestContextBatchCounter uint64         `json:"request_context_batch_counter"`
+}
+
+func serviceParams(ctx Context) *service.Params {
+	params, _ := ctx.Params(service.ModuleName)
+	return params.(*service.Params)
+}
+
+func bindingKey(serviceName string, provider sdk.AccAddress) string {
+	return fmt.Sprintf("%s/%s", serviceName, provider.String())
+}
+
+// newRequestContextID returns the id of the request context created by the next message of the transaction,
+// the hash of the transaction followed by the index of the message
+func (s *State) newRequestContextID(txHash []byte) cmn.HexBytes {
+	var index uint64
+	for _, reqCtx := range s.requestContexts {
+		if bytes.HasPrefix(reqCtx.id, txHash) {
+			index++
+		}
+	}
+
+	id := make([]byte, len(txHash)+8)
+	copy(id, txHash)
+	binary.BigEndian.PutUint64(id[len(txHash):], index)
+	return id
+}
+
+// requestContextsOf returns the request contexts which match the filter, sorted by id
+func (s *State) requestContextsOf(filter func(reqCtx *requestContext) bool) []*requestContext {
+	ids := make([]string, 0, len(s.requestContexts))
+	for id, reqCtx := range s.requestContexts {
+		if filter(reqCtx) {
+			ids = append(ids, id)
+		}
+	}
+	sort.Strings(ids)
+
+	reqCtxs := make([]*requestContext, len(ids))
+	for i, id := range ids {
+		reqCtxs[i] = s.requestContexts[id]
+	}
+	return reqCtxs
+}
+
+// serviceRequestsOf returns the requests which match the filter, sorted by id
+func (s *State) serviceRequestsOf(filter func(req *serviceRequest) bool) []*serviceRequest {
+	ids := make([]string, 0, len(s.serviceRequests))
+	for id, req := range s.serviceRequests {
+		if filter(req) {
+			ids = append(ids, id)
+		}
+	}
+	sort.Strings(ids)
+
+	reqs := make([]*serviceRequest, len(ids))
+	for i, id := range ids {
+		reqs[i] = s.serviceRequests[id]
+	}
+	return reqs
+}
+
+// ServiceWithdrawAddress returns the address to which the earned fees of the provider are paid
+func (s *State) ServiceWithdrawAddress(provider sdk.AccAddress) sdk.AccAddress {
+	if addr, ok := s.serviceWithdrawAddrs[provider.String()]; ok {
+		return addr
+	}
+	return provider
+}
+
+func registerService(c *Chain) {
+	c.handlers[service.ModuleName] = func(ctx Context, msg sdk.Msg) (sdk.Tags, error) {
+		switch msg := msg.(type) {
+		case service.MsgDefineService:
+			return handleMsgDefineService(ctx, msg)
+		case service.MsgBindService:
+			return handleMsgBindService(ctx, msg)
+		case service.MsgUpdateServiceBinding:
+			return handleMsgUpdateServiceBinding(ctx, msg)
+		case service.MsgDisableServiceBinding:
+			return handleMsgDisableServiceBinding(ctx, msg)
+		case service.MsgEnableServiceBinding:
+			return handleMsgEnableServiceBinding(ctx, msg)
+		case service.MsgRefundServiceDeposit:
+			return handleMsgRefundServiceDeposit(ctx, msg)
+		case service.MsgSetWithdrawAddress:
+			return handleMsgSetServiceWithdrawAddress(ctx, msg)
+		case service.MsgCallService:
+			return handleMsgCallService(ctx, msg)
+		case service.MsgRespondService:
+			return handleMsgRespondService(ctx, msg)
+		case service.MsgPauseRequestContext:
+			return handleMsgPauseRequestContext(ctx, msg)
+		case service.MsgStartRequestContext:
+			return handleMsgStartRequestContext(ctx, msg)
+		case service.MsgKillRequestContext:
+			return handleMsgKillRequestContext(ctx, msg)
+		case service.MsgUpdateRequestContext:
+			return handleMsgUpdateRequestContext(ctx, msg)
+		case service.MsgWithdrawEarnedFees:
+			return handleMsgWithdrawEarnedFees(ctx, msg)
+		case service.MsgWithdrawTax:
+			return handleMsgWithdrawTax(ctx, msg)
+		}
+		return nil, NewError(sdk.UnknownRequest, "unrecognized service message type: %s", msg.Type())
+	}
+	c.queriers["custom/service/definition"] = queryDefinition
+	c.queriers["custom/service/binding"] = queryBinding
+	c.queriers["custom/service/bindings"] = queryBindings
+	c.queriers["custom/service/request"] = queryServiceRequest
+	c.queriers["custom/service/requests"] = queryServiceRequests
+	c.queriers["custom/service/requests_by_ctx"] = queryServiceRequestsByReqCtx
+	c.queriers["custom/service/response"] = queryServiceResponse
+	c.queriers["custom/service/responses"] = queryServiceResponses
+	c.queriers["custom/service/context"] = queryRequestContext
+	c.queriers["custom/service/fees"] = queryEarnedFees
+	c.endBlockers = append(c.endBlockers, expireRequests, startBatches)
+	c.awaiters = append(c.awaiters, awaitsBatch)
+}
+
+func handleMsgDefineService(ctx Context, msg service.MsgDefineService) (sdk.Tags, error) {
+	if _, ok := ctx.serviceDefinitions[msg.Name]; ok {
+		return nil, NewError(sdk.InvalidRequest, "service definition %s already exists", msg.Name)
+	}
+	ctx.serviceDefinitions[msg.Name] = &serviceDefinition{
+		Name:              msg.Name,
+		Description:       msg.Description,
+		Tags:              msg.Tags,
+		Author:            msg.Author,
+		AuthorDescription: msg.AuthorDescription,
+		Schemas:           msg.Schemas,
+	}
+	return sdk.Tags{
+		{Key: string(sdk.ActionKey), Value: msg.Type()},
+		{Key: "service-name", Value: msg.Name},
+		{Key: "author", Value: ctx.AddrPrefixCfg().AccAddressString(msg.Author)},
+	}, nil
+}
+
+func handleMsgBindService(ctx Context, msg service.MsgBindService) (sdk.Tags, error) {
+	if _, ok := ctx.serviceDefinitions[msg.ServiceName]; !ok {
+		return nil, NewError(sdk.InvalidRequest, "service definition %s does not exist", msg.ServiceName)
+	}
+	key := bindingKey(msg.ServiceName, msg.Provider)
+	if _, ok := ctx.serviceBindings[key]; ok {
+		return nil, NewError(sdk.InvalidRequest, "service binding %s already exists", key)
+	}
+	if _, err := parsePrice(ctx, msg.Pricing); err != nil {
+		return nil, err
+	}
+	if minDeposit := serviceParams(ctx).MinDeposit; !coversCoins(msg.Deposit, minDeposit) {
+		return nil, NewError(sdk.InvalidCoins, "insufficient deposit; %s < %s", msg.Deposit, minDeposit)
+	}
+	if err := ctx.SubtractCoins(msg.Provider, msg.Deposit); err != nil {
+		return nil, err
+	}
+
+	ctx.serviceBindings[key] = &serviceBinding{
+		ServiceName: msg.ServiceName,
+		Provider:    msg.Provider,
+		Deposit:     msg.Deposit,
+		Pricing:     msg.Pricing,
+		Available:   true,
+	}
+	return bindingTags(ctx, msg, msg.ServiceName, msg.Provider), nil
+}
+
+func handleMsgUpdateServiceBinding(ctx Context, msg service.MsgUpdateServiceBinding) (sdk.Tags, error) {
+	binding, err := ownBinding(ctx, msg.ServiceName, msg.Provider)
+	if err != nil {
+		return nil, err
+	}
+	if len(msg.Pricing) > 0 {
+		if _, err := parsePrice(ctx, msg.Pricing); err != nil {
+			return nil, err
+		}
+		binding.Pricing = msg.Pricing
+	}
+	if !msg.Deposit.Empty() {
+		if err := ctx.SubtractCoins(msg.Provider, msg.Deposit); err != nil {
+			return nil, err
+		}
+		binding.Deposit = binding.Deposit.Add(msg.Deposit...)
+	}
+	return bindingTags(ctx, msg, msg.ServiceName, msg.Provider), nil
+}
+
+func handleMsgDisableServiceBinding(ctx Context, msg service.MsgDisableServiceBinding) (sdk.Tags, error) {
+	binding, err := ownBinding(ctx, msg.ServiceName, msg.Provider)
+	if err != nil {
+		return nil, err
+	}
+	if !binding.Available {
+		return nil, NewError(sdk.InvalidRequest, "service binding %s is already disabled", msg.ServiceName)
+	}
+	binding.Available = false
+	binding.DisabledTime = ctx.Time
+	return bindingTags(ctx, msg, msg.ServiceName, msg.Provider), nil
+}
+
+func handleMsgEnableServiceBinding(ctx Context, msg service.MsgEnableServiceBinding) (sdk.Tags, error) {
+	binding, err := ownBinding(ctx, msg.ServiceName, msg.Provider)
+	if err != nil {
+		return nil, err
+	}
+	if binding.Available {
+		return nil, NewError(sdk.InvalidRequest, "service binding %s is already enabled", msg.ServiceName)
+	}
+	if !msg.Deposit.Empty() {
+		if err := ctx.SubtractCoins(msg.Provider, msg.Deposit); err != nil {
+			return nil, err
+		}
+		binding.Deposit = binding.Deposit.Add(msg.Deposit...)
+	}
+	if minDeposit := serviceParams(ctx).MinDeposit; !coversCoins(binding.Deposit, minDeposit) {
+		return nil, NewError(sdk.InvalidCoins, "insufficient deposit; %s < %s", binding.Deposit, minDeposit)
+	}
+	binding.Available = true
+	binding.DisabledTime = time.Time{}
+	return bindingTags(ctx, msg, msg.ServiceName, msg.Provider), nil
+}
+
+// handleMsgRefundServiceDeposit refunds the deposit of a binding disabled for longer than the complaint retrospect
+func handleMsgRefundServiceDeposit(ctx Context, msg service.MsgRefundServiceDeposit) (sdk.Tags, error) {
+	binding, err := ownBinding(ctx, msg.ServiceName, msg.Provider)
+	if err != nil {
+		return nil, err
+	}
+	if binding.Available {
+		return nil, NewError(sdk.InvalidRequest, "service binding %s must be disabled", msg.ServiceName)
+	}
+	if binding.Deposit.Empty() {
+		return nil, NewError(sdk.InvalidRequest, "service binding %s has no deposit", msg.ServiceName)
+	}
+	if refundTime := binding.DisabledTime.Add(serviceParams(ctx).ComplaintRetrospect); ctx.Time.Before(refundTime) {
+		return nil, NewError(sdk.InvalidRequest, "the deposit can not be refunded before %s", refundTime)
+	}
+	ctx.AddCoins(msg.Provider, binding.Deposit)
+	binding.Deposit = nil
+	return bindingTags(ctx, msg, msg.ServiceName, msg.Provider), nil
+}
+
+func handleMsgSetServiceWithdrawAddress(ctx Context, msg service.MsgSetWithdrawAddress) (sdk.Tags, error) {
+	ctx.serviceWithdrawAddrs[msg.Provider.String()] = msg.WithdrawAddress
+	return sdk.Tags{
+		{Key: string(sdk.ActionKey), Value: msg.Type()},
+		{Key: "provider", Value: ctx.AddrPrefixCfg().AccAddressString(msg.Provider)},
+	}, nil
+}
+
+func handleMsgCallService(ctx Context, msg service.MsgCallService) (sdk.Tags, error) {
+	reqCtx, err := newRequestContext(ctx, msg.ServiceName, msg.Providers, msg.Consumer, msg.Input,
+		msg.ServiceFeeCap, msg.Timeout, msg.Repeated, msg.RepeatedFrequency, msg.RepeatedTotal)
+	if err != nil {
+		return nil, err
+	}
+	reqCtx.SuperMode = msg.SuperMode
+	reqCtx.State = runningState
+	// the first batch starts at the end of the block
+	reqCtx.nextBatchHeight = ctx.Height
+
+	return sdk.Tags{
+		{Key: string(sdk.ActionKey), Value: msg.Type()},
+		{Key: "request-context-id", Value: reqCtx.id.String()},
+		{Key: "service-name", Value: msg.ServiceName},
+		{Key: "consumer", Value: ctx.AddrPrefixCfg().AccAddressString(msg.Consumer)},
+	}, nil
+}
+
+// newRequestContext saves a paused request context of the transaction, the providers must be bound to the service
+func newRequestContext(ctx Context, serviceName string, providers []sdk.AccAddress, consumer sdk.AccAddress,
+	input string, serviceFeeCap sdk.Coins, timeout int64, repeated bool, frequency uint64, total int64) (*requestContext, error) {
+	if _, ok := ctx.serviceDefinitions[serviceName]; !ok {
+		return nil, NewError(sdk.InvalidRequest, "service definition %s does not exist", serviceName)
+	}
+	for _, provider := range providers {
+		if _, ok := ctx.serviceBindings[bindingKey(serviceName, provider)]; !ok {
+			return nil, NewError(sdk.InvalidRequest, "provider %s is not bound to service %s",
+				ctx.AddrPrefixCfg().AccAddressString(provider), serviceName)
+		}
+	}
+	if maxTimeout := serviceParams(ctx).MaxRequestTimeout; timeout <= 0 || timeout > maxTimeout {
+		return nil, NewError(sdk.InvalidRequest, "timeout %d must be between 1 and %d", timeout, maxTimeout)
+	}
+	if repeated {
+		if frequency == 0 {
+			frequency = uint64(timeout)
+		}
+		if frequency < uint64(timeout) {
+			return nil, NewError(sdk.InvalidRequest, "repeated frequency %d must not be less than the timeout %d", frequency, timeout)
+		}
+	}
+
+	reqCtx := &requestContext{
+		ServiceName:       serviceName,
+		Providers:         providers,
+		Consumer:          consumer,
+		Input:             input,
+		ServiceFeeCap:     serviceFeeCap,
+		Timeout:           timeout,
+		Repeated:          repeated,
+		RepeatedFrequency: frequency,
+		RepeatedTotal:     total,
+		BatchState:        completedState,
+		State:             pausedState,
+		id:                ctx.newRequestContextID(ctx.TxHash),
+	}
+	ctx.requestContexts[reqCtx.id.String()] = reqCtx
+	return reqCtx, nil
+}
+
+func handleMsgRespondService(ctx Context, msg service.MsgRespondService) (sdk.Tags, error) {
+	req, ok := ctx.serviceRequests[msg.RequestID.String()]
+	if !ok || req.done {
+		return nil, NewError(sdk.InvalidRequest, "active request %s does not exist", msg.RequestID.String())
+	}
+	if !req.Provider.Equals(msg.Provider) {
+		return nil, NewError(sdk.Unauthorized, "request %s is not sent to %s",
+			msg.RequestID.String(), ctx.AddrPrefixCfg().AccAddressString(msg.Provider))
+	}
+
+	req.done = true
+	ctx.serviceResponses[req.ID] = &serviceResponse{
+		Provider:                   msg.Provider,
+		Consumer:                   req.Consumer,
+		Output:                     msg.Output,
+		Result:                     msg.Result,
+		RequestContextID:           req.RequestContextID,
+		RequestContextBatchCounter: req.RequestContextBatchCounter,
+	}
+
+	// the service fee minus the tax is earned by the provider
+	params := serviceParams(ctx)
+	var tax sdk.Coins
+	for _, c := range req.ServiceFee {
+		tax = tax.Add(sdk.NewCoin(c.Denom, params.ServiceFeeTax.MulInt(c.Amount).TruncateInt()))
+	}
+	earned, _ := req.ServiceFee.SafeAdd(negative(tax))
+	ctx.serviceTax = ctx.serviceTax.Add(tax...)
+	ctx.earnedFees[req.Provider.String()] = ctx.earnedFees[req.Provider.String()].Add(earned...)
+
+	tags := sdk.Tags{
+		{Key: string(sdk.ActionKey), Value: msg.Type()},
+		{Key: "request-context-id", Value: req.RequestContextID.String()},
+		{Key: "request-id", Value: req.ID},
+		{Key: "service-name", Value: req.ServiceName},
+		{Key: "provider", Value: ctx.AddrPrefixCfg().AccAddressString(req.Provider)},
+		{Key: "consumer", Value: ctx.AddrPrefixCfg().AccAddressString(req.Consumer)},
+	}
+
+	reqCtx := ctx.requestContexts[req.RequestContextID.String()]
+	reqCtx.BatchResponseCount++
+	return append(tags, completeBatch(ctx, reqCtx)...), nil
+}
+
+func handleMsgPauseRequestContext(ctx Context, msg service.MsgPauseRequestContext) (sdk.Tags, error) {
+	reqCtx, err := ownRequestContext(ctx, msg.RequestContextID, msg.Consumer)
+	if err != nil {
+		return nil, err
+	}
+	if reqCtx.State != runningState {
+		return nil, NewError(sdk.InvalidRequest, "request context %s is not running", msg.RequestContextID.String())
+	}
+	reqCtx.State = pausedState
+	reqCtx.nextBatchHeight = 0
+	return requestContextTags(ctx, msg, reqCtx), nil
+}
+
+func handleMsgStartRequestContext(ctx Context, msg service.MsgStartRequestContext) (sdk.Tags, error) {
+	reqCtx, err := ownRequestContext(ctx, msg.RequestContextID, msg.Consumer)
+	if err != nil {
+		return nil, err
+	}
+	if reqCtx.State != pausedState {
+		return nil, NewError(sdk.InvalidRequest, "request context %s is not paused", msg.RequestContextID.String())
+	}
+	reqCtx.State = runningState
+	reqCtx.nextBatchHeight = ctx.Height
+	return requestContextTags(ctx, msg, reqCtx), nil
+}
+
+func handleMsgKillRequestContext(ctx Context, msg service.MsgKillRequestContext) (sdk.Tags, error) {
+	reqCtx, err := ownRequestContext(ctx, msg.RequestContextID, msg.Consumer)
+	if err != nil {
+		return nil, err
+	}
+	if reqCtx.State == completedState {
+		return nil, NewError(sdk.InvalidRequest, "request context %s is completed", msg.RequestContextID.String())
+	}
+	reqCtx.State = completedState
+	reqCtx.nextBatchHeight = 0
+	return requestContextTags(ctx, msg, reqCtx), nil
+}
+
+func handleMsgUpdateRequestContext(ctx Context, msg service.MsgUpdateRequestContext) (sdk.Tags, error) {
+	reqCtx, err := ownRequestContext(ctx, msg.RequestContextID, msg.Consumer)
+	if err != nil {
+		return nil, err
+	}
+	if reqCtx.State == completedState {
+		return nil, NewError(sdk.InvalidRequest, "request context %s is completed", msg.RequestContextID.String())
+	}
+	if err := updateRequestContext(ctx, reqCtx, msg.Providers, msg.ServiceFeeCap, msg.Timeout,
+		msg.RepeatedFrequency, msg.RepeatedTotal); err != nil {
+		return nil, err
+	}
+	return requestContextTags(ctx, msg, reqCtx), nil
+}
+
+// updateRequestContext updates the fields which are set
+func updateRequestContext(ctx Context, reqCtx *requestContext, providers []sdk.AccAddress,
+	serviceFeeCap sdk.Coins, timeout int64, frequency uint64, total int64) error {
+	for _, provider := range providers {
+		if _, ok := ctx.serviceBindings[bindingKey(reqCtx.ServiceName, provider)]; !ok {
+			return NewError(sdk.InvalidRequest, "provider %s is not bound to service %s",
+				ctx.AddrPrefixCfg().AccAddressString(provider), reqCtx.ServiceName)
+		}
+	}
+	if maxTimeout := serviceParams(ctx).MaxRequestTimeout; timeout < 0 || timeout > maxTimeout {
+		return NewError(sdk.InvalidRequest, "timeout %d must be between 1 and %d", timeout, maxTimeout)
+	}
+
+	if len(providers) > 0 {
+		reqCtx.Providers = providers
+	}
+	if !serviceFeeCap.Empty() {
+		reqCtx.ServiceFeeCap = serviceFeeCap
+	}
+	if timeout > 0 {
+		reqCtx.Timeout = timeout
+	}
+	if frequency > 0 {
+		reqCtx.RepeatedFrequency = frequency
+	}
+	if total != 0 {
+		reqCtx.RepeatedTotal = total
+	}
+	if reqCtx.Repeated && reqCtx.RepeatedFrequency < uint64(reqCtx.Timeout) {
+		return NewError(sdk.InvalidRequest, "repeated frequency %d must not be less than the timeout %d",
+			reqCtx.RepeatedFrequency, reqCtx.Timeout)
+	}
+	return nil
+}
+
+func handleMsgWithdrawEarnedFees(ctx Context, msg service.MsgWithdrawEarnedFees) (sdk.Tags, error) {
+	fees := ctx.earnedFees[msg.Provider.String()]
+	if fees.Empty() {
+		return nil, NewError(sdk.InvalidRequest, "no earned fees for %s", ctx.AddrPrefixCfg().AccAddressString(msg.Provider))
+	}
+	delete(ctx.earnedFees, msg.Provider.String())
+	ctx.AddCoins(ctx.ServiceWithdrawAddress(msg.Provider), fees)
+	return sdk.Tags{
+		{Key: string(sdk.ActionKey), Value: msg.Type()},
+		{Key: "provider", Value: ctx.AddrPrefixCfg().AccAddressString(msg.Provider)},
+	}, nil
+}
+
+// handleMsgWithdrawTax pays the service tax to the destination address, only the trustees may withdraw it
+func handleMsgWithdrawTax(ctx Context, msg service.MsgWithdrawTax) (sdk.Tags, error) {
+	if !ctx.guardians[msg.Trustee.String()] {
+		return nil, NewError(sdk.Unauthorized, "%s is not a trustee", ctx.AddrPrefixCfg().AccAddressString(msg.Trustee))
+	}
+	if !coversCoins(ctx.serviceTax, msg.Amount) {
+		return nil, NewError(sdk.InsufficientFunds, "insufficient service tax; %s < %s", ctx.serviceTax, msg.Amount)
+	}
+	ctx.serviceTax, _ = ctx.serviceTax.SafeAdd(negative(msg.Amount))
+	ctx.AddCoins(msg.DestAddress, msg.Amount)
+	return sdk.Tags{
+		{Key: string(sdk.ActionKey), Value: msg.Type()},
+		{Key: "trustee", Value: ctx.AddrPrefixCfg().AccAddressString(msg.Trustee)},
+	}, nil
+}
+
+// expireRequests refunds the service fees of the requests which are not responded before their expiration height
+func expireRequests(ctx Context) (tags sdk.Tags) {
+	for _, req := range ctx.serviceRequestsOf(func(req *serviceRequest) bool {
+		return !req.done && req.ExpirationHeight <= ctx.Height
+	}) {
+		req.done = true
+		ctx.AddCoins(req.Consumer, req.ServiceFee)
+		if reqCtx, ok := ctx.requestContexts[req.RequestContextID.String()]; ok {
+			tags = append(tags, completeBatch(ctx, reqCtx)...)
+		}
+	}
+	return tags
+}
+
+// startBatches sends the requests of the batches which start at the height, the consumers pay the service fees
+// which are escrowed until the requests are responded. The providers find their requests in the end block tags.
+func startBatches(ctx Context) (tags sdk.Tags) {
+	var serviceNames, providers []string
+	requestIDs := make(map[string][]string)
+	for _, reqCtx := range ctx.requestContextsOf(func(reqCtx *requestContext) bool {
+		return reqCtx.State == runningState && reqCtx.nextBatchHeight == ctx.Height
+	}) {
+		reqCtx.BatchCounter++
+		reqCtx.BatchState = runningState
+		reqCtx.BatchRequestCount = 0
+		reqCtx.BatchResponseCount = 0
+		reqCtx.nextBatchHeight = 0
+		if reqCtx.Repeated && (reqCtx.RepeatedTotal < 0 || reqCtx.BatchCounter < uint64(reqCtx.RepeatedTotal)) {
+			reqCtx.nextBatchHeight = ctx.Height + int64(reqCtx.RepeatedFrequency)
+		}
+
+		for _, provider := range reqCtx.Providers {
+			binding, ok := ctx.serviceBindings[bindingKey(reqCtx.ServiceName, provider)]
+			if !ok || !binding.Available {
+				continue
+			}
+			price, err := parsePrice(ctx, binding.Pricing)
+			if err != nil || !coversCoins(reqCtx.ServiceFeeCap, price) {
+				continue
+			}
+			if err := ctx.SubtractCoins(reqCtx.Consumer, price); err != nil {
+				// the consumer can not pay the fees, the request context is paused until it is started again
+				reqCtx.State = pausedState
+				reqCtx.nextBatchHeight = 0
+				break
+			}
+
+			req := &serviceRequest{
+				ID:                         requestID(reqCtx.id, reqCtx.BatchCounter, ctx.Height, reqCtx.BatchRequestCount).String(),
+				ServiceName:                reqCtx.ServiceName,
+				Provider:                   provider,
+				Consumer:                   reqCtx.Consumer,
+				Input:                      reqCtx.Input,
+				ServiceFee:                 price,
+				SuperMode:                  reqCtx.SuperMode,
+				RequestHeight:              ctx.Height,
+				ExpirationHeight:           ctx.Height + reqCtx.Timeout,
+				RequestContextID:           reqCtx.id,
+				RequestContextBatchCounter: reqCtx.BatchCounter,
+			}
+			ctx.serviceRequests[req.ID] = req
+			reqCtx.BatchRequestCount++
+
+			addr := ctx.AddrPrefixCfg().AccAddressString(provider)
+			key := fmt.Sprintf("%s.%s.%s", actionNewBatchRequest, reqCtx.ServiceName, addr)
+			if _, ok := requestIDs[key]; !ok {
+				serviceNames = append(serviceNames, reqCtx.ServiceName)
+				providers = append(providers, addr)
+			}
+			requestIDs[key] = append(requestIDs[key], req.ID)
+		}
+		tags = append(tags, completeBatch(ctx, reqCtx)...)
+	}
+	if len(requestIDs) == 0 {
+		return tags
+	}
+
+	// the tags of the block are matched by key, the requests of all the batches are merged under the same keys
+	tags = append(tags,
+		sdk.Tag{Key: actionNewBatchRequest + ".service-name", Value: strings.Join(serviceNames, ",")},
+		sdk.Tag{Key: actionNewBatchRequest + ".provider", Value: strings.Join(providers, ",")},
+	)
+	keys := make([]string, 0, len(requestIDs))
+	for key := range requestIDs {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		bz, _ := json.Marshal(requestIDs[key])
+		tags = append(tags, sdk.Tag{Key: key, Value: string(bz)})
+	}
+	return tags
+}
+
+// completeBatch completes the running batch of the request context once all its requests are done,
+// the request context is completed if no other batch is scheduled. The responses of the batches of
+// the oracle module are aggregated into the value of the feed.
+func completeBatch(ctx Context, reqCtx *requestContext) sdk.Tags {
+	if reqCtx.BatchState != runningState {
+		return nil
+	}
+	for _, req := range ctx.serviceRequestsOf(func(req *serviceRequest) bool {
+		return bytes.Equal(req.RequestContextID, reqCtx.id) && req.RequestContextBatchCounter == reqCtx.BatchCounter
+	}) {
+		if !req.done {
+			return nil
+		}
+	}
+
+	reqCtx.BatchState = completedState
+	if reqCtx.State == runningState && reqCtx.nextBatchHeight == 0 {
+		reqCtx.State = completedState
+	}
+	if reqCtx.ModuleName == oracle.ModuleName {
+		return ctx.updateFeedValue(reqCtx)
+	}
+	return nil
+}
+
+// awaitsBatch reports whether requests are waiting for their expiration or batches for their start
+func awaitsBatch(ctx Context) bool {
+	for _, reqCtx := range ctx.requestContexts {
+		if reqCtx.State == runningState && reqCtx.nextBatchHeight > ctx.Height {
+			return true
+		}
+	}
+	for _, req := range ctx.serviceRequests {
+		if !req.done {
+			return true
+		}
+	}
+	return false
+}
+
+// requestID returns the id of the request, the id of its request context followed by the batch counter,
+// the height and the index of the request in the batch
+func requestID(reqCtxID []byte, batchCounter uint64, height int64, index uint16) cmn.HexBytes {
+	id := make([]byte, len(reqCtxID)+18)
+	copy(id, reqCtxID)
+	binary.BigEndian.PutUint64(id[len(reqCtxID):], batchCounter)
+	binary.BigEndian.PutUint64(id[len(reqCtxID)+8:], uint64(height))
+	binary.BigEndian.PutUint16(id[len(reqCtxID)+16:], index)
+	return id
+}
+
+// parsePrice returns the price of the pricing of a binding, e.g. {"price":"1iris"}, in min units
+func parsePrice(ctx Context, pricing string) (sdk.Coins, error) {
+	var p struct {
+		Price string `json:"price"`
+	}
+	if err := json.Unmarshal([]byte(pricing), &p); err != nil {
+		return nil, NewError(sdk.InvalidRequest, "invalid pricing: %s", err.Error())
+	}
+	if len(p.Price) == 0 {
+		return nil, nil
+	}
+
+	decCoins, err := sdk.ParseDecCoins(p.Price)
+	if err != nil {
+		return nil, NewError(sdk.InvalidCoins, "invalid price: %s", err.Error())
+	}
+	var price sdk.Coins
+	for _, c := range decCoins {
+		token, ok := ctx.Token(c.Denom)
+		if !ok {
+			return nil, NewError(sdk.InvalidCoins, "token %s does not exist", c.Denom)
+		}
+		coin, err := token.GetCoinType().ConvertToMinCoin(c)
+		if err != nil {
+			return nil, NewError(sdk.InvalidCoins, "invalid price: %s", err.Error())
+		}
+		price = price.Add(coin)
+	}
+	return price, nil
+}
+
+// coversCoins reports whether the coins are greater than or equal to the amounts
+func coversCoins(coins, amounts sdk.Coins) bool {
+	_, hasNeg := coins.SafeAdd(negative(amounts))
+	return !hasNeg
+}
+
+func ownBinding(ctx Context, serviceName string, provider sdk.AccAddress) (*serviceBinding, error) {
+	binding, ok := ctx.serviceBindings[bindingKey(serviceName, provider)]
+	if !ok {
+		return nil, NewError(sdk.InvalidRequest, "service binding %s of %s does not exist",
+			serviceName, ctx.AddrPrefixCfg().AccAddressString(provider))
+	}
+	return binding, nil
+}
+
+// ownRequestContext returns the request context of the consumer, those created by the modules can not be
+// updated through the service messages
+func ownRequestContext(ctx Context, id cmn.HexBytes, consumer sdk.AccAddress) (*requestContext, error) {
+	reqCtx, ok := ctx.requestContexts[id.String()]
+	if !ok {
+		return nil, NewError(sdk.InvalidRequest, "request context %s does not exist", id.String())
+	}
+	if len(reqCtx.ModuleName) > 0 || !reqCtx.Consumer.Equals(consumer) {
+		return nil, NewError(sdk.Unauthorized, "request context %s is not owned by %s",
+			id.String(), ctx.AddrPrefixCfg().AccAddressString(consumer))
+	}
+	return reqCtx, nil
+}
+
+func bindingTags(ctx Context, msg sdk.Msg, serviceName string, provider sdk.AccAddress) sdk.Tags {
+	return sdk.Tags{
+		{Key: string(sdk.ActionKey), Value: msg.Type()},
+		{Key: "service-name", Value: serviceName},
+		{Key: "provider", Value: ctx.AddrPrefixCfg().AccAddressString(provider)},
+	}
+}
+
+func requestContextTags(ctx Context, msg sdk.Msg, reqCtx *requestContext) sdk.Tags {
+	return sdk.Tags{
+		{Key: string(sdk.ActionKey), Value: msg.Type()},
+		{Key: "request-context-id", Value: reqCtx.id.String()},
+		{Key: "consumer", Value: ctx.AddrPrefixCfg().AccAddressString(reqCtx.Consumer)},
+	}
+}
+
+func queryDefinition(ctx Context, data []byte) ([]byte, error) {
+	var params struct {
+		ServiceName string
+	}
+	if err := ctx.DecodeJSON(data, &params); err != nil {
+		return nil, NewError(sdk.ErrJsonUnmarshal, err.Error())
+	}
+
+	definition, ok := ctx.serviceDefinitions[params.ServiceName]
+	if !ok {
+		return nil, NewError(sdk.InvalidRequest, "service definition %s does not exist", params.ServiceName)
+	}
+	return ctx.encodeRegistered("irishub/service/ServiceDefinition", definition)
+}
+
+func queryBinding(ctx Context, data []byte) ([]byte, error) {
+	var params struct {
+		ServiceName string
+		Provider    sdk.AccAddress
+	}
+	if err := ctx.DecodeJSON(data, &params); err != nil {
+		return nil, NewError(sdk.ErrJsonUnmarshal, err.Error())
+	}
+
+	binding, err := ownBinding(ctx, params.ServiceName, params.Provider)
+	if err != nil {
+		return nil, err
+	}
+	return ctx.encodeRegistered("irishub/service/ServiceBinding", ctx.withWithdrawAddress(*binding))
+}
+
+func queryBindings(ctx Context, data []byte) ([]byte, error) {
+	var params struct {
+		ServiceName string
+	}
+	if err := ctx.DecodeJSON(data, &params); err != nil {
+		return nil, NewError(sdk.ErrJsonUnmarshal, err.Error())
+	}
+
+	keys := make([]string, 0, len(ctx.serviceBindings))
+	for key, binding := range ctx.serviceBindings {
+		if binding.ServiceName == params.ServiceName {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+
+	bindings := []serviceBinding{}
+	for _, key := range keys {
+		bindings = append(bindings, ctx.withWithdrawAddress(*ctx.serviceBindings[key]))
+	}
+	return ctx.EncodeJSON(bindings)
+}
+
+func (ctx Context) withWithdrawAddress(binding serviceBinding) serviceBinding {
+	binding.WithdrawAddress = ctx.ServiceWithdrawAddress(binding.Provider)
+	return binding
+}
+
+func queryServiceRequest(ctx Context, data []byte) ([]byte, error) {
+	var params struct {
+		RequestID []byte
+	}
+	if err := ctx.DecodeJSON(data, &params); err != nil {
+		return nil, NewError(sdk.ErrJsonUnmarshal, err.Error())
+	}
+
+	id := cmn.HexBytes(params.RequestID).String()
+	req, ok := ctx.serviceRequests[id]
+	if !ok {
+		return nil, NewError(sdk.InvalidRequest, "request %s does not exist", id)
+	}
+	return ctx.encodeRegistered("irishub/service/Request", req)
+}
+
+// queryServiceRequests returns the active requests of the binding
+func queryServiceRequests(ctx Context, data []byte) ([]byte, error) {
+	var params struct {
+		ServiceName string
+		Provider    sdk.AccAddress
+	}
+	if err := ctx.DecodeJSON(data, &params); err != nil {
+		return nil, NewError(sdk.ErrJsonUnmarshal, err.Error())
+	}
+
+	return encodeRequests(ctx, func(req *serviceRequest) bool {
+		return !req.done && req.ServiceName == params.ServiceName && req.Provider.Equals(params.Provider)
+	})
+}
+
+func queryServiceRequestsByReqCtx(ctx Context, data []byte) ([]byte, error) {
+	var params struct {
+		RequestContextID cmn.HexBytes
+		BatchCounter     uint64
+	}
+	if err := ctx.DecodeJSON(data, &params); err != nil {
+		return nil, NewError(sdk.ErrJsonUnmarshal, err.Error())
+	}
+
+	return encodeRequests(ctx, func(req *serviceRequest) bool {
+		return bytes.Equal(req.RequestContextID, params.RequestContextID) && req.RequestContextBatchCounter == params.BatchCounter
+	})
+}
+
+func encodeRequests(ctx Context, filter func(req *serviceRequest) bool) ([]byte, error) {
+	reqs := []serviceRequest{}
+	for _, req := range ctx.serviceRequestsOf(filter) {
+		reqs = append(reqs, *req)
+	}
+	return ctx.EncodeJSON(reqs)
+}
+
+func queryServiceResponse(ctx Context, data []byte) ([]byte, error) {
+	var params struct {
+		RequestID string
+	}
+	if err := ctx.DecodeJSON(data, &params); err != nil {
+		return nil, NewError(sdk.ErrJsonUnmarshal, err.Error())
+	}
+
+	resp, ok := ctx.serviceResponses[strings.ToUpper(params.RequestID)]
+	if !ok {
+		return nil, NewError(sdk.InvalidRequest, "response of request %s does not exist", params.RequestID)
+	}
+	return ctx.encodeRegistered("irishub/service/Response", resp)
+}
+
+func queryServiceResponses(ctx Context, data []byte) ([]byte, error) {
+	var params struct {
+		RequestContextID cmn.HexBytes
+		BatchCounter     uint64
+	}
+	if err := ctx.DecodeJSON(data, &params); err != nil {
+		return nil, NewError(sdk.ErrJsonUnmarshal, err.Error())
+	}
+
+	resps := []serviceResponse{}
+	for _, req := range ctx.serviceRequestsOf(func(req *serviceRequest) bool {
+		return bytes.Equal(req.RequestContextID, params.RequestContextID) && req.RequestContextBatchCounter == params.BatchCounter
+	}) {
+		if resp, ok := ctx.serviceResponses[req.ID]; ok {
+			resps = append(resps, *resp)
+		}
+	}
+	return ctx.EncodeJSON(resps)
+}
+
+func queryRequestContext(ctx Context, data []byte) ([]byte, error) {
+	var params struct {
+		RequestContextID cmn.HexBytes
+	}
+	if err := ctx.DecodeJSON(data, &params); err != nil {
+		return nil, NewError(sdk.ErrJsonUnmarshal, err.Error())
+	}
+
+	reqCtx, ok := ctx.requestContexts[params.RequestContextID.String()]
+	if !ok {
+		return nil, NewError(sdk.InvalidRequest, "request context %s does not exist", params.RequestContextID.String())
+	}
+	return ctx.encodeRegistered("irishub/service/RequestContext", reqCtx)
+}
+
+func queryEarnedFees(ctx Context, data []byte) ([]byte, error) {
+	var params struct {
+		Address sdk.AccAddress
+	}
+	if err := ctx.DecodeJSON(data, &params); err != nil {
+		return nil, NewError(sdk.ErrJsonUnmarshal, err.Error())
+	}
+
+	fees, ok := ctx.earnedFees[params.Address.String()]
+	if !ok {
+		return nil, NewError(sdk.InvalidRequest, "no earned fees for %s", ctx.AddrPrefixCfg().AccAddressString(params.Address))
+	}
+	return ctx.encodeRegistered("irishub/service/EarnedFees", struct {
+		Address sdk.AccAddress `json:"address"`
+		Coins   sdk.Coins      `json:"coins"`
+	}{
+		Address: params.Address,
+		Coins:   fees,
+	})
+}
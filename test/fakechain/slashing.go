@@ -0,0 +1,139 @@
+package fakechain
+
+import (
+	"bytes"
+	"fmt"
+	"time"
+
+	"github.com/irisnet/irishub-sdk-go/modules/slashing"
+	sdk "github.com/irisnet/irishub-sdk-go/types"
+)
+
+// signingInfoKey is the prefix of the signing infos in the slashing store
+const signingInfoKey = 0x01
+
+type slashingParams struct {
+	MaxEvidenceAge          int64         `json:"max_evidence_age"`
+	SignedBlocksWindow      int64         `json:"signed_blocks_window"`
+	MinSignedPerWindow      sdk.Dec       `json:"min_signed_per_window"`
+	DoubleSignJailDuration  time.Duration `json:"double_sign_jail_duration"`
+	DowntimeJailDuration    time.Duration `json:"downtime_jail_duration"`
+	CensorshipJailDuration  time.Duration `json:"censorship_jail_duration"`
+	SlashFractionDoubleSign sdk.Dec       `json:"slash_fraction_double_sign"`
+	SlashFractionDowntime   sdk.Dec       `json:"slash_fraction_downtime"`
+	SlashFractionCensorship sdk.Dec       `json:"slash_fraction_censorship"`
+}
+
+var defaultSlashingParams = slashingParams{
+	MaxEvidenceAge:          51840,
+	SignedBlocksWindow:      34560,
+	MinSignedPerWindow:      sdk.NewDecWithPrec(5, 1),
+	DoubleSignJailDuration:  48 * time.Hour,
+	DowntimeJailDuration:    36 * time.Hour,
+	CensorshipJailDuration:  48 * time.Hour,
+	SlashFractionDoubleSign: sdk.NewDecWithPrec(1, 2),
+	SlashFractionDowntime:   sdk.NewDecWithPrec(5, 4),
+	SlashFractionCensorship: sdk.ZeroDec(),
+}
+
+// validatorByConsAddr returns the validator whose consensus public key has the address
+func (s *State) validatorByConsAddr(addr sdk.ConsAddress) (*validator, bool) {
+	for _, val := range s.validators {
+		if bytes.Equal(val.ConsPubKey.Address(), addr) {
+			return val, true
+		}
+	}
+	return nil, false
+}
+
+func registerSlashing(c *Chain) {
+	c.handlers[slashing.ModuleName] = func(ctx Context, msg sdk.Msg) (sdk.Tags, error) {
+		if msg, ok := msg.(slashing.MsgUnjail); ok {
+			return nil, NewError(sdk.InvalidRequest, "validator %s is not jailed", ctx.AddrPrefixCfg().ValAddressString(msg.ValidatorAddr))
+		}
+		return nil, NewError(sdk.UnknownRequest, "unrecognized slashing message type: %s", msg.Type())
+	}
+	c.queriers[fmt.Sprintf("/store/%s/key", slashing.ModuleName)] = querySigningInfoStore
+	c.queriers["custom/slashing/signingInfo"] = querySigningInfo
+	c.queriers["custom/slashing/parameters"] = querySlashingParams
+	c.blockers = append(c.blockers, func(ctx Context) sdk.Tags {
+		for _, val := range ctx.Validators() {
+			val.IndexOffset++
+		}
+		return nil
+	})
+}
+
+// querySigningInfoStore answers the queries of the signing infos in the store, as the 0.x chains are queried
+func querySigningInfoStore(ctx Context, key []byte) ([]byte, error) {
+	if len(key) == 0 || key[0] != signingInfoKey {
+		return nil, NewError(sdk.UnknownRequest, "unknown key %X of the slashing store", key)
+	}
+
+	val, ok := ctx.validatorByConsAddr(key[1:])
+	if !ok {
+		return nil, nil
+	}
+	return ctx.Codec.MarshalBinaryLengthPrefixed(struct {
+		StartHeight         int64     `json:"start_height"`
+		IndexOffset         int64     `json:"index_offset"`
+		JailedUntil         time.Time `json:"jailed_until"`
+		MissedBlocksCounter int64     `json:"missed_blocks_counter"`
+	}{
+		StartHeight: val.BondHeight,
+		IndexOffset: val.IndexOffset,
+		JailedUntil: time.Unix(0, 0).UTC(),
+	})
+}
+
+func querySigningInfo(ctx Context, data []byte) ([]byte, error) {
+	var params struct {
+		ConsAddress sdk.ConsAddress
+	}
+	if err := ctx.DecodeJSON(data, &params); err != nil {
+		return nil, NewError(sdk.ErrJsonUnmarshal, err.Error())
+	}
+
+	val, ok := ctx.validatorByConsAddr(params.ConsAddress)
+	if !ok {
+		return nil, NewError(sdk.InvalidRequest, "no signing info of %s", ctx.AddrPrefixCfg().ConsAddressString(params.ConsAddress))
+	}
+	return ctx.EncodeJSON(struct {
+		Address             string    `json:"address"`
+		StartHeight         int64     `json:"start_height"`
+		IndexOffset         int64     `json:"index_offset"`
+		JailedUntil         time.Time `json:"jailed_until"`
+		Tombstoned          bool      `json:"tombstoned"`
+		MissedBlocksCounter int64     `json:"missed_blocks_counter"`
+	}{
+		Address:     ctx.AddrPrefixCfg().ConsAddressString(params.ConsAddress),
+		StartHeight: val.BondHeight,
+		IndexOffset: val.IndexOffset,
+		JailedUntil: time.Unix(0, 0).UTC(),
+	})
+}
+
+// querySlashingParams answers the query of the params of the 1.x chains
+func querySlashingParams(ctx Context, _ []byte) ([]byte, error) {
+	p := defaultSlashingParams
+	if r, ok := ctx.params[slashing.ModuleName].(registered); ok {
+		if params, ok := r.Value.(slashingParams); ok {
+			p = params
+		}
+	}
+	return ctx.EncodeJSON(struct {
+		MaxEvidenceAge          time.Duration `json:"max_evidence_age"`
+		SignedBlocksWindow      int64         `json:"signed_blocks_window"`
+		MinSignedPerWindow      sdk.Dec       `json:"min_signed_per_window"`
+		DowntimeJailDuration    time.Duration `json:"downtime_jail_duration"`
+		SlashFractionDoubleSign sdk.Dec       `json:"slash_fraction_double_sign"`
+		SlashFractionDowntime   sdk.Dec       `json:"slash_fraction_downtime"`
+	}{
+		MaxEvidenceAge:          time.Duration(p.MaxEvidenceAge) * blockInterval,
+		SignedBlocksWindow:      p.SignedBlocksWindow,
+		MinSignedPerWindow:      p.MinSignedPerWindow,
+		DowntimeJailDuration:    p.DowntimeJailDuration,
+		SlashFractionDoubleSign: p.SlashFractionDoubleSign,
+		SlashFractionDowntime:   p.SlashFractionDowntime,
+	})
+}
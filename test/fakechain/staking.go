@@ -0,0 +1,678 @@
+package fakechain
+
+import (
+	"encoding/hex"
+	"sort"
+	"time"
+
+	"github.com/tendermint/tendermint/crypto"
+
+	"github.com/irisnet/irishub-sdk-go/modules/staking"
+	sdk "github.com/irisnet/irishub-sdk-go/types"
+)
+
+const (
+	defaultUnbondingTime = 3 * 7 * 24 * time.Hour
+	defaultMaxValidators = 100
+
+	bondedStatus = 0x02
+)
+
+var defaultCommissionRate = sdk.NewDecWithPrec(1, 1)
+
+// validator is bonded as long as it exists, the validators are never jailed nor slashed
+type validator struct {
+	Operator    sdk.ValAddress
+	ConsPubKey  crypto.PubKey
+	Description staking.Description
+	Commission  sdk.Dec
+	Tokens      sdk.Dec
+	Shares      sdk.Dec
+	BondHeight  int64
+	BondTime    time.Time
+
+	// signed blocks since the validator is bonded
+	IndexOffset int64
+	// commission earned from the block provisions and not yet withdrawn
+	AccumCommission sdk.Dec
+}
+
+type delegation struct {
+	Delegator sdk.AccAddress
+	Validator sdk.ValAddress
+	Shares    sdk.Dec
+	Height    int64
+	// rewards earned from the block provisions and not yet withdrawn
+	Rewards sdk.Dec
+}
+
+// unbonding is never completed, the unbonding time of the chain is weeks
+type unbonding struct {
+	TxHash         string
+	Delegator      sdk.AccAddress
+	Validator      sdk.ValAddress
+	CreationHeight int64
+	MinTime        time.Time
+	InitialBalance sdk.Coin
+	Balance        sdk.Coin
+}
+
+type redelegation struct {
+	Delegator      sdk.AccAddress
+	ValidatorSrc   sdk.ValAddress
+	ValidatorDst   sdk.ValAddress
+	CreationHeight int64
+	MinTime        time.Time
+	InitialBalance sdk.Coin
+	Balance        sdk.Coin
+	SharesSrc      sdk.Dec
+	SharesDst      sdk.Dec
+}
+
+// Validator returns the validator of the operator address
+func (s *State) Validator(operator sdk.ValAddress) (*validator, bool) {
+	val, ok := s.validators[operator.String()]
+	return val, ok
+}
+
+// Validators returns the validators sorted by operator address
+func (s *State) Validators() []*validator {
+	keys := make([]string, 0, len(s.validators))
+	for k := range s.validators {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	validators := make([]*validator, len(keys))
+	for i, k := range keys {
+		validators[i] = s.validators[k]
+	}
+	return validators
+}
+
+// CreateValidator bonds a validator with the self delegation taken from the balance of the operator
+func (s *State) CreateValidator(ctx Context, operator sdk.AccAddress, consPubKey crypto.PubKey, selfDelegation sdk.Coin) error {
+	valAddr := sdk.ValAddress(operator)
+	if _, ok := s.Validator(valAddr); ok {
+		return NewError(sdk.InvalidRequest, "validator %s already exists", ctx.AddrPrefixCfg().ValAddressString(valAddr))
+	}
+	if err := s.SubtractCoins(operator, sdk.Coins{selfDelegation}); err != nil {
+		return err
+	}
+
+	s.validators[valAddr.String()] = &validator{
+		Operator:        valAddr,
+		ConsPubKey:      consPubKey,
+		Description:     staking.Description{Moniker: ctx.AddrPrefixCfg().AccAddressString(operator)},
+		Commission:      defaultCommissionRate,
+		Tokens:          sdk.ZeroDec(),
+		Shares:          sdk.ZeroDec(),
+		BondHeight:      ctx.Height,
+		BondTime:        ctx.Time,
+		AccumCommission: sdk.ZeroDec(),
+	}
+	_, err := s.Delegate(ctx, operator, valAddr, sdk.NewDecFromInt(selfDelegation.Amount))
+	return err
+}
+
+// Delegation returns the delegation of the delegator to the validator
+func (s *State) Delegation(delegator sdk.AccAddress, validator sdk.ValAddress) (*delegation, bool) {
+	d, ok := s.delegations[delegationKey(delegator, validator)]
+	return d, ok
+}
+
+// Delegations returns the delegations which match the filter, sorted by delegator and validator
+func (s *State) Delegations(filter func(d *delegation) bool) []*delegation {
+	keys := make([]string, 0, len(s.delegations))
+	for k, d := range s.delegations {
+		if filter(d) {
+			keys = append(keys, k)
+		}
+	}
+	sort.Strings(keys)
+
+	delegations := make([]*delegation, len(keys))
+	for i, k := range keys {
+		delegations[i] = s.delegations[k]
+	}
+	return delegations
+}
+
+// Delegate bonds the tokens to the validator and returns the issued shares, the tokens must have
+// been taken from the delegator. The rewards of the delegation are withdrawn first.
+func (s *State) Delegate(ctx Context, delegator sdk.AccAddress, valAddr sdk.ValAddress, tokens sdk.Dec) (sdk.Dec, error) {
+	val, ok := s.Validator(valAddr)
+	if !ok {
+		return sdk.Dec{}, NewError(sdk.InvalidRequest, "validator %s does not exist", ctx.AddrPrefixCfg().ValAddressString(valAddr))
+	}
+
+	d, ok := s.Delegation(delegator, valAddr)
+	if ok {
+		s.withdrawDelegationRewards(d)
+	} else {
+		d = &delegation{
+			Delegator: delegator,
+			Validator: valAddr,
+			Shares:    sdk.ZeroDec(),
+			Rewards:   sdk.ZeroDec(),
+		}
+		s.delegations[delegationKey(delegator, valAddr)] = d
+	}
+
+	shares := tokens
+	if !val.Tokens.IsZero() {
+		shares = tokens.Mul(val.Shares).Quo(val.Tokens)
+	}
+	val.Tokens = val.Tokens.Add(tokens)
+	val.Shares = val.Shares.Add(shares)
+	d.Shares = d.Shares.Add(shares)
+	d.Height = ctx.Height
+	return shares, nil
+}
+
+// Unbond removes the shares from the delegation and returns the tokens they were worth,
+// the rewards of the delegation are withdrawn first
+func (s *State) Unbond(ctx Context, delegator sdk.AccAddress, valAddr sdk.ValAddress, shares sdk.Dec) (sdk.Dec, error) {
+	val, ok := s.Validator(valAddr)
+	if !ok {
+		return sdk.Dec{}, NewError(sdk.InvalidRequest, "validator %s does not exist", ctx.AddrPrefixCfg().ValAddressString(valAddr))
+	}
+	d, ok := s.Delegation(delegator, valAddr)
+	if !ok {
+		return sdk.Dec{}, NewError(sdk.InvalidRequest, "no delegation of %s to %s",
+			ctx.AddrPrefixCfg().AccAddressString(delegator), ctx.AddrPrefixCfg().ValAddressString(valAddr))
+	}
+	if d.Shares.LT(shares) {
+		return sdk.Dec{}, NewError(sdk.InsufficientFunds, "insufficient shares; %s < %s", d.Shares, shares)
+	}
+
+	s.withdrawDelegationRewards(d)
+	tokens := shares.Mul(val.Tokens).Quo(val.Shares)
+	val.Tokens = val.Tokens.Sub(tokens)
+	val.Shares = val.Shares.Sub(shares)
+	d.Shares = d.Shares.Sub(shares)
+	d.Height = ctx.Height
+	if d.Shares.IsZero() {
+		delete(s.delegations, delegationKey(delegator, valAddr))
+	}
+	return tokens, nil
+}
+
+// Unbondings returns the unbonding delegations which match the filter, sorted by delegator and validator
+func (s *State) Unbondings(filter func(ubd *unbonding) bool) []*unbonding {
+	keys := make([]string, 0, len(s.unbondings))
+	for k, ubd := range s.unbondings {
+		if filter(ubd) {
+			keys = append(keys, k)
+		}
+	}
+	sort.Strings(keys)
+
+	ubds := make([]*unbonding, len(keys))
+	for i, k := range keys {
+		ubds[i] = s.unbondings[k]
+	}
+	return ubds
+}
+
+// Redelegations returns the redelegations which match the filter, sorted by delegator and validators
+func (s *State) Redelegations(filter func(red *redelegation) bool) []*redelegation {
+	keys := make([]string, 0, len(s.redelegations))
+	for k, red := range s.redelegations {
+		if filter(red) {
+			keys = append(keys, k)
+		}
+	}
+	sort.Strings(keys)
+
+	reds := make([]*redelegation, len(keys))
+	for i, k := range keys {
+		reds[i] = s.redelegations[k]
+	}
+	return reds
+}
+
+// BondedTokens returns the tokens bonded to the validators and those being unbonded
+func (s *State) BondedTokens() (bonded sdk.Dec, unbonding sdk.Dec) {
+	bonded, unbonding = sdk.ZeroDec(), sdk.ZeroDec()
+	for _, val := range s.validators {
+		bonded = bonded.Add(val.Tokens)
+	}
+	for _, ubd := range s.unbondings {
+		unbonding = unbonding.Add(sdk.NewDecFromInt(ubd.Balance.Amount))
+	}
+	return bonded, unbonding
+}
+
+func (s *State) unbondingTime() time.Duration {
+	if p, ok := s.Params(staking.ModuleName); ok {
+		if params, ok := p.(registered).Value.(stakeParams); ok {
+			return params.UnbondingTime
+		}
+	}
+	return defaultUnbondingTime
+}
+
+func delegationKey(delegator sdk.AccAddress, validator sdk.ValAddress) string {
+	return delegator.String() + "/" + validator.String()
+}
+
+func registerStaking(c *Chain) {
+	c.handlers[staking.ModuleName] = func(ctx Context, msg sdk.Msg) (sdk.Tags, error) {
+		switch msg := msg.(type) {
+		case staking.MsgDelegate:
+			return handleMsgDelegate(ctx, msg)
+		case staking.MsgUndelegate:
+			return handleMsgUndelegate(ctx, msg)
+		case staking.MsgBeginRedelegate:
+			return handleMsgBeginRedelegate(ctx, msg)
+		}
+		return nil, NewError(sdk.UnknownRequest, "unrecognized stake message type: %s", msg.Type())
+	}
+
+	c.queriers["custom/stake/validator"] = queryValidator
+	c.queriers["custom/stake/validators"] = queryValidators
+	c.queriers["custom/stake/delegation"] = queryDelegation
+	c.queriers["custom/stake/delegatorDelegations"] = queryDelegatorDelegations
+	c.queriers["custom/stake/validatorDelegations"] = queryValidatorDelegations
+	c.queriers["custom/stake/unbondingDelegation"] = queryUnbondingDelegation
+	c.queriers["custom/stake/delegatorUnbondingDelegations"] = queryDelegatorUnbondingDelegations
+	c.queriers["custom/stake/validatorUnbondingDelegations"] = queryValidatorUnbondingDelegations
+	c.queriers["custom/stake/redelegation"] = queryRedelegation
+	c.queriers["custom/stake/delegatorRedelegations"] = queryDelegatorRedelegations
+	c.queriers["custom/stake/validatorRedelegations"] = queryValidatorRedelegations
+	c.queriers["custom/stake/pool"] = queryPool
+}
+
+func handleMsgDelegate(ctx Context, msg staking.MsgDelegate) (sdk.Tags, error) {
+	if _, ok := ctx.Validator(msg.ValidatorAddr); !ok {
+		return nil, NewError(sdk.InvalidRequest, "validator %s does not exist", ctx.AddrPrefixCfg().ValAddressString(msg.ValidatorAddr))
+	}
+	if err := ctx.SubtractCoins(msg.DelegatorAddr, sdk.Coins{msg.Delegation}); err != nil {
+		return nil, err
+	}
+	if _, err := ctx.Delegate(ctx, msg.DelegatorAddr, msg.ValidatorAddr, sdk.NewDecFromInt(msg.Delegation.Amount)); err != nil {
+		return nil, err
+	}
+	return sdk.Tags{
+		{Key: string(sdk.ActionKey), Value: msg.Type()},
+		{Key: "delegator", Value: ctx.AddrPrefixCfg().AccAddressString(msg.DelegatorAddr)},
+		{Key: "destination-validator", Value: ctx.AddrPrefixCfg().ValAddressString(msg.ValidatorAddr)},
+	}, nil
+}
+
+func handleMsgUndelegate(ctx Context, msg staking.MsgUndelegate) (sdk.Tags, error) {
+	tokens, err := ctx.Unbond(ctx, msg.DelegatorAddr, msg.ValidatorAddr, msg.SharesAmount)
+	if err != nil {
+		return nil, err
+	}
+
+	balance := sdk.NewCoin(sdk.IRIS.MinUnit, tokens.TruncateInt())
+	minTime := ctx.Time.Add(ctx.unbondingTime())
+	key := delegationKey(msg.DelegatorAddr, msg.ValidatorAddr)
+	if ubd, ok := ctx.unbondings[key]; ok {
+		ubd.InitialBalance = ubd.InitialBalance.Add(balance)
+		ubd.Balance = ubd.Balance.Add(balance)
+		ubd.MinTime = minTime
+	} else {
+		ctx.unbondings[key] = &unbonding{
+			TxHash:         hex.EncodeToString(ctx.TxHash),
+			Delegator:      msg.DelegatorAddr,
+			Validator:      msg.ValidatorAddr,
+			CreationHeight: ctx.Height,
+			MinTime:        minTime,
+			InitialBalance: balance,
+			Balance:        balance,
+		}
+	}
+	return sdk.Tags{
+		{Key: string(sdk.ActionKey), Value: msg.Type()},
+		{Key: "delegator", Value: ctx.AddrPrefixCfg().AccAddressString(msg.DelegatorAddr)},
+		{Key: "source-validator", Value: ctx.AddrPrefixCfg().ValAddressString(msg.ValidatorAddr)},
+		{Key: "end-time", Value: minTime.Format(time.RFC3339)},
+	}, nil
+}
+
+func handleMsgBeginRedelegate(ctx Context, msg staking.MsgBeginRedelegate) (sdk.Tags, error) {
+	tokens, err := ctx.Unbond(ctx, msg.DelegatorAddr, msg.ValidatorSrcAddr, msg.SharesAmount)
+	if err != nil {
+		return nil, err
+	}
+	sharesDst, err := ctx.Delegate(ctx, msg.DelegatorAddr, msg.ValidatorDstAddr, tokens)
+	if err != nil {
+		return nil, err
+	}
+
+	balance := sdk.NewCoin(sdk.IRIS.MinUnit, tokens.TruncateInt())
+	minTime := ctx.Time.Add(ctx.unbondingTime())
+	key := delegationKey(msg.DelegatorAddr, msg.ValidatorSrcAddr) + "/" + msg.ValidatorDstAddr.String()
+	if red, ok := ctx.redelegations[key]; ok {
+		red.InitialBalance = red.InitialBalance.Add(balance)
+		red.Balance = red.Balance.Add(balance)
+		red.SharesSrc = red.SharesSrc.Add(msg.SharesAmount)
+		red.SharesDst = red.SharesDst.Add(sharesDst)
+		red.MinTime = minTime
+	} else {
+		ctx.redelegations[key] = &redelegation{
+			Delegator:      msg.DelegatorAddr,
+			ValidatorSrc:   msg.ValidatorSrcAddr,
+			ValidatorDst:   msg.ValidatorDstAddr,
+			CreationHeight: ctx.Height,
+			MinTime:        minTime,
+			InitialBalance: balance,
+			Balance:        balance,
+			SharesSrc:      msg.SharesAmount,
+			SharesDst:      sharesDst,
+		}
+	}
+	return sdk.Tags{
+		{Key: string(sdk.ActionKey), Value: msg.Type()},
+		{Key: "delegator", Value: ctx.AddrPrefixCfg().AccAddressString(msg.DelegatorAddr)},
+		{Key: "source-validator", Value: ctx.AddrPrefixCfg().ValAddressString(msg.ValidatorSrcAddr)},
+		{Key: "destination-validator", Value: ctx.AddrPrefixCfg().ValAddressString(msg.ValidatorDstAddr)},
+		{Key: "end-time", Value: minTime.Format(time.RFC3339)},
+	}, nil
+}
+
+// the results of the queries have the JSON of the unexported types of the staking module
+
+type stakeParams struct {
+	UnbondingTime time.Duration `json:"unbonding_time"`
+	MaxValidators uint16        `json:"max_validators"`
+}
+
+type validatorResult struct {
+	OperatorAddr     string              `json:"operator_address"`
+	ConsPubKey       string              `json:"consensus_pubkey"`
+	Jailed           bool                `json:"jailed"`
+	Status           byte                `json:"status"`
+	Tokens           string              `json:"tokens"`
+	DelegatorShares  string              `json:"delegator_shares"`
+	Description      staking.Description `json:"description"`
+	BondHeight       int64               `json:"bond_height"`
+	UnbondingHeight  int64               `json:"unbonding_height"`
+	UnbondingMinTime time.Time           `json:"unbonding_time"`
+	Commission       staking.Commission  `json:"commission"`
+}
+
+type delegationResult struct {
+	DelegatorAddr string  `json:"delegator_addr"`
+	ValidatorAddr string  `json:"validator_addr"`
+	Shares        sdk.Dec `json:"shares"`
+	Height        int64   `json:"height"`
+}
+
+type unbondingResult struct {
+	TxHash         string    `json:"tx_hash"`
+	DelegatorAddr  string    `json:"delegator_addr"`
+	ValidatorAddr  string    `json:"validator_addr"`
+	CreationHeight int64     `json:"creation_height"`
+	MinTime        time.Time `json:"min_time"`
+	InitialBalance sdk.Coin  `json:"initial_balance"`
+	Balance        sdk.Coin  `json:"balance"`
+}
+
+type redelegationResult struct {
+	DelegatorAddr    string    `json:"delegator_addr"`
+	ValidatorSrcAddr string    `json:"validator_src_addr"`
+	ValidatorDstAddr string    `json:"validator_dst_addr"`
+	CreationHeight   int64     `json:"creation_height"`
+	MinTime          time.Time `json:"min_time"`
+	InitialBalance   sdk.Coin  `json:"initial_balance"`
+	Balance          sdk.Coin  `json:"balance"`
+	SharesSrc        sdk.Dec   `json:"shares_src"`
+	SharesDst        sdk.Dec   `json:"shares_dst"`
+}
+
+func (ctx Context) validatorResult(val *validator) (validatorResult, error) {
+	consPubKey, err := ctx.AddrPrefixCfg().Bech32ifyConsPub(val.ConsPubKey)
+	if err != nil {
+		return validatorResult{}, err
+	}
+	return validatorResult{
+		OperatorAddr:     ctx.AddrPrefixCfg().ValAddressString(val.Operator),
+		ConsPubKey:       consPubKey,
+		Status:           bondedStatus,
+		Tokens:           val.Tokens.String(),
+		DelegatorShares:  val.Shares.String(),
+		Description:      val.Description,
+		BondHeight:       val.BondHeight,
+		UnbondingMinTime: time.Unix(0, 0).UTC(),
+		Commission: staking.Commission{
+			Rate:          val.Commission,
+			MaxRate:       sdk.OneDec(),
+			MaxChangeRate: sdk.OneDec(),
+			UpdateTime:    val.BondTime,
+		},
+	}, nil
+}
+
+func (ctx Context) delegationResults(ds []*delegation) []delegationResult {
+	results := make([]delegationResult, len(ds))
+	for i, d := range ds {
+		results[i] = delegationResult{
+			DelegatorAddr: ctx.AddrPrefixCfg().AccAddressString(d.Delegator),
+			ValidatorAddr: ctx.AddrPrefixCfg().ValAddressString(d.Validator),
+			Shares:        d.Shares,
+			Height:        d.Height,
+		}
+	}
+	return results
+}
+
+func (ctx Context) unbondingResults(ubds []*unbonding) []unbondingResult {
+	results := make([]unbondingResult, len(ubds))
+	for i, ubd := range ubds {
+		results[i] = unbondingResult{
+			TxHash:         ubd.TxHash,
+			DelegatorAddr:  ctx.AddrPrefixCfg().AccAddressString(ubd.Delegator),
+			ValidatorAddr:  ctx.AddrPrefixCfg().ValAddressString(ubd.Validator),
+			CreationHeight: ubd.CreationHeight,
+			MinTime:        ubd.MinTime,
+			InitialBalance: ubd.InitialBalance,
+			Balance:        ubd.Balance,
+		}
+	}
+	return results
+}
+
+func (ctx Context) redelegationResults(reds []*redelegation) []redelegationResult {
+	results := make([]redelegationResult, len(reds))
+	for i, red := range reds {
+		results[i] = redelegationResult{
+			DelegatorAddr:    ctx.AddrPrefixCfg().AccAddressString(red.Delegator),
+			ValidatorSrcAddr: ctx.AddrPrefixCfg().ValAddressString(red.ValidatorSrc),
+			ValidatorDstAddr: ctx.AddrPrefixCfg().ValAddressString(red.ValidatorDst),
+			CreationHeight:   red.CreationHeight,
+			MinTime:          red.MinTime,
+			InitialBalance:   red.InitialBalance,
+			Balance:          red.Balance,
+			SharesSrc:        red.SharesSrc,
+			SharesDst:        red.SharesDst,
+		}
+	}
+	return results
+}
+
+type stakeQueryParams struct {
+	DelegatorAddr sdk.AccAddress
+	ValidatorAddr sdk.ValAddress
+	ValSrcAddr    sdk.ValAddress
+	ValDstAddr    sdk.ValAddress
+}
+
+func decodeStakeQueryParams(ctx Context, data []byte) (stakeQueryParams, error) {
+	var params stakeQueryParams
+	if err := ctx.DecodeJSON(data, &params); err != nil {
+		return params, NewError(sdk.ErrJsonUnmarshal, err.Error())
+	}
+	return params, nil
+}
+
+func queryValidator(ctx Context, data []byte) ([]byte, error) {
+	params, err := decodeStakeQueryParams(ctx, data)
+	if err != nil {
+		return nil, err
+	}
+
+	val, ok := ctx.Validator(params.ValidatorAddr)
+	if !ok {
+		return nil, NewError(sdk.InvalidRequest, "validator %s does not exist", ctx.AddrPrefixCfg().ValAddressString(params.ValidatorAddr))
+	}
+	res, err := ctx.validatorResult(val)
+	if err != nil {
+		return nil, err
+	}
+	return ctx.encodeRegistered("irishub/stake/Validator", res)
+}
+
+func queryValidators(ctx Context, data []byte) ([]byte, error) {
+	var params struct {
+		Page uint64
+		Size uint16
+	}
+	if err := ctx.DecodeJSON(data, &params); err != nil {
+		return nil, NewError(sdk.ErrJsonUnmarshal, err.Error())
+	}
+	if params.Page == 0 || params.Size == 0 {
+		return nil, NewError(sdk.InvalidRequest, "page and size must be greater than 0")
+	}
+
+	validators := ctx.Validators()
+	start := (params.Page - 1) * uint64(params.Size)
+	if start > uint64(len(validators)) {
+		start = uint64(len(validators))
+	}
+	end := start + uint64(params.Size)
+	if end > uint64(len(validators)) {
+		end = uint64(len(validators))
+	}
+
+	results := []validatorResult{}
+	for _, val := range validators[start:end] {
+		res, err := ctx.validatorResult(val)
+		if err != nil {
+			return nil, err
+		}
+		results = append(results, res)
+	}
+	return ctx.EncodeJSON(results)
+}
+
+func queryDelegation(ctx Context, data []byte) ([]byte, error) {
+	params, err := decodeStakeQueryParams(ctx, data)
+	if err != nil {
+		return nil, err
+	}
+
+	d, ok := ctx.Delegation(params.DelegatorAddr, params.ValidatorAddr)
+	if !ok {
+		return nil, NewError(sdk.InvalidRequest, "no delegation of %s to %s",
+			ctx.AddrPrefixCfg().AccAddressString(params.DelegatorAddr), ctx.AddrPrefixCfg().ValAddressString(params.ValidatorAddr))
+	}
+	return ctx.encodeRegistered("irishub/stake/Delegation", ctx.delegationResults([]*delegation{d})[0])
+}
+
+func queryDelegatorDelegations(ctx Context, data []byte) ([]byte, error) {
+	params, err := decodeStakeQueryParams(ctx, data)
+	if err != nil {
+		return nil, err
+	}
+	return ctx.EncodeJSON(ctx.delegationResults(ctx.Delegations(func(d *delegation) bool {
+		return d.Delegator.Equals(params.DelegatorAddr)
+	})))
+}
+
+func queryValidatorDelegations(ctx Context, data []byte) ([]byte, error) {
+	params, err := decodeStakeQueryParams(ctx, data)
+	if err != nil {
+		return nil, err
+	}
+	return ctx.EncodeJSON(ctx.delegationResults(ctx.Delegations(func(d *delegation) bool {
+		return d.Validator.Equals(params.ValidatorAddr)
+	})))
+}
+
+func queryUnbondingDelegation(ctx Context, data []byte) ([]byte, error) {
+	params, err := decodeStakeQueryParams(ctx, data)
+	if err != nil {
+		return nil, err
+	}
+
+	ubd, ok := ctx.unbondings[delegationKey(params.DelegatorAddr, params.ValidatorAddr)]
+	if !ok {
+		return nil, NewError(sdk.InvalidRequest, "no unbonding delegation of %s from %s",
+			ctx.AddrPrefixCfg().AccAddressString(params.DelegatorAddr), ctx.AddrPrefixCfg().ValAddressString(params.ValidatorAddr))
+	}
+	return ctx.encodeRegistered("irishub/stake/UnbondingDelegation", ctx.unbondingResults([]*unbonding{ubd})[0])
+}
+
+func queryDelegatorUnbondingDelegations(ctx Context, data []byte) ([]byte, error) {
+	params, err := decodeStakeQueryParams(ctx, data)
+	if err != nil {
+		return nil, err
+	}
+	return ctx.EncodeJSON(ctx.unbondingResults(ctx.Unbondings(func(ubd *unbonding) bool {
+		return ubd.Delegator.Equals(params.DelegatorAddr)
+	})))
+}
+
+func queryValidatorUnbondingDelegations(ctx Context, data []byte) ([]byte, error) {
+	params, err := decodeStakeQueryParams(ctx, data)
+	if err != nil {
+		return nil, err
+	}
+	return ctx.EncodeJSON(ctx.unbondingResults(ctx.Unbondings(func(ubd *unbonding) bool {
+		return ubd.Validator.Equals(params.ValidatorAddr)
+	})))
+}
+
+func queryRedelegation(ctx Context, data []byte) ([]byte, error) {
+	params, err := decodeStakeQueryParams(ctx, data)
+	if err != nil {
+		return nil, err
+	}
+
+	red, ok := ctx.redelegations[delegationKey(params.DelegatorAddr, params.ValSrcAddr)+"/"+params.ValDstAddr.String()]
+	if !ok {
+		return nil, NewError(sdk.InvalidRequest, "no redelegation of %s from %s to %s",
+			ctx.AddrPrefixCfg().AccAddressString(params.DelegatorAddr),
+			ctx.AddrPrefixCfg().ValAddressString(params.ValSrcAddr),
+			ctx.AddrPrefixCfg().ValAddressString(params.ValDstAddr))
+	}
+	return ctx.encodeRegistered("irishub/stake/Redelegation", ctx.redelegationResults([]*redelegation{red})[0])
+}
+
+func queryDelegatorRedelegations(ctx Context, data []byte) ([]byte, error) {
+	params, err := decodeStakeQueryParams(ctx, data)
+	if err != nil {
+		return nil, err
+	}
+	return ctx.EncodeJSON(ctx.redelegationResults(ctx.Redelegations(func(red *redelegation) bool {
+		return red.Delegator.Equals(params.DelegatorAddr)
+	})))
+}
+
+func queryValidatorRedelegations(ctx Context, data []byte) ([]byte, error) {
+	params, err := decodeStakeQueryParams(ctx, data)
+	if err != nil {
+		return nil, err
+	}
+	return ctx.EncodeJSON(ctx.redelegationResults(ctx.Redelegations(func(red *redelegation) bool {
+		return red.ValidatorSrc.Equals(params.ValidatorAddr)
+	})))
+}
+
+func queryPool(ctx Context, _ []byte) ([]byte, error) {
+	supply, burned := ctx.Supply()
+	bonded, unbonding := ctx.BondedTokens()
+	loose := sdk.NewDecFromInt(amountOf(supply, sdk.IRIS.MinUnit).Sub(amountOf(burned, sdk.IRIS.MinUnit))).
+		Sub(bonded).Sub(unbonding)
+	return ctx.EncodeJSON(struct {
+		LooseTokens  sdk.Dec `json:"loose_tokens"`
+		BondedTokens sdk.Dec `json:"bonded_tokens"`
+	}{
+		LooseTokens:  loose,
+		BondedTokens: bonded,
+	})
+}
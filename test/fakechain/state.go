@@ -0,0 +1,276 @@
+package fakechain
+
+import (
+	"fmt"
+	"strings"
+
+	sdk "github.com/irisnet/irishub-sdk-go/types"
+)
+
+// State is the application state of the chain, it is not safe for concurrent use
+// and must only be accessed through handlers, queriers or the Chain methods
+type State struct {
+	accounts      map[string]*sdk.BaseAccount
+	accountNumber uint64
+	tokens        map[string]sdk.Token
+	params        map[string]interface{}
+	supply        sdk.Coins
+	burned        sdk.Coins
+
+	validators    map[string]*validator
+	delegations   map[string]*delegation
+	unbondings    map[string]*unbonding
+	redelegations map[string]*redelegation
+	withdrawAddrs map[string]sdk.AccAddress
+	randRequests  map[string]*randRequest
+
+	serviceDefinitions   map[string]*serviceDefinition
+	serviceBindings      map[string]*serviceBinding
+	serviceWithdrawAddrs map[string]sdk.AccAddress
+	requestContexts      map[string]*requestContext
+	serviceRequests      map[string]*serviceRequest
+	serviceResponses     map[string]*serviceResponse
+	earnedFees           map[string]sdk.Coins
+	serviceTax           sdk.Coins
+	feeds                map[string]*feed
+	// the profilers and trustees of the guardian module
+	guardians map[string]bool
+}
+
+func NewState() *State {
+	return &State{
+		accounts:      make(map[string]*sdk.BaseAccount),
+		tokens:        make(map[string]sdk.Token),
+		params:        defaultParams(),
+		validators:    make(map[string]*validator),
+		delegations:   make(map[string]*delegation),
+		unbondings:    make(map[string]*unbonding),
+		redelegations: make(map[string]*redelegation),
+		withdrawAddrs: make(map[string]sdk.AccAddress),
+		randRequests:  make(map[string]*randRequest),
+
+		serviceDefinitions:   make(map[string]*serviceDefinition),
+		serviceBindings:      make(map[string]*serviceBinding),
+		serviceWithdrawAddrs: make(map[string]sdk.AccAddress),
+		requestContexts:      make(map[string]*requestContext),
+		serviceRequests:      make(map[string]*serviceRequest),
+		serviceResponses:     make(map[string]*serviceResponse),
+		earnedFees:           make(map[string]sdk.Coins),
+		feeds:                make(map[string]*feed),
+		guardians:            make(map[string]bool),
+	}
+}
+
+// Account returns the account of the address
+func (s *State) Account(addr sdk.AccAddress) (*sdk.BaseAccount, bool) {
+	acc, ok := s.accounts[addr.String()]
+	return acc, ok
+}
+
+// GetOrCreateAccount returns the account of the address, a new account number is assigned if it does not exist
+func (s *State) GetOrCreateAccount(addr sdk.AccAddress) *sdk.BaseAccount {
+	if acc, ok := s.Account(addr); ok {
+		return acc
+	}
+	acc := &sdk.BaseAccount{
+		Address:       addr,
+		AccountNumber: s.accountNumber,
+	}
+	s.accountNumber++
+	s.accounts[addr.String()] = acc
+	return acc
+}
+
+// AddCoins adds the coins to the balance of the address
+func (s *State) AddCoins(addr sdk.AccAddress, coins sdk.Coins) {
+	acc := s.GetOrCreateAccount(addr)
+	acc.Coins = acc.Coins.Add(coins...)
+}
+
+// SubtractCoins subtracts the coins from the balance of the address
+func (s *State) SubtractCoins(addr sdk.AccAddress, coins sdk.Coins) error {
+	acc, ok := s.Account(addr)
+	if !ok {
+		return NewError(sdk.UnknownAddress, "account %s does not exist", addr.String())
+	}
+
+	balance, hasNeg := acc.Coins.SafeAdd(negative(coins))
+	if hasNeg {
+		return NewError(sdk.InsufficientFunds, "insufficient account funds; %s < %s", acc.Coins, coins)
+	}
+	acc.Coins = balance
+	return nil
+}
+
+// Mint creates the coins and adds them to the balance of the address
+func (s *State) Mint(addr sdk.AccAddress, coins sdk.Coins) {
+	s.AddCoins(addr, coins)
+	s.supply = s.supply.Add(coins...)
+}
+
+// Burn destroys the coins of the address
+func (s *State) Burn(addr sdk.AccAddress, coins sdk.Coins) error {
+	if err := s.SubtractCoins(addr, coins); err != nil {
+		return err
+	}
+	s.burned = s.burned.Add(coins...)
+	return nil
+}
+
+// Supply returns the total supply and the burned coins
+func (s *State) Supply() (supply sdk.Coins, burned sdk.Coins) {
+	return s.supply, s.burned
+}
+
+// Token returns the token by symbol or min unit
+func (s *State) Token(symbol string) (sdk.Token, bool) {
+	symbol = strings.ToLower(symbol)
+	if symbol == sdk.IRIS.Symbol || symbol == sdk.IRIS.MinUnit {
+		return sdk.IRIS, true
+	}
+	for _, t := range s.tokens {
+		if t.Symbol == symbol || t.GetMinUnit() == symbol {
+			return t, true
+		}
+	}
+	return sdk.Token{}, false
+}
+
+// Tokens returns all the tokens, including iris
+func (s *State) Tokens() sdk.Tokens {
+	tokens := sdk.Tokens{sdk.IRIS}
+	for _, t := range s.tokens {
+		tokens = append(tokens, t)
+	}
+	return tokens
+}
+
+func (s *State) SetToken(token sdk.Token) {
+	token.Symbol = strings.ToLower(token.Symbol)
+	s.tokens[token.Symbol] = token
+}
+
+// Params returns the params of the module
+func (s *State) Params(module string) (interface{}, bool) {
+	params, ok := s.params[module]
+	return params, ok
+}
+
+func (s *State) SetParams(module string, params interface{}) {
+	s.params[module] = params
+}
+
+// snapshot returns a copy of the accounts, the staking, distribution, random, service and oracle records
+// which can be restored when a message fails
+func (s *State) snapshot() State {
+	cp := *s
+	cp.accounts = make(map[string]*sdk.BaseAccount, len(s.accounts))
+	for k, acc := range s.accounts {
+		a := *acc
+		cp.accounts[k] = &a
+	}
+	cp.validators = make(map[string]*validator, len(s.validators))
+	for k, v := range s.validators {
+		val := *v
+		cp.validators[k] = &val
+	}
+	cp.delegations = make(map[string]*delegation, len(s.delegations))
+	for k, d := range s.delegations {
+		del := *d
+		cp.delegations[k] = &del
+	}
+	cp.unbondings = make(map[string]*unbonding, len(s.unbondings))
+	for k, ubd := range s.unbondings {
+		u := *ubd
+		cp.unbondings[k] = &u
+	}
+	cp.redelegations = make(map[string]*redelegation, len(s.redelegations))
+	for k, red := range s.redelegations {
+		r := *red
+		cp.redelegations[k] = &r
+	}
+	cp.withdrawAddrs = make(map[string]sdk.AccAddress, len(s.withdrawAddrs))
+	for k, addr := range s.withdrawAddrs {
+		cp.withdrawAddrs[k] = addr
+	}
+	cp.randRequests = make(map[string]*randRequest, len(s.randRequests))
+	for k, req := range s.randRequests {
+		r := *req
+		cp.randRequests[k] = &r
+	}
+	cp.serviceDefinitions = make(map[string]*serviceDefinition, len(s.serviceDefinitions))
+	for k, def := range s.serviceDefinitions {
+		d := *def
+		cp.serviceDefinitions[k] = &d
+	}
+	cp.serviceBindings = make(map[string]*serviceBinding, len(s.serviceBindings))
+	for k, binding := range s.serviceBindings {
+		b := *binding
+		cp.serviceBindings[k] = &b
+	}
+	cp.serviceWithdrawAddrs = make(map[string]sdk.AccAddress, len(s.serviceWithdrawAddrs))
+	for k, addr := range s.serviceWithdrawAddrs {
+		cp.serviceWithdrawAddrs[k] = addr
+	}
+	cp.requestContexts = make(map[string]*requestContext, len(s.requestContexts))
+	for k, reqCtx := range s.requestContexts {
+		r := *reqCtx
+		cp.requestContexts[k] = &r
+	}
+	cp.serviceRequests = make(map[string]*serviceRequest, len(s.serviceRequests))
+	for k, req := range s.serviceRequests {
+		r := *req
+		cp.serviceRequests[k] = &r
+	}
+	cp.serviceResponses = make(map[string]*serviceResponse, len(s.serviceResponses))
+	for k, resp := range s.serviceResponses {
+		r := *resp
+		cp.serviceResponses[k] = &r
+	}
+	cp.earnedFees = make(map[string]sdk.Coins, len(s.earnedFees))
+	for k, fees := range s.earnedFees {
+		cp.earnedFees[k] = fees
+	}
+	cp.feeds = make(map[string]*feed, len(s.feeds))
+	for k, fd := range s.feeds {
+		f := *fd
+		cp.feeds[k] = &f
+	}
+	return cp
+}
+
+func (s *State) restore(snapshot State) {
+	*s = snapshot
+}
+
+func negative(coins sdk.Coins) sdk.Coins {
+	neg := make(sdk.Coins, len(coins))
+	for i, c := range coins {
+		neg[i] = sdk.Coin{Denom: c.Denom, Amount: c.Amount.Neg()}
+	}
+	return neg
+}
+
+// Error is returned by handlers to set the code of the transaction result
+type Error struct {
+	Code sdk.Code
+	Log  string
+}
+
+func NewError(code sdk.Code, format string, args ...interface{}) Error {
+	return Error{
+		Code: code,
+		Log:  fmt.Sprintf(format, args...),
+	}
+}
+
+func (e Error) Error() string {
+	return e.Log
+}
+
+func errorCode(err error, defaultCode sdk.Code) (uint32, string) {
+	if e, ok := err.(Error); ok {
+		return uint32(e.Code), e.Log
+	}
+	return uint32(defaultCode), err.Error()
+}
@@ -0,0 +1,149 @@
+// Package recorder captures the RPC traffic between the SDK and a node, and replays it later,
+// so that integration tests can run deterministically without a node.
+//
+// A Recorder wraps a types.TmClient and saves every request/response pair, as well as the
+// websocket events, to a golden file (a cassette). A Replayer serves the cassette and fails on
+// any request which was not recorded:
+//
+// 	// once, against a node
+// 	rec := recorder.NewRemote("localhost:26657", "testdata/bank.json")
+// 	client := sdk.NewClient(types.ClientConfig{TmClient: rec, ...})
+//
+// 	// in CI
+// 	replayer, err := recorder.NewReplayer("testdata/bank.json")
+// 	client := sdk.NewClient(types.ClientConfig{TmClient: replayer, ...})
+//
+// Replaying only works if the test sends the same requests in the same order as the recording,
+// so the test must not depend on random data such as newly generated keys: the tests recover
+// their accounts from test.Mnemonic. The module suites are recorded by `make test_record` against
+// a node: a recording of the in-process chain would only replay what the chain already serves.
+package recorder
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	amino "github.com/tendermint/go-amino"
+	cmn "github.com/tendermint/tendermint/libs/common"
+	rpcclient "github.com/tendermint/tendermint/rpc/client"
+	ctypes "github.com/tendermint/tendermint/rpc/core/types"
+	tmtypes "github.com/tendermint/tendermint/types"
+)
+
+// methods of the recorded requests, named after the tendermint RPC routes
+const (
+	methodABCIInfo          = "abci_info"
+	methodABCIQuery         = "abci_query"
+	methodBroadcastTxCommit = "broadcast_tx_commit"
+	methodBroadcastTxAsync  = "broadcast_tx_async"
+	methodBroadcastTxSync   = "broadcast_tx_sync"
+	methodBlock             = "block"
+	methodBlockResults      = "block_results"
+	methodCommit            = "commit"
+	methodValidators        = "validators"
+	methodTx                = "tx"
+	methodTxSearch          = "tx_search"
+)
+
+// types of the recorded events
+const (
+	eventNewBlock            = "new_block"
+	eventNewBlockHeader      = "new_block_header"
+	eventTx                  = "tx"
+	eventValidatorSetUpdates = "validator_set_updates"
+)
+
+// cdc encodes the results of the tendermint RPC
+var cdc = amino.NewCodec()
+
+func init() {
+	ctypes.RegisterAmino(cdc)
+}
+
+// Cassette is the content of a golden file
+type Cassette struct {
+	Interactions []Interaction `json:"interactions"`
+	Events       []Event       `json:"events"`
+}
+
+// Interaction is a request and the response of the node
+type Interaction struct {
+	Method   string          `json:"method"`
+	Request  string          `json:"request"`
+	Response json.RawMessage `json:"response,omitempty"`
+	Error    string          `json:"error,omitempty"`
+}
+
+// Event is a websocket event received by a subscription
+type Event struct {
+	// After is the number of interactions completed before the event was received
+	After int             `json:"after"`
+	Query string          `json:"query"`
+	Type  string          `json:"type"`
+	Data  json.RawMessage `json:"data"`
+}
+
+func (i Interaction) key() string {
+	return key(i.Method, i.Request)
+}
+
+func key(method, request string) string {
+	return fmt.Sprintf("%s %s", method, request)
+}
+
+// Load reads the cassette from the file
+func Load(path string) (Cassette, error) {
+	var cassette Cassette
+	bz, err := ioutil.ReadFile(path)
+	if err != nil {
+		return cassette, err
+	}
+	if err := json.Unmarshal(bz, &cassette); err != nil {
+		return cassette, fmt.Errorf("invalid cassette %s: %s", path, err.Error())
+	}
+	return cassette, nil
+}
+
+// Save writes the cassette to the file, the directory is created if it does not exist
+func (c Cassette) Save(path string) error {
+	bz, err := json.MarshalIndent(c, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+
+	tmp := path + ".tmp"
+	if err := ioutil.WriteFile(tmp, bz, 0644); err != nil {
+		return err
+	}
+	return os.Rename(tmp, path)
+}
+
+func abciQueryRequest(path string, data cmn.HexBytes, opts rpcclient.ABCIQueryOptions) string {
+	return fmt.Sprintf("path=%s data=%X height=%d prove=%t", path, []byte(data), opts.Height, opts.Prove)
+}
+
+// broadcastRequest identifies the transaction by its hash to keep the golden files readable
+func broadcastRequest(tx tmtypes.Tx) string {
+	return fmt.Sprintf("hash=%X", tx.Hash())
+}
+
+func heightRequest(height *int64) string {
+	if height == nil {
+		return "height=latest"
+	}
+	return fmt.Sprintf("height=%d", *height)
+}
+
+func txRequest(hash []byte, prove bool) string {
+	return fmt.Sprintf("hash=%X prove=%t", hash, prove)
+}
+
+func txSearchRequest(query string, prove bool, page, perPage int) string {
+	return fmt.Sprintf("query=%s prove=%t page=%d per_page=%d", query, prove, page, perPage)
+}
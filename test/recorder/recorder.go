@@ -0,0 +1,280 @@
+package recorder
+
+import (
+	"encoding/json"
+	"errors"
+	"sync"
+
+	cmn "github.com/tendermint/tendermint/libs/common"
+	rpcclient "github.com/tendermint/tendermint/rpc/client"
+	ctypes "github.com/tendermint/tendermint/rpc/core/types"
+	tmtypes "github.com/tendermint/tendermint/types"
+
+	"github.com/irisnet/irishub-sdk-go/modules"
+	sdk "github.com/irisnet/irishub-sdk-go/types"
+	"github.com/irisnet/irishub-sdk-go/utils/log"
+)
+
+var (
+	_ sdk.TmClient    = &Recorder{}
+	_ sdk.CodecSetter = &Recorder{}
+)
+
+// Recorder is a types.TmClient which forwards the requests to another client
+// and saves the traffic to a cassette after each request
+type Recorder struct {
+	mu       sync.Mutex
+	path     string
+	cdc      sdk.Codec
+	tmClient sdk.TmClient
+	dial     func(cdc sdk.Codec) sdk.TmClient
+	cassette Cassette
+	err      error
+}
+
+// New returns a Recorder which wraps the client and writes the cassette to path
+func New(client sdk.TmClient, path string) *Recorder {
+	return &Recorder{
+		path:     path,
+		tmClient: client,
+	}
+}
+
+// NewRemote returns a Recorder which connects to the node once it receives the codec of the SDK client
+func NewRemote(nodeURI, path string) *Recorder {
+	return &Recorder{
+		path: path,
+		dial: func(cdc sdk.Codec) sdk.TmClient {
+			return modules.NewRPCClient(nodeURI, cdc, log.NewLogger("info"))
+		},
+	}
+}
+
+// SetCodec implements types.CodecSetter, the codec is used to encode the events
+func (r *Recorder) SetCodec(cdc sdk.Codec) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.cdc != nil {
+		return
+	}
+
+	r.cdc = cdc
+	if r.tmClient == nil && r.dial != nil {
+		r.tmClient = r.dial(cdc)
+	}
+	if setter, ok := r.tmClient.(sdk.CodecSetter); ok {
+		setter.SetCodec(cdc)
+	}
+}
+
+// Cassette returns a copy of the recorded traffic
+func (r *Recorder) Cassette() Cassette {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return Cassette{
+		Interactions: append([]Interaction{}, r.cassette.Interactions...),
+		Events:       append([]Event{}, r.cassette.Events...),
+	}
+}
+
+// Err returns the first error that occurred while saving the cassette
+func (r *Recorder) Err() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.err
+}
+
+func (r *Recorder) ABCIInfo() (*ctypes.ResultABCIInfo, error) {
+	res, err := r.client().ABCIInfo()
+	r.record(methodABCIInfo, "", res, err)
+	return res, err
+}
+
+func (r *Recorder) ABCIQuery(path string, data cmn.HexBytes) (*ctypes.ResultABCIQuery, error) {
+	res, err := r.client().ABCIQuery(path, data)
+	r.record(methodABCIQuery, abciQueryRequest(path, data, rpcclient.DefaultABCIQueryOptions), res, err)
+	return res, err
+}
+
+func (r *Recorder) ABCIQueryWithOptions(path string, data cmn.HexBytes,
+	opts rpcclient.ABCIQueryOptions) (*ctypes.ResultABCIQuery, error) {
+	res, err := r.client().ABCIQueryWithOptions(path, data, opts)
+	r.record(methodABCIQuery, abciQueryRequest(path, data, opts), res, err)
+	return res, err
+}
+
+func (r *Recorder) BroadcastTxCommit(tx tmtypes.Tx) (*ctypes.ResultBroadcastTxCommit, error) {
+	res, err := r.client().BroadcastTxCommit(tx)
+	r.record(methodBroadcastTxCommit, broadcastRequest(tx), res, err)
+	return res, err
+}
+
+func (r *Recorder) BroadcastTxAsync(tx tmtypes.Tx) (*ctypes.ResultBroadcastTx, error) {
+	res, err := r.client().BroadcastTxAsync(tx)
+	r.record(methodBroadcastTxAsync, broadcastRequest(tx), res, err)
+	return res, err
+}
+
+func (r *Recorder) BroadcastTxSync(tx tmtypes.Tx) (*ctypes.ResultBroadcastTx, error) {
+	res, err := r.client().BroadcastTxSync(tx)
+	r.record(methodBroadcastTxSync, broadcastRequest(tx), res, err)
+	return res, err
+}
+
+func (r *Recorder) Block(height *int64) (*ctypes.ResultBlock, error) {
+	res, err := r.client().Block(height)
+	r.record(methodBlock, heightRequest(height), res, err)
+	return res, err
+}
+
+func (r *Recorder) BlockResults(height *int64) (*ctypes.ResultBlockResults, error) {
+	res, err := r.client().BlockResults(height)
+	r.record(methodBlockResults, heightRequest(height), res, err)
+	return res, err
+}
+
+func (r *Recorder) Commit(height *int64) (*ctypes.ResultCommit, error) {
+	res, err := r.client().Commit(height)
+	r.record(methodCommit, heightRequest(height), res, err)
+	return res, err
+}
+
+func (r *Recorder) Validators(height *int64) (*ctypes.ResultValidators, error) {
+	res, err := r.client().Validators(height)
+	r.record(methodValidators, heightRequest(height), res, err)
+	return res, err
+}
+
+func (r *Recorder) Tx(hash []byte, prove bool) (*ctypes.ResultTx, error) {
+	res, err := r.client().Tx(hash, prove)
+	r.record(methodTx, txRequest(hash, prove), res, err)
+	return res, err
+}
+
+func (r *Recorder) TxSearch(query string, prove bool, page, perPage int) (*ctypes.ResultTxSearch, error) {
+	res, err := r.client().TxSearch(query, prove, page, perPage)
+	r.record(methodTxSearch, txSearchRequest(query, prove, page, perPage), res, err)
+	return res, err
+}
+
+func (r *Recorder) SubscribeNewBlock(builder *sdk.EventQueryBuilder, handler sdk.EventNewBlockHandler) (sdk.Subscription, sdk.Error) {
+	return r.subscribe(eventNewBlock, func(record sdk.EventHandler) (sdk.Subscription, sdk.Error) {
+		return r.client().SubscribeNewBlock(builder, func(data sdk.EventDataNewBlock) {
+			record(data)
+			handler(data)
+		})
+	})
+}
+
+func (r *Recorder) SubscribeTx(builder *sdk.EventQueryBuilder, handler sdk.EventTxHandler) (sdk.Subscription, sdk.Error) {
+	return r.subscribe(eventTx, func(record sdk.EventHandler) (sdk.Subscription, sdk.Error) {
+		return r.client().SubscribeTx(builder, func(data sdk.EventDataTx) {
+			record(data)
+			handler(data)
+		})
+	})
+}
+
+func (r *Recorder) SubscribeNewBlockHeader(handler sdk.EventNewBlockHeaderHandler) (sdk.Subscription, sdk.Error) {
+	return r.subscribe(eventNewBlockHeader, func(record sdk.EventHandler) (sdk.Subscription, sdk.Error) {
+		return r.client().SubscribeNewBlockHeader(func(data sdk.EventDataNewBlockHeader) {
+			record(data)
+			handler(data)
+		})
+	})
+}
+
+func (r *Recorder) SubscribeValidatorSetUpdates(handler sdk.EventValidatorSetUpdatesHandler) (sdk.Subscription, sdk.Error) {
+	return r.subscribe(eventValidatorSetUpdates, func(record sdk.EventHandler) (sdk.Subscription, sdk.Error) {
+		return r.client().SubscribeValidatorSetUpdates(func(data sdk.EventDataValidatorSetUpdates) {
+			record(data)
+			handler(data)
+		})
+	})
+}
+
+func (r *Recorder) Unsubscribe(subscription sdk.Subscription) sdk.Error {
+	return r.client().Unsubscribe(subscription)
+}
+
+func (r *Recorder) client() sdk.TmClient {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.tmClient
+}
+
+// subscribe calls fn with a handler which records the events of the returned subscription.
+// The query of the subscription is only known once fn returns, so the events wait for it.
+func (r *Recorder) subscribe(eventType string,
+	fn func(record sdk.EventHandler) (sdk.Subscription, sdk.Error)) (sdk.Subscription, sdk.Error) {
+	var query string
+	ready := make(chan struct{})
+	sub, err := fn(func(data sdk.EventData) {
+		<-ready
+		r.recordEvent(query, eventType, data)
+	})
+	query = sub.Query
+	close(ready)
+	return sub, err
+}
+
+func (r *Recorder) record(method, request string, res interface{}, err error) {
+	interaction := Interaction{
+		Method:  method,
+		Request: request,
+	}
+	if err != nil {
+		interaction.Error = err.Error()
+	} else {
+		bz, e := cdc.MarshalJSON(res)
+		if e != nil {
+			r.fail(e)
+			return
+		}
+		interaction.Response = json.RawMessage(bz)
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.cassette.Interactions = append(r.cassette.Interactions, interaction)
+	r.save()
+}
+
+func (r *Recorder) recordEvent(query, eventType string, data sdk.EventData) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.cdc == nil {
+		r.setErr(errors.New("codec of the recorder is not set"))
+		return
+	}
+	bz, err := r.cdc.MarshalJSON(data)
+	if err != nil {
+		r.setErr(err)
+		return
+	}
+	r.cassette.Events = append(r.cassette.Events, Event{
+		After: len(r.cassette.Interactions),
+		Query: query,
+		Type:  eventType,
+		Data:  json.RawMessage(bz),
+	})
+	r.save()
+}
+
+// save must be called with the lock held
+func (r *Recorder) save() {
+	r.setErr(r.cassette.Save(r.path))
+}
+
+func (r *Recorder) fail(err error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.setErr(err)
+}
+
+func (r *Recorder) setErr(err error) {
+	if r.err == nil {
+		r.err = err
+	}
+}
@@ -0,0 +1,133 @@
+package recorder_test
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+
+	sdk "github.com/irisnet/irishub-sdk-go"
+	"github.com/irisnet/irishub-sdk-go/rpc"
+	"github.com/irisnet/irishub-sdk-go/test/fakechain"
+	"github.com/irisnet/irishub-sdk-go/test/recorder"
+	"github.com/irisnet/irishub-sdk-go/types"
+)
+
+const (
+	chainID  = "test"
+	name     = "test1"
+	password = "11111111"
+	to       = "faa1hp29kuh22vpjjlnctmyml5s75evsnsd8r4x0mm"
+)
+
+type RecorderTestSuite struct {
+	suite.Suite
+	dir string
+}
+
+func TestRecorderTestSuite(t *testing.T) {
+	suite.Run(t, new(RecorderTestSuite))
+}
+
+func (rts *RecorderTestSuite) SetupTest() {
+	dir, err := ioutil.TempDir("", "recorder")
+	require.NoError(rts.T(), err)
+	rts.dir = dir
+}
+
+func (rts *RecorderTestSuite) TearDownTest() {
+	_ = os.RemoveAll(rts.dir)
+}
+
+// scenario subscribes to the transfers, sends one and queries the sender
+func (rts *RecorderTestSuite) scenario(client sdk.Client, from string) (types.ResultTx, types.BaseAccount, rpc.EventDataMsgSend) {
+	ch := make(chan rpc.EventDataMsgSend, 1)
+	sub := client.Bank().SubscribeSendTx(from, to, func(data rpc.EventDataMsgSend) {
+		ch <- data
+	})
+	defer func() {
+		_ = client.Unsubscribe(sub)
+	}()
+
+	coins, err := types.ParseDecCoins("1iris")
+	require.NoError(rts.T(), err)
+	result, err := client.Bank().Send(to, coins, types.BaseTx{
+		From:     name,
+		Gas:      20000,
+		Memo:     "test",
+		Mode:     types.Commit,
+		Password: password,
+	})
+	require.NoError(rts.T(), err)
+
+	acc, err := client.Bank().QueryAccount(from)
+	require.NoError(rts.T(), err)
+
+	select {
+	case data := <-ch:
+		return result, acc, data
+	case <-time.After(5 * time.Second):
+		rts.T().Fatal("no event received")
+	}
+	return result, acc, rpc.EventDataMsgSend{}
+}
+
+func (rts *RecorderTestSuite) TestRecordAndReplay() {
+	path := filepath.Join(rts.dir, "testdata", "bank.json")
+
+	chain := fakechain.New(fakechain.WithChainID(chainID))
+	rec := recorder.New(chain, path)
+	client, from := rts.newClient(rec)
+	balance := types.NewCoin(types.IRIS.MinUnit, types.NewIntWithDecimal(10, int(types.IRIS.Scale)))
+	require.NoError(rts.T(), chain.Fund(from, types.NewCoins(balance)))
+	recorded, recordedAcc, recordedEvent := rts.scenario(client, from)
+	require.NoError(rts.T(), rec.Err())
+	require.NotEmpty(rts.T(), rec.Cassette().Interactions)
+	require.Len(rts.T(), rec.Cassette().Events, 1)
+
+	replayer, err := recorder.NewReplayer(path)
+	require.NoError(rts.T(), err)
+	client, from = rts.newClient(replayer)
+	replayed, replayedAcc, replayedEvent := rts.scenario(client, from)
+	require.Equal(rts.T(), recorded.Hash, replayed.Hash)
+	require.Equal(rts.T(), recorded.Height, replayed.Height)
+	require.Equal(rts.T(), recordedAcc, replayedAcc)
+	require.Equal(rts.T(), recordedEvent, replayedEvent)
+
+	// neither the transaction nor the block was recorded
+	_, err = replayer.BroadcastTxCommit([]byte("tx"))
+	require.Error(rts.T(), err)
+	height := int64(100)
+	_, err = replayer.Block(&height)
+	require.Error(rts.T(), err)
+}
+
+// newClient returns a client whose key is imported from the test keystore
+func (rts *RecorderTestSuite) newClient(tmClient types.TmClient) (sdk.Client, string) {
+	fees, err := types.ParseDecCoins("0.6iris")
+	require.NoError(rts.T(), err)
+
+	client := sdk.NewClient(types.ClientConfig{
+		TmClient:  tmClient,
+		Network:   types.Testnet,
+		ChainID:   chainID,
+		Gas:       20000,
+		Fee:       fees,
+		KeyDAO:    types.NewMemoryDB(),
+		Mode:      types.Commit,
+		StoreType: types.PrivKey,
+		Timeout:   10 * time.Second,
+		Level:     "info",
+		DBRootDir: rts.dir,
+	})
+
+	keystore, err := ioutil.ReadFile("../scripts/keystore1.json")
+	require.NoError(rts.T(), err)
+	address, err := client.Keys().Import(name, password, string(keystore))
+	require.NoError(rts.T(), err)
+	return client, address
+}
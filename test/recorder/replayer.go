@@ -0,0 +1,322 @@
+package recorder
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+
+	cmn "github.com/tendermint/tendermint/libs/common"
+	rpcclient "github.com/tendermint/tendermint/rpc/client"
+	ctypes "github.com/tendermint/tendermint/rpc/core/types"
+	tmtypes "github.com/tendermint/tendermint/types"
+
+	sdk "github.com/irisnet/irishub-sdk-go/types"
+)
+
+var (
+	_ sdk.TmClient    = &Replayer{}
+	_ sdk.CodecSetter = &Replayer{}
+)
+
+// Replayer is a types.TmClient which serves a cassette instead of a node.
+//
+// The responses of identical requests are served in the recorded order. Once they are all
+// served, the last one is served again, except for broadcasts which can only be replayed once.
+// A request which was never recorded returns an error.
+//
+// The recorded events are dispatched to the subscription with the same query, as soon as
+// the interactions which preceded them in the recording have been served.
+type Replayer struct {
+	mu  sync.Mutex
+	cdc sdk.Codec
+
+	queues map[string][]Interaction
+	last   map[string]Interaction
+	served int
+
+	events   []Event
+	consumed []bool
+	subs     map[string]replaySub
+	nextID   int
+}
+
+type replaySub struct {
+	sdk.Subscription
+	eventType string
+	handler   sdk.EventHandler
+}
+
+// NewReplayer loads the cassette from the file
+func NewReplayer(path string) (*Replayer, error) {
+	cassette, err := Load(path)
+	if err != nil {
+		return nil, err
+	}
+	return NewReplayerFromCassette(cassette), nil
+}
+
+// NewReplayerFromCassette returns a Replayer which serves the cassette
+func NewReplayerFromCassette(cassette Cassette) *Replayer {
+	r := &Replayer{
+		queues:   make(map[string][]Interaction),
+		last:     make(map[string]Interaction),
+		events:   cassette.Events,
+		consumed: make([]bool, len(cassette.Events)),
+		subs:     make(map[string]replaySub),
+	}
+	for _, i := range cassette.Interactions {
+		r.queues[i.key()] = append(r.queues[i.key()], i)
+	}
+	return r
+}
+
+// SetCodec implements types.CodecSetter, the codec is used to decode the events
+func (r *Replayer) SetCodec(cdc sdk.Codec) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.cdc == nil {
+		r.cdc = cdc
+	}
+}
+
+func (r *Replayer) ABCIInfo() (*ctypes.ResultABCIInfo, error) {
+	res := new(ctypes.ResultABCIInfo)
+	if err := r.replay(methodABCIInfo, "", res); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+func (r *Replayer) ABCIQuery(path string, data cmn.HexBytes) (*ctypes.ResultABCIQuery, error) {
+	return r.ABCIQueryWithOptions(path, data, rpcclient.DefaultABCIQueryOptions)
+}
+
+func (r *Replayer) ABCIQueryWithOptions(path string, data cmn.HexBytes,
+	opts rpcclient.ABCIQueryOptions) (*ctypes.ResultABCIQuery, error) {
+	res := new(ctypes.ResultABCIQuery)
+	if err := r.replay(methodABCIQuery, abciQueryRequest(path, data, opts), res); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+func (r *Replayer) BroadcastTxCommit(tx tmtypes.Tx) (*ctypes.ResultBroadcastTxCommit, error) {
+	res := new(ctypes.ResultBroadcastTxCommit)
+	if err := r.replay(methodBroadcastTxCommit, broadcastRequest(tx), res); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+func (r *Replayer) BroadcastTxAsync(tx tmtypes.Tx) (*ctypes.ResultBroadcastTx, error) {
+	res := new(ctypes.ResultBroadcastTx)
+	if err := r.replay(methodBroadcastTxAsync, broadcastRequest(tx), res); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+func (r *Replayer) BroadcastTxSync(tx tmtypes.Tx) (*ctypes.ResultBroadcastTx, error) {
+	res := new(ctypes.ResultBroadcastTx)
+	if err := r.replay(methodBroadcastTxSync, broadcastRequest(tx), res); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+func (r *Replayer) Block(height *int64) (*ctypes.ResultBlock, error) {
+	res := new(ctypes.ResultBlock)
+	if err := r.replay(methodBlock, heightRequest(height), res); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+func (r *Replayer) BlockResults(height *int64) (*ctypes.ResultBlockResults, error) {
+	res := new(ctypes.ResultBlockResults)
+	if err := r.replay(methodBlockResults, heightRequest(height), res); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+func (r *Replayer) Commit(height *int64) (*ctypes.ResultCommit, error) {
+	res := new(ctypes.ResultCommit)
+	if err := r.replay(methodCommit, heightRequest(height), res); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+func (r *Replayer) Validators(height *int64) (*ctypes.ResultValidators, error) {
+	res := new(ctypes.ResultValidators)
+	if err := r.replay(methodValidators, heightRequest(height), res); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+func (r *Replayer) Tx(hash []byte, prove bool) (*ctypes.ResultTx, error) {
+	res := new(ctypes.ResultTx)
+	if err := r.replay(methodTx, txRequest(hash, prove), res); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+func (r *Replayer) TxSearch(query string, prove bool, page, perPage int) (*ctypes.ResultTxSearch, error) {
+	res := new(ctypes.ResultTxSearch)
+	if err := r.replay(methodTxSearch, txSearchRequest(query, prove, page, perPage), res); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+func (r *Replayer) SubscribeNewBlock(builder *sdk.EventQueryBuilder, handler sdk.EventNewBlockHandler) (sdk.Subscription, sdk.Error) {
+	if builder == nil {
+		builder = sdk.NewEventQueryBuilder()
+	}
+	builder.AddCondition(sdk.Cond(sdk.TypeKey).EQ(tmtypes.EventNewBlock))
+	return r.subscribe(builder.Build(), eventNewBlock, func(data sdk.EventData) {
+		handler(data.(sdk.EventDataNewBlock))
+	}), nil
+}
+
+func (r *Replayer) SubscribeTx(builder *sdk.EventQueryBuilder, handler sdk.EventTxHandler) (sdk.Subscription, sdk.Error) {
+	if builder == nil {
+		builder = sdk.NewEventQueryBuilder()
+	}
+	q := builder.AddCondition(sdk.Cond(sdk.TypeKey).EQ(sdk.TxValue)).Build()
+	return r.subscribe(q, eventTx, func(data sdk.EventData) {
+		handler(data.(sdk.EventDataTx))
+	}), nil
+}
+
+func (r *Replayer) SubscribeNewBlockHeader(handler sdk.EventNewBlockHeaderHandler) (sdk.Subscription, sdk.Error) {
+	q := tmtypes.QueryForEvent(tmtypes.EventNewBlockHeader).String()
+	return r.subscribe(q, eventNewBlockHeader, func(data sdk.EventData) {
+		handler(data.(sdk.EventDataNewBlockHeader))
+	}), nil
+}
+
+func (r *Replayer) SubscribeValidatorSetUpdates(handler sdk.EventValidatorSetUpdatesHandler) (sdk.Subscription, sdk.Error) {
+	q := tmtypes.QueryForEvent(tmtypes.EventValidatorSetUpdates).String()
+	return r.subscribe(q, eventValidatorSetUpdates, func(data sdk.EventData) {
+		handler(data.(sdk.EventDataValidatorSetUpdates))
+	}), nil
+}
+
+func (r *Replayer) Unsubscribe(subscription sdk.Subscription) sdk.Error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if _, ok := r.subs[subscription.ID]; !ok {
+		return sdk.Wrapf("subscription %s not found", subscription.ID)
+	}
+	delete(r.subs, subscription.ID)
+	return nil
+}
+
+// replay decodes the next recorded response of the request into res
+func (r *Replayer) replay(method, request string, res interface{}) error {
+	r.mu.Lock()
+	k := key(method, request)
+	interaction, ok := r.next(k)
+	if !ok {
+		r.mu.Unlock()
+		return fmt.Errorf("unexpected request: %s", k)
+	}
+	r.served++
+	r.dispatch()
+	r.mu.Unlock()
+
+	if len(interaction.Error) > 0 {
+		return errors.New(interaction.Error)
+	}
+	return cdc.UnmarshalJSON(interaction.Response, res)
+}
+
+// next must be called with the lock held
+func (r *Replayer) next(k string) (Interaction, bool) {
+	queue := r.queues[k]
+	if len(queue) == 0 {
+		last, ok := r.last[k]
+		if !ok || strings.HasPrefix(last.Method, "broadcast_tx") {
+			return Interaction{}, false
+		}
+		return last, true
+	}
+
+	r.queues[k] = queue[1:]
+	r.last[k] = queue[0]
+	return queue[0], true
+}
+
+func (r *Replayer) subscribe(query, eventType string, handler sdk.EventHandler) sdk.Subscription {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.nextID++
+	sub := replaySub{
+		Subscription: sdk.Subscription{
+			Query: query,
+			ID:    fmt.Sprintf("replay-%d", r.nextID),
+		},
+		eventType: eventType,
+		handler:   handler,
+	}
+	r.subs[sub.ID] = sub
+	r.dispatch()
+	return sub.Subscription
+}
+
+// dispatch delivers the due events to their subscriptions, must be called with the lock held
+func (r *Replayer) dispatch() {
+	for i, e := range r.events {
+		if r.consumed[i] || e.After > r.served {
+			continue
+		}
+		for _, sub := range r.subs {
+			if sub.Query != e.Query || sub.eventType != e.Type {
+				continue
+			}
+			data, err := r.decodeEvent(e)
+			if err != nil {
+				break
+			}
+			r.consumed[i] = true
+			go func(sub replaySub, data sdk.EventData) {
+				defer sdk.CatchPanic(func(errMsg string) {})
+				sub.handler(data)
+			}(sub, data)
+			break
+		}
+	}
+}
+
+func (r *Replayer) decodeEvent(e Event) (sdk.EventData, error) {
+	if r.cdc == nil {
+		return nil, errors.New("codec of the replayer is not set")
+	}
+
+	switch e.Type {
+	case eventNewBlock:
+		var data sdk.EventDataNewBlock
+		err := r.cdc.UnmarshalJSON(e.Data, &data)
+		return data, err
+	case eventNewBlockHeader:
+		var data sdk.EventDataNewBlockHeader
+		err := r.cdc.UnmarshalJSON(e.Data, &data)
+		return data, err
+	case eventTx:
+		var data sdk.EventDataTx
+		err := r.cdc.UnmarshalJSON(e.Data, &data)
+		return data, err
+	case eventValidatorSetUpdates:
+		var data sdk.EventDataValidatorSetUpdates
+		err := r.cdc.UnmarshalJSON(e.Data, &data)
+		return data, err
+	default:
+		return nil, fmt.Errorf("unknown event type: %s", e.Type)
+	}
+}
@@ -23,10 +23,14 @@ const (
 // When marshaled to a string or JSON, it uses Bech32.
 type AccAddress []byte
 
-// AccAddressFromBech32 creates an AccAddress from a Bech32 string.
+// AccAddressFromBech32 creates an AccAddress from a Bech32 string with the default prefix.
 func AccAddressFromBech32(address string) (AccAddress, Error) {
-	bech32PrefixAccAddr := GetAddrPrefixCfg().GetBech32AccountAddrPrefix()
-	bz, err := bech32.GetFromBech32(address, bech32PrefixAccAddr)
+	return GetAddrPrefixCfg().AccAddressFromBech32(address)
+}
+
+// AccAddressFromBech32 creates an AccAddress from a Bech32 string with the account prefix of the config.
+func (config *AddrPrefixCfg) AccAddressFromBech32(address string) (AccAddress, Error) {
+	bz, err := bech32.GetFromBech32(address, config.GetBech32AccountAddrPrefix())
 	if err != nil {
 		return nil, Wrap(err)
 	}
@@ -34,23 +38,27 @@ func AccAddressFromBech32(address string) (AccAddress, Error) {
 	return AccAddress(bz), nil
 }
 
-func MustAccAddressFromBech32(address string) AccAddress {
-	addr, err := AccAddressFromBech32(address)
+// AccAddressString returns the Bech32 encoding of the address with the account prefix of the config.
+func (config *AddrPrefixCfg) AccAddressString(aa AccAddress) string {
+	bech32Addr, err := bech32.ConvertAndEncode(config.GetBech32AccountAddrPrefix(), aa.Bytes())
 	if err != nil {
 		panic(err)
 	}
-	return addr
+
+	return bech32Addr
 }
 
-// String implements the Stringer interface.
-func (aa AccAddress) String() string {
-	bech32PrefixAccAddr := GetAddrPrefixCfg().GetBech32AccountAddrPrefix()
-	bech32Addr, err := bech32.ConvertAndEncode(bech32PrefixAccAddr, aa.Bytes())
+func MustAccAddressFromBech32(address string) AccAddress {
+	addr, err := AccAddressFromBech32(address)
 	if err != nil {
 		panic(err)
 	}
+	return addr
+}
 
-	return bech32Addr
+// String implements the Stringer interface, with the default prefix.
+func (aa AccAddress) String() string {
+	return GetAddrPrefixCfg().AccAddressString(aa)
 }
 
 // Returns boolean for whether two AccAddresses are equal
@@ -90,7 +98,7 @@ func (aa AccAddress) MarshalJSON() ([]byte, error) {
 	return json.Marshal(aa.String())
 }
 
-// UnmarshalJSON unmarshals from JSON assuming Bech32 encoding.
+// UnmarshalJSON unmarshals from JSON assuming Bech32 encoding, whatever its prefix.
 func (aa *AccAddress) UnmarshalJSON(data []byte) error {
 	var s string
 	err := json.Unmarshal(data, &s)
@@ -98,15 +106,27 @@ func (aa *AccAddress) UnmarshalJSON(data []byte) error {
 		return err
 	}
 
-	aa2, err := AccAddressFromBech32(s)
+	bz, err := Bech32Bytes(s)
 	if err != nil {
 		return err
 	}
 
-	*aa = aa2
+	*aa = AccAddress(bz)
 	return nil
 }
 
+// AccAddressStrings returns the Bech32 encoding of the addresses with the account prefix of the config.
+func (config *AddrPrefixCfg) AccAddressStrings(addrs []AccAddress) []string {
+	if addrs == nil {
+		return nil
+	}
+	strs := make([]string, len(addrs))
+	for i, addr := range addrs {
+		strs[i] = config.AccAddressString(addr)
+	}
+	return strs
+}
+
 // Bytes returns the raw address bytes.
 func (aa AccAddress) Bytes() []byte {
 	return aa
@@ -134,10 +154,14 @@ func ValAddressFromHex(address string) (addr ValAddress, err error) {
 	return ValAddress(bz), nil
 }
 
-// ValAddressFromBech32 creates a ValAddress from a Bech32 string.
+// ValAddressFromBech32 creates a ValAddress from a Bech32 string with the default prefix.
 func ValAddressFromBech32(address string) (ValAddress, Error) {
-	bech32PrefixValAddr := GetAddrPrefixCfg().GetBech32ValidatorAddrPrefix()
-	bz, err := bech32.GetFromBech32(address, bech32PrefixValAddr)
+	return GetAddrPrefixCfg().ValAddressFromBech32(address)
+}
+
+// ValAddressFromBech32 creates a ValAddress from a Bech32 string with the validator prefix of the config.
+func (config *AddrPrefixCfg) ValAddressFromBech32(address string) (ValAddress, Error) {
+	bz, err := bech32.GetFromBech32(address, config.GetBech32ValidatorAddrPrefix())
 	if err != nil {
 		return nil, Wrap(err)
 	}
@@ -145,6 +169,16 @@ func ValAddressFromBech32(address string) (ValAddress, Error) {
 	return ValAddress(bz), nil
 }
 
+// ValAddressString returns the Bech32 encoding of the address with the validator prefix of the config.
+func (config *AddrPrefixCfg) ValAddressString(va ValAddress) string {
+	bech32Addr, err := bech32.ConvertAndEncode(config.GetBech32ValidatorAddrPrefix(), va.Bytes())
+	if err != nil {
+		panic(err)
+	}
+
+	return bech32Addr
+}
+
 // Returns boolean for whether two ValAddresses are equal
 func (va ValAddress) Equals(va2 ValAddress) bool {
 	if va.Empty() && va2.Empty() {
@@ -182,7 +216,7 @@ func (va ValAddress) MarshalJSON() ([]byte, error) {
 	return json.Marshal(va.String())
 }
 
-// UnmarshalJSON unmarshals from JSON assuming Bech32 encoding.
+// UnmarshalJSON unmarshals from JSON assuming Bech32 encoding, whatever its prefix.
 func (va *ValAddress) UnmarshalJSON(data []byte) error {
 	var s string
 
@@ -191,12 +225,12 @@ func (va *ValAddress) UnmarshalJSON(data []byte) error {
 		return nil
 	}
 
-	va2, err := ValAddressFromBech32(s)
+	bz, err := Bech32Bytes(s)
 	if err != nil {
 		return err
 	}
 
-	*va = va2
+	*va = ValAddress(bz)
 	return nil
 }
 
@@ -205,15 +239,9 @@ func (va ValAddress) Bytes() []byte {
 	return va
 }
 
-// String implements the Stringer interface.
+// String implements the Stringer interface, with the default prefix.
 func (va ValAddress) String() string {
-	bech32PrefixValAddr := GetAddrPrefixCfg().GetBech32ValidatorAddrPrefix()
-	bech32Addr, err := bech32.ConvertAndEncode(bech32PrefixValAddr, va.Bytes())
-	if err != nil {
-		panic(err)
-	}
-
-	return bech32Addr
+	return GetAddrPrefixCfg().ValAddressString(va)
 }
 
 // Format implements the fmt.Formatter interface.
@@ -233,10 +261,14 @@ func (va ValAddress) Format(s fmt.State, verb rune) {
 // When marshaled to a string or JSON, it uses Bech32.
 type ConsAddress []byte
 
-// String implements the Stringer interface.
+// String implements the Stringer interface, with the default prefix.
 func (ca ConsAddress) String() string {
-	bech32PrefixConsAddr := GetAddrPrefixCfg().GetBech32ConsensusAddrPrefix()
-	bech32Addr, err := bech32.ConvertAndEncode(bech32PrefixConsAddr, ca.Bytes())
+	return GetAddrPrefixCfg().ConsAddressString(ca)
+}
+
+// ConsAddressString returns the Bech32 encoding of the address with the consensus prefix of the config.
+func (config *AddrPrefixCfg) ConsAddressString(ca ConsAddress) string {
+	bech32Addr, err := bech32.ConvertAndEncode(config.GetBech32ConsensusAddrPrefix(), ca.Bytes())
 	if err != nil {
 		panic(err)
 	}
@@ -263,10 +295,14 @@ func ConsAddressFromHex(address string) (addr ConsAddress, err error) {
 	return ConsAddress(bz), nil
 }
 
-// ConsAddressFromBech32 creates a ConsAddress from a Bech32 string.
+// ConsAddressFromBech32 creates a ConsAddress from a Bech32 string with the default prefix.
 func ConsAddressFromBech32(address string) (addr ConsAddress, err error) {
-	bech32PrefixConsAddr := GetAddrPrefixCfg().GetBech32ConsensusAddrPrefix()
-	bz, err := GetFromBech32(address, bech32PrefixConsAddr)
+	return GetAddrPrefixCfg().ConsAddressFromBech32(address)
+}
+
+// ConsAddressFromBech32 creates a ConsAddress from a Bech32 string with the consensus prefix of the config.
+func (config *AddrPrefixCfg) ConsAddressFromBech32(address string) (addr ConsAddress, err error) {
+	bz, err := GetFromBech32(address, config.GetBech32ConsensusAddrPrefix())
 	if err != nil {
 		return nil, err
 	}
@@ -274,18 +310,58 @@ func ConsAddressFromBech32(address string) (addr ConsAddress, err error) {
 	return ConsAddress(bz), nil
 }
 
+// Bech32ifyAccPub returns a Bech32 encoded string containing the
+// Bech32PrefixAccPub prefix for a given account PubKey.
+func Bech32ifyAccPub(pub crypto.PubKey) (string, error) {
+	return GetAddrPrefixCfg().Bech32ifyAccPub(pub)
+}
+
+// Bech32ifyAccPub is Bech32ifyAccPub with the account public key prefix of the config.
+func (config *AddrPrefixCfg) Bech32ifyAccPub(pub crypto.PubKey) (string, error) {
+	return bech32.ConvertAndEncode(config.GetBech32AccountPubPrefix(), pub.Bytes())
+}
+
+// GetAccPubKeyBech32 creates a PubKey for an account with a given public key
+// string using the Bech32 Bech32PrefixAccPub prefix.
+func GetAccPubKeyBech32(pubkey string) (pk crypto.PubKey, err error) {
+	return GetAddrPrefixCfg().GetAccPubKeyBech32(pubkey)
+}
+
+// GetAccPubKeyBech32 is GetAccPubKeyBech32 with the account public key prefix of the config.
+func (config *AddrPrefixCfg) GetAccPubKeyBech32(pubkey string) (pk crypto.PubKey, err error) {
+	bz, err := GetFromBech32(pubkey, config.GetBech32AccountPubPrefix())
+	if err != nil {
+		return nil, err
+	}
+
+	pk, err = cryptoAmino.PubKeyFromBytes(bz)
+	if err != nil {
+		return nil, err
+	}
+
+	return pk, nil
+}
+
 // Bech32ifyConsPub returns a Bech32 encoded string containing the
 // Bech32PrefixConsPub prefixfor a given consensus node's PubKey.
 func Bech32ifyConsPub(pub crypto.PubKey) (string, error) {
-	bech32PrefixConsPub := GetAddrPrefixCfg().GetBech32ConsensusPubPrefix()
-	return bech32.ConvertAndEncode(bech32PrefixConsPub, pub.Bytes())
+	return GetAddrPrefixCfg().Bech32ifyConsPub(pub)
+}
+
+// Bech32ifyConsPub is Bech32ifyConsPub with the consensus public key prefix of the config.
+func (config *AddrPrefixCfg) Bech32ifyConsPub(pub crypto.PubKey) (string, error) {
+	return bech32.ConvertAndEncode(config.GetBech32ConsensusPubPrefix(), pub.Bytes())
 }
 
 // GetConsPubKeyBech32 creates a PubKey for a consensus node with a given public
 // key string using the Bech32 Bech32PrefixConsPub prefix.
 func GetConsPubKeyBech32(pubkey string) (pk crypto.PubKey, err error) {
-	bech32PrefixConsPub := GetAddrPrefixCfg().GetBech32ConsensusPubPrefix()
-	bz, err := GetFromBech32(pubkey, bech32PrefixConsPub)
+	return GetAddrPrefixCfg().GetConsPubKeyBech32(pubkey)
+}
+
+// GetConsPubKeyBech32 is GetConsPubKeyBech32 with the consensus public key prefix of the config.
+func (config *AddrPrefixCfg) GetConsPubKeyBech32(pubkey string) (pk crypto.PubKey, err error) {
+	bz, err := GetFromBech32(pubkey, config.GetBech32ConsensusPubPrefix())
 	if err != nil {
 		return nil, err
 	}
@@ -298,6 +374,28 @@ func GetConsPubKeyBech32(pubkey string) (pk crypto.PubKey, err error) {
 	return pk, nil
 }
 
+// PubKeyFromBech32 creates a PubKey from a Bech32 string whatever its prefix, e.g. the public key
+// returned by a KeyManager whose prefixes are not those of the client.
+func PubKeyFromBech32(pubkey string) (crypto.PubKey, error) {
+	bz, err := Bech32Bytes(pubkey)
+	if err != nil {
+		return nil, err
+	}
+
+	return cryptoAmino.PubKeyFromBytes(bz)
+}
+
+// Bech32Bytes decodes a bytestring from a Bech32 encoded string whatever its prefix, e.g. an address
+// returned by a node whose prefixes are not the default ones of the process.
+func Bech32Bytes(bech32str string) ([]byte, error) {
+	if len(bech32str) == 0 {
+		return nil, errors.New("decoding Bech32 address failed: must provide an address")
+	}
+
+	_, bz, err := bech32.DecodeAndConvert(bech32str)
+	return bz, err
+}
+
 // GetFromBech32 decodes a bytestring from a Bech32 encoded string.
 func GetFromBech32(bech32str, prefix string) ([]byte, error) {
 	if len(bech32str) == 0 {
@@ -5,8 +5,13 @@ import (
 	"crypto/aes"
 	"crypto/cipher"
 	"encoding/base64"
+	"errors"
 )
 
+// AES is the legacy Crypto, it is kept to read the existing keybases.
+// It has no authentication, so a wrong password is only detected by a malformed padding.
+//
+// Deprecated: use AESGCM
 type AES struct{}
 
 func (a AES) Encrypt(orig string, key string) (string, error) {
@@ -22,14 +27,26 @@ func (a AES) Encrypt(orig string, key string) (string, error) {
 }
 
 func (a AES) Decrypt(cryted string, key string) (string, error) {
-	crytedByte, _ := base64.StdEncoding.DecodeString(cryted)
+	crytedByte, err := base64.StdEncoding.DecodeString(cryted)
+	if err != nil {
+		return "", err
+	}
 	k := a.generateKey(key)
-	block, _ := aes.NewCipher(k)
+	block, err := aes.NewCipher(k)
+	if err != nil {
+		return "", err
+	}
 	blockSize := block.BlockSize()
+	if len(crytedByte) == 0 || len(crytedByte)%blockSize != 0 {
+		return "", errors.New("invalid ciphertext length")
+	}
 	blockMode := cipher.NewCBCDecrypter(block, k[:blockSize])
 	orig := make([]byte, len(crytedByte))
 	blockMode.CryptBlocks(orig, crytedByte)
-	orig = a.pkcs7UnPadding(orig)
+	orig, err = a.pkcs7UnPadding(orig, blockSize)
+	if err != nil {
+		return "", err
+	}
 	return string(orig), nil
 }
 
@@ -39,10 +56,18 @@ func (a AES) pkcs7Padding(ciphertext []byte, blocksize int) []byte {
 	return append(ciphertext, padtext...)
 }
 
-func (a AES) pkcs7UnPadding(origData []byte) []byte {
+func (a AES) pkcs7UnPadding(origData []byte, blockSize int) ([]byte, error) {
 	length := len(origData)
 	unpadding := int(origData[length-1])
-	return origData[:(length - unpadding)]
+	if unpadding == 0 || unpadding > blockSize || unpadding > length {
+		return nil, ErrWrongPassword
+	}
+	for _, b := range origData[length-unpadding:] {
+		if int(b) != unpadding {
+			return nil, ErrWrongPassword
+		}
+	}
+	return origData[:(length - unpadding)], nil
 }
 
 func (a AES) generateKey(key string) []byte {
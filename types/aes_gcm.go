@@ -0,0 +1,127 @@
+package types
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"golang.org/x/crypto/scrypt"
+)
+
+const (
+	cryptoVersion1 = "v1"
+
+	// scrypt parameters of v1, about 32MB of memory for each derivation
+	scryptN      = 1 << 15
+	scryptR      = 8
+	scryptP      = 1
+	scryptKeyLen = 32
+	saltLen      = 16
+)
+
+var (
+	_ Crypto = AESGCM{}
+
+	// ErrWrongPassword is returned when the ciphertext can not be authenticated with the password
+	ErrWrongPassword = errors.New("wrong password or corrupted ciphertext")
+)
+
+// AESGCM is the default Crypto of the keybases. The key is derived from the password by scrypt
+// with a random salt, then the data is sealed with AES-256-GCM.
+//
+// The ciphertext is versioned, "v1:" followed by the base64 encoding of salt || nonce || sealed data.
+// A ciphertext without version was produced by the legacy AES and is still decrypted,
+// the keybase should be migrated to re-encrypt it.
+//
+// The derivation costs about 32MB of memory and tens of milliseconds on every Decrypt, so a key
+// signing many transactions should be unlocked once by KeyManager.Unlock rather than decrypted
+// with its password for each of them.
+type AESGCM struct{}
+
+func (a AESGCM) Encrypt(data string, password string) (string, error) {
+	salt := make([]byte, saltLen)
+	if _, err := io.ReadFull(rand.Reader, salt); err != nil {
+		return "", err
+	}
+
+	aead, err := a.newAEAD(password, salt)
+	if err != nil {
+		return "", err
+	}
+
+	nonce := make([]byte, aead.NonceSize())
+	if _, err := io.ReadFull(rand.Reader, nonce); err != nil {
+		return "", err
+	}
+
+	bz := append(salt, nonce...)
+	bz = aead.Seal(bz, nonce, []byte(data), []byte(cryptoVersion1))
+	return fmt.Sprintf("%s:%s", cryptoVersion1, base64.StdEncoding.EncodeToString(bz)), nil
+}
+
+func (a AESGCM) Decrypt(data string, password string) (string, error) {
+	version, payload, ok := splitVersion(data)
+	if !ok {
+		return AES{}.Decrypt(data, password)
+	}
+	if version != cryptoVersion1 {
+		return "", fmt.Errorf("unsupported ciphertext version: %s", version)
+	}
+
+	bz, err := base64.StdEncoding.DecodeString(payload)
+	if err != nil {
+		return "", fmt.Errorf("invalid ciphertext: %s", err.Error())
+	}
+	if len(bz) < saltLen {
+		return "", errors.New("invalid ciphertext: too short")
+	}
+
+	salt := bz[:saltLen]
+	aead, err := a.newAEAD(password, salt)
+	if err != nil {
+		return "", err
+	}
+
+	bz = bz[saltLen:]
+	if len(bz) < aead.NonceSize() {
+		return "", errors.New("invalid ciphertext: too short")
+	}
+	nonce, sealed := bz[:aead.NonceSize()], bz[aead.NonceSize():]
+	plain, err := aead.Open(nil, nonce, sealed, []byte(version))
+	if err != nil {
+		return "", ErrWrongPassword
+	}
+	return string(plain), nil
+}
+
+func (a AESGCM) newAEAD(password string, salt []byte) (cipher.AEAD, error) {
+	key, err := scrypt.Key([]byte(password), salt, scryptN, scryptR, scryptP, scryptKeyLen)
+	if err != nil {
+		return nil, err
+	}
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
+
+// IsLegacyCiphertext reports whether the data was encrypted by the legacy AES
+func IsLegacyCiphertext(data string) bool {
+	_, _, ok := splitVersion(data)
+	return !ok
+}
+
+// splitVersion splits "version:payload", the base64 alphabet does not contain ':'
+func splitVersion(data string) (version, payload string, ok bool) {
+	i := strings.Index(data, ":")
+	if i < 0 {
+		return "", data, false
+	}
+	return data[:i], data[i+1:], true
+}
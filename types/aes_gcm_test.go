@@ -0,0 +1,41 @@
+package types_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/irisnet/irishub-sdk-go/types"
+)
+
+func TestAESGCM(t *testing.T) {
+	c := types.AESGCM{}
+	data := "2b8f7bd1a7b3d1eb4c8cb1cbe1d0e8b1f8a1e6f4b5c2d7a9e0f1a2b3c4d5e6f7"
+
+	ciphertext, err := c.Encrypt(data, "password")
+	require.NoError(t, err)
+	require.True(t, strings.HasPrefix(ciphertext, "v1:"))
+	require.False(t, types.IsLegacyCiphertext(ciphertext))
+
+	ciphertext1, err := c.Encrypt(data, "password")
+	require.NoError(t, err)
+	require.NotEqual(t, ciphertext, ciphertext1)
+
+	plain, err := c.Decrypt(ciphertext, "password")
+	require.NoError(t, err)
+	require.Equal(t, data, plain)
+
+	_, err = c.Decrypt(ciphertext, "wrong password")
+	require.Equal(t, types.ErrWrongPassword, err)
+
+	_, err = c.Decrypt("v2:"+strings.TrimPrefix(ciphertext, "v1:"), "password")
+	require.Error(t, err)
+
+	legacy, err := types.AES{}.Encrypt(data, "password")
+	require.NoError(t, err)
+	require.True(t, types.IsLegacyCiphertext(legacy))
+	plain, err = c.Decrypt(legacy, "password")
+	require.NoError(t, err)
+	require.Equal(t, data, plain)
+}
@@ -97,18 +97,18 @@ func ParseBlockResult(res *ctypes.ResultBlockResults) BlockResult {
 	}
 }
 
-func ParseValidators(vs []*tmtypes.Validator) []Validator {
+// ParseValidators returns the validators with their addresses and public keys encoded with the consensus prefixes of config
+func ParseValidators(config *AddrPrefixCfg, vs []*tmtypes.Validator) []Validator {
 	var validators = make([]Validator, len(vs))
 	for i, v := range vs {
-		bech32Addr, _ := ConsAddressFromHex(v.Address.String())
-		bech32PubKey, _ := Bech32ifyConsPub(v.PubKey)
+		bech32PubKey, _ := config.Bech32ifyConsPub(v.PubKey)
 
 		var pubKey PubKey
 		if bz, err := codec.MarshalJSON(v.PubKey); err == nil {
 			_ = codec.UnmarshalJSON(bz, &pubKey)
 		}
 		validators[i] = Validator{
-			Bech32Address:    bech32Addr.String(),
+			Bech32Address:    config.ConsAddressString(ConsAddress(v.Address)),
 			Bech32PubKey:     bech32PubKey,
 			Address:          v.Address.String(),
 			PubKey:           pubKey,
@@ -2,6 +2,7 @@ package types
 
 import (
 	"github.com/irisnet/irishub-sdk-go/utils/log"
+	"github.com/tendermint/tendermint/crypto"
 	cmn "github.com/tendermint/tendermint/libs/common"
 )
 
@@ -9,6 +10,11 @@ type TxManager interface {
 	BuildAndSend(msg []Msg, baseTx BaseTx) (ResultTx, Error)
 	SendMsgBatch(msgs Msgs, baseTx BaseTx) ([]ResultTx, Error)
 	Broadcast(signedTx StdTx, mode BroadcastMode) (ResultTx, Error)
+	// BuildUnsignedTx builds a transaction to be signed outside of the SDK,
+	// baseTx.From is usually a watch-only account
+	BuildUnsignedTx(msgs []Msg, baseTx BaseTx) (UnsignedTx, Error)
+	// AttachSignature verifies the external signature of the transaction and returns it ready to Broadcast
+	AttachSignature(tx UnsignedTx, pubKey crypto.PubKey, signature []byte) (StdTx, Error)
 }
 
 type Queries interface {
@@ -18,6 +24,13 @@ type Queries interface {
 	ParamQuery
 }
 
+// BatchQuery runs the queries of a batch, e.g. of many addresses, with the bounded concurrency of the client
+type BatchQuery interface {
+	// QueryBatch calls query for 0 <= i < n, at most ClientConfig.BatchConcurrency at once, and returns when they
+	// all returned: query stores its result and its error at index i to keep the order of the input
+	QueryBatch(n int, query func(i int))
+}
+
 type ParamQuery interface {
 	QueryParams(module string, res Response) Error
 }
@@ -26,6 +39,9 @@ type StoreQuery interface {
 	QueryWithResponse(path string, data interface{}, result Response) error
 	Query(path string, data interface{}) ([]byte, error)
 	QueryStore(key cmn.HexBytes, storeName string) (res []byte, err error)
+	// QueryProto sends the protobuf request to a gRPC query of IRIShub 1.x, e.g. /cosmos.slashing.v1beta1.Query/Params,
+	// and returns the protobuf response
+	QueryProto(path string, req []byte) ([]byte, error)
 }
 
 type AccountQuery interface {
@@ -48,14 +64,29 @@ type TokenConvert interface {
 	ToMainCoin(coin ...Coin) (DecCoins, Error)
 }
 
+// CodecSetter is implemented by a TmClient which decodes transactions itself,
+// it receives the codec of the client before any module is used
+type CodecSetter interface {
+	SetCodec(cdc Codec)
+}
+
 type Logger interface {
-	Logger() *log.Logger
+	Logger() log.Logger
 }
 
+// BaseClient is shared by the modules of a client, including those registered with Client.RegisterModule: BuildAndSend
+// signs and broadcasts their msgs, QueryWithResponse runs their custom queries and the TmClient subscribes to their events
 type BaseClient interface {
+	// AddrPrefixCfg returns the bech32 prefixes of the client
+	AddrPrefixCfg() *AddrPrefixCfg
+	// ChainVersion returns the version of IRIShub detected when the client is created
+	ChainVersion() Version
+	// TxEncoding returns the encoding of the transactions of the client
+	TxEncoding() TxEncoding
 	TxManager
 	TokenManager
 	Queries
+	BatchQuery
 	TokenConvert
 	TmClient
 	Logger
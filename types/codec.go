@@ -65,6 +65,7 @@ func RegisterCodec(cdc Codec) {
 	cdc.RegisterInterface((*Store)(nil))
 	cdc.RegisterConcrete(PrivKeyInfo{}, "sdk/PrivKeyInfo")
 	cdc.RegisterConcrete(KeystoreInfo{}, "sdk/KeystoreInfo")
+	cdc.RegisterConcrete(WatchOnlyInfo{}, "sdk/WatchOnlyInfo")
 
 	codec = cdc
 }
@@ -72,6 +72,11 @@ func (coin Coin) IsValidIrisAtto() bool {
 	return coin.Denom == "iris-atto" && coin.IsPositive()
 }
 
+// IsValidIris returns true if the coin is a positive amount of the native token of IRIShub 0.x or 1.x
+func (coin Coin) IsValidIris() bool {
+	return coin.IsValidIrisAtto() || (coin.Denom == IRISv1.MinUnit && coin.IsPositive())
+}
+
 // IsZero returns if this coin has zero amount
 func (coin Coin) IsZero() bool {
 	return coin.Amount.i == nil || coin.Amount.IsZero()
@@ -92,6 +97,9 @@ func (coin Coin) IsEqual(other Coin) bool {
 }
 
 func IsCoinMinDenomValid(denom string) bool {
+	if denom == IRISv1.MinUnit {
+		return true
+	}
 	if denom != irisAtto && (!strings.HasSuffix(denom, minDenomSuffix) || strings.HasPrefix(denom, iris+"-")) {
 		return false
 	}
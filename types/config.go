@@ -1,6 +1,14 @@
 package types
 
-import "time"
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/irisnet/irishub-sdk-go/utils/cache"
+	"github.com/irisnet/irishub-sdk-go/utils/log"
+	"github.com/irisnet/irishub-sdk-go/utils/trace"
+)
 
 type ClientConfig struct {
 	// IRISHub node rpc address
@@ -9,9 +17,20 @@ type ClientConfig struct {
 	// IRISHub Network type, mainnet / testnet
 	Network Network
 
+	//AddrPrefixCfg replaces the bech32 prefixes of Network, e.g. for a fork or a private chain
+	AddrPrefixCfg *AddrPrefixCfg
+
 	// IRISHub chain-id
 	ChainID string
 
+	//DiscoverChain fetches the chain-id from the status of the node and infers the network from the node and
+	//KnownChains, the client is not created if ChainID, Network or AddrPrefixCfg disagree with the node or if
+	//the network is unknown and not configured
+	DiscoverChain bool
+
+	//TxEncoding is the encoding of the transactions of the chain, detected from the version of the node if empty
+	TxEncoding TxEncoding
+
 	// Default Gas limit
 	Gas uint64
 
@@ -21,6 +40,18 @@ type ClientConfig struct {
 	// PrivKey DAO Implements
 	KeyDAO KeyDAO
 
+	//KeyManager replaces the key manager backed by KeyDAO, e.g. a remote signer (package signer)
+	KeyManager KeyManager
+
+	//PasswordProvider is asked for the password of a key which is not unlocked when BaseTx.Password is empty
+	PasswordProvider PasswordProvider
+
+	//SigningPolicy restricts what the keys may sign, e.g. a policy.Engine
+	SigningPolicy SigningPolicy
+
+	//SignatureLog records every signed transaction, e.g. an audit.Log
+	SignatureLog SignatureLog
+
 	// Transaction broadcast Mode
 	Mode BroadcastMode
 
@@ -33,6 +64,112 @@ type ClientConfig struct {
 	//log level(trace|debug|info|warn|error|fatal|panic)
 	Level string
 
+	//LogFormat is the output format of the default logger, log.Console or log.JSON, default: log.Console
+	LogFormat log.Format
+
+	//Logger replaces the default logger writing to stdout, the secrets of its lines are redacted, see log.Redact
+	Logger log.Logger
+
 	//Database file storage location
 	DBRootDir string
+
+	//Cache configures the caches of the accounts, the tokens and the params
+	Cache CacheConfig
+
+	//BatchConcurrency bounds the queries run at once by each batch query, e.g. Bank.BatchQueryAccounts, default: 8
+	BatchConcurrency int
+
+	//TmClient replaces the rpc client connected to NodeURI, e.g. an in-process chain for testing
+	TmClient TmClient
+
+	//Tracer is called at each step of a transaction, default: trace.NoopTracer
+	Tracer trace.Tracer
+}
+
+// CacheConfig configures the caches of the client, the zero capacities and TTLs use the defaults
+type CacheConfig struct {
+	//Accounts caches the numbers and the sequences of the accounts and the addresses of the keys, default: 100 for 1m
+	Accounts cache.Config
+
+	//Tokens caches the tokens, default: 100 without expiration
+	Tokens cache.Config
+
+	//Params caches the params of the modules, default: 100 for 1m
+	Params cache.Config
+
+	//Backend is read on a miss of the caches of the tokens and the params and written with them, e.g. to share them
+	//between processes. The accounts stay in the process.
+	Backend cache.Backend
+
+	//Invalidate subscribes to the txs and the blocks of the chain to remove the accounts which sent a tx, the tokens
+	//which were edited and the params when a proposal passes. It has no effect with the Protobuf encoding of
+	//IRIShub 1.x, whose events are not decoded: the caches expire with their TTL.
+	Invalidate bool
+}
+
+// ConfigError lists every problem of a ClientConfig
+type ConfigError struct {
+	Problems []string
+}
+
+func (e ConfigError) Error() string {
+	return fmt.Sprintf("invalid config: %s", strings.Join(e.Problems, "; "))
+}
+
+// Validate returns a ConfigError listing the missing and invalid fields, the empty fields which have a default are valid
+func (cfg ClientConfig) Validate() error {
+	var problems []string
+	if len(cfg.NodeURI) == 0 && cfg.TmClient == nil {
+		problems = append(problems, "nodeURI is required")
+	}
+	if cfg.AddrPrefixCfg == nil {
+		if len(cfg.Network) > 0 && cfg.Network.AddrPrefixCfg() == nil {
+			problems = append(problems, fmt.Sprintf("unknown network %s, AddrPrefixCfg is required", cfg.Network))
+		}
+	} else if err := cfg.AddrPrefixCfg.Validate(); err != nil {
+		problems = append(problems, err.Error())
+	}
+	if len(cfg.ChainID) == 0 && !cfg.DiscoverChain {
+		problems = append(problems, "chainID is required")
+	}
+	switch cfg.TxEncoding {
+	case "", Amino, Protobuf:
+	default:
+		problems = append(problems, fmt.Sprintf("unknown tx encoding %s", cfg.TxEncoding))
+	}
+	if cfg.Fee == nil || cfg.Fee.Empty() {
+		problems = append(problems, "fee is required")
+	} else if !cfg.Fee.IsValid() {
+		problems = append(problems, fmt.Sprintf("invalid fee %s", cfg.Fee))
+	}
+	if cfg.KeyDAO == nil && cfg.KeyManager == nil && len(cfg.DBRootDir) == 0 {
+		problems = append(problems, "DBRootDir is required when use default keyDao")
+	}
+	switch cfg.Mode {
+	case "", Sync, Async, Commit:
+	default:
+		problems = append(problems, fmt.Sprintf("unknown broadcast mode %s", cfg.Mode))
+	}
+	switch cfg.LogFormat {
+	case "", log.Console, log.JSON:
+	default:
+		problems = append(problems, fmt.Sprintf("unknown log format %s", cfg.LogFormat))
+	}
+	for i, c := range []cache.Config{cfg.Cache.Accounts, cfg.Cache.Tokens, cfg.Cache.Params} {
+		if c.Capacity < 0 || c.TTL < 0 {
+			problems = append(problems, fmt.Sprintf("negative capacity or TTL of the %s cache",
+				[]string{"accounts", "tokens", "params"}[i]))
+		}
+	}
+	if cfg.BatchConcurrency < 0 {
+		problems = append(problems, fmt.Sprintf("negative batch concurrency %d", cfg.BatchConcurrency))
+	}
+	if cfg.Timeout < 0 {
+		problems = append(problems, fmt.Sprintf("negative timeout %s", cfg.Timeout))
+	}
+
+	if len(problems) > 0 {
+		return ConfigError{Problems: problems}
+	}
+	return nil
 }
@@ -0,0 +1,189 @@
+package types
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/BurntSushi/toml"
+	"gopkg.in/yaml.v2"
+
+	"github.com/irisnet/irishub-sdk-go/utils/log"
+)
+
+// ConfigEnvPrefix prefixes the environment variables read by LoadConfig, e.g. IRIS_CHAIN_ID
+const ConfigEnvPrefix = "IRIS_"
+
+// configSetters set the fields of a ClientConfig which can be loaded, by key of the config files
+var configSetters = map[string]func(cfg *ClientConfig, v string) error{
+	"node_uri": func(cfg *ClientConfig, v string) error {
+		cfg.NodeURI = v
+		return nil
+	},
+	"network": func(cfg *ClientConfig, v string) error {
+		cfg.Network = Network(v)
+		if cfg.Network.AddrPrefixCfg() == nil {
+			return fmt.Errorf("unknown network %s", v)
+		}
+		return nil
+	},
+	"chain_id": func(cfg *ClientConfig, v string) error {
+		cfg.ChainID = v
+		return nil
+	},
+	"tx_encoding": func(cfg *ClientConfig, v string) error {
+		cfg.TxEncoding = TxEncoding(v)
+		if cfg.TxEncoding != Amino && cfg.TxEncoding != Protobuf {
+			return fmt.Errorf("unknown tx encoding %s", v)
+		}
+		return nil
+	},
+	"gas": func(cfg *ClientConfig, v string) (err error) {
+		cfg.Gas, err = strconv.ParseUint(v, 10, 64)
+		return err
+	},
+	"fee": func(cfg *ClientConfig, v string) (err error) {
+		cfg.Fee, err = ParseDecCoins(v)
+		return err
+	},
+	"mode": func(cfg *ClientConfig, v string) error {
+		cfg.Mode = BroadcastMode(v)
+		if cfg.Mode != Sync && cfg.Mode != Async && cfg.Mode != Commit {
+			return fmt.Errorf("unknown broadcast mode %s", v)
+		}
+		return nil
+	},
+	"store_type": func(cfg *ClientConfig, v string) error {
+		switch strings.ToLower(v) {
+		case "keystore":
+			cfg.StoreType = Keystore
+		case "privkey":
+			cfg.StoreType = PrivKey
+		default:
+			return fmt.Errorf("unknown store type %s", v)
+		}
+		return nil
+	},
+	"timeout": func(cfg *ClientConfig, v string) (err error) {
+		cfg.Timeout, err = time.ParseDuration(v)
+		return err
+	},
+	"level": func(cfg *ClientConfig, v string) error {
+		cfg.Level = v
+		return nil
+	},
+	"log_format": func(cfg *ClientConfig, v string) error {
+		cfg.LogFormat = log.Format(v)
+		if cfg.LogFormat != log.Console && cfg.LogFormat != log.JSON {
+			return fmt.Errorf("unknown log format %s", v)
+		}
+		return nil
+	},
+	"batch_concurrency": func(cfg *ClientConfig, v string) (err error) {
+		cfg.BatchConcurrency, err = strconv.Atoi(v)
+		return err
+	},
+	"db_root_dir": func(cfg *ClientConfig, v string) error {
+		cfg.DBRootDir = v
+		return nil
+	},
+}
+
+// LoadConfig returns the config of the file (.toml, .yaml, .yml or .json) if path is not empty, overridden by the
+// environment variables named IRIS_ followed by the upper case keys, e.g. IRIS_NODE_URI. The keys are node_uri,
+// network, chain_id, tx_encoding, gas, fee (e.g. 0.6iris), mode, store_type (keystore or privkey), timeout (e.g. 5s),
+// level, log_format (console or json), batch_concurrency and db_root_dir.
+//
+// Every problem of the file and of the variables is reported at once by a ConfigError, the config is not
+// validated: the fields which can not be loaded, such as KeyDAO, are set by the caller before calling NewClient.
+func LoadConfig(path string) (ClientConfig, error) {
+	var cfg ClientConfig
+	var problems []string
+
+	// a file which can not be read is one of the problems, those of the variables are still reported
+	var values map[string]interface{}
+	if len(path) > 0 {
+		var err error
+		if values, err = readConfigFile(path); err != nil {
+			problems = append(problems, err.Error())
+		}
+	}
+	if len(values) > 0 {
+		keys := make([]string, 0, len(values))
+		for key := range values {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		for _, key := range keys {
+			setter, ok := configSetters[key]
+			if !ok {
+				problems = append(problems, fmt.Sprintf("%s: unknown key %s", path, key))
+				continue
+			}
+			var v string
+			switch value := values[key].(type) {
+			case string:
+				v = value
+			case json.Number, int, int64, uint64, float64, bool:
+				v = fmt.Sprint(value)
+			default:
+				problems = append(problems, fmt.Sprintf("%s: %s must be a string or a number", path, key))
+				continue
+			}
+			if err := setter(&cfg, v); err != nil {
+				problems = append(problems, fmt.Sprintf("%s: %s: %s", path, key, err.Error()))
+			}
+		}
+	}
+
+	keys := make([]string, 0, len(configSetters))
+	for key := range configSetters {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		name := ConfigEnvPrefix + strings.ToUpper(key)
+		if v, ok := os.LookupEnv(name); ok {
+			if err := configSetters[key](&cfg, v); err != nil {
+				problems = append(problems, fmt.Sprintf("%s: %s", name, err.Error()))
+			}
+		}
+	}
+
+	if len(problems) > 0 {
+		return cfg, ConfigError{Problems: problems}
+	}
+	return cfg, nil
+}
+
+// readConfigFile returns the values of the file by key
+func readConfigFile(path string) (map[string]interface{}, error) {
+	bz, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	values := make(map[string]interface{})
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".toml":
+		err = toml.Unmarshal(bz, &values)
+	case ".yaml", ".yml":
+		err = yaml.Unmarshal(bz, &values)
+	case ".json":
+		decoder := json.NewDecoder(strings.NewReader(string(bz)))
+		decoder.UseNumber()
+		err = decoder.Decode(&values)
+	default:
+		return nil, fmt.Errorf("%s: unknown config format %s", path, filepath.Ext(path))
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %s", path, err.Error())
+	}
+	return values, nil
+}
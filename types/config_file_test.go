@@ -0,0 +1,144 @@
+package types_test
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/irisnet/irishub-sdk-go/types"
+)
+
+func writeConfig(t *testing.T, dir, name, content string) string {
+	path := filepath.Join(dir, name)
+	require.NoError(t, ioutil.WriteFile(path, []byte(content), 0600))
+	return path
+}
+
+func TestLoadConfig(t *testing.T) {
+	dir, err := ioutil.TempDir("", "config")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	files := map[string]string{
+		"config.toml": `
+# the node of the mainnet
+node_uri = "tcp://localhost:26657"
+network = "mainnet"
+chain_id = 'irishub'
+gas = 200_000
+fee = "0.6iris" # paid by every transaction
+timeout = "10s"
+store_type = "keystore"
+batch_concurrency = 16
+`,
+		"config.yaml": `
+node_uri: tcp://localhost:26657
+network: mainnet
+chain_id: irishub
+gas: 200000
+fee: 0.6iris
+timeout: 10s
+store_type: keystore
+batch_concurrency: 16
+`,
+		"config.json": `{"node_uri": "tcp://localhost:26657", "network": "mainnet", "chain_id": "irishub",
+"gas": 200000, "fee": "0.6iris", "timeout": "10s", "store_type": "keystore",
+"batch_concurrency": 16}`,
+	}
+	fee, err := types.ParseDecCoins("0.6iris")
+	require.NoError(t, err)
+
+	for name, content := range files {
+		cfg, err := types.LoadConfig(writeConfig(t, dir, name, content))
+		require.NoError(t, err, name)
+		require.Equal(t, "tcp://localhost:26657", cfg.NodeURI, name)
+		require.Equal(t, types.Mainnet, cfg.Network, name)
+		require.Equal(t, "irishub", cfg.ChainID, name)
+		require.Equal(t, uint64(200000), cfg.Gas, name)
+		require.Equal(t, fee, cfg.Fee, name)
+		require.Equal(t, 10*time.Second, cfg.Timeout, name)
+		require.Equal(t, types.Keystore, cfg.StoreType, name)
+		require.Equal(t, 16, cfg.BatchConcurrency, name)
+	}
+}
+
+func TestLoadConfigFromEnv(t *testing.T) {
+	dir, err := ioutil.TempDir("", "config")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	path := writeConfig(t, dir, "config.toml", `chain_id = "irishub"
+mode = "sync"`)
+	require.NoError(t, os.Setenv("IRIS_CHAIN_ID", "nyancat"))
+	require.NoError(t, os.Setenv("IRIS_MODE", "commit"))
+	defer os.Unsetenv("IRIS_CHAIN_ID")
+	defer os.Unsetenv("IRIS_MODE")
+
+	// the environment overrides the file
+	cfg, err := types.LoadConfig(path)
+	require.NoError(t, err)
+	require.Equal(t, "nyancat", cfg.ChainID)
+	require.Equal(t, types.Commit, cfg.Mode)
+
+	cfg, err = types.LoadConfig("")
+	require.NoError(t, err)
+	require.Equal(t, "nyancat", cfg.ChainID)
+}
+
+func TestLoadConfigProblems(t *testing.T) {
+	dir, err := ioutil.TempDir("", "config")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	path := writeConfig(t, dir, "config.yaml", `
+network: devnet
+gas: lots
+fee: 0.6
+chain: irishub
+timeout: [1, 2]
+`)
+	require.NoError(t, os.Setenv("IRIS_MODE", "block"))
+	defer os.Unsetenv("IRIS_MODE")
+
+	_, err = types.LoadConfig(path)
+	require.Error(t, err)
+	configErr, ok := err.(types.ConfigError)
+	require.True(t, ok)
+	require.Len(t, configErr.Problems, 6, err.Error())
+	require.Contains(t, err.Error(), "unknown key chain")
+	require.Contains(t, err.Error(), "IRIS_MODE: unknown broadcast mode block")
+
+	// the problems of the variables are reported with those of a file which can not be read
+	for _, path := range []string{
+		writeConfig(t, dir, "config.ini", "chain_id = irishub"),
+		writeConfig(t, dir, "config.toml", "chain_id = irishub"),
+		filepath.Join(dir, "missing.yaml"),
+	} {
+		_, err = types.LoadConfig(path)
+		require.Error(t, err)
+		configErr, ok = err.(types.ConfigError)
+		require.True(t, ok, err.Error())
+		require.Len(t, configErr.Problems, 2, err.Error())
+		require.Contains(t, configErr.Problems[0], path)
+		require.Contains(t, err.Error(), "IRIS_MODE: unknown broadcast mode block")
+	}
+}
+
+func TestValidateConfig(t *testing.T) {
+	err := types.ClientConfig{Network: "devnet", Mode: "block"}.Validate()
+	require.Error(t, err)
+	require.Len(t, err.(types.ConfigError).Problems, 6, err.Error())
+
+	fee, e := types.ParseDecCoins("0.6iris")
+	require.NoError(t, e)
+	require.NoError(t, types.ClientConfig{
+		NodeURI: "tcp://localhost:26657",
+		ChainID: "irishub",
+		Fee:     fee,
+		KeyDAO:  types.NewMemoryDB(),
+	}.Validate())
+}
@@ -1,7 +1,14 @@
 package types
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+
 	"github.com/pkg/errors"
+	"github.com/tendermint/tendermint/crypto"
+	"github.com/tendermint/tendermint/crypto/tmhash"
+	cmn "github.com/tendermint/tendermint/libs/common"
 )
 
 // TxContext implements a transaction context created in SDK modules.
@@ -19,7 +26,11 @@ type TxContext struct {
 	mode       BroadcastMode
 	simulate   bool
 	codec      Codec
+	encoder    TxEncoder
 	keyManager KeyManager
+	policy     SigningPolicy
+	release    func()
+	log        SignatureLog
 }
 
 // WithCodec returns a pointer of the context with an updated codec.
@@ -33,6 +44,20 @@ func (txCtx *TxContext) Codec() Codec {
 	return txCtx.codec
 }
 
+// WithTxEncoder returns a pointer of the context with an updated tx encoder.
+func (txCtx *TxContext) WithTxEncoder(encoder TxEncoder) *TxContext {
+	txCtx.encoder = encoder
+	return txCtx
+}
+
+// TxEncoder returns the tx encoder, the amino encoding of the codec by default.
+func (txCtx *TxContext) TxEncoder() TxEncoder {
+	if txCtx.encoder == nil {
+		return aminoTxEncoder{cdc: txCtx.codec}
+	}
+	return txCtx.encoder
+}
+
 // WithChainID returns a pointer of the context with an updated ChainID.
 func (txCtx *TxContext) WithChainID(chainID string) *TxContext {
 	txCtx.chainID = chainID
@@ -110,6 +135,28 @@ func (txCtx *TxContext) KeyManager() KeyManager {
 	return txCtx.keyManager
 }
 
+// WithSigningPolicy returns a pointer of the context with a signing policy.
+func (txCtx *TxContext) WithSigningPolicy(policy SigningPolicy) *TxContext {
+	txCtx.policy = policy
+	return txCtx
+}
+
+// SigningPolicy returns the signing policy.
+func (txCtx *TxContext) SigningPolicy() SigningPolicy {
+	return txCtx.policy
+}
+
+// WithSignatureLog returns a pointer of the context with a signature log.
+func (txCtx *TxContext) WithSignatureLog(log SignatureLog) *TxContext {
+	txCtx.log = log
+	return txCtx
+}
+
+// SignatureLog returns the signature log.
+func (txCtx *TxContext) SignatureLog() SignatureLog {
+	return txCtx.log
+}
+
 // WithNetwork returns a pointer of the context with a Network.
 func (txCtx *TxContext) WithNetwork(network Network) *TxContext {
 	txCtx.network = network
@@ -190,13 +237,67 @@ func (txCtx *TxContext) Build(msgs []Msg) (StdSignMsg, error) {
 }
 
 // Sign signs a transaction given a name, passphrase, and a single message to
-// signed. An error is returned if signing fails.
+// signed. An error is returned if signing fails or if the signing policy rejects the message.
 func (txCtx *TxContext) Sign(name string, msg StdSignMsg) (StdTx, error) {
+	txCtx.release = nil
+	if txCtx.policy != nil && !txCtx.Simulate() {
+		release, err := txCtx.policy.Check(name, msg.Msgs, msg.Fee)
+		if err != nil {
+			return StdTx{}, err
+		}
+		txCtx.release = release
+	}
+
 	sig, err := txCtx.makeSignature(name, msg)
 	if err != nil {
+		txCtx.ReleasePolicy()
 		return StdTx{}, err
 	}
-	return NewStdTx(msg.Msgs, msg.Fee, []StdSignature{sig}, msg.Memo), nil
+	tx := NewStdTx(msg.Msgs, msg.Fee, []StdSignature{sig}, msg.Memo)
+
+	if txCtx.log != nil && !txCtx.Simulate() {
+		if err := txCtx.record(name, msg, tx); err != nil {
+			txCtx.ReleasePolicy()
+			return StdTx{}, errors.Wrap(err, "failed to record the signature")
+		}
+	}
+	return tx, nil
+}
+
+// ReleasePolicy gives back to the signing policy what it reserved for the last signed transaction,
+// it is called when the transaction is known not to be executed, e.g. it is refused by the chain.
+func (txCtx *TxContext) ReleasePolicy() {
+	if txCtx.release != nil {
+		txCtx.release()
+		txCtx.release = nil
+	}
+}
+
+func (txCtx *TxContext) record(name string, msg StdSignMsg, tx StdTx) error {
+	txBytes, err := txCtx.TxEncoder().EncodeTx(tx)
+	if err != nil {
+		return err
+	}
+	signBytes, err := txCtx.TxEncoder().SignBytes(msg, tx.Signatures[0].PubKey)
+	if err != nil {
+		return err
+	}
+	msgs := make([]string, len(msg.Msgs))
+	for i, m := range msg.Msgs {
+		msgs[i] = fmt.Sprintf("%s/%s", m.Route(), m.Type())
+	}
+	signBytesHash := sha256.Sum256(signBytes)
+
+	return txCtx.log.Append(SignatureRecord{
+		Name:          name,
+		Address:       AddrPrefixCfgOf(txCtx.codec).AccAddressString(tx.Signatures[0].PubKey.Address().Bytes()),
+		ChainID:       msg.ChainID,
+		AccountNumber: msg.AccountNumber,
+		Sequence:      msg.Sequence,
+		Msgs:          msgs,
+		SignBytesHash: hex.EncodeToString(signBytesHash[:]),
+		TxHash:        cmn.HexBytes(tmhash.Sum(txBytes)).String(),
+	})
 }
 
 func (txCtx *TxContext) makeSignature(name string, msg StdSignMsg) (sig StdSignature, err error) {
@@ -205,12 +306,35 @@ func (txCtx *TxContext) makeSignature(name string, msg StdSignMsg) (sig StdSigna
 		Sequence:      msg.Sequence,
 	}
 	if !txCtx.Simulate() {
-		signature, err := txCtx.keyManager.Sign(name, txCtx.password, msg.Bytes(txCtx.codec))
+		var pubKey crypto.PubKey
+		if txCtx.TxEncoder().Encoding() == Protobuf {
+			// SIGN_MODE_DIRECT signs the public key of the signer, the KeyManager returns the one stored with the key
+			// so that the key is decrypted once, by Sign
+			if pubKey, err = txCtx.pubKey(name); err != nil {
+				return sig, err
+			}
+		}
+		signBytes, err := txCtx.TxEncoder().SignBytes(msg, pubKey)
+		if err != nil {
+			return sig, err
+		}
+		signature, err := txCtx.keyManager.Sign(name, txCtx.password, signBytes)
 		if err != nil {
 			return sig, err
 		}
+		if pubKey != nil && !pubKey.Equals(signature.PubKey) {
+			return sig, fmt.Errorf("the public key of %s does not match the key which signed", name)
+		}
 		sig.PubKey = signature.PubKey
 		sig.Signature = signature.Signature
 	}
 	return sig, nil
 }
+
+func (txCtx *TxContext) pubKey(name string) (crypto.PubKey, error) {
+	pubKey, err := QueryPubKey(txCtx.keyManager, name, txCtx.password)
+	if err != nil {
+		return nil, err
+	}
+	return PubKeyFromBech32(pubKey)
+}
@@ -1,5 +1,11 @@
 package types
 
+import (
+	"fmt"
+	"strings"
+	"sync"
+)
+
 const (
 	Testnet Network = "testnet"
 	Mainnet Network = "mainnet"
@@ -7,11 +13,44 @@ const (
 
 type Network string
 
-// Can be configured through environment variables
+// KnownChains are the networks of the public chains by chain-id, used with ClientConfig.DiscoverChain.
+// The testnets are known by name: their successive chains are numbered, e.g. fuxi-9000.
+var KnownChains = map[string]Network{
+	"irishub":   Mainnet,
+	"irishub-1": Mainnet,
+	"fuxi":      Testnet,
+	"nyancat":   Testnet,
+}
+
+// NetworkOfChainID returns the network of a chain of KnownChains, by chain-id or by the name of a numbered chain
+func NetworkOfChainID(chainID string) (Network, bool) {
+	if network, ok := KnownChains[chainID]; ok {
+		return network, true
+	}
+	i := strings.LastIndexByte(chainID, '-')
+	if i <= 0 || i == len(chainID)-1 {
+		return "", false
+	}
+	for _, c := range chainID[i+1:] {
+		if c < '0' || c > '9' {
+			return "", false
+		}
+	}
+	network, ok := KnownChains[chainID[:i]]
+	return network, ok
+}
+
+// the default bech32 prefixes of the process, see SetAddrPrefixCfg
 var (
-	defaultNetwork = Testnet
+	defaultPrefixes    *AddrPrefixCfg
+	defaultPrefixesMtx sync.RWMutex
 )
 
+// the kinds of prefixes of an AddrPrefixCfg
+var prefixKinds = []string{
+	"account_addr", "validator_addr", "consensus_addr", "account_pub", "validator_pub", "consensus_pub",
+}
+
 var (
 	testnetEnv = &AddrPrefixCfg{
 		bech32AddressPrefix: map[string]string{
@@ -35,20 +74,107 @@ var (
 	}
 )
 
+// AddrPrefixCfg is a set of bech32 prefixes. Each client has its own, given by ClientConfig.Network
+// or ClientConfig.AddrPrefixCfg, with which it encodes the addresses of its msgs, queries and logs.
+// The String and JSON encoding of AccAddress, ValAddress and ConsAddress use the default set of the
+// process (see GetAddrPrefixCfg), which the clients neither read nor set, while their JSON decoding
+// accepts any prefix.
 type AddrPrefixCfg struct {
 	bech32AddressPrefix map[string]string
 }
 
+// NewAddrPrefixCfg returns the bech32 prefixes of a chain, e.g. of a fork or a private chain
+func NewAddrPrefixCfg(accountAddr, validatorAddr, consensusAddr, accountPub, validatorPub, consensusPub string) *AddrPrefixCfg {
+	return &AddrPrefixCfg{
+		bech32AddressPrefix: map[string]string{
+			"account_addr":   accountAddr,
+			"validator_addr": validatorAddr,
+			"consensus_addr": consensusAddr,
+			"account_pub":    accountPub,
+			"validator_pub":  validatorPub,
+			"consensus_pub":  consensusPub,
+		},
+	}
+}
+
+// AddrPrefixCfg returns the bech32 prefixes of the network, nil if the network is unknown
+func (network Network) AddrPrefixCfg() *AddrPrefixCfg {
+	switch network {
+	case Mainnet:
+		return mainnetEnv
+	case Testnet:
+		return testnetEnv
+	}
+	return nil
+}
+
+// NetworkOfValidatorPrefix returns the network whose validator addresses use the bech32 prefix
+func NetworkOfValidatorPrefix(prefix string) (Network, bool) {
+	for _, network := range []Network{Mainnet, Testnet} {
+		if network.AddrPrefixCfg().GetBech32ValidatorAddrPrefix() == prefix {
+			return network, true
+		}
+	}
+	return "", false
+}
+
+// SetNetwork sets the default bech32 prefixes of the process to the ones of the network, Testnet if it is unknown
 func SetNetwork(network Network) {
-	defaultNetwork = network
+	config := network.AddrPrefixCfg()
+	if config == nil {
+		config = testnetEnv
+	}
+	SetAddrPrefixCfg(config)
 }
 
-// GetAddrPrefixCfg returns the config instance for the corresponding Network type
+// SetAddrPrefixCfg sets the default bech32 prefixes of the process, used by the String and JSON encoding of the addresses.
+// With nil, the default prefixes are those of Mainnet, the default network of the clients, until they are set again.
+func SetAddrPrefixCfg(config *AddrPrefixCfg) {
+	defaultPrefixesMtx.Lock()
+	defer defaultPrefixesMtx.Unlock()
+	defaultPrefixes = config
+}
+
+// GetAddrPrefixCfg returns the default bech32 prefixes of the process, those of Mainnet, the default network of the
+// clients, if they are not set
 func GetAddrPrefixCfg() *AddrPrefixCfg {
-	if defaultNetwork == Mainnet {
+	defaultPrefixesMtx.RLock()
+	defer defaultPrefixesMtx.RUnlock()
+	if defaultPrefixes == nil {
 		return mainnetEnv
 	}
-	return testnetEnv
+	return defaultPrefixes
+}
+
+// Validate checks that the prefixes are valid bech32 human-readable parts and are all different
+func (config *AddrPrefixCfg) Validate() error {
+	seen := make(map[string]string, len(config.bech32AddressPrefix))
+	for _, kind := range prefixKinds {
+		prefix := config.bech32AddressPrefix[kind]
+		if len(prefix) == 0 || len(prefix) > 83 {
+			return fmt.Errorf("invalid %s prefix: %q", kind, prefix)
+		}
+		for _, c := range prefix {
+			if c < 33 || c > 126 || (c >= 'A' && c <= 'Z') {
+				return fmt.Errorf("invalid %s prefix: %q", kind, prefix)
+			}
+		}
+		if other, ok := seen[prefix]; ok {
+			return fmt.Errorf("the %s and %s prefixes are both %s", other, kind, prefix)
+		}
+		seen[prefix] = kind
+	}
+	return nil
+}
+
+// Equal reports whether both sets have the same prefixes
+func (config *AddrPrefixCfg) Equal(other *AddrPrefixCfg) bool {
+	for _, kind := range prefixKinds {
+		if config.bech32AddressPrefix[kind] != other.bech32AddressPrefix[kind] {
+			return false
+		}
+	}
+	return true
 }
 
 // GetBech32AccountAddrPrefix returns the Bech32 prefix for account address
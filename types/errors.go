@@ -2,6 +2,8 @@ package types
 
 import (
 	"fmt"
+	"regexp"
+	"strconv"
 
 	"github.com/pkg/errors"
 )
@@ -37,32 +39,31 @@ const (
 var (
 	errUnknown = register(RootCodespace, 111222, "unknown error")
 	errInvalid = register(RootCodespace, 999999, "sdk check error")
-)
 
-func init() {
-	_ = register(RootCodespace, OK, "success")
-	_ = register(RootCodespace, Internal, "internal")
-	_ = register(RootCodespace, TxDecode, "tx parse error")
-	_ = register(RootCodespace, InvalidSequence, "invalid sequence")
-	_ = register(RootCodespace, Unauthorized, "unauthorized")
-	_ = register(RootCodespace, InsufficientFunds, "insufficient funds")
-	_ = register(RootCodespace, UnknownRequest, "unknown request")
-	_ = register(RootCodespace, InvalidAddress, "invalid address")
-	_ = register(RootCodespace, InvalidPubkey, "invalid pubkey")
-	_ = register(RootCodespace, UnknownAddress, "unknown address")
-	_ = register(RootCodespace, InvalidCoins, "invalid coins")
-	_ = register(RootCodespace, OutOfGas, "out of gas")
-	_ = register(RootCodespace, MemoTooLarge, "memo too large")
-	_ = register(RootCodespace, InsufficientFee, "insufficient fee")
-	_ = register(RootCodespace, TooManySignatures, "maximum number of signatures exceeded")
-	_ = register(RootCodespace, NoSignatures, "no signatures supplied")
-	_ = register(RootCodespace, ErrJsonMarshal, "failed to marshal JSON bytes")
-	_ = register(RootCodespace, ErrJsonUnmarshal, "failed to unmarshal JSON bytes")
-	_ = register(RootCodespace, InvalidRequest, "invalid request")
-	_ = register(RootCodespace, TxInMempoolCache, "tx already in mempool")
-	_ = register(RootCodespace, MempoolIsFull, "mempool is full")
-	_ = register(RootCodespace, TxTooLarge, "tx too large")
-}
+	// the root errors returned by the chain, which can be matched with errors.Is
+	ErrOK                = register(RootCodespace, OK, "success")
+	ErrInternal          = register(RootCodespace, Internal, "internal")
+	ErrTxDecode          = register(RootCodespace, TxDecode, "tx parse error")
+	ErrInvalidSequence   = register(RootCodespace, InvalidSequence, "invalid sequence")
+	ErrUnauthorized      = register(RootCodespace, Unauthorized, "unauthorized")
+	ErrInsufficientFunds = register(RootCodespace, InsufficientFunds, "insufficient funds")
+	ErrUnknownRequest    = register(RootCodespace, UnknownRequest, "unknown request")
+	ErrInvalidAddress    = register(RootCodespace, InvalidAddress, "invalid address")
+	ErrInvalidPubkey     = register(RootCodespace, InvalidPubkey, "invalid pubkey")
+	ErrUnknownAddress    = register(RootCodespace, UnknownAddress, "unknown address")
+	ErrInvalidCoins      = register(RootCodespace, InvalidCoins, "invalid coins")
+	ErrOutOfGas          = register(RootCodespace, OutOfGas, "out of gas")
+	ErrMemoTooLarge      = register(RootCodespace, MemoTooLarge, "memo too large")
+	ErrInsufficientFee   = register(RootCodespace, InsufficientFee, "insufficient fee")
+	ErrTooManySignatures = register(RootCodespace, TooManySignatures, "maximum number of signatures exceeded")
+	ErrNoSignatures      = register(RootCodespace, NoSignatures, "no signatures supplied")
+	ErrJSONMarshal       = register(RootCodespace, ErrJsonMarshal, "failed to marshal JSON bytes")
+	ErrJSONUnmarshal     = register(RootCodespace, ErrJsonUnmarshal, "failed to unmarshal JSON bytes")
+	ErrInvalidRequest    = register(RootCodespace, InvalidRequest, "invalid request")
+	ErrTxInMempoolCache  = register(RootCodespace, TxInMempoolCache, "tx already in mempool")
+	ErrMempoolIsFull     = register(RootCodespace, MempoolIsFull, "mempool is full")
+	ErrTxTooLarge        = register(RootCodespace, TxTooLarge, "tx too large")
+)
 
 type Code uint32
 type CodeV017 uint32
@@ -84,21 +85,54 @@ type Error interface {
 
 // GetError is used to covert irishub error to sdk error
 func GetError(codespace string, code uint32, log ...string) Error {
-	codeV1, ok := v17CodeMap[code]
+	return GetErrorOf(Version{}, codespace, code, log...)
+}
+
+// GetErrorOf is used to covert the error returned by a node of the version to sdk error,
+// the codes of the root codespace of IRIShub 0.x are mapped to those of 1.x. The error matches
+// the registered error of its codespace and code with errors.Is, and MsgIndex returns the index
+// of the failed msg. The codes of the modules of 0.x are kept, they only match the errors registered
+// by RegisterLegacyError, never those of 1.x. The codes of 1.x are always kept as sent by the chain,
+// so a code unknown to the SDK matches no registered error.
+func GetErrorOf(version Version, codespace string, code uint32, log ...string) Error {
+	var desc string
+	if len(log) > 0 {
+		desc = log[0]
+	}
+	if len(codespace) == 0 {
+		codespace = parseCodespace(desc)
+	}
+
+	var codeV1 Code
+	legacy := version.Compare(V100) < 0
+	switch {
+	case legacy && codespace == RootCodespace:
+		c, ok := v17CodeMap[code]
+		if !ok {
+			c = InvalidRequest
+		}
+		codeV1, legacy = c, false
+	default:
+		codeV1 = Code(code)
+	}
+	msgIndex, ok := parseMsgIndex(desc)
 	if !ok {
-		codeV1 = InvalidRequest
+		msgIndex = -1
 	}
 	return sdkError{
 		codespace: codespace,
 		code:      uint32(codeV1),
-		desc:      log[0],
+		desc:      desc,
+		msgIndex:  msgIndex,
+		legacy:    legacy,
 	}
 }
 
 // Wrap extends given error with an additional information.
 //
-// If the wrapped error does not provide ABCICode method (ie. stdlib errors),
-// it will be labeled as internal error.
+// If the wrapped error is not an Error (ie. stdlib errors), it will be labeled
+// as internal error, otherwise its codespace and code are kept. The wrapped error
+// is returned by errors.Unwrap.
 //
 // If err is nil, this returns nil, avoiding the need for an if statement when
 // wrapping a error returned at the end of a function
@@ -107,11 +141,24 @@ func Wrap(err error) Error {
 		return nil
 	}
 
-	return sdkError{
+	wrapped := sdkError{
 		codespace: errInvalid.Codespace(),
 		code:      errInvalid.Code(),
 		desc:      err.Error(),
+		msgIndex:  -1,
+		cause:     err,
+	}
+	var e Error
+	if errors.As(err, &e) {
+		wrapped.codespace, wrapped.code = e.Codespace(), e.Code()
+		if index, ok := MsgIndex(e); ok {
+			wrapped.msgIndex = index
+		}
+		if se, ok := e.(sdkError); ok {
+			wrapped.legacy = se.legacy
+		}
 	}
+	return wrapped
 }
 
 func WrapWithMessage(err error, format string, args ...interface{}) Error {
@@ -128,10 +175,33 @@ func Wrapf(format string, args ...interface{}) Error {
 	return Wrap(errors.New(desc))
 }
 
+// MsgIndex returns the index of the msg which failed the transaction, if the chain reported it
+func MsgIndex(err error) (int, bool) {
+	var e sdkError
+	if !errors.As(err, &e) || e.msgIndex < 0 {
+		return 0, false
+	}
+	return e.msgIndex, true
+}
+
+// IsChainError returns whether err has been returned by the chain, e.g. a transaction refused by CheckTx
+// or failed in DeliverTx, as opposed to an error of the client such as a timeout
+func IsChainError(err error) bool {
+	var e Error
+	if !errors.As(err, &e) {
+		return false
+	}
+	return e.Codespace() != errInvalid.Codespace() || e.Code() != errInvalid.Code()
+}
+
 type sdkError struct {
 	codespace string
 	code      uint32
 	desc      string
+	msgIndex  int
+	cause     error
+	// legacy is set on the errors of the modules of IRIShub 0.x, whose codes are not those of 1.x
+	legacy bool
 }
 
 func (e sdkError) Error() string {
@@ -146,6 +216,53 @@ func (e sdkError) Codespace() string {
 	return e.codespace
 }
 
+// Unwrap returns the wrapped error
+func (e sdkError) Unwrap() error {
+	return e.cause
+}
+
+// Is reports whether the target is an Error of the same codespace and code,
+// an error of a module of IRIShub 0.x only matches the errors of the modules of 0.x
+func (e sdkError) Is(target error) bool {
+	t, ok := target.(Error)
+	if !ok || t.Codespace() != e.codespace || t.Code() != e.code {
+		return false
+	}
+	se, ok := t.(sdkError)
+	return (ok && se.legacy) == e.legacy
+}
+
+// RegisterError returns the error of the code in the codespace, which the errors
+// returned by the chain match with errors.Is.
+//
+// Attempt to reuse an error code results in panic. Use this function only
+// during a program startup phase.
+func RegisterError(codespace string, code Code, description string) Error {
+	return register(codespace, code, description)
+}
+
+// RegisterLegacyError returns the error of the code in the codespace of a module of IRIShub 0.x,
+// which only the errors returned by the nodes of 0.x match with errors.Is.
+//
+// Attempt to reuse an error code results in panic. Use this function only
+// during a program startup phase.
+func RegisterLegacyError(codespace string, code CodeV017, description string) Error {
+	err := sdkError{
+		codespace: codespace,
+		code:      uint32(code),
+		desc:      description,
+		msgIndex:  -1,
+		legacy:    true,
+	}
+	id := legacyErrorID(codespace, uint32(code))
+	if _, ok := usedCodes[id]; ok {
+		panic(fmt.Sprintf("error with code %d of IRIShub 0.x is already registered in %s", code, codespace))
+	}
+	usedCodes[id] = err
+
+	return err
+}
+
 // register returns an error instance that should be used as the base for
 // creating error instances during runtime.
 //
@@ -159,6 +276,10 @@ func register(codespace string, code Code, description string) Error {
 		codespace: codespace,
 		code:      uint32(code),
 		desc:      description,
+		msgIndex:  -1,
+	}
+	if isRegistered(codespace, uint32(code)) {
+		panic(fmt.Sprintf("error with code %d is already registered in %s", code, codespace))
 	}
 	setUsed(err)
 
@@ -173,10 +294,49 @@ func errorID(codespace string, code uint32) string {
 	return fmt.Sprintf("%s:%d", codespace, code)
 }
 
+// legacyErrorID is the key of the errors of IRIShub 0.x in usedCodes, which never collides with errorID
+func legacyErrorID(codespace string, code uint32) string {
+	return fmt.Sprintf("v0/%s:%d", codespace, code)
+}
+
 func setUsed(err Error) {
 	usedCodes[errorID(err.Codespace(), err.Code())] = err
 }
 
+func isRegistered(codespace string, code uint32) bool {
+	_, ok := usedCodes[errorID(codespace, code)]
+	return ok
+}
+
+var (
+	// the message index of the logs of Cosmos SDK 0.38+, e.g. failed to execute message; message index: 1: ...
+	msgIndexPattern = regexp.MustCompile(`message index: (\d+)`)
+	// the message index of the JSON logs of IRIShub 0.x, e.g. [{"msg_index":1,"success":false,...}]
+	jsonMsgIndexPattern = regexp.MustCompile(`"msg_index":\s*"?(\d+)"?\s*,\s*"success":\s*false`)
+	// the message index of the logs of CheckTx of IRIShub 0.x, e.g. Msg 1 failed: {"codespace":"gov",...}
+	failedMsgPattern = regexp.MustCompile(`^Msg (\d+) failed`)
+	codespacePattern = regexp.MustCompile(`"codespace":\s*"([^"]+)"`)
+)
+
+func parseMsgIndex(log string) (int, bool) {
+	for _, pattern := range []*regexp.Regexp{msgIndexPattern, jsonMsgIndexPattern, failedMsgPattern} {
+		if m := pattern.FindStringSubmatch(log); m != nil {
+			if index, err := strconv.Atoi(m[1]); err == nil {
+				return index, true
+			}
+		}
+	}
+	return 0, false
+}
+
+// parseCodespace returns the codespace of a JSON log, the root codespace if there is none
+func parseCodespace(log string) string {
+	if m := codespacePattern.FindStringSubmatch(log); m != nil {
+		return m[1]
+	}
+	return RootCodespace
+}
+
 // will remove from irishub v1.0
 var v17CodeMap = map[uint32]Code{
 	0:  OK,
@@ -189,14 +349,14 @@ var v17CodeMap = map[uint32]Code{
 	7:  InvalidAddress,
 	8:  InvalidPubkey,
 	9:  UnknownAddress,
-	10: InvalidCoins,
+	10: InsufficientFunds, // InsufficientCoins of 0.x
 	11: InvalidCoins,
 	12: OutOfGas,
 	13: MemoTooLarge,
 	14: InsufficientFee,
 	15: UnknownRequest,
 	16: TooManySignatures,
-	17: OutOfGas,
+	17: InsufficientFee, // GasPriceTooLow of 0.x
 	18: InvalidRequest,
 	19: InvalidRequest,
 	20: InvalidRequest,
@@ -0,0 +1,181 @@
+package types_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/irisnet/irishub-sdk-go/modules/asset"
+	"github.com/irisnet/irishub-sdk-go/modules/bank"
+	"github.com/irisnet/irishub-sdk-go/modules/gov"
+	"github.com/irisnet/irishub-sdk-go/modules/oracle"
+	"github.com/irisnet/irishub-sdk-go/modules/service"
+	"github.com/irisnet/irishub-sdk-go/modules/staking"
+	"github.com/irisnet/irishub-sdk-go/types"
+)
+
+func TestErrorIs(t *testing.T) {
+	err := types.GetErrorOf(types.V100, bank.Codespace, 5, "send transactions are disabled")
+	require.True(t, errors.Is(err, bank.ErrSendDisabled))
+	require.False(t, errors.Is(err, bank.ErrNoInputs))
+	require.False(t, errors.Is(err, staking.ErrNoValidatorFound))
+
+	var sdkErr types.Error
+	require.True(t, errors.As(err, &sdkErr))
+	require.Equal(t, bank.Codespace, sdkErr.Codespace())
+
+	// the codes unknown to the SDK are kept, they match no registered error
+	err = types.GetErrorOf(types.V100, bank.Codespace, 99, "unknown")
+	require.Equal(t, uint32(99), err.Code())
+	require.Equal(t, bank.Codespace, err.Codespace())
+
+	// 0.x codes are mapped to the root errors
+	err = types.GetError(types.RootCodespace, 10, "insufficient coins")
+	require.True(t, errors.Is(err, types.ErrInsufficientFunds))
+}
+
+func TestUnregisteredModuleCode(t *testing.T) {
+	// code 18 is registered by the service and staking modules, an unknown code never becomes it
+	for _, codespace := range []string{service.Codespace, staking.Codespace} {
+		err := types.GetErrorOf(types.V100, codespace, 99, "unknown")
+		require.Equal(t, uint32(99), err.Code())
+		require.Equal(t, codespace, err.Codespace())
+		require.False(t, errors.Is(err, service.ErrInvalidServiceFeeCap))
+		require.False(t, errors.Is(err, staking.ErrMinSelfDelegationInvalid))
+		require.False(t, errors.Is(err, types.ErrInvalidRequest))
+	}
+}
+
+func TestErrorIsV017(t *testing.T) {
+	// the errors of the modules of 0.x keep their codes, which never match the errors of 1.x
+	for _, c := range []struct {
+		codespace string
+		code      uint32
+		v1        types.Error
+	}{
+		{bank.Codespace, 5, bank.ErrSendDisabled},
+		{service.Codespace, 2, service.ErrInvalidServiceName},
+		{oracle.Codespace, 2, oracle.ErrUnknownFeedName},
+		{"stake", 4, staking.ErrNoValidatorFound},
+		{staking.Codespace, 4, staking.ErrNoValidatorFound},
+		{gov.Codespace, 2, gov.ErrUnknownProposal},
+		{"asset", 10, asset.ErrTokenNotExists},
+		{asset.Codespace, 10, asset.ErrTokenNotExists},
+	} {
+		err := types.GetErrorOf(types.V017, c.codespace, c.code, "failed")
+		require.Equal(t, c.codespace, err.Codespace())
+		require.Equal(t, c.code, err.Code())
+		require.False(t, errors.Is(err, c.v1), "%s:%d", c.codespace, c.code)
+		require.False(t, errors.Is(types.WrapWithMessage(err, "send"), c.v1), "%s:%d", c.codespace, c.code)
+		require.True(t, types.IsChainError(err))
+
+		// the same code of a 1.x node is the registered error
+		require.Equal(t, errors.Is(types.GetErrorOf(types.V100, c.codespace, c.code, "failed"), c.v1),
+			c.codespace == c.v1.Codespace())
+	}
+
+	// the codes of the modules are not mapped as those of the root codespace
+	err := types.GetErrorOf(types.V017, bank.Codespace, 10, "insufficient coins")
+	require.False(t, errors.Is(err, types.ErrInvalidCoins))
+	require.Equal(t, uint32(10), err.Code())
+	err = types.GetErrorOf(types.V017, gov.Codespace, 12, "invalid param")
+	require.Equal(t, uint32(12), err.Code())
+
+	err = types.GetErrorOf(types.V017, types.RootCodespace, 11, "insufficient coins")
+	require.True(t, errors.Is(err, types.ErrInvalidCoins))
+	require.True(t, errors.Is(types.Wrap(err), types.ErrInvalidCoins))
+}
+
+func TestLegacyErrors(t *testing.T) {
+	// the log of an irishub v0.16 node voting on an unknown proposal
+	err := types.GetErrorOf(types.V017, gov.Codespace, 1,
+		`Msg 0 failed: {"codespace":"gov","code":1,"message":"Unknown proposal with id 999"}`)
+	require.True(t, errors.Is(err, gov.ErrLegacyUnknownProposal))
+	require.True(t, errors.Is(types.WrapWithMessage(err, "vote"), gov.ErrLegacyUnknownProposal))
+	require.False(t, errors.Is(err, gov.ErrUnknownProposal))
+	require.False(t, errors.Is(err, gov.ErrLegacyInactiveProposal))
+	index, ok := types.MsgIndex(err)
+	require.True(t, ok)
+	require.Equal(t, 0, index)
+
+	// the errors of 0.x never match those of 1.x with the same code
+	require.False(t, errors.Is(types.GetErrorOf(types.V100, gov.Codespace, 1, "failed"), gov.ErrLegacyUnknownProposal))
+	require.False(t, errors.Is(types.GetErrorOf(types.V100, bank.Codespace, 101, "failed"), bank.ErrLegacyInvalidInput))
+
+	for _, c := range []struct {
+		codespace string
+		code      uint32
+		legacy    types.Error
+	}{
+		{bank.Codespace, 101, bank.ErrLegacyInvalidInput},
+		{service.Codespace, 110, service.ErrLegacySvcBindingNotExists},
+		{asset.LegacyCodespace, 122, asset.ErrLegacyAssetNotExists},
+		{staking.LegacyCodespace, 101, staking.ErrLegacyInvalidValidator},
+		{staking.LegacyCodespace, 7, staking.ErrLegacyInvalidAddress},
+		{gov.Codespace, 25, gov.ErrLegacyAlreadyVote},
+	} {
+		err := types.GetErrorOf(types.V017, c.codespace, c.code, "failed")
+		require.True(t, errors.Is(err, c.legacy), "%s:%d", c.codespace, c.code)
+	}
+
+	// the log of an irishub v0.16 node sending more coins than the balance
+	err = types.GetErrorOf(types.V017, types.RootCodespace, 10,
+		`{"codespace":"sdk","code":10,"message":"subtracting [1000000000000000000000iris-atto] from [999000000000000000iris-atto] yields negative coin(s)"}`)
+	require.True(t, errors.Is(err, types.ErrInsufficientFunds))
+
+	// the gas price too low of 0.x
+	require.True(t, errors.Is(types.GetErrorOf(types.V017, types.RootCodespace, 17, "gas price too low"), types.ErrInsufficientFee))
+
+	require.Panics(t, func() {
+		types.RegisterLegacyError(gov.Codespace, 1, "unknown proposal")
+	})
+}
+
+func TestWrapKeepsCause(t *testing.T) {
+	cause := errors.New("connection refused")
+	err := types.Wrap(cause)
+	require.True(t, errors.Is(err, cause))
+	require.Equal(t, cause, errors.Unwrap(err))
+
+	// the code of a wrapped Error is kept
+	err = types.WrapWithMessage(types.GetErrorOf(types.V100, types.RootCodespace, 5, "5iris < 10iris"), "send")
+	require.True(t, errors.Is(err, types.ErrInsufficientFunds))
+	require.Equal(t, "send: 5iris < 10iris", err.Error())
+}
+
+func TestIsChainError(t *testing.T) {
+	err := types.GetErrorOf(types.V100, types.RootCodespace, 5, "5iris < 10iris")
+	require.True(t, types.IsChainError(err))
+	require.True(t, types.IsChainError(types.WrapWithMessage(err, "send")))
+
+	require.False(t, types.IsChainError(types.Wrapf("commit transaction timed out")))
+	require.False(t, types.IsChainError(errors.New("connection refused")))
+}
+
+func TestMsgIndex(t *testing.T) {
+	err := types.GetErrorOf(types.V100, types.RootCodespace, 5,
+		"failed to execute message; message index: 2: 5iris < 10iris: insufficient funds")
+	index, ok := types.MsgIndex(err)
+	require.True(t, ok)
+	require.Equal(t, 2, index)
+
+	// the JSON logs of IRIShub 0.x
+	err = types.GetErrorOf(types.V017, "", 10,
+		`[{"msg_index":0,"success":true,"log":""},{"msg_index":1,"success":false,"log":"{\"codespace\":\"sdk\",\"code\":10}"}]`)
+	index, ok = types.MsgIndex(types.Wrap(err))
+	require.True(t, ok)
+	require.Equal(t, 1, index)
+	require.Equal(t, types.RootCodespace, err.Codespace())
+
+	_, ok = types.MsgIndex(types.Wrapf("no log"))
+	require.False(t, ok)
+}
+
+func TestRegisterError(t *testing.T) {
+	require.Panics(t, func() {
+		types.RegisterError(bank.Codespace, 2, "no inputs")
+	})
+	err := types.RegisterError("custom", 2, "custom error")
+	require.True(t, errors.Is(types.GetErrorOf(types.V100, "custom", 2, "failed"), err))
+}
@@ -1,15 +1,39 @@
 package types
 
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
 type StoreType int
 
 const (
-	Keystore StoreType = 0
-	PrivKey  StoreType = 1
+	Keystore  StoreType = 0
+	PrivKey   StoreType = 1
+	WatchOnly StoreType = 2
 )
 
+func (s StoreType) String() string {
+	switch s {
+	case Keystore:
+		return "keystore"
+	case PrivKey:
+		return "privkey"
+	case WatchOnly:
+		return "watch-only"
+	}
+	return fmt.Sprintf("unknown(%d)", int(s))
+}
+
+// ErrKeyManagerUnsupported is returned by the keys module when the KeyManager does not implement
+// the optional interface of the method
+var ErrKeyManagerUnsupported = errors.New("not supported by the key manager")
+
 var (
 	_ Store = PrivKeyInfo{}
 	_ Store = KeystoreInfo{}
+	_ Store = WatchOnlyInfo{}
 )
 
 type Store interface {
@@ -18,6 +42,12 @@ type Store interface {
 type PrivKeyInfo struct {
 	PrivKey string `json:"priv_key"`
 	Address string `json:"address"`
+	// PubKey is the bech32 encoded account public key, QueryPubKey returns it without decrypting the key.
+	// It is empty for the keys stored by the older versions.
+	PubKey string `json:"pub_key,omitempty"`
+	// Mnemonic is the mnemonic of the keys created or recovered from a mnemonic with HDOptions.KeepMnemonic, with
+	// their BIP39 passphrase and HD path, encoded by crypto.EncodeMnemonic and encrypted as the private key
+	Mnemonic string `json:"mnemonic,omitempty"`
 }
 
 func (p PrivKeyInfo) GetType() StoreType {
@@ -26,24 +56,57 @@ func (p PrivKeyInfo) GetType() StoreType {
 
 type KeystoreInfo struct {
 	Keystore string `json:"keystore"`
+	// PubKey is the bech32 encoded account public key, see PrivKeyInfo
+	PubKey string `json:"pub_key,omitempty"`
+	// Mnemonic is encrypted by the Crypto of the KeyDAO with the password of the keystore, see PrivKeyInfo
+	Mnemonic string `json:"mnemonic,omitempty"`
 }
 
 func (k KeystoreInfo) GetType() StoreType {
 	return Keystore
 }
 
+// WatchOnlyInfo holds the address, and optionally the bech32 public key, of an account
+// whose private key is not on this machine. Its transactions are signed externally.
+type WatchOnlyInfo struct {
+	Address string `json:"address"`
+	PubKey  string `json:"pub_key"`
+}
+
+func (w WatchOnlyInfo) GetType() StoreType {
+	return WatchOnly
+}
+
 type KeyDAO interface {
 	AccountAccess
 	Crypto
 }
 
+// AccountAccess stores the keys. The implementations must be safe for concurrent use,
+// NewSyncAccountAccess makes any implementation safe. The other features are optional, they are
+// checked with a type assertion: AccountReplacer and AccountLister.
 type AccountAccess interface {
+	// Write stores a new key, it fails if the name is already used
 	Write(name string, store Store) error
 	Read(name string) (Store, error)
 	Delete(name string) error
 	Has(name string) bool
 }
 
+// AccountReplacer overwrites the stored keys, the keys are renamed, their passwords changed
+// and the backups restored over them with it
+type AccountReplacer interface {
+	// Replace atomically overwrites the key of a used name, it fails if the name is not used:
+	// the name keeps a key even if Replace fails
+	Replace(name string, store Store) error
+}
+
+// AccountLister lists the stored keys
+type AccountLister interface {
+	// List returns the names of all the stored keys
+	List() ([]string, error)
+}
+
 type Crypto interface {
 	Encrypt(data string, password string) (string, error)
 	Decrypt(data string, password string) (string, error)
@@ -51,16 +114,91 @@ type Crypto interface {
 
 type defaultKeyDAOImpl struct {
 	AccountAccess
-	AES
+	Crypto
+}
+
+// Replace implements AccountReplacer if the AccountAccess does
+func (dao defaultKeyDAOImpl) Replace(name string, store Store) error {
+	return ReplaceAccount(dao.AccountAccess, name, store)
+}
+
+// List implements AccountLister if the AccountAccess does
+func (dao defaultKeyDAOImpl) List() ([]string, error) {
+	return ListAccounts(dao.AccountAccess)
+}
+
+// ReplaceAccount overwrites the key of a used name if access implements AccountReplacer,
+// it returns ErrKeyManagerUnsupported otherwise
+func ReplaceAccount(access AccountAccess, name string, store Store) error {
+	replacer, ok := access.(AccountReplacer)
+	if !ok {
+		return fmt.Errorf("%T does not implement AccountReplacer: %w", access, ErrKeyManagerUnsupported)
+	}
+	return replacer.Replace(name, store)
+}
+
+// ListAccounts returns the names of the stored keys if access implements AccountLister,
+// it returns ErrKeyManagerUnsupported otherwise
+func ListAccounts(access AccountAccess) ([]string, error) {
+	lister, ok := access.(AccountLister)
+	if !ok {
+		return nil, fmt.Errorf("%T does not implement AccountLister: %w", access, ErrKeyManagerUnsupported)
+	}
+	return lister.List()
+}
+
+// NewKeyDAO return a KeyDAO object which uses the default encryption (AESGCM).
+// The calls to account are serialized by NewSyncAccountAccess.
+func NewKeyDAO(account AccountAccess) KeyDAO {
+	return defaultKeyDAOImpl{
+		AccountAccess: NewSyncAccountAccess(account),
+		Crypto:        AESGCM{},
+	}
 }
 
-// NewKeyDaoWithAES return a KeyDAO object. by default,the SDK's own encryption(AES) method is used
+// NewKeyDaoWithAES return a KeyDAO object which uses the legacy encryption (AES)
+//
+// Deprecated: use NewKeyDAO
 func NewKeyDaoWithAES(account AccountAccess) KeyDAO {
 	return defaultKeyDAOImpl{
-		AccountAccess: account,
+		AccountAccess: NewSyncAccountAccess(account),
+		Crypto:        AES{},
 	}
 }
 
+// HDOptions specifies how a key is derived from a mnemonic.
+// The zero value derives the default path 44'/118'/0'/0/0 without BIP39 passphrase.
+type HDOptions struct {
+	Account uint32 `json:"account"`
+	Change  bool   `json:"change"`
+	Index   uint32 `json:"index"`
+	// HDPath is a full BIP44 path which overrides Account, Change and Index,
+	// it is required to use another coin type than 118, e.g. 44'/60'/0'/0/0
+	HDPath          string `json:"hd_path"`
+	BIP39Passphrase string `json:"-"`
+	// KeepMnemonic stores the mnemonic, with the BIP39 passphrase and the HD path, encrypted with the key, so that
+	// BackupShares splits it rather than the private key. The mnemonic derives all the keys of the wallet: it is not
+	// stored unless the caller opts in.
+	KeepMnemonic bool `json:"keep_mnemonic"`
+}
+
+// KeyInfo describes a stored key
+type KeyInfo struct {
+	Name      string    `json:"name"`
+	Address   string    `json:"address"`
+	StoreType StoreType `json:"store_type"`
+}
+
+// DerivedAccount is a key derived and stored by KeyManager.RecoverAccounts
+type DerivedAccount struct {
+	Name    string `json:"name"`
+	Address string `json:"address"`
+	HDPath  string `json:"hd_path"`
+}
+
+// KeyManager signs with the keys stored by name. The other features are optional, the keys module
+// checks with a type assertion whether the KeyManager implements them: HDKeyManager, KeyImporter,
+// ArmorKeyManager, ShareKeyManager, KeyLister, KeyEditor, KeySessions and PubKeyQuerier.
 type KeyManager interface {
 	Sign(name, password string, data []byte) (Signature, error)
 	Insert(name, password string) (string, string, error)
@@ -70,3 +208,78 @@ type KeyManager interface {
 	Delete(name string) error
 	Query(name string) (address AccAddress, err error)
 }
+
+// PubKeyQuerier returns the public keys of the stored keys, the transactions encoded in Protobuf are signed
+// only by the KeyManagers which implement it
+type PubKeyQuerier interface {
+	// QueryPubKey returns the bech32 encoded account public key
+	QueryPubKey(name, password string) (pubKey string, err error)
+}
+
+// QueryPubKey returns the public key of the key name if km implements PubKeyQuerier,
+// it returns ErrKeyManagerUnsupported otherwise
+func QueryPubKey(km KeyManager, name, password string) (string, error) {
+	querier, ok := km.(PubKeyQuerier)
+	if !ok {
+		return "", fmt.Errorf("%T does not implement PubKeyQuerier: %w", km, ErrKeyManagerUnsupported)
+	}
+	return querier.QueryPubKey(name, password)
+}
+
+// HDKeyManager derives the keys from a mnemonic with HD options
+type HDKeyManager interface {
+	InsertWithOptions(name, password string, opts HDOptions) (address, mnemonic string, err error)
+	RecoverWithOptions(name, password, mnemonic string, opts HDOptions) (address string, err error)
+	// RecoverAccounts derives one key per name from the mnemonic, with consecutive address
+	// indexes starting at opts.Index, and stores them
+	RecoverAccounts(password, mnemonic string, opts HDOptions, names ...string) ([]DerivedAccount, error)
+}
+
+// KeyImporter stores the keys given as a private key or as an account which can not sign
+type KeyImporter interface {
+	// ImportPrivKey imports a hex encoded secp256k1 private key
+	ImportPrivKey(name, password, privKey string) (address string, err error)
+	// AddWatchOnly stores an account which can not sign, by address or bech32 public key
+	AddWatchOnly(name, address, pubKey string) (string, error)
+}
+
+// ArmorKeyManager imports and exports the armored private keys of the Cosmos SDK
+type ArmorKeyManager interface {
+	// ImportArmor imports an armored private key, as exported by the `keys export` command of the Cosmos SDK
+	ImportArmor(name, password, armor, passphrase string) (address string, err error)
+	// ExportArmor exports the private key armored and encrypted with passphrase, for the `keys import` command of the Cosmos SDK
+	ExportArmor(name, password, passphrase string) (armor string, err error)
+}
+
+// ShareKeyManager backs up the keys as secret shares
+type ShareKeyManager interface {
+	// BackupShares splits the key into shares, any threshold of them recover it. The mnemonic kept with the keys
+	// created or recovered with HDOptions.KeepMnemonic is split with its HD path, the private key of the others.
+	BackupShares(name, password string, threshold, shares int) ([]string, error)
+	// RecoverFromShares rebuilds a key from the shares written by BackupShares or crypto.SplitMnemonic
+	RecoverFromShares(name, password string, shares []string) (address string, err error)
+}
+
+// KeyLister lists the stored keys
+type KeyLister interface {
+	List() ([]KeyInfo, error)
+}
+
+// KeyEditor renames the keys and changes their passwords
+type KeyEditor interface {
+	Rename(name, newName string) error
+	// ChangePassword encrypts the key with the new password, the store type is unchanged
+	ChangePassword(name, password, newPassword string) error
+}
+
+// KeySessions keeps the decrypted keys in memory
+type KeySessions interface {
+	// Unlock decrypts the key once and keeps it in memory, Sign then accepts an empty password.
+	// The key is locked after timeout, or only by Lock when timeout is 0. Each decryption by AESGCM runs scrypt,
+	// the keys signing many transactions should be unlocked.
+	Unlock(name, password string, timeout time.Duration) error
+	// Lock zeroes the decrypted key in memory
+	Lock(name string)
+	LockAll()
+	Unlocked(name string) bool
+}
@@ -1,8 +1,12 @@
 package types
 
 import (
+	"encoding/hex"
 	"fmt"
 	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
 
 	dbm "github.com/tendermint/tm-db"
 )
@@ -13,13 +17,18 @@ const (
 )
 
 var (
-	_ KeyDAO = LevelDB{}
+	_ KeyDAO          = LevelDB{}
+	_ AccountReplacer = LevelDB{}
+	_ AccountLister   = LevelDB{}
 )
 
+// LevelDB is safe for concurrent use, a key is only written if its name is not used yet
 type LevelDB struct {
 	db  dbm.DB
 	cdc Codec
-	AES
+	// mu makes the check for an existing name and the write atomic
+	mu *sync.Mutex
+	AESGCM
 }
 
 // NewLevelDB initialize a keybase based on the configuration.
@@ -32,20 +41,23 @@ func NewLevelDB(rootDir string, cdc Codec) (KeyDAO, error) {
 	keybase := LevelDB{
 		db:  db,
 		cdc: cdc,
+		mu:  &sync.Mutex{},
 	}
 	return keybase, nil
 }
 
 // Write add a key information to the local store
 func (k LevelDB) Write(name string, store Store) error {
-	if k.Has(name) {
-		return fmt.Errorf("name %s has exist", name)
-	}
-
 	bz, err := k.cdc.MarshalBinaryLengthPrefixed(store)
 	if err != nil {
 		return err
 	}
+
+	k.mu.Lock()
+	defer k.mu.Unlock()
+	if k.Has(name) {
+		return errKeyExists(name)
+	}
 	return k.db.SetSync(infoKey(name), bz)
 }
 
@@ -64,10 +76,27 @@ func (k LevelDB) Read(name string) (store Store, err error) {
 
 // Delete delete a key from the local store
 func (k LevelDB) Delete(name string) error {
+	k.mu.Lock()
+	defer k.mu.Unlock()
 	return k.db.DeleteSync(infoKey(name))
 }
 
-// Delete delete a key from the local store
+// Replace overwrites the key information of a used name in the local store
+func (k LevelDB) Replace(name string, store Store) error {
+	bz, err := k.cdc.MarshalBinaryLengthPrefixed(store)
+	if err != nil {
+		return err
+	}
+
+	k.mu.Lock()
+	defer k.mu.Unlock()
+	if !k.Has(name) {
+		return errKeyNotExist(name)
+	}
+	return k.db.SetSync(infoKey(name), bz)
+}
+
+// Has returns whether the name is used in the local store
 func (k LevelDB) Has(name string) bool {
 	existed, err := k.db.Has(infoKey(name))
 	if err != nil {
@@ -76,36 +105,244 @@ func (k LevelDB) Has(name string) bool {
 	return existed
 }
 
+// List returns the names of all the keys in the local store
+func (k LevelDB) List() ([]string, error) {
+	it, err := k.db.Iterator(nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer it.Close()
+
+	var names []string
+	suffix := fmt.Sprintf(".%s", infoSuffix)
+	for ; it.Valid(); it.Next() {
+		key := string(it.Key())
+		if strings.HasSuffix(key, suffix) {
+			names = append(names, strings.TrimSuffix(key, suffix))
+		}
+	}
+	return names, nil
+}
+
+// Migrate re-encrypts the private key of name with AESGCM if it was encrypted by the legacy AES.
+// The password is the one of the key, keystore entries are left untouched.
+// It returns whether the entry has been re-encrypted.
+func (k LevelDB) Migrate(name, password string) (bool, error) {
+	store, err := k.Read(name)
+	if err != nil {
+		return false, err
+	}
+	if store == nil {
+		return false, errKeyNotExist(name)
+	}
+
+	info, ok := store.(PrivKeyInfo)
+	if !ok || !IsLegacyCiphertext(info.PrivKey) {
+		return false, nil
+	}
+
+	privKey, err := AES{}.Decrypt(info.PrivKey, password)
+	if err != nil {
+		return false, err
+	}
+	// the legacy ciphertext is not authenticated, check that it is a hex encoded private key
+	if bz, err := hex.DecodeString(privKey); err != nil || len(bz) != 32 {
+		return false, ErrWrongPassword
+	}
+
+	info.PrivKey, err = k.Encrypt(privKey, password)
+	if err != nil {
+		return false, err
+	}
+	bz, err := k.cdc.MarshalBinaryLengthPrefixed(info)
+	if err != nil {
+		return false, err
+	}
+
+	k.mu.Lock()
+	defer k.mu.Unlock()
+	// the key may have been deleted or replaced meanwhile
+	if current, err := k.Read(name); err != nil || current != store {
+		return false, fmt.Errorf("%s has been modified during the migration", name)
+	}
+	return true, k.db.SetSync(infoKey(name), bz)
+}
+
+// MigrateAll calls Migrate on every key of the local store, password returns the password of a key.
+// It stops at the first error and returns the names of the re-encrypted keys.
+func (k LevelDB) MigrateAll(password func(name string) (string, error)) ([]string, error) {
+	names, err := k.List()
+	if err != nil {
+		return nil, err
+	}
+
+	var migrated []string
+	for _, name := range names {
+		store, err := k.Read(name)
+		if err != nil {
+			return migrated, err
+		}
+		if info, ok := store.(PrivKeyInfo); !ok || !IsLegacyCiphertext(info.PrivKey) {
+			continue
+		}
+
+		pwd, err := password(name)
+		if err != nil {
+			return migrated, err
+		}
+		ok, err := k.Migrate(name, pwd)
+		if err != nil {
+			return migrated, fmt.Errorf("failed to migrate %s: %s", name, err.Error())
+		}
+		if ok {
+			migrated = append(migrated, name)
+		}
+	}
+	return migrated, nil
+}
+
 func infoKey(name string) []byte {
 	return []byte(fmt.Sprintf("%s.%s", name, infoSuffix))
 }
 
-// Use memory as storage, use with caution in build environment
+func errKeyExists(name string) error {
+	return fmt.Errorf("name %s has exist", name)
+}
+
+func errKeyNotExist(name string) error {
+	return fmt.Errorf("name %s not exist", name)
+}
+
+// Use memory as storage, use with caution in build environment.
+// It is safe for concurrent use.
 type MemoryDB struct {
+	mu    *sync.RWMutex
 	store map[string]Store
-	AES
+	AESGCM
 }
 
 func NewMemoryDB() MemoryDB {
 	return MemoryDB{
+		mu:    &sync.RWMutex{},
 		store: make(map[string]Store),
 	}
 }
+
 func (m MemoryDB) Write(name string, store Store) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if _, ok := m.store[name]; ok {
+		return errKeyExists(name)
+	}
 	m.store[name] = store
 	return nil
 }
 
 func (m MemoryDB) Read(name string) (Store, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
 	return m.store[name], nil
 }
 
 func (m MemoryDB) Delete(name string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
 	delete(m.store, name)
 	return nil
 }
 
+func (m MemoryDB) Replace(name string, store Store) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if _, ok := m.store[name]; !ok {
+		return errKeyNotExist(name)
+	}
+	m.store[name] = store
+	return nil
+}
+
 func (m MemoryDB) Has(name string) bool {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
 	_, ok := m.store[name]
 	return ok
 }
+
+func (m MemoryDB) List() ([]string, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	names := make([]string, 0, len(m.store))
+	for name := range m.store {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names, nil
+}
+
+// syncAccountAccess serializes the calls to an AccountAccess
+type syncAccountAccess struct {
+	mu     *sync.Mutex
+	access AccountAccess
+}
+
+// NewSyncAccountAccess makes an AccountAccess safe for concurrent use: the calls to access
+// are serialized, Write only stores the key if the name is not used yet and Replace only if it is used.
+func NewSyncAccountAccess(access AccountAccess) AccountAccess {
+	if s, ok := access.(syncAccountAccess); ok {
+		return s
+	}
+	return syncAccountAccess{
+		mu:     &sync.Mutex{},
+		access: access,
+	}
+}
+
+// NewSyncKeyDAO is NewSyncAccountAccess for a KeyDAO, the encryption of dao is kept
+func NewSyncKeyDAO(dao KeyDAO) KeyDAO {
+	return defaultKeyDAOImpl{
+		AccountAccess: NewSyncAccountAccess(dao),
+		Crypto:        dao,
+	}
+}
+
+func (s syncAccountAccess) Write(name string, store Store) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.access.Has(name) {
+		return errKeyExists(name)
+	}
+	return s.access.Write(name, store)
+}
+
+func (s syncAccountAccess) Read(name string) (Store, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.access.Read(name)
+}
+
+func (s syncAccountAccess) Delete(name string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.access.Delete(name)
+}
+
+func (s syncAccountAccess) Replace(name string, store Store) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if !s.access.Has(name) {
+		return errKeyNotExist(name)
+	}
+	return ReplaceAccount(s.access, name, store)
+}
+
+func (s syncAccountAccess) Has(name string) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.access.Has(name)
+}
+
+func (s syncAccountAccess) List() ([]string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return ListAccounts(s.access)
+}
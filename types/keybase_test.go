@@ -0,0 +1,232 @@
+package types_test
+
+import (
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"sort"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/irisnet/irishub-sdk-go/types"
+)
+
+func TestLevelDBList(t *testing.T) {
+	dir, err := ioutil.TempDir("", "keybase")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	cdc := types.NewAminoCodec()
+	types.RegisterCodec(cdc)
+	db, err := types.NewLevelDB(dir, cdc)
+	require.NoError(t, err)
+
+	names, err := types.ListAccounts(db)
+	require.NoError(t, err)
+	require.Empty(t, names)
+
+	require.NoError(t, db.Write("b", types.PrivKeyInfo{Address: "b"}))
+	require.NoError(t, db.Write("a", types.KeystoreInfo{Keystore: "{}"}))
+	names, err = types.ListAccounts(db)
+	require.NoError(t, err)
+	require.Equal(t, []string{"a", "b"}, names)
+
+	require.NoError(t, db.Delete("a"))
+	names, err = types.ListAccounts(db)
+	require.NoError(t, err)
+	require.Equal(t, []string{"b"}, names)
+
+	require.Error(t, types.ReplaceAccount(db, "a", types.PrivKeyInfo{Address: "a"}))
+	require.NoError(t, types.ReplaceAccount(db, "b", types.PrivKeyInfo{Address: "c"}))
+	store, err := db.Read("b")
+	require.NoError(t, err)
+	require.Equal(t, "c", store.(types.PrivKeyInfo).Address)
+}
+
+func TestLevelDBMigrate(t *testing.T) {
+	dir, err := ioutil.TempDir("", "keybase")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	cdc := types.NewAminoCodec()
+	types.RegisterCodec(cdc)
+	dao, err := types.NewLevelDB(dir, cdc)
+	require.NoError(t, err)
+	db := dao.(types.LevelDB)
+
+	privKey := "2b8f7bd1a7b3d1eb4c8cb1cbe1d0e8b1f8a1e6f4b5c2d7a9e0f1a2b3c4d5e6f7"
+	legacy, err := types.AES{}.Encrypt(privKey, "password")
+	require.NoError(t, err)
+	require.NoError(t, db.Write("legacy", types.PrivKeyInfo{PrivKey: legacy, Address: "a"}))
+	require.NoError(t, db.Write("keystore", types.KeystoreInfo{Keystore: "{}"}))
+
+	_, err = db.Migrate("legacy", "wrong password")
+	require.Error(t, err)
+
+	migrated, err := db.MigrateAll(func(name string) (string, error) {
+		return "password", nil
+	})
+	require.NoError(t, err)
+	require.Equal(t, []string{"legacy"}, migrated)
+
+	store, err := db.Read("legacy")
+	require.NoError(t, err)
+	info := store.(types.PrivKeyInfo)
+	require.False(t, types.IsLegacyCiphertext(info.PrivKey))
+	plain, err := db.Decrypt(info.PrivKey, "password")
+	require.NoError(t, err)
+	require.Equal(t, privKey, plain)
+
+	ok, err := db.Migrate("legacy", "password")
+	require.NoError(t, err)
+	require.False(t, ok)
+}
+
+func TestMemoryDBConcurrency(t *testing.T) {
+	testConcurrentAccess(t, types.NewMemoryDB())
+}
+
+func TestLevelDBConcurrency(t *testing.T) {
+	dir, err := ioutil.TempDir("", "keybase")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	cdc := types.NewAminoCodec()
+	types.RegisterCodec(cdc)
+	db, err := types.NewLevelDB(dir, cdc)
+	require.NoError(t, err)
+	testConcurrentAccess(t, db)
+}
+
+func TestSyncAccountAccess(t *testing.T) {
+	testConcurrentAccess(t, types.NewSyncAccountAccess(mapAccess{}))
+
+	dao := types.NewKeyDAO(mapAccess{})
+	require.NoError(t, dao.Write("a", types.KeystoreInfo{Keystore: "{}"}))
+	require.Error(t, dao.Write("a", types.KeystoreInfo{Keystore: "{}"}))
+}
+
+func TestOptionalAccountAccess(t *testing.T) {
+	// an AccountAccess implementing only the required methods still makes a KeyDAO
+	dao := types.NewKeyDAO(coreAccess{mapAccess{}})
+	require.NoError(t, dao.Write("a", types.KeystoreInfo{Keystore: "{}"}))
+	require.True(t, dao.Has("a"))
+
+	_, err := types.ListAccounts(dao)
+	require.True(t, errors.Is(err, types.ErrKeyManagerUnsupported))
+	err = types.ReplaceAccount(dao, "a", types.KeystoreInfo{Keystore: "{}"})
+	require.True(t, errors.Is(err, types.ErrKeyManagerUnsupported))
+
+	// the optional methods are reached through the KeyDAO
+	dao = types.NewKeyDAO(mapAccess{})
+	require.NoError(t, dao.Write("a", types.KeystoreInfo{Keystore: "{}"}))
+	require.NoError(t, types.ReplaceAccount(dao, "a", types.KeystoreInfo{Keystore: "{\"replaced\":true}"}))
+	names, err := types.ListAccounts(dao)
+	require.NoError(t, err)
+	require.Equal(t, []string{"a"}, names)
+}
+
+// testConcurrentAccess runs the operations of the keybase from many goroutines, run it with -race
+func testConcurrentAccess(t *testing.T, access types.AccountAccess) {
+	const workers = 16
+
+	var wg sync.WaitGroup
+	created := make(chan int, workers)
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			// only one of the workers creates the shared key
+			if err := access.Write("shared", types.PrivKeyInfo{Address: fmt.Sprint(i)}); err == nil {
+				created <- i
+			}
+
+			name := fmt.Sprintf("key%d", i)
+			require.NoError(t, access.Write(name, types.PrivKeyInfo{Address: name}))
+			require.True(t, access.Has(name))
+			store, err := access.Read(name)
+			require.NoError(t, err)
+			require.Equal(t, name, store.(types.PrivKeyInfo).Address)
+			_, err = types.ListAccounts(access)
+			require.NoError(t, err)
+			require.NoError(t, types.ReplaceAccount(access, name, types.PrivKeyInfo{Address: name + "-replaced"}))
+			if i%2 == 0 {
+				require.NoError(t, access.Delete(name))
+				require.Error(t, types.ReplaceAccount(access, name, types.PrivKeyInfo{Address: name}))
+			}
+		}(i)
+	}
+	wg.Wait()
+	close(created)
+
+	var winners []int
+	for i := range created {
+		winners = append(winners, i)
+	}
+	require.Len(t, winners, 1)
+	store, err := access.Read("shared")
+	require.NoError(t, err)
+	require.Equal(t, fmt.Sprint(winners[0]), store.(types.PrivKeyInfo).Address)
+
+	names, err := types.ListAccounts(access)
+	require.NoError(t, err)
+	expected := []string{"shared"}
+	for i := 1; i < workers; i += 2 {
+		expected = append(expected, fmt.Sprintf("key%d", i))
+	}
+	sort.Strings(names)
+	sort.Strings(expected)
+	require.Equal(t, expected, names)
+}
+
+// coreAccess hides the optional methods of an AccountAccess
+type coreAccess struct {
+	access types.AccountAccess
+}
+
+func (c coreAccess) Write(name string, store types.Store) error { return c.access.Write(name, store) }
+
+func (c coreAccess) Read(name string) (types.Store, error) { return c.access.Read(name) }
+
+func (c coreAccess) Delete(name string) error { return c.access.Delete(name) }
+
+func (c coreAccess) Has(name string) bool { return c.access.Has(name) }
+
+// mapAccess is an AccountAccess which is not safe for concurrent use
+// and overwrites the existing keys
+type mapAccess map[string]types.Store
+
+func (m mapAccess) Write(name string, store types.Store) error {
+	m[name] = store
+	return nil
+}
+
+func (m mapAccess) Read(name string) (types.Store, error) {
+	return m[name], nil
+}
+
+func (m mapAccess) Delete(name string) error {
+	delete(m, name)
+	return nil
+}
+
+func (m mapAccess) Replace(name string, store types.Store) error {
+	m[name] = store
+	return nil
+}
+
+func (m mapAccess) Has(name string) bool {
+	_, ok := m[name]
+	return ok
+}
+
+func (m mapAccess) List() ([]string, error) {
+	var names []string
+	for name := range m {
+		names = append(names, name)
+	}
+	return names, nil
+}
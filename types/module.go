@@ -1,5 +1,6 @@
 package types
 
+// Module is a module of the chain, RegisterCodec registers its msgs and the types of its queries
 type Module interface {
 	RegisterCodec(cdc Codec)
 	//RegisterErrorCode()
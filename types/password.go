@@ -0,0 +1,109 @@
+package types
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"unicode"
+
+	"golang.org/x/crypto/ssh/terminal"
+)
+
+// PasswordProvider returns the password of a key when a transaction is signed without BaseTx.Password
+// and the key is not unlocked
+type PasswordProvider interface {
+	Password(name string) (string, error)
+}
+
+// PasswordFunc is a PasswordProvider calling the function
+type PasswordFunc func(name string) (string, error)
+
+func (f PasswordFunc) Password(name string) (string, error) {
+	return f(name)
+}
+
+type envPasswordProvider struct {
+	prefix string
+}
+
+// NewEnvPasswordProvider reads the password of a key from the environment variable prefix + name,
+// where name is upper cased and its characters other than letters and digits are replaced by '_',
+// e.g. IRIS_PASSWORD_MY_KEY for the key my-key with the prefix IRIS_PASSWORD_
+func NewEnvPasswordProvider(prefix string) PasswordProvider {
+	return envPasswordProvider{prefix: prefix}
+}
+
+func (p envPasswordProvider) Password(name string) (string, error) {
+	variable := p.prefix + strings.Map(func(r rune) rune {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			return unicode.ToUpper(r)
+		}
+		return '_'
+	}, name)
+
+	password, ok := os.LookupEnv(variable)
+	if !ok {
+		return "", fmt.Errorf("the password of %s is not set, expected in %s", name, variable)
+	}
+	return password, nil
+}
+
+type filePasswordProvider struct {
+	dir string
+}
+
+// NewFilePasswordProvider reads the password of a key from the file dir/name, e.g. a mounted secret.
+// The trailing new line is removed and the files readable by the group or the others are refused.
+func NewFilePasswordProvider(dir string) PasswordProvider {
+	return filePasswordProvider{dir: dir}
+}
+
+func (p filePasswordProvider) Password(name string) (string, error) {
+	if len(name) == 0 || strings.ContainsAny(name, `/\`) || name == "." || name == ".." {
+		return "", fmt.Errorf("invalid key name %s", name)
+	}
+
+	path := filepath.Join(p.dir, name)
+	info, err := os.Stat(path)
+	if err != nil {
+		return "", err
+	}
+	if info.Mode().Perm()&0077 != 0 {
+		return "", fmt.Errorf("%s is accessible by other users, its mode must be 0600 or 0400", path)
+	}
+
+	bz, err := ioutil.ReadFile(path)
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimRight(string(bz), "\r\n"), nil
+}
+
+type terminalPasswordProvider struct{}
+
+// NewTerminalPasswordProvider prompts for the password on the terminal, without echo.
+// When the standard input is not a terminal, a line is read from it.
+func NewTerminalPasswordProvider() PasswordProvider {
+	return terminalPasswordProvider{}
+}
+
+func (terminalPasswordProvider) Password(name string) (string, error) {
+	fmt.Fprintf(os.Stderr, "Password of %s: ", name)
+
+	fd := int(os.Stdin.Fd())
+	if terminal.IsTerminal(fd) {
+		bz, err := terminal.ReadPassword(fd)
+		fmt.Fprintln(os.Stderr)
+		return string(bz), err
+	}
+
+	line, err := bufio.NewReader(os.Stdin).ReadString('\n')
+	if err != nil && len(line) == 0 {
+		return "", errors.New("failed to read the password from the standard input")
+	}
+	return strings.TrimRight(line, "\r\n"), nil
+}
@@ -0,0 +1,46 @@
+package types_test
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/irisnet/irishub-sdk-go/types"
+)
+
+func TestEnvPasswordProvider(t *testing.T) {
+	require.NoError(t, os.Setenv("SDK_TEST_PASSWORD_MY_KEY_1", "password"))
+	defer os.Unsetenv("SDK_TEST_PASSWORD_MY_KEY_1")
+
+	provider := types.NewEnvPasswordProvider("SDK_TEST_PASSWORD_")
+	password, err := provider.Password("my-key.1")
+	require.NoError(t, err)
+	require.Equal(t, "password", password)
+
+	_, err = provider.Password("unknown")
+	require.Error(t, err)
+}
+
+func TestFilePasswordProvider(t *testing.T) {
+	dir, err := ioutil.TempDir("", "passwords")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	require.NoError(t, ioutil.WriteFile(filepath.Join(dir, "key"), []byte("password\n"), 0600))
+	require.NoError(t, ioutil.WriteFile(filepath.Join(dir, "shared"), []byte("password"), 0644))
+
+	provider := types.NewFilePasswordProvider(dir)
+	password, err := provider.Password("key")
+	require.NoError(t, err)
+	require.Equal(t, "password", password)
+
+	_, err = provider.Password("shared")
+	require.Error(t, err)
+	_, err = provider.Password("../key")
+	require.Error(t, err)
+	_, err = provider.Password("unknown")
+	require.Error(t, err)
+}
@@ -0,0 +1,46 @@
+package types
+
+// SigningPolicy is checked by TxContext.Sign before a transaction is signed with the key name,
+// e.g. the policy.Engine
+type SigningPolicy interface {
+	// Check returns an error if the key name may not sign the msgs, otherwise what the transaction
+	// counts against the policy, e.g. its amounts for a daily maximum, is reserved until release is called.
+	// release is called if the transaction is not signed or is refused by the chain, it is never nil.
+	Check(name string, msgs []Msg, fee StdFee) (release func(), err error)
+}
+
+// SpendMsg is implemented by the msgs which move coins out of the account of their signer,
+// the signing policies check their amounts and their recipients
+type SpendMsg interface {
+	RecipientMsg
+	// SpentCoins returns the coins leaving the account of the signer
+	SpentCoins() Coins
+}
+
+// RecipientMsg is implemented by the msgs which report the accounts they give coins, tokens or rights to.
+// The signing policies limiting the recipients refuse the msgs which do not implement it.
+type RecipientMsg interface {
+	Msg
+	// Recipients returns the accounts receiving the coins, the tokens or the rights, if any
+	Recipients() []AccAddress
+}
+
+// NoSpendMsg is implemented by the msgs which move no coins out of the account of their signer.
+// The signing policies limiting the amounts refuse the msgs implementing neither SpendMsg nor NoSpendMsg.
+type NoSpendMsg interface {
+	Msg
+	SpendsNoCoins()
+}
+
+// ValidatorMsg is implemented by the msgs acting on validators
+type ValidatorMsg interface {
+	Msg
+	Validators() []ValAddress
+}
+
+// NoValidatorMsg is implemented by the msgs which act on no validator.
+// The signing policies limiting the validators refuse the msgs implementing neither ValidatorMsg nor NoValidatorMsg.
+type NoValidatorMsg interface {
+	Msg
+	ActsOnNoValidator()
+}
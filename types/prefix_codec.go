@@ -0,0 +1,112 @@
+package types
+
+import (
+	"reflect"
+	"strings"
+)
+
+var _ Codec = prefixCodec{}
+
+// prefixCodec is a codec carrying the bech32 prefixes of a client, returned by AddrPrefixCfgOf.
+// Its encoding is the one of the wrapped codec, the sign bytes and the transactions encoded with it
+// use its prefixes.
+type prefixCodec struct {
+	Codec
+	config *AddrPrefixCfg
+}
+
+// NewPrefixCodec returns cdc carrying the prefixes of config, see AddrPrefixCfgOf
+func NewPrefixCodec(cdc Codec, config *AddrPrefixCfg) Codec {
+	if pc, ok := cdc.(prefixCodec); ok {
+		cdc = pc.Codec
+	}
+	return prefixCodec{
+		Codec:  cdc,
+		config: config,
+	}
+}
+
+// AddrPrefixCfg returns the prefixes carried by the codec
+func (cdc prefixCodec) AddrPrefixCfg() *AddrPrefixCfg {
+	return cdc.config
+}
+
+// AddrPrefixCfgOf returns the prefixes carried by the codec, the default ones of the process if it carries none
+func AddrPrefixCfgOf(cdc Codec) *AddrPrefixCfg {
+	if pc, ok := cdc.(interface{ AddrPrefixCfg() *AddrPrefixCfg }); ok {
+		return pc.AddrPrefixCfg()
+	}
+	return GetAddrPrefixCfg()
+}
+
+// EncodeSignBytes returns the sign bytes of a msg which does not implement SignDocMsg, those of GetSignBytes
+// with the typed addresses of the msg encoded with the prefixes carried by the codec instead of the default ones
+// of the process
+func EncodeSignBytes(cdc Codec, msg Msg) []byte {
+	bz := msg.GetSignBytes()
+	config, defaults := AddrPrefixCfgOf(cdc), GetAddrPrefixCfg()
+	if config.Equal(defaults) {
+		return bz
+	}
+
+	var replacements []string
+	walkAddresses(reflect.ValueOf(msg), func(addr reflect.Value) {
+		var from, to string
+		switch a := addr.Interface().(type) {
+		case AccAddress:
+			from, to = defaults.AccAddressString(a), config.AccAddressString(a)
+		case ValAddress:
+			from, to = defaults.ValAddressString(a), config.ValAddressString(a)
+		case ConsAddress:
+			from, to = defaults.ConsAddressString(a), config.ConsAddressString(a)
+		}
+		if len(from) > 0 {
+			replacements = append(replacements, `"`+from+`"`, `"`+to+`"`)
+		}
+	})
+	if len(replacements) == 0 {
+		return bz
+	}
+	return []byte(strings.NewReplacer(replacements...).Replace(string(bz)))
+}
+
+var (
+	accAddressType  = reflect.TypeOf(AccAddress{})
+	valAddressType  = reflect.TypeOf(ValAddress{})
+	consAddressType = reflect.TypeOf(ConsAddress{})
+)
+
+// walkAddresses calls f with each non-empty AccAddress, ValAddress and ConsAddress held by rv
+func walkAddresses(rv reflect.Value, f func(reflect.Value)) {
+	switch rv.Kind() {
+	case reflect.Ptr, reflect.Interface:
+		if !rv.IsNil() {
+			walkAddresses(rv.Elem(), f)
+		}
+	case reflect.Struct:
+		for i := 0; i < rv.NumField(); i++ {
+			if rv.Type().Field(i).PkgPath == "" {
+				walkAddresses(rv.Field(i), f)
+			}
+		}
+	case reflect.Slice, reflect.Array:
+		switch rv.Type() {
+		case accAddressType, valAddressType, consAddressType:
+			if rv.Len() > 0 {
+				f(rv)
+			}
+			return
+		}
+		if rv.Type().Elem().Kind() == reflect.Uint8 {
+			return
+		}
+		for i := 0; i < rv.Len(); i++ {
+			walkAddresses(rv.Index(i), f)
+		}
+	case reflect.Map:
+		iter := rv.MapRange()
+		for iter.Next() {
+			walkAddresses(iter.Value(), f)
+		}
+	}
+}
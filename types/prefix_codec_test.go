@@ -0,0 +1,129 @@
+package types_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/irisnet/irishub-sdk-go/modules/bank"
+	"github.com/irisnet/irishub-sdk-go/modules/staking"
+	"github.com/irisnet/irishub-sdk-go/types"
+)
+
+// withDefaultPrefixes runs f with the default prefixes of the process set to config
+func withDefaultPrefixes(config *types.AddrPrefixCfg, f func()) {
+	defaultPrefixes := types.GetAddrPrefixCfg()
+	types.SetAddrPrefixCfg(config)
+	defer types.SetAddrPrefixCfg(defaultPrefixes)
+	f()
+}
+
+func TestStdSignMsgBytesWithPrefixes(t *testing.T) {
+	testnet := types.Testnet.AddrPrefixCfg()
+	cdc := types.NewAminoCodec()
+	cdc.RegisterConcrete(bank.MsgSend{}, "irishub/bank/Send")
+	cdc.RegisterConcrete(bank.MsgSetMemoRegexp{}, "irishub/bank/SetMemoRegexp")
+	cdc.RegisterConcrete(staking.MsgUndelegate{}, "irishub/stake/BeginUnbonding")
+
+	from := types.AccAddress([]byte("address_____________"))
+	to := types.AccAddress([]byte("recipient___________"))
+	coins := types.NewCoins(types.NewCoin("uiris", types.NewInt(10)))
+	msg := types.StdSignMsg{
+		ChainID:       "irishub",
+		AccountNumber: 7,
+		Sequence:      3,
+		Fee:           types.StdFee{Amount: coins, Gas: 20000},
+		Msgs: []types.Msg{
+			bank.NewMsgSend([]bank.Input{bank.NewInput(from, coins)}, []bank.Output{bank.NewOutput(to, coins)}),
+			bank.NewMsgSetMemoRegexp(from, testnet.AccAddressString(to)),
+			staking.MsgUndelegate{
+				DelegatorAddr: from,
+				ValidatorAddr: types.ValAddress(to),
+				SharesAmount:  types.NewDec(10),
+			},
+		},
+		Memo: testnet.AccAddressString(to),
+	}
+
+	// the sign bytes are those of a process whose default prefixes are those of the client
+	var want []byte
+	withDefaultPrefixes(testnet, func() {
+		want = msg.Bytes(cdc)
+	})
+	bz := msg.Bytes(types.NewPrefixCodec(cdc, testnet))
+	require.Equal(t, string(want), string(bz))
+	require.NotEqual(t, string(msg.Bytes(cdc)), string(bz))
+}
+
+// msgLegacy does not implement SignDocMsg
+type msgLegacy struct {
+	Owner types.AccAddress `json:"owner"`
+}
+
+func (msg msgLegacy) Route() string { return "legacy" }
+
+func (msg msgLegacy) Type() string { return "legacy" }
+
+func (msg msgLegacy) ValidateBasic() error { return nil }
+
+func (msg msgLegacy) GetSignBytes() []byte {
+	bz, _ := json.Marshal(msg)
+	return bz
+}
+
+func (msg msgLegacy) GetSigners() []types.AccAddress { return []types.AccAddress{msg.Owner} }
+
+func TestStdSignMsgBytesLegacyMsg(t *testing.T) {
+	testnet, mainnet := types.Testnet.AddrPrefixCfg(), types.Mainnet.AddrPrefixCfg()
+	cdc := types.NewAminoCodec()
+	msg := types.StdSignMsg{
+		ChainID: "irishub",
+		Msgs:    []types.Msg{msgLegacy{Owner: types.AccAddress([]byte("address_____________"))}},
+	}
+
+	// the addresses of the msg are encoded with the prefixes of the client whatever the default ones
+	for _, config := range []*types.AddrPrefixCfg{testnet, mainnet} {
+		var want []byte
+		withDefaultPrefixes(config, func() {
+			want = msg.Bytes(cdc)
+		})
+		bz, err := msg.SignBytes(types.NewPrefixCodec(cdc, config))
+		require.NoError(t, err)
+		require.Equal(t, string(want), string(bz))
+		require.Contains(t, string(bz), config.AccAddressString(msg.Msgs[0].GetSigners()[0]))
+	}
+}
+
+func TestAddressJSONPrefixes(t *testing.T) {
+	testnet, mainnet := types.Testnet.AddrPrefixCfg(), types.Mainnet.AddrPrefixCfg()
+	addr := types.AccAddress([]byte("address_____________"))
+
+	// the addresses of the nodes are decoded whatever their prefixes
+	for _, s := range []string{testnet.AccAddressString(addr), mainnet.AccAddressString(addr)} {
+		var decoded types.AccAddress
+		require.NoError(t, json.Unmarshal([]byte(`"`+s+`"`), &decoded))
+		require.Equal(t, addr, decoded)
+	}
+	var decoded types.AccAddress
+	require.Error(t, json.Unmarshal([]byte(`"not an address"`), &decoded))
+}
+
+func TestAddrPrefixCfgValidate(t *testing.T) {
+	custom := types.NewAddrPrefixCfg("cosmos", "cosmosvaloper", "cosmosvalcons", "cosmospub", "cosmosvaloperpub", "cosmosvalconspub")
+	require.NoError(t, custom.Validate())
+
+	addr := types.AccAddress([]byte("address_____________"))
+	s := custom.AccAddressString(addr)
+	require.Contains(t, s, "cosmos1")
+	addr1, err := custom.AccAddressFromBech32(s)
+	require.NoError(t, err)
+	require.Equal(t, addr, addr1)
+	_, err = types.Testnet.AddrPrefixCfg().AccAddressFromBech32(s)
+	require.Error(t, err)
+
+	require.Error(t, types.NewAddrPrefixCfg("", "b", "c", "d", "e", "f").Validate())
+	require.Error(t, types.NewAddrPrefixCfg("A", "b", "c", "d", "e", "f").Validate())
+	require.Error(t, types.NewAddrPrefixCfg("a", "a", "c", "d", "e", "f").Validate())
+	require.Nil(t, types.Network("unknown").AddrPrefixCfg())
+}
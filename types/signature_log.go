@@ -0,0 +1,21 @@
+package types
+
+// SignatureLog records every transaction signed by TxContext.Sign, e.g. the audit.Log.
+// A transaction is not returned by Sign when it can not be recorded.
+type SignatureLog interface {
+	Append(record SignatureRecord) error
+}
+
+// SignatureRecord describes a signed transaction
+type SignatureRecord struct {
+	Name          string   `json:"name"`
+	Address       string   `json:"address"`
+	ChainID       string   `json:"chain_id"`
+	AccountNumber uint64   `json:"account_number"`
+	Sequence      uint64   `json:"sequence"`
+	Msgs          []string `json:"msgs"`
+	// SignBytesHash is the hex encoded SHA-256 of the signed bytes
+	SignBytesHash string `json:"sign_bytes_hash"`
+	// TxHash is the hash of the signed transaction, as returned by the node when it is broadcast
+	TxHash string `json:"tx_hash"`
+}
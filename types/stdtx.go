@@ -1,6 +1,7 @@
 package types
 
 import (
+	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -44,6 +45,22 @@ type Msg interface {
 	GetSigners() []AccAddress
 }
 
+// SignDocMsg is a Msg whose addresses are signed with the prefixes of the client: its sign bytes are the
+// JSON of SignDoc, while GetSignBytes encodes them with the default prefixes of the process. The msgs
+// which do not implement it can only be signed by the clients whose prefixes are the default ones.
+type SignDocMsg interface {
+	Msg
+
+	// SignDoc returns the document signed, with the addresses and public keys encoded with the prefixes of config
+	SignDoc(config *AddrPrefixCfg) interface{}
+}
+
+// TypedSignDoc is the sign document of a msg whose JSON is wrapped with the name of its registered type
+type TypedSignDoc struct {
+	Type  string
+	Value interface{}
+}
+
 type Msgs []Msg
 
 func (m Msgs) Len() int {
@@ -116,11 +133,34 @@ type StdSignMsg struct {
 	Memo          string `json:"memo"`
 }
 
-// get message bytes
+// UnsignedTx is a transaction built for an account whose key is not on this machine
+type UnsignedTx struct {
+	StdSignMsg
+	// SignBytes are the bytes to be signed with the key of the account
+	SignBytes []byte `json:"sign_bytes"`
+}
+
+// get message bytes, the addresses are encoded with the prefixes of the codec. It panics if a msg can not
+// be signed with them, see SignBytes.
 func (msg StdSignMsg) Bytes(cdc Codec) []byte {
+	bz, err := msg.SignBytes(cdc)
+	if err != nil {
+		panic(err)
+	}
+	return bz
+}
+
+// SignBytes returns the message bytes, the addresses are encoded with the prefixes of the codec. The
+// msgs which do not implement SignDocMsg are encoded by EncodeSignBytes.
+func (msg StdSignMsg) SignBytes(cdc Codec) ([]byte, error) {
+	config := AddrPrefixCfgOf(cdc)
 	var msgsBytes []json.RawMessage
 	for _, msg := range msg.Msgs {
-		msgsBytes = append(msgsBytes, json.RawMessage(msg.GetSignBytes()))
+		bz, err := signBytes(cdc, config, msg)
+		if err != nil {
+			return nil, err
+		}
+		msgsBytes = append(msgsBytes, json.RawMessage(bz))
 	}
 	bz, err := cdc.MarshalJSON(StdSignDoc{
 		AccountNumber: msg.AccountNumber,
@@ -131,9 +171,37 @@ func (msg StdSignMsg) Bytes(cdc Codec) []byte {
 		Sequence:      msg.Sequence,
 	})
 	if err != nil {
-		panic(err)
+		return nil, err
 	}
-	return json2.MustSort(bz)
+	return json2.MustSort(bz), nil
+}
+
+// signBytes returns the sign bytes of the msg with the addresses encoded with the prefixes of config
+func signBytes(cdc Codec, config *AddrPrefixCfg, msg Msg) ([]byte, error) {
+	m, ok := msg.(SignDocMsg)
+	if !ok {
+		return EncodeSignBytes(cdc, msg), nil
+	}
+
+	doc := m.SignDoc(config)
+	if typed, ok := doc.(TypedSignDoc); ok {
+		value, err := cdc.MarshalJSON(typed.Value)
+		if err != nil {
+			return nil, err
+		}
+		doc = struct {
+			Type  string          `json:"type"`
+			Value json.RawMessage `json:"value"`
+		}{
+			Type:  typed.Type,
+			Value: value,
+		}
+	}
+	bz, err := cdc.MarshalJSON(doc)
+	if err != nil {
+		return nil, err
+	}
+	return json2.MustSort(bz), nil
 }
 
 // StdSignDoc is replay-prevention structure.
@@ -262,6 +330,9 @@ type BaseTx struct {
 	Memo     string        `json:"memo"`
 	Mode     BroadcastMode `json:"broadcast_mode"`
 	Simulate bool          `json:"simulate"`
+
+	// Ctx is propagated to the tracer and its trace ID is written into every log line of the transaction
+	Ctx context.Context `json:"-"`
 }
 
 // ResultTx encapsulates the return result of the transaction. When the transaction fails,
@@ -16,6 +16,18 @@ var (
 		Mintable:      true,
 		Owner:         "",
 	}
+
+	// IRISv1 is the native token of IRIShub 1.x
+	IRISv1 = Token{
+		Symbol:        iris,
+		Name:          "IRIS Network",
+		Scale:         6,
+		MinUnit:       "uiris",
+		InitialSupply: 2000000000,
+		MaxSupply:     1000000000000,
+		Mintable:      true,
+		Owner:         "",
+	}
 )
 
 type Token struct {
@@ -34,6 +46,9 @@ func (t Token) GetMinUnit() string {
 	symbol := strings.ToLower(strings.TrimSpace(t.Symbol))
 
 	if symbol == IRIS.Symbol {
+		if t.MinUnit == IRISv1.MinUnit {
+			return IRISv1.MinUnit
+		}
 		return IRIS.MinUnit
 	}
 
@@ -0,0 +1,288 @@
+package types
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/tendermint/tendermint/crypto"
+	"github.com/tendermint/tendermint/crypto/ed25519"
+	"github.com/tendermint/tendermint/crypto/secp256k1"
+
+	"github.com/irisnet/irishub-sdk-go/utils/bech32"
+	"github.com/irisnet/irishub-sdk-go/utils/proto"
+)
+
+// TxEncoding is the encoding of the transactions of a chain
+type TxEncoding string
+
+const (
+	// Amino is the encoding of IRIShub 0.x: amino transactions signed with their sorted JSON
+	Amino TxEncoding = "amino"
+	// Protobuf is the encoding of IRIShub 1.x (Cosmos SDK 0.40+): protobuf transactions signed with SIGN_MODE_DIRECT
+	Protobuf TxEncoding = "protobuf"
+)
+
+// ErrProtobufUnsupported is returned by the queries which a client of the Protobuf encoding does not support:
+// they are the amino queries of IRIShub 0.x, which the nodes of 1.x do not answer or answer with other types
+var ErrProtobufUnsupported = errors.New("not supported by the protobuf encoding")
+
+// RequireAmino returns ErrProtobufUnsupported, with the name of the query, if the encoding is Protobuf
+func RequireAmino(encoding TxEncoding, query string) Error {
+	if encoding != Protobuf {
+		return nil
+	}
+	return UnsupportedByProtobuf(query)
+}
+
+// UnsupportedByProtobuf returns ErrProtobufUnsupported with the name of the query,
+// e.g. by the queries of a module which have no counterpart in IRIShub 1.x
+func UnsupportedByProtobuf(query string) Error {
+	return WrapWithMessage(ErrProtobufUnsupported, "%s", query)
+}
+
+// TxEncoder encodes the transactions and the bytes signed by their signers
+type TxEncoder interface {
+	Encoding() TxEncoding
+	// SignBytes returns the bytes to be signed by the key of pubKey, which Amino does not use
+	SignBytes(msg StdSignMsg, pubKey crypto.PubKey) ([]byte, error)
+	// EncodeTx returns the bytes broadcast to the chain
+	EncodeTx(tx StdTx) ([]byte, error)
+}
+
+// ProtoMsg is a Msg which can be sent to a chain of the Protobuf encoding
+type ProtoMsg interface {
+	Msg
+	// ProtoTypeURL returns the protobuf type of the msg, e.g. /cosmos.bank.v1beta1.MsgSend
+	ProtoTypeURL() string
+	// MarshalProto encodes the msg, whose addresses use the given prefixes
+	MarshalProto(prefixes *AddrPrefixCfg) ([]byte, error)
+}
+
+// NewTxEncoder returns the encoder of the encoding, the addresses of the Protobuf transactions use the prefixes
+// of the JSON encoding of the codec
+func NewTxEncoder(encoding TxEncoding, cdc Codec) (TxEncoder, error) {
+	switch encoding {
+	case Amino, "":
+		return aminoTxEncoder{cdc: cdc}, nil
+	case Protobuf:
+		return protoTxEncoder{prefixes: AddrPrefixCfgOf(cdc)}, nil
+	default:
+		return nil, fmt.Errorf("unknown tx encoding %s", encoding)
+	}
+}
+
+// TxEncodingOf returns the encoding used by the version of IRIShub reported by ABCIInfo, Amino if it is unknown
+func TxEncodingOf(appVersion string) TxEncoding {
+	v, err := ParseVersion(appVersion)
+	if err != nil {
+		return Amino
+	}
+	return v.TxEncoding()
+}
+
+type aminoTxEncoder struct {
+	cdc Codec
+}
+
+func (enc aminoTxEncoder) Encoding() TxEncoding {
+	return Amino
+}
+
+func (enc aminoTxEncoder) SignBytes(msg StdSignMsg, _ crypto.PubKey) ([]byte, error) {
+	return msg.SignBytes(enc.cdc)
+}
+
+func (enc aminoTxEncoder) EncodeTx(tx StdTx) ([]byte, error) {
+	return enc.cdc.MarshalBinaryLengthPrefixed(tx)
+}
+
+type protoTxEncoder struct {
+	prefixes *AddrPrefixCfg
+}
+
+func (enc protoTxEncoder) Encoding() TxEncoding {
+	return Protobuf
+}
+
+// SignBytes returns the SignDoc of SIGN_MODE_DIRECT
+func (enc protoTxEncoder) SignBytes(msg StdSignMsg, pubKey crypto.PubKey) ([]byte, error) {
+	body, err := enc.body(msg.Msgs, msg.Memo)
+	if err != nil {
+		return nil, err
+	}
+	authInfo, err := enc.authInfo(msg.Fee, StdSignature{PubKey: pubKey, Sequence: msg.Sequence})
+	if err != nil {
+		return nil, err
+	}
+	doc := new(proto.Encoder).
+		Raw(1, body).
+		Raw(2, authInfo).
+		String(3, msg.ChainID).
+		Uint64(4, msg.AccountNumber)
+	return doc.Bytes(), nil
+}
+
+// EncodeTx returns the TxRaw of the transaction
+func (enc protoTxEncoder) EncodeTx(tx StdTx) ([]byte, error) {
+	body, err := enc.body(tx.Msgs, tx.Memo)
+	if err != nil {
+		return nil, err
+	}
+	authInfo, err := enc.authInfo(tx.Fee, tx.Signatures...)
+	if err != nil {
+		return nil, err
+	}
+	raw := new(proto.Encoder).Raw(1, body).Raw(2, authInfo)
+	for _, sig := range tx.Signatures {
+		raw.Message(3, sig.Signature)
+	}
+	return raw.Bytes(), nil
+}
+
+// body returns the TxBody of the msgs
+func (enc protoTxEncoder) body(msgs []Msg, memo string) ([]byte, error) {
+	body := new(proto.Encoder)
+	for _, msg := range msgs {
+		m, ok := msg.(ProtoMsg)
+		if !ok {
+			return nil, fmt.Errorf("msg %s/%s is not supported by the protobuf encoding", msg.Route(), msg.Type())
+		}
+		bz, err := m.MarshalProto(enc.prefixes)
+		if err != nil {
+			return nil, err
+		}
+		body.Any(1, m.ProtoTypeURL(), bz)
+	}
+	return body.String(2, memo).Bytes(), nil
+}
+
+// authInfo returns the AuthInfo of the signers and the fee
+func (enc protoTxEncoder) authInfo(fee StdFee, signatures ...StdSignature) ([]byte, error) {
+	authInfo := new(proto.Encoder)
+	for _, sig := range signatures {
+		signerInfo := new(proto.Encoder)
+		if sig.PubKey != nil {
+			typeURL, key, err := marshalProtoPubKey(sig.PubKey)
+			if err != nil {
+				return nil, err
+			}
+			signerInfo.Any(1, typeURL, key)
+		}
+		// mode_info.single.mode = SIGN_MODE_DIRECT
+		single := new(proto.Encoder).Uint64(1, 1)
+		modeInfo := new(proto.Encoder).Message(1, single.Bytes())
+		signerInfo.Message(2, modeInfo.Bytes()).Uint64(3, sig.Sequence)
+		authInfo.Message(1, signerInfo.Bytes())
+	}
+
+	protoFee := new(proto.Encoder)
+	for _, coin := range fee.Amount {
+		protoFee.Message(1, MarshalProtoCoin(coin))
+	}
+	protoFee.Uint64(2, fee.Gas)
+	return authInfo.Message(2, protoFee.Bytes()).Bytes(), nil
+}
+
+// MarshalProtoCoin encodes a cosmos.base.v1beta1.Coin
+func MarshalProtoCoin(coin Coin) []byte {
+	return new(proto.Encoder).String(1, coin.Denom).String(2, coin.Amount.String()).Bytes()
+}
+
+// UnmarshalProtoCoin decodes a cosmos.base.v1beta1.Coin
+func UnmarshalProtoCoin(bz []byte) (Coin, error) {
+	fields, err := proto.Decode(bz)
+	if err != nil {
+		return Coin{}, err
+	}
+	coin := Coin{Amount: ZeroInt()}
+	for _, f := range fields {
+		switch f.Number {
+		case 1:
+			coin.Denom = string(f.Value)
+		case 2:
+			amount, ok := NewIntFromString(string(f.Value))
+			if !ok {
+				return Coin{}, fmt.Errorf("invalid amount %s", f.Value)
+			}
+			coin.Amount = amount
+		}
+	}
+	return coin, nil
+}
+
+func marshalProtoPubKey(pubKey crypto.PubKey) (string, []byte, error) {
+	switch pk := pubKey.(type) {
+	case secp256k1.PubKeySecp256k1:
+		return "/cosmos.crypto.secp256k1.PubKey", new(proto.Encoder).Raw(1, pk[:]).Bytes(), nil
+	case ed25519.PubKeyEd25519:
+		return "/cosmos.crypto.ed25519.PubKey", new(proto.Encoder).Raw(1, pk[:]).Bytes(), nil
+	default:
+		return "", nil, fmt.Errorf("public key %T is not supported by the protobuf encoding", pubKey)
+	}
+}
+
+// UnmarshalProtoAccount decodes the account of a cosmos.auth.v1beta1.QueryAccountResponse,
+// the vesting and module accounts are reduced to their base account
+func UnmarshalProtoAccount(bz []byte, prefixes *AddrPrefixCfg) (BaseAccount, error) {
+	fields, err := proto.Decode(bz)
+	if err != nil {
+		return BaseAccount{}, err
+	}
+	for _, f := range fields {
+		if f.Number != 1 {
+			continue
+		}
+		_, value, err := proto.DecodeAny(f.Value)
+		if err != nil {
+			return BaseAccount{}, err
+		}
+		return unmarshalProtoBaseAccount(value, prefixes)
+	}
+	return BaseAccount{}, fmt.Errorf("account not found")
+}
+
+func unmarshalProtoBaseAccount(bz []byte, prefixes *AddrPrefixCfg) (BaseAccount, error) {
+	fields, err := proto.Decode(bz)
+	if err != nil {
+		return BaseAccount{}, err
+	}
+	var acc BaseAccount
+	for _, f := range fields {
+		switch {
+		case f.Number == 1 && f.WireType == proto.Bytes:
+			if _, _, err := bech32.DecodeAndConvert(string(f.Value)); err != nil {
+				// the base account embedded by the other accounts
+				return unmarshalProtoBaseAccount(f.Value, prefixes)
+			}
+			addr, err := prefixes.AccAddressFromBech32(string(f.Value))
+			if err != nil {
+				return BaseAccount{}, err
+			}
+			acc.Address = addr
+		case f.Number == 2:
+			typeURL, value, err := proto.DecodeAny(f.Value)
+			if err != nil {
+				return BaseAccount{}, err
+			}
+			key, err := proto.Decode(value)
+			if err != nil || len(key) == 0 {
+				continue
+			}
+			switch typeURL {
+			case "/cosmos.crypto.secp256k1.PubKey":
+				var pk secp256k1.PubKeySecp256k1
+				copy(pk[:], key[0].Value)
+				acc.PubKey = pk
+			case "/cosmos.crypto.ed25519.PubKey":
+				var pk ed25519.PubKeyEd25519
+				copy(pk[:], key[0].Value)
+				acc.PubKey = pk
+			}
+		case f.Number == 3:
+			acc.AccountNumber = f.Varint
+		case f.Number == 4:
+			acc.Sequence = f.Varint
+		}
+	}
+	return acc, nil
+}
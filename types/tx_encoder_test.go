@@ -0,0 +1,234 @@
+package types_test
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io/ioutil"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/tendermint/tendermint/crypto/secp256k1"
+
+	"github.com/irisnet/irishub-sdk-go/adapter"
+	"github.com/irisnet/irishub-sdk-go/modules/bank"
+	"github.com/irisnet/irishub-sdk-go/modules/distribution"
+	"github.com/irisnet/irishub-sdk-go/modules/gov"
+	"github.com/irisnet/irishub-sdk-go/modules/slashing"
+	"github.com/irisnet/irishub-sdk-go/modules/staking"
+	"github.com/irisnet/irishub-sdk-go/types"
+	"github.com/irisnet/irishub-sdk-go/utils/proto"
+)
+
+func TestTxEncodingOf(t *testing.T) {
+	require.Equal(t, types.Amino, types.TxEncodingOf(""))
+	require.Equal(t, types.Amino, types.TxEncodingOf("0.16.3"))
+	require.Equal(t, types.Protobuf, types.TxEncodingOf("1.0.0"))
+	require.Equal(t, types.Protobuf, types.TxEncodingOf("v1.1.1-mainnet"))
+}
+
+// decryptCounter counts the decryptions of the keys
+type decryptCounter struct {
+	types.KeyDAO
+	decrypts int
+}
+
+func (dao *decryptCounter) Decrypt(data string, password string) (string, error) {
+	dao.decrypts++
+	return dao.KeyDAO.Decrypt(data, password)
+}
+
+func TestProtoTxEncoder(t *testing.T) {
+	dao := &decryptCounter{KeyDAO: types.NewMemoryDB()}
+	km := adapter.NewDAOAdapter(dao, types.PrivKey)
+	_, _, err := km.Insert("test", "password")
+	require.NoError(t, err)
+	from, err := km.Query("test")
+	require.NoError(t, err)
+
+	mainnet := types.Mainnet.AddrPrefixCfg()
+	cdc := types.NewPrefixCodec(types.NewAminoCodec(), mainnet)
+	encoder, err := types.NewTxEncoder(types.Protobuf, cdc)
+	require.NoError(t, err)
+
+	to := types.AccAddress([]byte("recipient___________"))
+	coins := types.NewCoins(types.NewCoin("uiris", types.NewInt(10)))
+	txCtx := &types.TxContext{}
+	txCtx.WithCodec(cdc).
+		WithTxEncoder(encoder).
+		WithChainID("irishub-1").
+		WithAccountNumber(7).
+		WithSequence(3).
+		WithMemo("memo").
+		WithGas(200000).
+		WithFee(types.NewCoins(types.NewCoin("uiris", types.NewInt(1)))).
+		WithKeyManager(km).
+		WithPassword("password")
+
+	msg := bank.NewMsgSend([]bank.Input{bank.NewInput(from, coins)}, []bank.Output{bank.NewOutput(to, coins)})
+	dao.decrypts = 0
+	tx, err := txCtx.BuildAndSign("test", []types.Msg{msg})
+	require.NoError(t, err)
+	// the public key is stored with the key, only Sign decrypts it
+	require.Equal(t, 1, dao.decrypts)
+	bz, err := encoder.EncodeTx(tx)
+	require.NoError(t, err)
+
+	// TxRaw: body, auth info, signatures
+	raw, err := proto.Decode(bz)
+	require.NoError(t, err)
+	require.Len(t, raw, 3)
+	body, authInfo, signature := raw[0].Value, raw[1].Value, raw[2].Value
+
+	bodyFields, err := proto.Decode(body)
+	require.NoError(t, err)
+	typeURL, value, err := proto.DecodeAny(bodyFields[0].Value)
+	require.NoError(t, err)
+	require.Equal(t, "/cosmos.bank.v1beta1.MsgSend", typeURL)
+	require.Equal(t, "memo", string(bodyFields[1].Value))
+	send, err := proto.Decode(value)
+	require.NoError(t, err)
+	require.Equal(t, mainnet.AccAddressString(from), string(send[0].Value))
+	require.Equal(t, mainnet.AccAddressString(to), string(send[1].Value))
+	coin, err := types.UnmarshalProtoCoin(send[2].Value)
+	require.NoError(t, err)
+	require.Equal(t, coins[0], coin)
+
+	// the signature is made over the SignDoc of the same body and auth info
+	signDoc := new(proto.Encoder).Raw(1, body).Raw(2, authInfo).String(3, "irishub-1").Uint64(4, 7)
+	pubKey := tx.Signatures[0].PubKey.(secp256k1.PubKeySecp256k1)
+	require.True(t, pubKey.VerifyBytes(signDoc.Bytes(), signature))
+
+	signBytes, err := encoder.SignBytes(types.StdSignMsg{
+		ChainID:       "irishub-1",
+		AccountNumber: 7,
+		Sequence:      3,
+		Memo:          "memo",
+		Msgs:          []types.Msg{msg},
+		Fee:           types.NewStdFee(200000, types.NewCoin("uiris", types.NewInt(1))),
+	}, pubKey)
+	require.NoError(t, err)
+	require.Equal(t, signDoc.Bytes(), signBytes)
+
+	_, err = encoder.EncodeTx(types.StdTx{Msgs: []types.Msg{bank.NewMsgBurn(from, coins)}})
+	require.Error(t, err)
+}
+
+// testdata/proto_txs.json holds the SignDoc and the TxRaw of the transactions built and signed by this SDK when
+// the Protobuf encoding was added, with the key of sha256("irishub-sdk-go"), the account number 7, the sequence 3,
+// the memo "memo", the fee 1uiris, 200000 gas and the chain-id irishub-1. They are regression vectors, they were not
+// produced by the Cosmos SDK.
+func TestProtoTxVectors(t *testing.T) {
+	bz, err := ioutil.ReadFile("testdata/proto_txs.json")
+	require.NoError(t, err)
+	var vectors []struct {
+		Name    string `json:"name"`
+		SignDoc string `json:"sign_doc"`
+		TxRaw   string `json:"tx_raw"`
+	}
+	require.NoError(t, json.Unmarshal(bz, &vectors))
+
+	km := adapter.NewDAOAdapter(types.NewMemoryDB(), types.PrivKey)
+	seed := sha256.Sum256([]byte("irishub-sdk-go"))
+	_, err = km.ImportPrivKey("test", "password", hex.EncodeToString(seed[:]))
+	require.NoError(t, err)
+	from, err := km.Query("test")
+	require.NoError(t, err)
+	to := types.AccAddress([]byte("recipient___________"))
+	other := types.AccAddress([]byte("other_recipient_____"))
+	validator := types.ValAddress([]byte("validator___________"))
+	uiris := func(amount int64) types.Coin { return types.NewCoin("uiris", types.NewInt(amount)) }
+
+	delegate := staking.MsgDelegate{DelegatorAddr: from, ValidatorAddr: validator, Delegation: uiris(100)}
+	msgs := map[string][]types.Msg{
+		"send": {bank.NewMsgSend(
+			[]bank.Input{bank.NewInput(from, types.NewCoins(uiris(10)))},
+			[]bank.Output{bank.NewOutput(to, types.NewCoins(uiris(10)))},
+		)},
+		"multi_send": {bank.NewMsgSend(
+			[]bank.Input{bank.NewInput(from, types.NewCoins(uiris(30)))},
+			[]bank.Output{bank.NewOutput(to, types.NewCoins(uiris(10))), bank.NewOutput(other, types.NewCoins(uiris(20)))},
+		)},
+		"delegate":                  {delegate},
+		"set_withdraw_address":      {distribution.MsgSetWithdrawAddress{DelegatorAddr: from, WithdrawAddr: to}},
+		"withdraw_delegator_reward": {distribution.MsgWithdrawDelegatorReward{DelegatorAddr: from, ValidatorAddr: validator}},
+		"deposit":                   {gov.MsgDeposit{ProposalID: 1, Depositor: from, Amount: types.NewCoins(uiris(5))}},
+		"vote":                      {gov.MsgVote{ProposalID: 2, Voter: from, Option: gov.OptionNoWithVeto}},
+		"unjail":                    {slashing.MsgUnjail{ValidatorAddr: types.ValAddress(from)}},
+		"delegate_and_vote":         {delegate, gov.MsgVote{ProposalID: 2, Voter: from, Option: gov.OptionYes}},
+	}
+	require.Len(t, vectors, len(msgs))
+
+	cdc := types.NewPrefixCodec(types.NewAminoCodec(), types.Mainnet.AddrPrefixCfg())
+	encoder, err := types.NewTxEncoder(types.Protobuf, cdc)
+	require.NoError(t, err)
+	for _, v := range vectors {
+		txCtx := &types.TxContext{}
+		txCtx.WithCodec(cdc).
+			WithTxEncoder(encoder).
+			WithChainID("irishub-1").
+			WithAccountNumber(7).
+			WithSequence(3).
+			WithMemo("memo").
+			WithGas(200000).
+			WithFee(types.NewCoins(uiris(1))).
+			WithKeyManager(km).
+			WithPassword("password")
+
+		require.Contains(t, msgs, v.Name)
+		tx, err := txCtx.BuildAndSign("test", msgs[v.Name])
+		require.NoError(t, err, v.Name)
+
+		signBytes, err := encoder.SignBytes(types.StdSignMsg{
+			ChainID:       "irishub-1",
+			AccountNumber: 7,
+			Sequence:      3,
+			Memo:          "memo",
+			Msgs:          msgs[v.Name],
+			Fee:           types.NewStdFee(200000, uiris(1)),
+		}, tx.Signatures[0].PubKey)
+		require.NoError(t, err, v.Name)
+		require.Equal(t, v.SignDoc, hex.EncodeToString(signBytes), v.Name)
+
+		bz, err := encoder.EncodeTx(tx)
+		require.NoError(t, err, v.Name)
+		require.Equal(t, v.TxRaw, hex.EncodeToString(bz), v.Name)
+
+		// the SignDoc is made of the body and the auth info of the TxRaw, and its signature verifies
+		raw, err := proto.Decode(bz)
+		require.NoError(t, err, v.Name)
+		require.Len(t, raw, 3, v.Name)
+		signDoc := new(proto.Encoder).Raw(1, raw[0].Value).Raw(2, raw[1].Value).String(3, "irishub-1").Uint64(4, 7)
+		require.Equal(t, signDoc.Bytes(), signBytes, v.Name)
+		require.True(t, tx.Signatures[0].PubKey.VerifyBytes(signBytes, raw[2].Value), v.Name)
+	}
+}
+
+func TestUnmarshalProtoAccount(t *testing.T) {
+	mainnet := types.Mainnet.AddrPrefixCfg()
+	addr := types.AccAddress([]byte("address_____________"))
+	pubKey := secp256k1.GenPrivKey().PubKey().(secp256k1.PubKeySecp256k1)
+
+	baseAccount := new(proto.Encoder).
+		String(1, mainnet.AccAddressString(addr)).
+		Any(2, "/cosmos.crypto.secp256k1.PubKey", new(proto.Encoder).Raw(1, pubKey[:]).Bytes()).
+		Uint64(3, 12).
+		Uint64(4, 5)
+	res := new(proto.Encoder).Any(1, "/cosmos.auth.v1beta1.BaseAccount", baseAccount.Bytes())
+	account, err := types.UnmarshalProtoAccount(res.Bytes(), mainnet)
+	require.NoError(t, err)
+	require.Equal(t, addr, account.Address)
+	require.Equal(t, pubKey, account.PubKey)
+	require.Equal(t, uint64(12), account.AccountNumber)
+	require.Equal(t, uint64(5), account.Sequence)
+
+	// a module account embeds its base account
+	moduleAccount := new(proto.Encoder).Message(1, baseAccount.Bytes()).String(2, "distribution")
+	res = new(proto.Encoder).Any(1, "/cosmos.auth.v1beta1.ModuleAccount", moduleAccount.Bytes())
+	account, err = types.UnmarshalProtoAccount(res.Bytes(), mainnet)
+	require.NoError(t, err)
+	require.Equal(t, addr, account.Address)
+
+	_, err = types.UnmarshalProtoAccount(res.Bytes(), types.Testnet.AddrPrefixCfg())
+	require.Error(t, err)
+}
@@ -0,0 +1,113 @@
+package types
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Version is the version of the IRIShub application run by a node
+type Version struct {
+	Major int
+	Minor int
+	Patch int
+}
+
+var (
+	// V017 is the version of the queries of IRIShub 0.x
+	V017 = Version{Major: 0, Minor: 17}
+	// V100 is the version of the queries of IRIShub 1.x
+	V100 = Version{Major: 1}
+)
+
+// ParseVersion parses the version reported by ABCIInfo, such as 0.16.3 or v1.1.1-mainnet
+func ParseVersion(s string) (Version, error) {
+	version := strings.TrimPrefix(strings.TrimSpace(s), "v")
+	if i := strings.IndexAny(version, "-+"); i >= 0 {
+		version = version[:i]
+	}
+
+	var v Version
+	parts := strings.SplitN(version, ".", 3)
+	for i, p := range []*int{&v.Major, &v.Minor, &v.Patch} {
+		if i >= len(parts) {
+			break
+		}
+		n, err := strconv.Atoi(parts[i])
+		if err != nil || n < 0 {
+			return Version{}, fmt.Errorf("invalid version %s", s)
+		}
+		*p = n
+	}
+	return v, nil
+}
+
+// MustParseVersion calls ParseVersion and panics on error
+func MustParseVersion(s string) Version {
+	v, err := ParseVersion(s)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+func (v Version) String() string {
+	return fmt.Sprintf("%d.%d.%d", v.Major, v.Minor, v.Patch)
+}
+
+// Compare returns -1, 0 or 1 if v is lower than, equal to or greater than o
+func (v Version) Compare(o Version) int {
+	for _, d := range []int{v.Major - o.Major, v.Minor - o.Minor, v.Patch - o.Patch} {
+		if d < 0 {
+			return -1
+		}
+		if d > 0 {
+			return 1
+		}
+	}
+	return 0
+}
+
+// TxEncoding returns the encoding of the transactions of the version, the versions from 1.0 are based on Cosmos SDK 0.40+
+func (v Version) TxEncoding() TxEncoding {
+	if v.Major >= 1 {
+		return Protobuf
+	}
+	return Amino
+}
+
+// VersionedQueries holds the implementations of the queries of a module, each one is used from the version
+// it is registered with until the next one
+type VersionedQueries struct {
+	routes []versionRoute
+}
+
+type versionRoute struct {
+	since Version
+	impl  interface{}
+}
+
+// Register adds the implementation used from the version since
+func (q *VersionedQueries) Register(since Version, impl interface{}) *VersionedQueries {
+	q.routes = append(q.routes, versionRoute{since: since, impl: impl})
+	sort.SliceStable(q.routes, func(i, j int) bool {
+		return q.routes[i].since.Compare(q.routes[j].since) < 0
+	})
+	return q
+}
+
+// Route returns the implementation for the version, the oldest one if the version is older than all of them
+func (q VersionedQueries) Route(v Version) interface{} {
+	if len(q.routes) == 0 {
+		return nil
+	}
+	impl := q.routes[0].impl
+	for _, r := range q.routes[1:] {
+		if v.Compare(r.since) < 0 {
+			break
+		}
+		impl = r.impl
+	}
+	return impl
+}
@@ -0,0 +1,61 @@
+package types_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/irisnet/irishub-sdk-go/types"
+)
+
+func TestParseVersion(t *testing.T) {
+	v, err := types.ParseVersion("0.16.3")
+	require.NoError(t, err)
+	require.Equal(t, types.Version{Major: 0, Minor: 16, Patch: 3}, v)
+
+	v, err = types.ParseVersion("v1.1.1-mainnet")
+	require.NoError(t, err)
+	require.Equal(t, "1.1.1", v.String())
+
+	v, err = types.ParseVersion("1.0")
+	require.NoError(t, err)
+	require.Equal(t, 0, v.Compare(types.V100))
+
+	_, err = types.ParseVersion("")
+	require.Error(t, err)
+	_, err = types.ParseVersion("mainnet")
+	require.Error(t, err)
+}
+
+func TestVersionCompare(t *testing.T) {
+	require.Equal(t, -1, types.MustParseVersion("0.16.3").Compare(types.V017))
+	require.Equal(t, 1, types.MustParseVersion("0.17.1").Compare(types.V017))
+	require.Equal(t, 1, types.MustParseVersion("1.0.1").Compare(types.V100))
+	require.Equal(t, types.Amino, types.V017.TxEncoding())
+	require.Equal(t, types.Protobuf, types.V100.TxEncoding())
+}
+
+func TestVersionedQueries(t *testing.T) {
+	queries := new(types.VersionedQueries).
+		Register(types.V100, "v100").
+		Register(types.V017, "v017")
+
+	require.Equal(t, "v017", queries.Route(types.Version{}))
+	require.Equal(t, "v017", queries.Route(types.MustParseVersion("0.16.3")))
+	require.Equal(t, "v017", queries.Route(types.MustParseVersion("0.17.0")))
+	require.Equal(t, "v100", queries.Route(types.MustParseVersion("1.0.0")))
+	require.Equal(t, "v100", queries.Route(types.MustParseVersion("2.1.0")))
+	require.Nil(t, new(types.VersionedQueries).Route(types.V100))
+}
+
+func TestGetErrorOf(t *testing.T) {
+	// 0.x codes are mapped to those of 1.x
+	err := types.GetErrorOf(types.V017, types.RootCodespace, 12, "out of gas")
+	require.Equal(t, uint32(types.OutOfGas), err.Code())
+	require.Equal(t, err, types.GetError(types.RootCodespace, 12, "out of gas"))
+
+	err = types.GetErrorOf(types.V100, types.RootCodespace, 12, "memo too large")
+	require.Equal(t, uint32(types.MemoTooLarge), err.Code())
+	err = types.GetErrorOf(types.V100, "bank", 99, "unknown")
+	require.Equal(t, uint32(99), err.Code())
+}
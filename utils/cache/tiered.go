@@ -0,0 +1,177 @@
+package cache
+
+import (
+	"errors"
+	"fmt"
+	"sync/atomic"
+	"time"
+
+	"github.com/bluele/gcache"
+)
+
+// ErrNotFound is returned by a Backend which does not have the key
+var ErrNotFound = errors.New("cache: key not found")
+
+// Config is the capacity and the TTL of the entries of a cache, a zero TTL keeps the entries until they are evicted
+type Config struct {
+	Capacity int
+	TTL      time.Duration
+}
+
+// Backend stores the entries of the caches of several clients, or of several processes, e.g. an adapter of redis.
+// The values written to a Backend are []byte.
+type Backend interface {
+	// Get returns the value of the key and its remaining TTL, zero if the entry does not expire
+	Get(key string) ([]byte, time.Duration, error)
+	Set(key string, value []byte, ttl time.Duration) error
+	Delete(key string) error
+	// DeletePrefix deletes the keys which start with the prefix, those written by any client
+	DeletePrefix(prefix string) error
+}
+
+// Stats counts the lookups and the removals of a cache
+type Stats struct {
+	// Hits is the number of lookups found, in the process or in the backend
+	Hits uint64 `json:"hits"`
+	// BackendHits is the number of lookups found in the backend only
+	BackendHits uint64 `json:"backend_hits"`
+	Misses      uint64 `json:"misses"`
+	// Evictions is the number of entries removed to make room for others or because they expired
+	Evictions uint64 `json:"evictions"`
+	// Invalidations is the number of entries removed because they were outdated, e.g. by the events of the chain
+	Invalidations uint64 `json:"invalidations"`
+	// Size is the number of entries in the process
+	Size int `json:"size"`
+}
+
+// Observable is implemented by the caches which report their statistics
+type Observable interface {
+	Stats() Stats
+}
+
+// Tiered is an LRU of the process in front of an optional Backend, whose keys are prefixed with the namespace
+type Tiered struct {
+	namespace string
+	local     gcache.Cache
+	backend   Backend
+	ttl       time.Duration
+
+	hits, backendHits, misses *uint64
+	// the evicted entries include the removed ones
+	evicted, removed, purged *uint64
+}
+
+var _ Observable = Tiered{}
+
+// NewTiered returns a cache of the config, backend may be nil
+func NewTiered(namespace string, cfg Config, backend Backend) Tiered {
+	c := Tiered{
+		namespace:   namespace,
+		backend:     backend,
+		ttl:         cfg.TTL,
+		hits:        new(uint64),
+		backendHits: new(uint64),
+		misses:      new(uint64),
+		evicted:     new(uint64),
+		removed:     new(uint64),
+		purged:      new(uint64),
+	}
+	builder := gcache.New(cfg.Capacity).LRU().EvictedFunc(func(key, value interface{}) {
+		atomic.AddUint64(c.evicted, 1)
+	})
+	if cfg.TTL > 0 {
+		builder = builder.Expiration(cfg.TTL)
+	}
+	c.local = builder.Build()
+	return c
+}
+
+func (c Tiered) Set(key, value interface{}) error {
+	return c.SetWithExpire(key, value, c.ttl)
+}
+
+func (c Tiered) SetWithExpire(key, value interface{}, expiration time.Duration) error {
+	var err error
+	if expiration > 0 {
+		err = c.local.SetWithExpire(key, value, expiration)
+	} else {
+		err = c.local.Set(key, value)
+	}
+	if err != nil {
+		return err
+	}
+
+	if c.backend != nil {
+		bz, ok := value.([]byte)
+		if !ok {
+			return fmt.Errorf("the values of the backend must be []byte, not %T", value)
+		}
+		return c.backend.Set(c.backendKey(key), bz, expiration)
+	}
+	return nil
+}
+
+func (c Tiered) Get(key interface{}) (interface{}, error) {
+	value, err := c.local.Get(key)
+	if err == nil {
+		atomic.AddUint64(c.hits, 1)
+		return value, nil
+	}
+	if c.backend == nil {
+		atomic.AddUint64(c.misses, 1)
+		return nil, err
+	}
+
+	bz, ttl, err := c.backend.Get(c.backendKey(key))
+	if err != nil {
+		atomic.AddUint64(c.misses, 1)
+		return nil, err
+	}
+	atomic.AddUint64(c.hits, 1)
+	atomic.AddUint64(c.backendHits, 1)
+	// the entry of the process expires with the one of the backend
+	if ttl > 0 {
+		_ = c.local.SetWithExpire(key, bz, ttl)
+	} else {
+		_ = c.local.Set(key, bz)
+	}
+	return bz, nil
+}
+
+// Remove removes the key from the process and from the backend, it counts an invalidation
+func (c Tiered) Remove(key interface{}) bool {
+	removed := c.local.Remove(key)
+	if removed {
+		atomic.AddUint64(c.removed, 1)
+	}
+	if c.backend != nil {
+		_ = c.backend.Delete(c.backendKey(key))
+	}
+	return removed
+}
+
+// Purge removes the entries of the process and those of the namespace from the backend, including the entries
+// written by the other clients
+func (c Tiered) Purge() {
+	atomic.AddUint64(c.purged, uint64(c.local.Len(false)))
+	c.local.Purge()
+	if c.backend != nil {
+		_ = c.backend.DeletePrefix(c.backendKey(""))
+	}
+}
+
+func (c Tiered) Stats() Stats {
+	removed := atomic.LoadUint64(c.removed)
+	return Stats{
+		Hits:          atomic.LoadUint64(c.hits),
+		BackendHits:   atomic.LoadUint64(c.backendHits),
+		Misses:        atomic.LoadUint64(c.misses),
+		Evictions:     atomic.LoadUint64(c.evicted) - removed,
+		Invalidations: removed + atomic.LoadUint64(c.purged),
+		Size:          c.local.Len(true),
+	}
+}
+
+func (c Tiered) backendKey(key interface{}) string {
+	return fmt.Sprintf("%s:%v", c.namespace, key)
+}
@@ -0,0 +1,144 @@
+package cache_test
+
+import (
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/irisnet/irishub-sdk-go/utils/cache"
+)
+
+type mapBackend struct {
+	mu       sync.Mutex
+	entries  map[string][]byte
+	deadline map[string]time.Time
+}
+
+func newMapBackend() *mapBackend {
+	return &mapBackend{entries: make(map[string][]byte), deadline: make(map[string]time.Time)}
+}
+
+func (b *mapBackend) Get(key string) ([]byte, time.Duration, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	bz, ok := b.entries[key]
+	if !ok {
+		return nil, 0, cache.ErrNotFound
+	}
+	deadline, ok := b.deadline[key]
+	if !ok {
+		return bz, 0, nil
+	}
+	ttl := time.Until(deadline)
+	if ttl <= 0 {
+		delete(b.entries, key)
+		delete(b.deadline, key)
+		return nil, 0, cache.ErrNotFound
+	}
+	return bz, ttl, nil
+}
+
+func (b *mapBackend) Set(key string, value []byte, ttl time.Duration) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.entries[key] = value
+	delete(b.deadline, key)
+	if ttl > 0 {
+		b.deadline[key] = time.Now().Add(ttl)
+	}
+	return nil
+}
+
+func (b *mapBackend) Delete(key string) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	delete(b.entries, key)
+	delete(b.deadline, key)
+	return nil
+}
+
+func (b *mapBackend) DeletePrefix(prefix string) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	for key := range b.entries {
+		if strings.HasPrefix(key, prefix) {
+			delete(b.entries, key)
+			delete(b.deadline, key)
+		}
+	}
+	return nil
+}
+
+func TestTiered(t *testing.T) {
+	c := cache.NewTiered("test", cache.Config{Capacity: 2, TTL: 50 * time.Millisecond}, nil)
+	require.NoError(t, c.Set("a", 1))
+	require.NoError(t, c.Set("b", 2))
+	require.NoError(t, c.Set("c", 3))
+
+	_, err := c.Get("a")
+	require.Error(t, err)
+	v, err := c.Get("c")
+	require.NoError(t, err)
+	require.Equal(t, 3, v)
+
+	require.True(t, c.Remove("c"))
+	time.Sleep(100 * time.Millisecond)
+	_, err = c.Get("b")
+	require.Error(t, err)
+
+	stats := c.Stats()
+	require.Equal(t, uint64(1), stats.Hits)
+	require.Equal(t, uint64(2), stats.Misses)
+	require.Equal(t, uint64(2), stats.Evictions)
+	require.Equal(t, uint64(1), stats.Invalidations)
+	require.Zero(t, stats.Size)
+}
+
+func TestTieredBackend(t *testing.T) {
+	backend := newMapBackend()
+	c1 := cache.NewTiered("test/tokens", cache.Config{Capacity: 10}, backend)
+	c2 := cache.NewTiered("test/tokens", cache.Config{Capacity: 10}, backend)
+
+	require.NoError(t, c1.Set("btc", []byte("bitcoin")))
+	require.Error(t, c1.Set("eth", "ether"))
+	require.Contains(t, backend.entries, "test/tokens:btc")
+
+	// c2 reads the entry written by c1 from the backend
+	v, err := c2.Get("btc")
+	require.NoError(t, err)
+	require.Equal(t, []byte("bitcoin"), v)
+	require.Equal(t, uint64(1), c2.Stats().BackendHits)
+	_, err = c2.Get("btc")
+	require.NoError(t, err)
+	require.Equal(t, uint64(1), c2.Stats().BackendHits)
+
+	// the purge of c2 removes the entries written by c1 only, and not those of the other namespaces
+	c3 := cache.NewTiered("test/tokens2", cache.Config{Capacity: 10}, backend)
+	require.NoError(t, c1.Set("eth", []byte("ether")))
+	require.NoError(t, c3.Set("atom", []byte("cosmos")))
+	c2.Purge()
+	require.Equal(t, map[string][]byte{"test/tokens2:atom": []byte("cosmos")}, backend.entries)
+	require.Equal(t, uint64(1), c2.Stats().Invalidations)
+	_, err = c1.Get("btc")
+	require.NoError(t, err, "the entries of the process are kept")
+}
+
+func TestTieredBackendTTL(t *testing.T) {
+	backend := newMapBackend()
+	c1 := cache.NewTiered("test/accounts", cache.Config{Capacity: 10, TTL: 200 * time.Millisecond}, backend)
+	c2 := cache.NewTiered("test/accounts", cache.Config{Capacity: 10}, backend)
+
+	require.NoError(t, c1.Set("alice", []byte("account")))
+	time.Sleep(100 * time.Millisecond)
+	_, err := c2.Get("alice")
+	require.NoError(t, err)
+
+	// the copy of c2 expires with the entry of the backend, not later
+	time.Sleep(150 * time.Millisecond)
+	_, err = c2.Get("alice")
+	require.Error(t, err)
+	require.Equal(t, uint64(1), c2.Stats().BackendHits)
+}
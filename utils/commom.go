@@ -2,6 +2,8 @@ package utils
 
 import (
 	"crypto/rand"
+	"sync"
+
 	sdk "github.com/irisnet/irishub-sdk-go/types"
 )
 
@@ -42,3 +44,26 @@ func SplitArray(subLen int, array sdk.SplitAble) (segments []sdk.SplitAble) {
 	}
 	return segments
 }
+
+// Parallel calls fn for 0 <= i < n, at most limit at once, and returns when they all returned
+func Parallel(n, limit int, fn func(i int)) {
+	if limit <= 0 || limit > n {
+		limit = n
+	}
+	indexes := make(chan int)
+	var wg sync.WaitGroup
+	wg.Add(limit)
+	for w := 0; w < limit; w++ {
+		go func() {
+			defer wg.Done()
+			for i := range indexes {
+				fn(i)
+			}
+		}()
+	}
+	for i := 0; i < n; i++ {
+		indexes <- i
+	}
+	close(indexes)
+	wg.Wait()
+}
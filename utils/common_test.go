@@ -1,9 +1,12 @@
 package utils
 
 import (
+	"sync/atomic"
+	"testing"
+	"time"
+
 	sdk "github.com/irisnet/irishub-sdk-go/types"
 	"github.com/stretchr/testify/require"
-	"testing"
 )
 
 func TestSplitArray(t *testing.T) {
@@ -12,6 +15,29 @@ func TestSplitArray(t *testing.T) {
 	require.Len(t, subData, 3)
 }
 
+func TestParallel(t *testing.T) {
+	var running, max int32
+	squares := make([]int, 20)
+	Parallel(len(squares), 3, func(i int) {
+		n := atomic.AddInt32(&running, 1)
+		for {
+			m := atomic.LoadInt32(&max)
+			if n <= m || atomic.CompareAndSwapInt32(&max, m, n) {
+				break
+			}
+		}
+		time.Sleep(5 * time.Millisecond)
+		squares[i] = i * i
+		atomic.AddInt32(&running, -1)
+	})
+	require.Equal(t, int32(3), max)
+	for i, square := range squares {
+		require.Equal(t, i*i, square)
+	}
+
+	Parallel(0, 3, func(i int) { t.Fatal("called without items") })
+}
+
 type Ints []int
 
 func (i Ints) Len() int {
@@ -1,60 +1,214 @@
 package log
 
 import (
+	"context"
 	"fmt"
 	"io"
 	"os"
+	"path/filepath"
+	"runtime"
 	"strconv"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/rs/zerolog"
+
+	"github.com/irisnet/irishub-sdk-go/utils/trace"
 )
 
-var defaultLogger *Logger
+// Logger is the logger of the SDK, it can be implemented by an adapter of another logger, e.g. zap.
+// The keyvals are pairs of keys and values, such as "address", addr.
+type Logger interface {
+	Debug(msg string, keyvals ...interface{})
+	Info(msg string, keyvals ...interface{})
+	Warn(msg string, keyvals ...interface{})
+	Error(msg string, keyvals ...interface{})
 
-func init() {
-	defaultLogger = NewLogger("info")
+	// With returns a logger which adds the keyvals to every line
+	With(keyvals ...interface{}) Logger
 }
 
-type Logger struct {
-	zerolog.Logger
+// Format is the output format of the loggers returned by New
+type Format string
+
+const (
+	// Console writes colored lines for humans
+	Console Format = "console"
+	// JSON writes a JSON object per line
+	JSON Format = "json"
+)
+
+// loggerFrames prefix the functions of the loggers of the package, which are skipped to find the caller
+var loggerFrames = []string{
+	"github.com/irisnet/irishub-sdk-go/utils/log.zeroLogger.",
+	"github.com/irisnet/irishub-sdk-go/utils/log.redactor.",
 }
 
-func NewLogger(level string) *Logger {
+var defaultLogger = NewLogger("info")
+
+// GetLogger returns a console logger of the info level writing to stdout
+func GetLogger() Logger {
+	return defaultLogger
+}
+
+// NewLogger returns a console logger writing to stdout
+func NewLogger(level string) Logger {
+	return New(os.Stdout, level, Console)
+}
+
+// New returns a logger of the level (trace|debug|info|warn|error|fatal|panic, default: info) writing to w
+// with the format (default: Console). The secrets of the lines are redacted, see Redact.
+func New(w io.Writer, level string, format Format) Logger {
 	l, err := zerolog.ParseLevel(level)
-	if err != nil {
+	if err != nil || len(level) == 0 {
 		l = zerolog.InfoLevel
 	}
+	out := &output{w: w}
+
+	var writer io.Writer = out
+	if format != JSON {
+		writer = prettyWriter(out)
+	}
+	return Redact(zeroLogger{
+		logger: zerolog.New(writer).Level(l).With().Timestamp().Logger(),
+		out:    out,
+	})
+}
+
+// SetOutput replaces the writer of a logger returned by New and of the loggers derived from it with With,
+// it has no effect on the other loggers
+func SetOutput(logger Logger, w io.Writer) {
+	if r, ok := logger.(redactor); ok {
+		logger = r.logger
+	}
+	if l, ok := logger.(zeroLogger); ok {
+		l.out.set(w)
+	}
+}
 
-	//override default CallerMarshalFunc
-	zerolog.CallerMarshalFunc = func(file string, line int) string {
-		index := strings.LastIndex(file, string(os.PathSeparator))
-		rs := []rune(file)
-		fileName := string(rs[index+1:])
-		return fileName + ":" + strconv.Itoa(line)
+// ForContext returns a logger which writes the trace ID carried by ctx into every line
+func ForContext(ctx context.Context, logger Logger) Logger {
+	traceID, ok := trace.TraceIDFromContext(ctx)
+	if !ok {
+		return logger
 	}
+	return logger.With("trace_id", traceID)
+}
 
-	log := zerolog.New(prettyWriter(os.Stdout)).
-		Level(l).
-		With().
-		Caller().
-		Timestamp().Logger()
+type zeroLogger struct {
+	logger zerolog.Logger
+	out    *output
+}
 
-	logger := &Logger{log}
-	defaultLogger = logger
-	return logger
+func (l zeroLogger) Debug(msg string, keyvals ...interface{}) {
+	l.write(l.logger.Debug(), msg, keyvals)
 }
 
-func (l *Logger) SetOutput(w io.Writer) {
-	l.Logger = zerolog.New(prettyWriter(w)).
-		Level(l.GetLevel()).
-		With().
-		Timestamp().Logger()
+func (l zeroLogger) Info(msg string, keyvals ...interface{}) {
+	l.write(l.logger.Info(), msg, keyvals)
 }
 
-func GetLogger() *Logger {
-	return defaultLogger
+func (l zeroLogger) Warn(msg string, keyvals ...interface{}) {
+	l.write(l.logger.Warn(), msg, keyvals)
+}
+
+func (l zeroLogger) Error(msg string, keyvals ...interface{}) {
+	l.write(l.logger.Error(), msg, keyvals)
+}
+
+func (l zeroLogger) With(keyvals ...interface{}) Logger {
+	ctx := l.logger.With()
+	for i := 0; i < len(keyvals); i += 2 {
+		key, value := pair(keyvals, i)
+		ctx = ctx.Interface(key, fieldValue(value))
+	}
+	return zeroLogger{logger: ctx.Logger(), out: l.out}
+}
+
+// enabled reports whether the lines of the level are written
+func (l zeroLogger) enabled(level zerolog.Level) bool {
+	return level >= l.logger.GetLevel() && level >= zerolog.GlobalLevel()
+}
+
+func (l zeroLogger) write(e *zerolog.Event, msg string, keyvals []interface{}) {
+	// e is nil when the level is disabled
+	if e == nil {
+		return
+	}
+	for i := 0; i < len(keyvals); i += 2 {
+		key, value := pair(keyvals, i)
+		e = e.Interface(key, fieldValue(value))
+	}
+	if caller, ok := caller(); ok {
+		e = e.Str(zerolog.CallerFieldName, caller)
+	}
+	e.Msg(msg)
+}
+
+// pair returns the key and the value at i of keyvals, the value of an odd key is nil
+func pair(keyvals []interface{}, i int) (string, interface{}) {
+	key, ok := keyvals[i].(string)
+	if !ok {
+		key = fmt.Sprint(keyvals[i])
+	}
+	if i+1 < len(keyvals) {
+		return key, keyvals[i+1]
+	}
+	return key, nil
+}
+
+// fieldValue returns the text of the errors and the stringers, which zerolog would marshal as JSON objects
+func fieldValue(value interface{}) interface{} {
+	switch v := value.(type) {
+	case error:
+		return v.Error()
+	case fmt.Stringer:
+		return v.String()
+	}
+	return value
+}
+
+// caller returns the file and the line of the first caller outside of the loggers
+func caller() (string, bool) {
+	pcs := make([]uintptr, 16)
+	frames := runtime.CallersFrames(pcs[:runtime.Callers(3, pcs)])
+	for {
+		frame, more := frames.Next()
+		if !isLoggerFrame(frame.Function) {
+			return filepath.Base(frame.File) + ":" + strconv.Itoa(frame.Line), frame.File != ""
+		}
+		if !more {
+			return "", false
+		}
+	}
+}
+
+func isLoggerFrame(function string) bool {
+	for _, prefix := range loggerFrames {
+		if strings.HasPrefix(function, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// output is the writer shared by a logger and the loggers derived from it, which SetOutput replaces
+type output struct {
+	mu sync.RWMutex
+	w  io.Writer
+}
+
+func (o *output) Write(p []byte) (int, error) {
+	o.mu.RLock()
+	defer o.mu.RUnlock()
+	return o.w.Write(p)
+}
+
+func (o *output) set(w io.Writer) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	o.w = w
 }
 
 func prettyWriter(w io.Writer) io.Writer {
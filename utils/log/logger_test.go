@@ -1,15 +1,242 @@
 package log
 
 import (
+	"bytes"
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
 	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/tendermint/tendermint/crypto/secp256k1"
+
+	"github.com/irisnet/irishub-sdk-go/utils/trace"
 )
 
 func TestNewLogger(t *testing.T) {
 	log1 := NewLogger("info")
 
-	log1.Info().Str("foo", "bar").Msg("Hello World")
-	log1.Info().Str("foo1", "bar").Msg("Hello World")
-	log1.Info().Str("foo2", "bar").Msg("Hello World")
-	log1.Info().Str("foo3", "bar").Msg("Hello World")
+	log1.Info("Hello World", "foo", "bar")
+	log1.Info("Hello World", "foo1", "bar")
+	log1.Info("Hello World", "foo2", "bar")
+	log1.Info("Hello World", "foo3", "bar")
+}
+
+func lines(t *testing.T, buf *bytes.Buffer) []map[string]interface{} {
+	var lines []map[string]interface{}
+	for _, line := range strings.Split(strings.TrimSpace(buf.String()), "\n") {
+		var fields map[string]interface{}
+		require.NoError(t, json.Unmarshal([]byte(line), &fields), line)
+		lines = append(lines, fields)
+	}
+	return lines
+}
+
+func TestJSONLogger(t *testing.T) {
+	var buf bytes.Buffer
+	log := New(&buf, "debug", JSON).With("module", "bank")
+
+	log.Debug("query account", "address", "iaa1", "height", 10, "err", errors.New("not found"))
+	log.Warn("odd", "key")
+
+	fields := lines(t, &buf)
+	require.Len(t, fields, 2)
+	require.Equal(t, "debug", fields[0]["level"])
+	require.Equal(t, "query account", fields[0]["message"])
+	require.Equal(t, "bank", fields[0]["module"])
+	require.Equal(t, "iaa1", fields[0]["address"])
+	require.Equal(t, float64(10), fields[0]["height"])
+	require.Equal(t, "not found", fields[0]["err"])
+	require.Contains(t, fields[0]["caller"], "logger_test.go:")
+	require.Contains(t, fields[1], "key")
+
+	buf.Reset()
+	New(&buf, "warn", JSON).Info("disabled")
+	require.Empty(t, buf.String())
+}
+
+func TestRedact(t *testing.T) {
+	type baseTx struct {
+		From     string `json:"from"`
+		Password string `json:"password"`
+	}
+	var buf bytes.Buffer
+	log := New(&buf, "info", JSON).With("api_secret", "s3cret")
+
+	log.Info("sign",
+		"password", "p@ss",
+		"Mnemonic", "abandon abandon about",
+		"priv_key", "deadbeef",
+		"key", secp256k1.GenPrivKey(),
+		"tx", baseTx{From: "alice", Password: "p@ss"},
+		"params", map[string]interface{}{"seed": "abandon", "name": "alice"},
+		"name", "alice",
+	)
+
+	require.NotContains(t, buf.String(), "p@ss")
+	require.NotContains(t, buf.String(), "abandon")
+	require.NotContains(t, buf.String(), "s3cret")
+	fields := lines(t, &buf)[0]
+	require.Equal(t, Redacted, fields["password"])
+	require.Equal(t, Redacted, fields["Mnemonic"])
+	require.Equal(t, Redacted, fields["priv_key"])
+	require.Equal(t, Redacted, fields["key"])
+	require.Equal(t, Redacted, fields["api_secret"])
+	require.Equal(t, map[string]interface{}{"from": "alice", "password": Redacted}, fields["tx"])
+	require.Equal(t, map[string]interface{}{"seed": Redacted, "name": "alice"}, fields["params"])
+	require.Equal(t, "alice", fields["name"])
+}
+
+// account writes its name only, as a Stringer whose fields hold secrets
+type account struct {
+	Name     string `json:"name"`
+	Mnemonic string `json:"mnemonic"`
+}
+
+func (a account) String() string { return a.Name }
+
+func TestRedactNested(t *testing.T) {
+	type key struct {
+		Name     string `json:"name"`
+		Password string `json:"password"`
+	}
+	var buf bytes.Buffer
+	log := New(&buf, "info", JSON)
+
+	log.Info("import",
+		"keys", []key{{Name: "alice", Password: "p@ss"}, {Name: "bob"}},
+		"privs", []interface{}{"alice", secp256k1.GenPrivKey()},
+		"batches", map[string]interface{}{"first": [1]key{{Name: "carol", Password: "p@ss"}}},
+		"account", &account{Name: "dave", Mnemonic: "abandon abandon about"},
+		"names", []string{"alice", "bob"},
+		"err", errors.New("not found"),
+	)
+
+	require.NotContains(t, buf.String(), "p@ss")
+	require.NotContains(t, buf.String(), "abandon")
+	fields := lines(t, &buf)[0]
+	require.Equal(t, []interface{}{
+		map[string]interface{}{"name": "alice", "password": Redacted},
+		map[string]interface{}{"name": "bob", "password": Redacted},
+	}, fields["keys"])
+	require.Equal(t, []interface{}{"alice", Redacted}, fields["privs"])
+	require.Equal(t, map[string]interface{}{
+		"first": []interface{}{map[string]interface{}{"name": "carol", "password": Redacted}},
+	}, fields["batches"])
+	require.Equal(t, map[string]interface{}{"name": "dave", "mnemonic": Redacted}, fields["account"])
+	require.Equal(t, []interface{}{"alice", "bob"}, fields["names"])
+	require.Equal(t, "not found", fields["err"])
+}
+
+// note is a value without secret fields whose text may hold a secret
+type note string
+
+func TestRedactSecretValues(t *testing.T) {
+	const mnemonic = "abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon about"
+	// 12 BIP39 words whose checksum is invalid
+	const words = "abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon"
+	hash := strings.ToUpper(hex.EncodeToString(make([]byte, 32)))
+	id := hex.EncodeToString(secp256k1.GenPrivKey().PubKey().Address()) + hex.EncodeToString(make([]byte, 12))
+
+	var buf bytes.Buffer
+	log := New(&buf, "info", JSON)
+	log.Info("recovered "+mnemonic,
+		"words", mnemonic,
+		"data", map[string]interface{}{"text": "mnemonic: " + mnemonic},
+		"items", []string{"alice", strings.ToUpper(mnemonic)},
+		"account", account{Name: "dave " + mnemonic},
+		"err", fmt.Errorf("invalid %s", mnemonic),
+		"note", note(mnemonic),
+		"txHash", hash,
+		"id", id,
+		"sentence", words,
+		mnemonic,
+	)
+
+	require.NotContains(t, buf.String(), "abandon about")
+	fields := lines(t, &buf)[0]
+	require.Equal(t, "recovered "+Redacted, fields["message"])
+	require.Equal(t, Redacted, fields["words"])
+	require.Equal(t, map[string]interface{}{"text": "mnemonic: " + Redacted}, fields["data"])
+	require.Equal(t, []interface{}{"alice", Redacted}, fields["items"])
+	require.Equal(t, "invalid "+Redacted, fields["err"])
+	require.Equal(t, Redacted, fields["note"])
+	// the hashes and the IDs are kept whatever their keys, the words which are not a mnemonic are kept
+	require.Equal(t, hash, fields["txHash"])
+	require.Equal(t, id, fields["id"])
+	require.Equal(t, words, fields["sentence"])
+
+	// fewer words than a mnemonic are kept
+	require.Equal(t, "abandon abandon about", RedactText("abandon abandon about"))
+	require.Equal(t, "a "+Redacted+", b", RedactText("a "+mnemonic+", b"))
+}
+
+// counter counts the calls of its String
+type counter struct {
+	calls *int
+}
+
+func (c counter) String() string {
+	*c.calls++
+	return "counter"
+}
+
+func TestRedactDisabledLevel(t *testing.T) {
+	var calls int
+	var buf bytes.Buffer
+	log := New(&buf, "info", JSON)
+
+	// the values of the disabled levels are not redacted
+	log.Debug("disabled", "value", counter{&calls})
+	require.Zero(t, calls)
+	require.Empty(t, buf.String())
+
+	log.Info("enabled", "value", counter{&calls})
+	require.NotZero(t, calls)
+	require.Equal(t, "counter", lines(t, &buf)[0]["value"])
+}
+
+// recorder is a logger of the caller, e.g. an adapter of zap
+type recorder struct {
+	keyvals []interface{}
+}
+
+func (r *recorder) Debug(msg string, keyvals ...interface{}) { r.keyvals = append(r.keyvals, keyvals...) }
+func (r *recorder) Info(msg string, keyvals ...interface{})  { r.keyvals = append(r.keyvals, keyvals...) }
+func (r *recorder) Warn(msg string, keyvals ...interface{})  { r.keyvals = append(r.keyvals, keyvals...) }
+func (r *recorder) Error(msg string, keyvals ...interface{}) { r.keyvals = append(r.keyvals, keyvals...) }
+func (r *recorder) With(keyvals ...interface{}) Logger       { return r }
+
+func TestRedactLogger(t *testing.T) {
+	r := &recorder{}
+	log := Redact(Redact(r))
+	log.Error("failed", "password", "p@ss", "name", "alice")
+	require.Equal(t, []interface{}{"password", Redacted, "name", "alice"}, r.keyvals)
+}
+
+func TestLoggerForContext(t *testing.T) {
+	var buf bytes.Buffer
+	log := NewLogger("info")
+	SetOutput(log, &buf)
+
+	ForContext(context.Background(), log).Info("without trace")
+	require.NotContains(t, buf.String(), "trace_id")
+
+	ctx := trace.ContextWithTraceID(context.Background(), "foo")
+	ForContext(ctx, log).Info("with trace")
+	require.Contains(t, buf.String(), "foo")
+}
+
+func TestSetOutput(t *testing.T) {
+	var buf1, buf2 bytes.Buffer
+	log := New(&buf1, "info", JSON)
+	child := log.With("module", "bank")
 
+	SetOutput(log, &buf2)
+	child.Info("moved")
+	require.Empty(t, buf1.String())
+	require.Contains(t, buf2.String(), "moved")
 }
@@ -0,0 +1,289 @@
+package log
+
+import (
+	"fmt"
+	"reflect"
+	"regexp"
+	"strings"
+
+	"github.com/cosmos/go-bip39"
+	"github.com/rs/zerolog"
+	"github.com/tendermint/tendermint/crypto"
+)
+
+// Redacted replaces the secrets in the lines
+const Redacted = "[REDACTED]"
+
+// secretKeys are the parts of the keys and of the field names whose values are redacted, compared in lower case
+// without separators, e.g. "Password", "priv_key" and "mnemonicWords"
+var secretKeys = []string{"password", "passwd", "passphrase", "mnemonic", "privkey", "privatekey", "secret", "seed"}
+
+// maxRedactDepth bounds the nested maps and structs walked by the redaction
+const maxRedactDepth = 8
+
+// mnemonicWords are the numbers of words of the BIP39 mnemonics
+var mnemonicWords = []int{12, 15, 18, 21, 24}
+
+var wordPattern = regexp.MustCompile(`[A-Za-z]+`)
+
+// Redact returns a logger which replaces by Redacted the values of the secret keys (passwords, mnemonics, private
+// keys...), the private keys and the secret fields of the maps, structs and slices before writing them to logger.
+// Whatever their keys, the mnemonics found in the message and in the strings, errors and Stringers of the values are
+// replaced too, see RedactText. The lines of the disabled levels of the loggers returned by New are not redacted.
+func Redact(logger Logger) Logger {
+	if _, ok := logger.(redactor); ok {
+		return logger
+	}
+	return redactor{logger}
+}
+
+// IsSecret reports whether the values of the key or of the field are redacted
+func IsSecret(key string) bool {
+	key = strings.NewReplacer("_", "", "-", "", ".", "", " ", "").Replace(strings.ToLower(key))
+	for _, secret := range secretKeys {
+		if strings.Contains(key, secret) {
+			return true
+		}
+	}
+	return false
+}
+
+// RedactText replaces by Redacted the runs of BIP39 words in text which hold a valid mnemonic, the other words,
+// the hashes and the hex strings are kept
+func RedactText(text string) string {
+	return redactMnemonics(text)
+}
+
+// hasSecretText reports whether RedactText changes text
+func hasSecretText(text string) bool {
+	return RedactText(text) != text
+}
+
+// redactMnemonics replaces the runs of BIP39 words only separated by spaces which hold a valid mnemonic
+func redactMnemonics(text string) string {
+	words := wordPattern.FindAllStringIndex(text, -1)
+	var b strings.Builder
+	last, start, count := 0, 0, 0
+	flush := func(end int) {
+		if count >= mnemonicWords[0] && holdsMnemonic(text[start:end]) {
+			b.WriteString(text[last:start])
+			b.WriteString(Redacted)
+			last = end
+		}
+		count = 0
+	}
+	for i, w := range words {
+		_, ok := bip39.ReverseWordMap[strings.ToLower(text[w[0]:w[1]])]
+		if count > 0 && (!ok || strings.TrimSpace(text[words[i-1][1]:w[0]]) != "") {
+			flush(words[i-1][1])
+		}
+		if !ok {
+			continue
+		}
+		if count == 0 {
+			start = w[0]
+		}
+		count++
+	}
+	if count > 0 {
+		flush(words[len(words)-1][1])
+	}
+	if last == 0 {
+		return text
+	}
+	b.WriteString(text[last:])
+	return b.String()
+}
+
+// holdsMnemonic reports whether consecutive words of the run are a mnemonic whose checksum is valid
+func holdsMnemonic(run string) bool {
+	words := strings.Fields(strings.ToLower(run))
+	for _, n := range mnemonicWords {
+		for i := 0; i+n <= len(words); i++ {
+			// IsMnemonicValid does not check the checksum
+			if _, err := bip39.MnemonicToByteArray(strings.Join(words[i:i+n], " ")); err == nil {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// text returns the string of a string, an error or a Stringer
+func text(value interface{}) (s string, ok bool) {
+	defer func() {
+		if recover() != nil {
+			s, ok = "", false
+		}
+	}()
+	switch v := value.(type) {
+	case string:
+		return v, true
+	case error:
+		return v.Error(), true
+	case fmt.Stringer:
+		return v.String(), true
+	}
+	return "", false
+}
+
+type redactor struct {
+	logger Logger
+}
+
+// enabled reports whether the logger writes the lines of the level, it is unknown for the loggers of the caller
+func (r redactor) enabled(level zerolog.Level) bool {
+	if l, ok := r.logger.(zeroLogger); ok {
+		return l.enabled(level)
+	}
+	return true
+}
+
+func (r redactor) Debug(msg string, keyvals ...interface{}) {
+	if r.enabled(zerolog.DebugLevel) {
+		r.logger.Debug(RedactText(msg), redactKeyvals(keyvals)...)
+	}
+}
+
+func (r redactor) Info(msg string, keyvals ...interface{}) {
+	if r.enabled(zerolog.InfoLevel) {
+		r.logger.Info(RedactText(msg), redactKeyvals(keyvals)...)
+	}
+}
+
+func (r redactor) Warn(msg string, keyvals ...interface{}) {
+	if r.enabled(zerolog.WarnLevel) {
+		r.logger.Warn(RedactText(msg), redactKeyvals(keyvals)...)
+	}
+}
+
+func (r redactor) Error(msg string, keyvals ...interface{}) {
+	if r.enabled(zerolog.ErrorLevel) {
+		r.logger.Error(RedactText(msg), redactKeyvals(keyvals)...)
+	}
+}
+
+func (r redactor) With(keyvals ...interface{}) Logger {
+	return redactor{r.logger.With(redactKeyvals(keyvals)...)}
+}
+
+func redactKeyvals(keyvals []interface{}) []interface{} {
+	redacted := make([]interface{}, len(keyvals))
+	for i := 0; i < len(keyvals); i += 2 {
+		key, value := pair(keyvals, i)
+		// a secret passed without key, e.g. the last of an odd number of arguments, is redacted as a key
+		redacted[i] = keyvals[i]
+		if hasSecretText(key) {
+			redacted[i] = RedactText(key)
+		}
+		if i+1 < len(keyvals) {
+			redacted[i+1] = redactValue(key, value, 0)
+		}
+	}
+	return redacted
+}
+
+// redactValue returns the value without its secrets, the values without secrets are returned as they are
+func redactValue(key string, value interface{}, depth int) interface{} {
+	if IsSecret(key) {
+		return Redacted
+	}
+	if _, ok := value.(crypto.PrivKey); ok {
+		return Redacted
+	}
+	if depth >= maxRedactDepth || !containsSecret(reflect.ValueOf(value), depth) {
+		// the text of a value without secret fields may still hold a mnemonic or a private key
+		if s, ok := text(value); ok && hasSecretText(s) {
+			return RedactText(s)
+		}
+		return value
+	}
+
+	v := reflect.Indirect(reflect.ValueOf(value))
+	if v.Kind() == reflect.String {
+		return RedactText(v.String())
+	}
+	if v.Kind() == reflect.Slice || v.Kind() == reflect.Array {
+		elems := make([]interface{}, v.Len())
+		for i := range elems {
+			elems[i] = redactValue(key, v.Index(i).Interface(), depth+1)
+		}
+		return elems
+	}
+
+	// the values with secrets, even those which marshal themselves, are written as maps of their fields
+	redacted := make(map[string]interface{})
+	switch v.Kind() {
+	case reflect.Map:
+		for _, k := range v.MapKeys() {
+			name := fmt.Sprint(k.Interface())
+			redacted[name] = redactValue(name, v.MapIndex(k).Interface(), depth+1)
+		}
+	case reflect.Struct:
+		for i := 0; i < v.NumField(); i++ {
+			field := v.Type().Field(i)
+			if field.PkgPath != "" {
+				continue
+			}
+			redacted[fieldName(field)] = redactValue(field.Name, v.Field(i).Interface(), depth+1)
+		}
+	}
+	return redacted
+}
+
+// containsSecret reports whether the map, the struct or the elements of the slice v have a secret key or field,
+// a private key or a string holding a mnemonic, at any depth. The fields of the values which marshal themselves
+// are checked too, as their String, Error or MarshalJSON may write them.
+func containsSecret(v reflect.Value, depth int) bool {
+	if !v.IsValid() || depth >= maxRedactDepth {
+		return false
+	}
+	if v.CanInterface() {
+		if _, ok := v.Interface().(crypto.PrivKey); ok {
+			return true
+		}
+	}
+
+	switch v.Kind() {
+	case reflect.String:
+		return hasSecretText(v.String())
+	case reflect.Ptr, reflect.Interface:
+		return !v.IsNil() && containsSecret(v.Elem(), depth)
+	case reflect.Slice, reflect.Array:
+		if v.Type().Elem().Kind() == reflect.Uint8 {
+			// bytes, e.g. addresses and hashes
+			return false
+		}
+		for i := 0; i < v.Len(); i++ {
+			if containsSecret(v.Index(i), depth+1) {
+				return true
+			}
+		}
+	case reflect.Map:
+		for _, k := range v.MapKeys() {
+			name := fmt.Sprint(k.Interface())
+			if IsSecret(name) || containsSecret(v.MapIndex(k), depth+1) {
+				return true
+			}
+		}
+	case reflect.Struct:
+		for i := 0; i < v.NumField(); i++ {
+			field := v.Type().Field(i)
+			if field.PkgPath != "" {
+				continue
+			}
+			if IsSecret(field.Name) || containsSecret(v.Field(i), depth+1) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// fieldName returns the JSON name of the field
+func fieldName(field reflect.StructField) string {
+	if name := strings.Split(field.Tag.Get("json"), ",")[0]; len(name) > 0 && name != "-" {
+		return name
+	}
+	return field.Name
+}
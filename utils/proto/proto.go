@@ -0,0 +1,264 @@
+// Package proto encodes and decodes the protobuf wire format of the few messages used by the SDK,
+// without generated code
+package proto
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+	"time"
+)
+
+// wire types
+const (
+	Varint  = 0
+	Fixed64 = 1
+	Bytes   = 2
+	Fixed32 = 5
+)
+
+// Encoder appends the fields of a message, the zero values are omitted like proto3 does
+type Encoder struct {
+	buf []byte
+}
+
+// Bytes returns the encoded message
+func (e *Encoder) Bytes() []byte {
+	return e.buf
+}
+
+// Uint64 encodes a varint field
+func (e *Encoder) Uint64(field int, v uint64) *Encoder {
+	if v == 0 {
+		return e
+	}
+	e.tag(field, Varint)
+	e.buf = appendVarint(e.buf, v)
+	return e
+}
+
+// String encodes a string field
+func (e *Encoder) String(field int, s string) *Encoder {
+	return e.Raw(field, []byte(s))
+}
+
+// Raw encodes a bytes field
+func (e *Encoder) Raw(field int, bz []byte) *Encoder {
+	if len(bz) == 0 {
+		return e
+	}
+	e.tag(field, Bytes)
+	e.buf = appendVarint(e.buf, uint64(len(bz)))
+	e.buf = append(e.buf, bz...)
+	return e
+}
+
+// Message encodes an embedded message, which is written even if it is empty
+func (e *Encoder) Message(field int, bz []byte) *Encoder {
+	e.tag(field, Bytes)
+	e.buf = appendVarint(e.buf, uint64(len(bz)))
+	e.buf = append(e.buf, bz...)
+	return e
+}
+
+// Any encodes a google.protobuf.Any field
+func (e *Encoder) Any(field int, typeURL string, value []byte) *Encoder {
+	any := new(Encoder).String(1, typeURL).Raw(2, value)
+	return e.Message(field, any.Bytes())
+}
+
+func (e *Encoder) tag(field, wireType int) {
+	e.buf = appendVarint(e.buf, uint64(field)<<3|uint64(wireType))
+}
+
+func appendVarint(buf []byte, v uint64) []byte {
+	var bz [binary.MaxVarintLen64]byte
+	n := binary.PutUvarint(bz[:], v)
+	return append(buf, bz[:n]...)
+}
+
+// Field is a decoded field, Value holds the bytes of a length-delimited field and Varint the others
+type Field struct {
+	Number   int
+	WireType int
+	Varint   uint64
+	Value    []byte
+}
+
+// Decode returns the fields of the message in order
+func Decode(bz []byte) ([]Field, error) {
+	var fields []Field
+	for len(bz) > 0 {
+		key, n := binary.Uvarint(bz)
+		if n <= 0 {
+			return nil, errors.New("invalid field key")
+		}
+		bz = bz[n:]
+
+		field := Field{Number: int(key >> 3), WireType: int(key & 7)}
+		switch field.WireType {
+		case Varint:
+			v, n := binary.Uvarint(bz)
+			if n <= 0 {
+				return nil, fmt.Errorf("invalid varint of field %d", field.Number)
+			}
+			field.Varint, bz = v, bz[n:]
+		case Bytes:
+			l, n := binary.Uvarint(bz)
+			if n <= 0 || uint64(len(bz)-n) < l {
+				return nil, fmt.Errorf("invalid length of field %d", field.Number)
+			}
+			field.Value, bz = bz[n:n+int(l)], bz[n+int(l):]
+		case Fixed64:
+			if len(bz) < 8 {
+				return nil, fmt.Errorf("invalid fixed64 of field %d", field.Number)
+			}
+			field.Varint, bz = binary.LittleEndian.Uint64(bz), bz[8:]
+		case Fixed32:
+			if len(bz) < 4 {
+				return nil, fmt.Errorf("invalid fixed32 of field %d", field.Number)
+			}
+			field.Varint, bz = uint64(binary.LittleEndian.Uint32(bz)), bz[4:]
+		default:
+			return nil, fmt.Errorf("unsupported wire type %d of field %d", field.WireType, field.Number)
+		}
+		fields = append(fields, field)
+	}
+	return fields, nil
+}
+
+// DecodeAny returns the type and the value of a google.protobuf.Any
+func DecodeAny(bz []byte) (typeURL string, value []byte, err error) {
+	fields, err := Decode(bz)
+	if err != nil {
+		return "", nil, err
+	}
+	for _, f := range fields {
+		switch f.Number {
+		case 1:
+			typeURL = string(f.Value)
+		case 2:
+			value = f.Value
+		}
+	}
+	return typeURL, value, nil
+}
+
+// Lookup returns the value of the last length-delimited field number of the message, found is false if the message
+// does not hold it
+func Lookup(bz []byte, number int) (value []byte, found bool, err error) {
+	fields, err := Decode(bz)
+	if err != nil {
+		return nil, false, err
+	}
+	for _, f := range fields {
+		if f.Number == number {
+			value, found = f.Value, true
+		}
+	}
+	return value, found, nil
+}
+
+// DecPrecision is the number of decimals of the Dec of the Cosmos SDK 0.40+
+const DecPrecision = 18
+
+// Dec returns the decimal string of a Dec of the Cosmos SDK 0.40+, which the messages hold as the integer of its
+// decimals, e.g. 500000000000000000 for 0.5
+func Dec(bz []byte) (string, error) {
+	i, ok := new(big.Int).SetString(string(bz), 10)
+	if !ok {
+		return "", fmt.Errorf("invalid decimal %s", bz)
+	}
+	digits := new(big.Int).Abs(i).String()
+	if len(digits) <= DecPrecision {
+		digits = strings.Repeat("0", DecPrecision+1-len(digits)) + digits
+	}
+	dec := digits[:len(digits)-DecPrecision] + "." + digits[len(digits)-DecPrecision:]
+	if i.Sign() < 0 {
+		dec = "-" + dec
+	}
+	return dec, nil
+}
+
+// Timestamp decodes a google.protobuf.Timestamp
+func Timestamp(bz []byte) (time.Time, error) {
+	fields, err := Decode(bz)
+	if err != nil {
+		return time.Time{}, err
+	}
+	var seconds, nanos int64
+	for _, f := range fields {
+		switch f.Number {
+		case 1:
+			seconds = int64(f.Varint)
+		case 2:
+			nanos = int64(int32(f.Varint))
+		}
+	}
+	return time.Unix(seconds, nanos).UTC(), nil
+}
+
+// Duration decodes a google.protobuf.Duration
+func Duration(bz []byte) (time.Duration, error) {
+	fields, err := Decode(bz)
+	if err != nil {
+		return 0, err
+	}
+	var d time.Duration
+	for _, f := range fields {
+		switch f.Number {
+		case 1:
+			d += time.Duration(int64(f.Varint)) * time.Second
+		case 2:
+			d += time.Duration(int32(f.Varint))
+		}
+	}
+	return d, nil
+}
+
+// PageRequest encodes a cosmos.base.query.v1beta1.PageRequest, the page starts after the key if it is given,
+// otherwise at the offset
+func PageRequest(key []byte, offset, limit uint64) []byte {
+	return new(Encoder).Raw(1, key).Uint64(2, offset).Uint64(3, limit).Bytes()
+}
+
+// Pages calls query with the PageRequest of each page, from the first one, until the
+// cosmos.base.query.v1beta1.PageResponse of the field pageField of the response holds no next key,
+// and returns the values of the field itemField of all the responses in order
+func Pages(query func(page []byte) ([]byte, error), itemField, pageField int) ([][]byte, error) {
+	var items [][]byte
+	var key []byte
+	for {
+		res, err := query(PageRequest(key, 0, 0))
+		if err != nil {
+			return nil, err
+		}
+		fields, err := Decode(res)
+		if err != nil {
+			return nil, err
+		}
+
+		key = nil
+		for _, f := range fields {
+			switch f.Number {
+			case itemField:
+				items = append(items, f.Value)
+			case pageField:
+				page, err := Decode(f.Value)
+				if err != nil {
+					return nil, err
+				}
+				for _, p := range page {
+					if p.Number == 1 {
+						key = p.Value
+					}
+				}
+			}
+		}
+		if len(key) == 0 {
+			return items, nil
+		}
+	}
+}
@@ -0,0 +1,65 @@
+package trace
+
+import "context"
+
+// OTelTracer is the subset of an OpenTelemetry-style tracer used by the SDK.
+// A thin shim over go.opentelemetry.io/otel/trace.Tracer satisfies it.
+type OTelTracer interface {
+	Start(ctx context.Context, spanName string) (context.Context, OTelSpan)
+}
+
+// OTelSpan is the subset of an OpenTelemetry-style span used by the SDK
+type OTelSpan interface {
+	SetAttribute(key string, value interface{})
+	RecordError(err error)
+	End()
+	// TraceID returns the hex encoded trace ID of the span, or "" if the span is not recording
+	TraceID() string
+}
+
+type otelTracer struct {
+	tracer OTelTracer
+}
+
+// NewOTelTracer returns a Tracer which delegates to an OpenTelemetry-style tracer.
+// When the context does not carry a trace ID yet, the one of the started span is used,
+// so that the SDK log lines can be joined with the exported spans.
+func NewOTelTracer(tracer OTelTracer) Tracer {
+	return otelTracer{tracer: tracer}
+}
+
+func (t otelTracer) Start(ctx context.Context, name string, attrs ...Attribute) (context.Context, Span) {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	ctx, s := t.tracer.Start(ctx, name)
+	span := otelSpan{span: s}
+	span.SetAttributes(attrs...)
+
+	if _, ok := TraceIDFromContext(ctx); !ok {
+		if traceID := s.TraceID(); len(traceID) > 0 {
+			ctx = ContextWithTraceID(ctx, traceID)
+		}
+	}
+	return ctx, span
+}
+
+type otelSpan struct {
+	span OTelSpan
+}
+
+func (s otelSpan) SetAttributes(attrs ...Attribute) {
+	for _, attr := range attrs {
+		s.span.SetAttribute(attr.Key, attr.Value)
+	}
+}
+
+func (s otelSpan) RecordError(err error) {
+	if err != nil {
+		s.span.RecordError(err)
+	}
+}
+
+func (s otelSpan) End() {
+	s.span.End()
+}
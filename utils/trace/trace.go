@@ -0,0 +1,95 @@
+// Package trace provides the tracing hooks called by the SDK at each step of a transaction
+//
+// A trace ID carried by context.Context is shared by every span and log line of one operation.
+package trace
+
+import (
+	"context"
+
+	"github.com/irisnet/irishub-sdk-go/utils/uuid"
+)
+
+type traceIDKey struct{}
+
+// Tracer creates spans, the default implementation is NoopTracer
+type Tracer interface {
+	Start(ctx context.Context, name string, attrs ...Attribute) (context.Context, Span)
+}
+
+// Span represents a single step of an operation
+type Span interface {
+	SetAttributes(attrs ...Attribute)
+	RecordError(err error)
+	End()
+}
+
+// Attribute is a key-value pair attached to a span
+type Attribute struct {
+	Key   string
+	Value interface{}
+}
+
+func String(key, value string) Attribute {
+	return Attribute{Key: key, Value: value}
+}
+
+func Int64(key string, value int64) Attribute {
+	return Attribute{Key: key, Value: value}
+}
+
+func Uint64(key string, value uint64) Attribute {
+	return Attribute{Key: key, Value: value}
+}
+
+func Bool(key string, value bool) Attribute {
+	return Attribute{Key: key, Value: value}
+}
+
+// ContextWithTraceID returns a copy of ctx which carries the trace ID
+func ContextWithTraceID(ctx context.Context, traceID string) context.Context {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	return context.WithValue(ctx, traceIDKey{}, traceID)
+}
+
+// TraceIDFromContext returns the trace ID carried by ctx
+func TraceIDFromContext(ctx context.Context) (string, bool) {
+	if ctx == nil {
+		return "", false
+	}
+	traceID, ok := ctx.Value(traceIDKey{}).(string)
+	return traceID, ok && len(traceID) > 0
+}
+
+// EnsureTraceID returns ctx unchanged if it already carries a trace ID,
+// otherwise a new correlation ID is generated and attached to it
+func EnsureTraceID(ctx context.Context) context.Context {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	if _, ok := TraceIDFromContext(ctx); ok {
+		return ctx
+	}
+	id, err := uuid.NewV4()
+	if err != nil {
+		return ctx
+	}
+	return ContextWithTraceID(ctx, id.String())
+}
+
+// NoopTracer is a Tracer which does nothing
+type NoopTracer struct{}
+
+func (NoopTracer) Start(ctx context.Context, _ string, _ ...Attribute) (context.Context, Span) {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	return ctx, noopSpan{}
+}
+
+type noopSpan struct{}
+
+func (noopSpan) SetAttributes(_ ...Attribute) {}
+func (noopSpan) RecordError(_ error)          {}
+func (noopSpan) End()                         {}
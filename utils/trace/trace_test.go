@@ -0,0 +1,68 @@
+package trace
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestEnsureTraceID(t *testing.T) {
+	_, ok := TraceIDFromContext(context.Background())
+	require.False(t, ok)
+
+	ctx := EnsureTraceID(context.Background())
+	traceID, ok := TraceIDFromContext(ctx)
+	require.True(t, ok)
+	require.NotEmpty(t, traceID)
+
+	ctx = EnsureTraceID(ContextWithTraceID(context.Background(), "foo"))
+	traceID, _ = TraceIDFromContext(ctx)
+	require.Equal(t, "foo", traceID)
+}
+
+type mockSpan struct {
+	traceID string
+	attrs   map[string]interface{}
+	errs    []error
+	ended   bool
+}
+
+func (s *mockSpan) SetAttribute(key string, value interface{}) { s.attrs[key] = value }
+func (s *mockSpan) RecordError(err error)                      { s.errs = append(s.errs, err) }
+func (s *mockSpan) End()                                       { s.ended = true }
+func (s *mockSpan) TraceID() string                            { return s.traceID }
+
+type mockTracer struct {
+	spans []*mockSpan
+}
+
+func (t *mockTracer) Start(ctx context.Context, _ string) (context.Context, OTelSpan) {
+	s := &mockSpan{traceID: "4bf92f3577b34da6a3ce929d0e0e4736", attrs: map[string]interface{}{}}
+	t.spans = append(t.spans, s)
+	return ctx, s
+}
+
+func TestOTelTracer(t *testing.T) {
+	mt := &mockTracer{}
+	tracer := NewOTelTracer(mt)
+
+	ctx, span := tracer.Start(nil, "root", String("from", "test1"))
+	span.RecordError(nil)
+	span.RecordError(errors.New("failed"))
+	span.End()
+
+	traceID, ok := TraceIDFromContext(ctx)
+	require.True(t, ok)
+	require.Equal(t, "4bf92f3577b34da6a3ce929d0e0e4736", traceID)
+
+	require.Len(t, mt.spans, 1)
+	require.Equal(t, "test1", mt.spans[0].attrs["from"])
+	require.Len(t, mt.spans[0].errs, 1)
+	require.True(t, mt.spans[0].ended)
+
+	ctx, _ = tracer.Start(ContextWithTraceID(context.Background(), "foo"), "child")
+	traceID, _ = TraceIDFromContext(ctx)
+	require.Equal(t, "foo", traceID)
+}